@@ -33,11 +33,18 @@ import (
 
 const maxChunkSize = 8 * 1024 * 1024 // 8 MB chunk size
 const maxRetryCount = 3
-const initialRetryDelay = time.Second
 const delayMultiplier = 2
 
+// initialRetryDelay is the delay before retrying a failed upload chunk. It is a
+// variable so that tests can shorten it.
+var initialRetryDelay = time.Second
+
 type apiClient struct {
 	clientConfig *ClientConfig
+	// The usage of the cached contents by the requests of the client.
+	cacheStats cacheStatsRegistry
+	// The data uploaded by the InlineDataOffload policy of the client.
+	offloads offloadCache
 }
 
 // sendStreamRequest issues an server streaming API request and returns a map of the response contents.
@@ -73,16 +80,34 @@ func sendRequest(ctx context.Context, ac *apiClient, path string, method string,
 }
 
 func downloadFile(ctx context.Context, ac *apiClient, path string, httpOptions *HTTPOptions) ([]byte, error) {
+	var b bytes.Buffer
+	if _, err := downloadFileTo(ctx, ac, path, httpOptions, &b); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// downloadFileTo streams the response body of a download request to w, and
+// returns the number of bytes written.
+func downloadFileTo(ctx context.Context, ac *apiClient, path string, httpOptions *HTTPOptions, w io.Writer) (int64, error) {
 	req, err := buildRequest(ctx, ac, path, nil, http.MethodGet, httpOptions)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
 	resp, err := doRequest(ac, req)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	return io.ReadAll(resp.Body)
+	defer resp.Body.Close()
+	if !httpStatusOk(resp) {
+		return 0, newAPIError(resp)
+	}
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("downloadFileTo: error copying response body: %w", err)
+	}
+	return n, nil
 }
 
 func mapToStruct[R any](input map[string]any, output *R) error {
@@ -104,7 +129,13 @@ func (ac *apiClient) createAPIURL(suffix, method string, httpOptions *HTTPOption
 		if !strings.HasPrefix(suffix, "projects/") && !queryVertexBaseModel {
 			suffix = fmt.Sprintf("projects/%s/locations/%s/%s", ac.clientConfig.Project, ac.clientConfig.Location, suffix)
 		}
-		u, err := url.Parse(fmt.Sprintf("%s/%s/%s", httpOptions.BaseURL, httpOptions.APIVersion, suffix))
+		baseURL := httpOptions.BaseURL
+		// Resources of other locations, e.g. the endpoint of a model tuned in another
+		// region, are served by the regional endpoint of their location.
+		if location := vertexResourceLocation(suffix); location != "" && location != ac.clientConfig.Location && baseURL == vertexBaseURL(ac.clientConfig.Location) {
+			baseURL = vertexBaseURL(location)
+		}
+		u, err := url.Parse(fmt.Sprintf("%s/%s/%s", baseURL, httpOptions.APIVersion, suffix))
 		if err != nil {
 			return nil, fmt.Errorf("createAPIURL: error parsing Vertex AI URL: %w", err)
 		}
@@ -141,6 +172,9 @@ func buildRequest(ctx context.Context, ac *apiClient, path string, body map[stri
 	// Set headers
 	doMergeHeaders(httpOptions.Headers, &req.Header)
 	doMergeHeaders(sdkHeader(ctx, ac), &req.Header)
+	if ac.clientConfig.Backend == BackendVertexAI && httpOptions.ProvisionedThroughput != ProvisionedThroughputModeUnspecified {
+		req.Header.Set("X-Vertex-AI-LLM-Request-Type", string(httpOptions.ProvisionedThroughput))
+	}
 	return req, nil
 }
 
@@ -260,11 +294,13 @@ func iterateResponseStream[R any](rs *responseStream[R], responseConverter func(
 				}
 			}
 		}
-		if rs.r.Err() != nil {
-			if rs.r.Err() == bufio.ErrTooLong {
+		if err := rs.r.Err(); err != nil {
+			if err == bufio.ErrTooLong {
 				log.Printf("The response is too large to process in streaming mode. Please use a non-streaming method.")
 			}
-			log.Printf("Error %v", rs.r.Err())
+			// Report read errors, e.g. a cancelled request context, so that an
+			// interrupted stream is not mistaken for a complete one.
+			yield(nil, fmt.Errorf("iterateResponseStream: error reading stream: %w", err))
 		}
 	}
 }
@@ -362,14 +398,13 @@ func scan(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	return 0, nil, nil
 }
 
-func (ac *apiClient) uploadFile(ctx context.Context, r io.Reader, uploadURL string, httpOptions *HTTPOptions) (*File, error) {
+// uploadFile uploads the contents of r to an upload session, calling the optional
+// progress after every chunk.
+func (ac *apiClient) uploadFile(ctx context.Context, r io.Reader, uploadURL string, httpOptions *HTTPOptions, progress func(UploadProgress)) (*File, error) {
 	var offset int64 = 0
-	var resp *http.Response
-	var respBody map[string]any
-	var uploadCommand = "upload"
-
 	buffer := make([]byte, maxChunkSize)
-	for {
+	for chunk := 0; ; chunk++ {
+		uploadCommand := "upload"
 		bytesRead, err := io.ReadFull(r, buffer)
 		// Check both EOF and UnexpectedEOF errors.
 		// ErrUnexpectedEOF: Reading a file file_size%maxChunkSize<len(buffer).
@@ -379,66 +414,129 @@ func (ac *apiClient) uploadFile(ctx context.Context, r io.Reader, uploadURL stri
 		} else if err != nil {
 			return nil, fmt.Errorf("Failed to read bytes from file at offset %d: %w. Bytes actually read: %d", offset, err, bytesRead)
 		}
-		for attempt := 0; attempt < maxRetryCount; attempt++ {
-			req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(buffer[:bytesRead]))
-			if err != nil {
-				return nil, fmt.Errorf("Failed to create upload request for chunk at offset %d: %w", offset, err)
-			}
-			doMergeHeaders(httpOptions.Headers, &req.Header)
-			doMergeHeaders(sdkHeader(ctx, ac), &req.Header)
-
-			req.Header.Set("X-Goog-Upload-Command", uploadCommand)
-			req.Header.Set("X-Goog-Upload-Offset", strconv.FormatInt(offset, 10))
-			req.Header.Set("Content-Length", strconv.FormatInt(int64(bytesRead), 10))
-			resp, err = doRequest(ac, req)
-			if err != nil {
-				return nil, fmt.Errorf("upload request failed for chunk at offset %d: %w", offset, err)
-			}
-			if resp.Header.Get("X-Goog-Upload-Status") != "" {
-				break
-			}
-			resp.Body.Close()
-
-			select {
-			case <-ctx.Done():
-				return nil, fmt.Errorf("upload aborted while waiting to retry (attempt %d, offset %d): %w", attempt+1, offset, ctx.Err())
-			case <-time.After(initialRetryDelay * time.Duration(delayMultiplier^attempt)):
-				// Sleep completed, continue to the next attempt.
-			}
-		}
-		defer resp.Body.Close()
 
-		respBody, err = deserializeUnaryResponse(resp)
+		respBody, uploadStatus, err := ac.uploadChunk(ctx, uploadURL, httpOptions, buffer[:bytesRead], offset, uploadCommand)
 		if err != nil {
-			return nil, fmt.Errorf("response body is invalid for chunk at offset %d: %w", offset, err)
+			return nil, err
 		}
-
 		offset += int64(bytesRead)
-
-		uploadStatus := resp.Header.Get("X-Goog-Upload-Status")
+		if progress != nil {
+			progress(UploadProgress{BytesSent: offset, Chunk: chunk, ChunkBytes: bytesRead})
+		}
 
 		if uploadStatus != "final" && strings.Contains(uploadCommand, "finalize") {
 			return nil, fmt.Errorf("send finalize command but doesn't receive final status. Offset %d, Bytes read: %d, Upload status: %s", offset, bytesRead, uploadStatus)
 		}
+		if uploadStatus == "final" {
+			file, ok := respBody["file"].(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("Failed to upload file: the final response has no file")
+			}
+			var response = new(File)
+			if err := mapToStruct(file, &response); err != nil {
+				return nil, err
+			}
+			return response, nil
+		}
 		if uploadStatus != "active" {
-			// Upload is complete ('final') or interrupted ('cancelled', etc.)
-			break
+			// The upload was interrupted ('cancelled', etc.)
+			return nil, fmt.Errorf("Failed to upload file: Upload status is not finalized. Upload status: %s", uploadStatus)
 		}
 	}
+}
 
-	if resp == nil {
-		return nil, fmt.Errorf("Upload request failed. No response received")
+// uploadChunk sends a chunk of a resumable upload starting at offset, and returns
+// the response body and the upload status. Failed attempts are retried with an
+// exponential backoff. Before retrying, the upload is queried for the number of
+// bytes the server has persisted, so that only the rest of the chunk is resent.
+func (ac *apiClient) uploadChunk(ctx context.Context, uploadURL string, httpOptions *HTTPOptions, chunk []byte, offset int64, uploadCommand string) (map[string]any, string, error) {
+	var lastErr error
+	delay := initialRetryDelay
+	sent := 0
+	for attempt := 0; attempt < maxRetryCount; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, "", fmt.Errorf("upload aborted while waiting to retry (attempt %d, offset %d): %w", attempt+1, offset, ctx.Err())
+			case <-time.After(delay):
+				// Sleep completed, continue to the next attempt.
+			}
+			delay *= delayMultiplier
+			if received, ok := ac.queryUpload(ctx, uploadURL, httpOptions); ok && received >= offset && received <= offset+int64(len(chunk)) {
+				sent = int(received - offset)
+			}
+		}
+		respBody, uploadStatus, retryable, err := ac.sendUploadRequest(ctx, uploadURL, httpOptions, chunk[sent:], offset+int64(sent), uploadCommand)
+		if err == nil {
+			return respBody, uploadStatus, nil
+		}
+		if !retryable || ctx.Err() != nil {
+			return nil, "", err
+		}
+		lastErr = err
 	}
+	return nil, "", fmt.Errorf("upload request failed for chunk at offset %d after %d attempts: %w", offset, maxRetryCount, lastErr)
+}
 
-	finalUploadStatus := resp.Header.Get("X-Goog-Upload-Status")
-	if finalUploadStatus != "final" {
-		return nil, fmt.Errorf("Failed to upload file: Upload status is not finalized")
+// sendUploadRequest sends a single upload request with the given data, and returns
+// the response body and the upload status. On failure, it reports whether the
+// request can be retried: network errors, responses without upload status, rate
+// limiting and server errors are transient.
+func (ac *apiClient) sendUploadRequest(ctx context.Context, uploadURL string, httpOptions *HTTPOptions, data []byte, offset int64, uploadCommand string) (map[string]any, string, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, "", false, fmt.Errorf("Failed to create upload request for chunk at offset %d: %w", offset, err)
 	}
+	doMergeHeaders(httpOptions.Headers, &req.Header)
+	doMergeHeaders(sdkHeader(ctx, ac), &req.Header)
 
-	var response = new(File)
-	err := mapToStruct(respBody["file"].(map[string]any), &response)
+	req.Header.Set("X-Goog-Upload-Command", uploadCommand)
+	req.Header.Set("X-Goog-Upload-Offset", strconv.FormatInt(offset, 10))
+	req.Header.Set("Content-Length", strconv.Itoa(len(data)))
+	resp, err := doRequest(ac, req)
 	if err != nil {
-		return nil, err
+		return nil, "", true, fmt.Errorf("upload request failed for chunk at offset %d: %w", offset, err)
+	}
+	defer resp.Body.Close()
+
+	if !httpStatusOk(resp) {
+		err := newAPIError(resp)
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return nil, "", retryable, fmt.Errorf("upload request failed for chunk at offset %d: %w", offset, err)
+	}
+	uploadStatus := resp.Header.Get("X-Goog-Upload-Status")
+	if uploadStatus == "" {
+		return nil, "", true, fmt.Errorf("upload response for chunk at offset %d has no upload status", offset)
+	}
+	respBody, err := deserializeUnaryResponse(resp)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("response body is invalid for chunk at offset %d: %w", offset, err)
+	}
+	return respBody, uploadStatus, false, nil
+}
+
+// queryUpload returns the number of bytes of an active upload persisted by the
+// server, and whether it could be determined.
+func (ac *apiClient) queryUpload(ctx context.Context, uploadURL string, httpOptions *HTTPOptions) (int64, bool) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, nil)
+	if err != nil {
+		return 0, false
+	}
+	doMergeHeaders(httpOptions.Headers, &req.Header)
+	doMergeHeaders(sdkHeader(ctx, ac), &req.Header)
+	req.Header.Set("X-Goog-Upload-Command", "query")
+	resp, err := doRequest(ac, req)
+	if err != nil {
+		return 0, false
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if !httpStatusOk(resp) || resp.Header.Get("X-Goog-Upload-Status") != "active" {
+		return 0, false
+	}
+	received, err := strconv.ParseInt(resp.Header.Get("X-Goog-Upload-Size-Received"), 10, 64)
+	if err != nil {
+		return 0, false
 	}
-	return response, nil
+	return received, true
 }
@@ -1,8 +1,10 @@
 package genai
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -618,6 +620,72 @@ func TestBuildRequest(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Vertex AI API with dedicated Provisioned Throughput",
+			clientConfig: &ClientConfig{
+				Project:     "test-project",
+				Location:    "test-location",
+				Backend:     BackendVertexAI,
+				HTTPClient:  &http.Client{},
+				Credentials: &auth.Credentials{},
+			},
+			path:   "models/test-model:generateContent",
+			body:   map[string]any{"key": "value"},
+			method: "POST",
+			httpOptions: &HTTPOptions{
+				BaseURL:               "https://test-location-aiplatform.googleapis.com",
+				APIVersion:            "v1beta1",
+				ProvisionedThroughput: ProvisionedThroughputModeDedicated,
+			},
+			want: &http.Request{
+				Method: "POST",
+				URL: &url.URL{
+					Scheme: "https",
+					Host:   "test-location-aiplatform.googleapis.com",
+					Path:   "/v1beta1/projects/test-project/locations/test-location/models/test-model:generateContent",
+				},
+				Header: http.Header{
+					"Content-Type":                 []string{"application/json"},
+					"X-Vertex-Ai-Llm-Request-Type": []string{"dedicated"},
+					"User-Agent":                   []string{fmt.Sprintf("google-genai-sdk/%s gl-go/%s", version, runtime.Version())},
+					"X-Goog-Api-Client":            []string{fmt.Sprintf("google-genai-sdk/%s gl-go/%s", version, runtime.Version())},
+				},
+				Body: io.NopCloser(strings.NewReader("{\"key\":\"value\"}\n")),
+			},
+			wantErr: false,
+		},
+		{
+			name: "MLDev API ignores Provisioned Throughput",
+			clientConfig: &ClientConfig{
+				APIKey:     "test-api-key",
+				Backend:    BackendGeminiAPI,
+				HTTPClient: &http.Client{},
+			},
+			path:   "models/test-model:generateContent",
+			body:   map[string]any{"key": "value"},
+			method: "POST",
+			httpOptions: &HTTPOptions{
+				BaseURL:               "https://generativelanguage.googleapis.com",
+				APIVersion:            "v1beta",
+				ProvisionedThroughput: ProvisionedThroughputModeShared,
+			},
+			want: &http.Request{
+				Method: "POST",
+				URL: &url.URL{
+					Scheme: "https",
+					Host:   "generativelanguage.googleapis.com",
+					Path:   "/v1beta/models/test-model:generateContent",
+				},
+				Header: http.Header{
+					"Content-Type":      []string{"application/json"},
+					"X-Goog-Api-Key":    []string{"test-api-key"},
+					"User-Agent":        []string{fmt.Sprintf("google-genai-sdk/%s gl-go/%s", version, runtime.Version())},
+					"X-Goog-Api-Client": []string{fmt.Sprintf("google-genai-sdk/%s gl-go/%s", version, runtime.Version())},
+				},
+				Body: io.NopCloser(strings.NewReader("{\"key\":\"value\"}\n")),
+			},
+			wantErr: false,
+		},
 		{
 			name: "Vertex AI API with full path",
 			clientConfig: &ClientConfig{
@@ -749,6 +817,70 @@ func TestBuildRequest(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Vertex AI endpoint in another location",
+			clientConfig: &ClientConfig{
+				Project:     "test-project",
+				Location:    "us-central1",
+				Backend:     BackendVertexAI,
+				HTTPClient:  &http.Client{},
+				Credentials: &auth.Credentials{},
+			},
+			path:   "projects/test-project/locations/europe-west4/endpoints/123:generateContent",
+			body:   map[string]any{},
+			method: "POST",
+			httpOptions: &HTTPOptions{
+				BaseURL:    "https://us-central1-aiplatform.googleapis.com/",
+				APIVersion: "v1beta1",
+			},
+			want: &http.Request{
+				Method: "POST",
+				URL: &url.URL{
+					Scheme: "https",
+					Host:   "europe-west4-aiplatform.googleapis.com",
+					Path:   "//v1beta1/projects/test-project/locations/europe-west4/endpoints/123:generateContent",
+				},
+				Header: http.Header{
+					"Content-Type":      []string{"application/json"},
+					"User-Agent":        []string{fmt.Sprintf("google-genai-sdk/%s gl-go/%s", version, runtime.Version())},
+					"X-Goog-Api-Client": []string{fmt.Sprintf("google-genai-sdk/%s gl-go/%s", version, runtime.Version())},
+				},
+				Body: io.NopCloser(strings.NewReader(``)),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Vertex AI endpoint in another location with custom base URL",
+			clientConfig: &ClientConfig{
+				Project:     "test-project",
+				Location:    "us-central1",
+				Backend:     BackendVertexAI,
+				HTTPClient:  &http.Client{},
+				Credentials: &auth.Credentials{},
+			},
+			path:   "projects/test-project/locations/europe-west4/endpoints/123:generateContent",
+			body:   map[string]any{},
+			method: "POST",
+			httpOptions: &HTTPOptions{
+				BaseURL:    "https://proxy.example.com",
+				APIVersion: "v1beta1",
+			},
+			want: &http.Request{
+				Method: "POST",
+				URL: &url.URL{
+					Scheme: "https",
+					Host:   "proxy.example.com",
+					Path:   "/v1beta1/projects/test-project/locations/europe-west4/endpoints/123:generateContent",
+				},
+				Header: http.Header{
+					"Content-Type":      []string{"application/json"},
+					"User-Agent":        []string{fmt.Sprintf("google-genai-sdk/%s gl-go/%s", version, runtime.Version())},
+					"X-Goog-Api-Client": []string{fmt.Sprintf("google-genai-sdk/%s gl-go/%s", version, runtime.Version())},
+				},
+				Body: io.NopCloser(strings.NewReader(``)),
+			},
+			wantErr: false,
+		},
 		{
 			name: "Invalid URL",
 			clientConfig: &ClientConfig{
@@ -1150,7 +1282,7 @@ func TestUploadFile(t *testing.T) {
 
 			uploadURL := server.URL + "/upload"
 
-			uploadedFile, err := ac.uploadFile(ctx, fileReader, uploadURL, httpOpts)
+			uploadedFile, err := ac.uploadFile(ctx, fileReader, uploadURL, httpOpts, nil)
 
 			if err != nil {
 				t.Fatalf("uploadFile failed: %v", err)
@@ -1181,3 +1313,84 @@ func TestUploadFile(t *testing.T) {
 		})
 	}
 }
+
+func TestUploadFileRetry(t *testing.T) {
+	defer func(delay time.Duration) { initialRetryDelay = delay }(initialRetryDelay)
+	initialRetryDelay = time.Millisecond
+
+	data := bytes.Repeat([]byte("0123456789"), 100)
+	var received []byte
+	var commands []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		command := r.Header.Get("X-Goog-Upload-Command")
+		commands = append(commands, command+"@"+r.Header.Get("X-Goog-Upload-Offset"))
+		if command == "query" {
+			w.Header().Set("X-Goog-Upload-Status", "active")
+			w.Header().Set("X-Goog-Upload-Size-Received", strconv.Itoa(len(received)))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		offset, _ := strconv.Atoi(r.Header.Get("X-Goog-Upload-Offset"))
+		if offset != len(received) {
+			http.Error(w, "offset mismatch", http.StatusBadRequest)
+			return
+		}
+		if len(commands) == 1 {
+			// Persist half of the chunk, then fail.
+			received = append(received, body[:len(body)/2]...)
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		received = append(received, body...)
+		w.Header().Set("X-Goog-Upload-Status", "final")
+		fmt.Fprintf(w, `{"file": {"name": "files/abc", "sizeBytes": "%d"}}`, len(received))
+	}))
+	defer server.Close()
+
+	ac := &apiClient{clientConfig: &ClientConfig{HTTPClient: server.Client(), APIKey: "test-key-upload"}}
+	file, err := ac.uploadFile(context.Background(), bytes.NewReader(data), server.URL, &HTTPOptions{Headers: http.Header{}}, nil)
+	if err != nil {
+		t.Fatalf("uploadFile() failed: %v", err)
+	}
+	if file.Name != "files/abc" {
+		t.Errorf("file.Name = %q, want %q", file.Name, "files/abc")
+	}
+	if !bytes.Equal(received, data) {
+		t.Errorf("received %d bytes, want the %d bytes of the file", len(received), len(data))
+	}
+	wantCommands := []string{"upload, finalize@0", "query@", "upload, finalize@500"}
+	if diff := cmp.Diff(wantCommands, commands); diff != "" {
+		t.Errorf("upload commands mismatch (-want +got):\n%s", diff)
+	}
+
+	t.Run("ClientError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bad request", http.StatusBadRequest)
+		}))
+		defer server.Close()
+		ac := &apiClient{clientConfig: &ClientConfig{HTTPClient: server.Client(), APIKey: "test-key-upload"}}
+		_, err := ac.uploadFile(context.Background(), bytes.NewReader(data), server.URL, &HTTPOptions{Headers: http.Header{}}, nil)
+		var apiErr APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+			t.Errorf("uploadFile() error = %v, want an APIError with code 400", err)
+		}
+	})
+
+	t.Run("RetriesExhausted", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Goog-Upload-Command") != "query" {
+				attempts++
+			}
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+		ac := &apiClient{clientConfig: &ClientConfig{HTTPClient: server.Client(), APIKey: "test-key-upload"}}
+		if _, err := ac.uploadFile(context.Background(), bytes.NewReader(data), server.URL, &HTTPOptions{Headers: http.Header{}}, nil); err == nil {
+			t.Errorf("uploadFile() succeeded, want error")
+		}
+		if attempts != maxRetryCount {
+			t.Errorf("attempts = %d, want %d", attempts, maxRetryCount)
+		}
+	})
+}
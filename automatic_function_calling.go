@@ -0,0 +1,190 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+)
+
+const (
+	defaultMaximumRemoteCalls = 10
+	defaultMaxConcurrentCalls = 8
+)
+
+// AutomaticFunctionCallingConfig configures the tool execution loop of
+// [Models.GenerateContentWithTools].
+type AutomaticFunctionCallingConfig struct {
+	// Required. Handlers maps [FunctionDeclaration.Name] to the Go function that
+	// implements it. See [NewFunctionDeclarationFromFunc].
+	Handlers map[string]FunctionHandler
+	// Optional. Maximum number of calls to the model. Defaults to 10.
+	MaximumRemoteCalls int
+	// Optional. Maximum number of handlers executed concurrently when the model
+	// returns several function calls in one turn. Defaults to 8. Set it to 1 to
+	// execute the calls sequentially.
+	MaxConcurrentCalls int
+}
+
+// GenerateContentWithTools generates content and automatically executes the
+// function calls returned by the model.
+//
+// Whenever the model responds with [FunctionCall] parts that all have a handler
+// registered in afc, the handlers are invoked, their results are sent back to the
+// model as [FunctionResponse] parts and the model is called again. The loop stops
+// when the model returns a response without function calls, when it calls a
+// function without a registered handler, or when afc.MaximumRemoteCalls is reached.
+// In the last two cases the response with the pending function calls is returned.
+//
+// The function declarations themselves must be provided in config.Tools. A handler
+// error is reported to the model under the "error" key of the [FunctionResponse].
+// The turns exchanged during the loop are recorded in
+// [GenerateContentResponse.AutomaticFunctionCallingHistory].
+func (m Models) GenerateContentWithTools(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig, afc *AutomaticFunctionCallingConfig) (*GenerateContentResponse, error) {
+	return m.generateContentWithTools(ctx, model, contents, config, afc, nil)
+}
+
+// generateContentWithTools implements [Models.GenerateContentWithTools], calling
+// the optional onResponse with the response of every call to the model.
+func (m Models) generateContentWithTools(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig, afc *AutomaticFunctionCallingConfig, onResponse func(*GenerateContentResponse)) (*GenerateContentResponse, error) {
+	if onResponse == nil {
+		onResponse = func(*GenerateContentResponse) {}
+	}
+	if afc == nil || len(afc.Handlers) == 0 {
+		response, err := m.GenerateContent(ctx, model, contents, config)
+		if err != nil {
+			return nil, err
+		}
+		onResponse(response)
+		return response, nil
+	}
+	maxCalls := afc.MaximumRemoteCalls
+	if maxCalls <= 0 {
+		maxCalls = defaultMaximumRemoteCalls
+	}
+
+	history := append([]*Content(nil), contents...)
+	for remoteCalls := 1; ; remoteCalls++ {
+		// GenerateContent clears the HTTPOptions of the config it is given, so every
+		// call gets its own copy.
+		var callConfig *GenerateContentConfig
+		if config != nil {
+			c := *config
+			callConfig = &c
+		}
+		response, err := m.GenerateContent(ctx, model, history, callConfig)
+		if err != nil {
+			return nil, err
+		}
+		onResponse(response)
+		if remoteCalls > 1 {
+			response.AutomaticFunctionCallingHistory = history
+		}
+
+		functionCalls := response.FunctionCalls()
+		if len(functionCalls) == 0 || !hasHandlers(functionCalls, afc.Handlers) {
+			return response, nil
+		}
+		if remoteCalls >= maxCalls {
+			log.Printf("Warning: reached the maximum number of remote calls (%d), returning the pending function calls.", maxCalls)
+			return response, nil
+		}
+
+		parts, err := callFunctions(ctx, functionCalls, afc.Handlers, afc.MaxConcurrentCalls)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history,
+			copySanitizedModelContent(response.Candidates[0].Content),
+			&Content{Role: RoleUser, Parts: parts},
+		)
+	}
+}
+
+func hasHandlers(functionCalls []*FunctionCall, handlers map[string]FunctionHandler) bool {
+	for _, fc := range functionCalls {
+		if _, ok := handlers[fc.Name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// callFunctions invokes the handler of each function call, running at most
+// maxConcurrent handlers at a time, and returns the corresponding [FunctionResponse]
+// parts in the same order as functionCalls.
+func callFunctions(ctx context.Context, functionCalls []*FunctionCall, handlers map[string]FunctionHandler, maxConcurrent int) ([]*Part, error) {
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrentCalls
+	}
+	parts := make([]*Part, len(functionCalls))
+	if len(functionCalls) == 1 || maxConcurrent == 1 {
+		for i, fc := range functionCalls {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("callFunctions: %w", err)
+			}
+			parts[i] = callFunction(ctx, fc, handlers[fc.Name])
+		}
+		return parts, nil
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrent)
+	for i, fc := range functionCalls {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, fmt.Errorf("callFunctions: %w", ctx.Err())
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			parts[i] = callFunction(ctx, fc, handlers[fc.Name])
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("callFunctions: %w", err)
+	}
+	return parts, nil
+}
+
+// functionCallKey is the context key of the function call passed to a handler.
+type functionCallKey struct{}
+
+// functionCallFromContext returns the function call whose handler is called with
+// ctx, if any.
+func functionCallFromContext(ctx context.Context) (*FunctionCall, bool) {
+	fc, ok := ctx.Value(functionCallKey{}).(*FunctionCall)
+	return fc, ok
+}
+
+func callFunction(ctx context.Context, fc *FunctionCall, handler FunctionHandler) *Part {
+	response, err := handler(context.WithValue(ctx, functionCallKey{}, fc), fc.Args)
+	if err != nil {
+		response = map[string]any{"error": err.Error()}
+	}
+	return &Part{
+		FunctionResponse: &FunctionResponse{
+			ID:       fc.ID,
+			Name:     fc.Name,
+			Response: response,
+		},
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const functionCallResponseJSON = `{
+	"candidates": [{
+		"content": {
+			"role": "model",
+			"parts": [
+				{"functionCall": {"id": "call-1", "name": "get_weather", "args": {"city": "Paris"}}},
+				{"functionCall": {"id": "call-2", "name": "get_time", "args": {}}}
+			]
+		}
+	}]
+}`
+
+const finalTextResponseJSON = `{
+	"candidates": [{
+		"content": {"role": "model", "parts": [{"text": "It is sunny."}]},
+		"finishReason": "STOP"
+	}]
+}`
+
+// newAutomaticFunctionCallingTestModels returns a Models backed by a test server that
+// replies with the given responses in order, recording each request body.
+func newAutomaticFunctionCallingTestModels(t *testing.T, responses []string, requests *[]map[string]any) Models {
+	t.Helper()
+	return *newTestClient(t, BackendGeminiAPI, replyInOrder(t, responses, requests)).Models
+}
+
+func TestGenerateContentWithTools(t *testing.T) {
+	ctx := context.Background()
+	handlers := map[string]FunctionHandler{
+		"get_weather": func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			return map[string]any{"weather": "sunny in " + args["city"].(string)}, nil
+		},
+		"get_time": func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			return nil, errors.New("clock unavailable")
+		},
+	}
+
+	t.Run("RunsUntilFinalAnswer", func(t *testing.T) {
+		var requests []map[string]any
+		models := newAutomaticFunctionCallingTestModels(t, []string{functionCallResponseJSON, finalTextResponseJSON}, &requests)
+
+		resp, err := models.GenerateContentWithTools(ctx, "gemini-2.0-flash", Text("Weather?"), nil, &AutomaticFunctionCallingConfig{Handlers: handlers})
+		if err != nil {
+			t.Fatalf("GenerateContentWithTools() failed: %v", err)
+		}
+		if got := resp.Text(); got != "It is sunny." {
+			t.Errorf("Text() = %q, want %q", got, "It is sunny.")
+		}
+		if len(requests) != 2 {
+			t.Fatalf("got %d requests, want 2", len(requests))
+		}
+		wantHistory := []*Content{
+			{Role: RoleUser, Parts: []*Part{{Text: "Weather?"}}},
+			{Role: RoleModel, Parts: []*Part{
+				{FunctionCall: &FunctionCall{ID: "call-1", Name: "get_weather", Args: map[string]any{"city": "Paris"}}},
+				{FunctionCall: &FunctionCall{ID: "call-2", Name: "get_time", Args: map[string]any{}}},
+			}},
+			{Role: RoleUser, Parts: []*Part{
+				{FunctionResponse: &FunctionResponse{ID: "call-1", Name: "get_weather", Response: map[string]any{"weather": "sunny in Paris"}}},
+				{FunctionResponse: &FunctionResponse{ID: "call-2", Name: "get_time", Response: map[string]any{"error": "clock unavailable"}}},
+			}},
+		}
+		if diff := cmp.Diff(wantHistory, resp.AutomaticFunctionCallingHistory); diff != "" {
+			t.Errorf("AutomaticFunctionCallingHistory mismatch (-want +got):\n%s", diff)
+		}
+		if got := len(requests[1]["contents"].([]any)); got != 3 {
+			t.Errorf("second request has %d contents, want 3", got)
+		}
+	})
+
+	t.Run("StopsAtMaximumRemoteCalls", func(t *testing.T) {
+		var requests []map[string]any
+		models := newAutomaticFunctionCallingTestModels(t, []string{functionCallResponseJSON}, &requests)
+
+		resp, err := models.GenerateContentWithTools(ctx, "gemini-2.0-flash", Text("Weather?"), nil, &AutomaticFunctionCallingConfig{Handlers: handlers, MaximumRemoteCalls: 3})
+		if err != nil {
+			t.Fatalf("GenerateContentWithTools() failed: %v", err)
+		}
+		if len(requests) != 3 {
+			t.Errorf("got %d requests, want 3", len(requests))
+		}
+		if len(resp.FunctionCalls()) != 2 {
+			t.Errorf("got %d pending function calls, want 2", len(resp.FunctionCalls()))
+		}
+	})
+
+	t.Run("ReturnsUnhandledFunctionCalls", func(t *testing.T) {
+		var requests []map[string]any
+		models := newAutomaticFunctionCallingTestModels(t, []string{functionCallResponseJSON}, &requests)
+
+		onlyWeather := map[string]FunctionHandler{"get_weather": handlers["get_weather"]}
+		resp, err := models.GenerateContentWithTools(ctx, "gemini-2.0-flash", Text("Weather?"), nil, &AutomaticFunctionCallingConfig{Handlers: onlyWeather})
+		if err != nil {
+			t.Fatalf("GenerateContentWithTools() failed: %v", err)
+		}
+		if len(requests) != 1 {
+			t.Errorf("got %d requests, want 1", len(requests))
+		}
+		if resp.AutomaticFunctionCallingHistory != nil {
+			t.Errorf("AutomaticFunctionCallingHistory = %v, want nil", resp.AutomaticFunctionCallingHistory)
+		}
+	})
+}
+
+func TestCallFunctionsConcurrently(t *testing.T) {
+	ctx := context.Background()
+	const numCalls = 6
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	release := make(chan struct{})
+	handler := func(ctx context.Context, args map[string]any) (map[string]any, error) {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return map[string]any{"n": args["n"]}, nil
+	}
+	var calls []*FunctionCall
+	for i := 0; i < numCalls; i++ {
+		calls = append(calls, &FunctionCall{ID: fmt.Sprintf("call-%d", i), Name: "f", Args: map[string]any{"n": i}})
+	}
+
+	done := make(chan struct{})
+	var parts []*Part
+	var err error
+	go func() {
+		parts, err = callFunctions(ctx, calls, map[string]FunctionHandler{"f": handler}, 3)
+		close(done)
+	}()
+	for {
+		mu.Lock()
+		r := running
+		mu.Unlock()
+		if r == 3 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	<-done
+
+	if err != nil {
+		t.Fatalf("callFunctions() failed: %v", err)
+	}
+	if maxRunning != 3 {
+		t.Errorf("max concurrent handlers = %d, want 3", maxRunning)
+	}
+	for i, part := range parts {
+		want := &FunctionResponse{ID: fmt.Sprintf("call-%d", i), Name: "f", Response: map[string]any{"n": i}}
+		if diff := cmp.Diff(want, part.FunctionResponse); diff != "" {
+			t.Errorf("part %d mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+}
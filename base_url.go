@@ -14,6 +14,11 @@
 
 package genai
 
+import (
+	"fmt"
+	"strings"
+)
+
 var defaultBaseGeminiURL string = ""
 var defaultBaseVertexURL string = ""
 
@@ -69,3 +74,37 @@ func getBaseURL(backend Backend, httpOptions *HTTPOptions, envVars map[string]st
 
 	return ""
 }
+
+// vertexBaseURL returns the default base URL of Vertex AI in the location.
+func vertexBaseURL(location string) string {
+	if location == "global" {
+		return "https://aiplatform.googleapis.com/"
+	}
+	return fmt.Sprintf("https://%s-aiplatform.googleapis.com/", location)
+}
+
+// vertexResourceLocation returns the location of a Vertex AI resource path such as
+// projects/p/locations/europe-west4/endpoints/123, or "" if the path has none.
+func vertexResourceLocation(path string) string {
+	parts := strings.SplitN(path, "/", 5)
+	if len(parts) < 4 || parts[0] != "projects" || parts[2] != "locations" {
+		return ""
+	}
+	return parts[3]
+}
+
+// discoveryEngineBaseURL is the base URL of the Vertex AI ranking API, see
+// [Models.Rerank].
+const discoveryEngineBaseURL = "https://discoveryengine.googleapis.com/"
+
+// rankingHTTPOptions returns the options of a request to the ranking API: the
+// requests sent to the default Vertex AI endpoint are sent to the ranking API.
+func rankingHTTPOptions(location string, httpOptions *HTTPOptions) *HTTPOptions {
+	if httpOptions.BaseURL != vertexBaseURL(location) {
+		return httpOptions
+	}
+	o := *httpOptions
+	o.BaseURL = discoveryEngineBaseURL
+	o.APIVersion = "v1"
+	return &o
+}
@@ -0,0 +1,383 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// bigqueryBaseURL is the endpoint of the BigQuery REST API.
+var bigqueryBaseURL = "https://bigquery.googleapis.com/"
+
+// BatchResult is the result of a request of a batch job.
+type BatchResult struct {
+	// The key of the request: the "key" value of its metadata or of its line in the
+	// source file, or "request-<i>" for the i-th result if the request has no key.
+	Key string
+	// The response to the request. Nil if the request failed.
+	Response *GenerateContentResponse
+	// The error of the request. Nil if the request succeeded.
+	Error *JobError
+}
+
+// Results returns an iterator over the results of a succeeded batch job, see
+// [Batches.Get]. The results are read from the destination of the job one page or
+// line at a time, so that the output of large jobs is never held in memory:
+//
+//   - the inlined responses and the responses file of a Gemini API job,
+//   - the "predictions*.jsonl" files of the Cloud Storage output directory of a
+//     Vertex AI job, or all the files of the destination prefix if the job has no
+//     output info,
+//   - the rows of the BigQuery output table of a Vertex AI job.
+//
+// The failure of a request is reported in the Error field of its result, and the
+// iteration continues. The iteration ends with a non-nil error if the results
+// cannot be read.
+//
+//	for result, err := range client.Batches.Results(ctx, job) {
+//		if err != nil {
+//			return err
+//		}
+//		if result.Error != nil {
+//			log.Printf("%s failed: %s", result.Key, result.Error.Message)
+//			continue
+//		}
+//		fmt.Println(result.Key, result.Response.Text())
+//	}
+func (m Batches) Results(ctx context.Context, job *BatchJob) iter.Seq2[*BatchResult, error] {
+	return func(yield func(*BatchResult, error) bool) {
+		yieldResult := func(r *BatchResult) bool { return yield(r, nil) }
+		var err error
+		switch {
+		case job == nil:
+			err = fmt.Errorf("batch job is nil")
+		case job.State != JobStateSucceeded && job.State != JobStatePartiallySucceeded:
+			err = fmt.Errorf("batch job %s has not succeeded, its state is %s", job.Name, job.State)
+		case job.Dest == nil:
+			err = fmt.Errorf("batch job %s has no destination", job.Name)
+		case job.Dest.InlinedResponses != nil:
+			for i, r := range job.Dest.InlinedResponses {
+				result := &BatchResult{Key: r.Metadata["key"], Response: r.Response, Error: r.Error}
+				if result.Key == "" {
+					result.Key = fmt.Sprintf("request-%d", i)
+				}
+				if !yield(result, nil) {
+					return
+				}
+			}
+		case job.Dest.FileName != "":
+			err = m.fileResults(ctx, job.Dest.FileName, yieldResult)
+		case job.OutputInfo != nil && job.OutputInfo.GCSOutputDirectory != "":
+			err = m.gcsResults(ctx, job.OutputInfo.GCSOutputDirectory+"/", true, yieldResult)
+		case job.OutputInfo != nil && job.OutputInfo.BigqueryOutputTable != "":
+			err = m.bigqueryResults(ctx, job.OutputInfo.BigqueryOutputDataset+"."+job.OutputInfo.BigqueryOutputTable, yieldResult)
+		case job.Dest.GCSURI != "":
+			err = m.gcsResults(ctx, strings.TrimSuffix(job.Dest.GCSURI, "/")+"/", false, yieldResult)
+		case job.Dest.BigqueryURI != "":
+			err = m.bigqueryResults(ctx, job.Dest.BigqueryURI, yieldResult)
+		default:
+			err = fmt.Errorf("batch job %s has no results", job.Name)
+		}
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// errStopResults is returned by the readers of results when the iteration is
+// stopped by the caller.
+var errStopResults = errors.New("iteration stopped")
+
+// fileResults yields the results of the responses file of a Gemini API batch job.
+func (m Batches) fileResults(ctx context.Context, fileName string, yield func(*BatchResult) bool) error {
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		return fmt.Errorf("batch job responses files are only supported in the Gemini Developer client")
+	}
+	name, err := tFileName(m.apiClient, fileName)
+	if err != nil {
+		return err
+	}
+	req, err := buildRequest(ctx, m.apiClient, fmt.Sprintf("files/%s:download?alt=media", name), nil, http.MethodGet, mergeHTTPOptions(m.apiClient.clientConfig, nil))
+	if err != nil {
+		return err
+	}
+	resp, err := doRequest(m.apiClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if !httpStatusOk(resp) {
+		return newAPIError(resp)
+	}
+	index := 0
+	return ignoreStop(m.readResults(resp.Body, &index, yield))
+}
+
+// gcsResults yields the results of the JSONL files of the Cloud Storage directory of
+// a Vertex AI batch job, in the order of their names. If predictionsOnly is set,
+// only the "predictions*.jsonl" files are read.
+func (m Batches) gcsResults(ctx context.Context, dir string, predictionsOnly bool, yield func(*BatchResult) bool) error {
+	bucket, prefix, err := parseGCSURI(dir)
+	if err != nil {
+		return err
+	}
+	if prefix != "" {
+		prefix += "/"
+	}
+	index := 0
+	pageToken := ""
+	for {
+		query := url.Values{"prefix": {prefix}}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		var objects struct {
+			Items []struct {
+				Name string `json:"name"`
+			} `json:"items"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := getCloudJSON(ctx, m.apiClient, fmt.Sprintf("%sstorage/v1/b/%s/o?%s", gcsBaseURL, url.PathEscape(bucket), query.Encode()), &objects); err != nil {
+			return err
+		}
+		for _, object := range objects.Items {
+			base := path.Base(object.Name)
+			if !strings.HasSuffix(base, ".jsonl") || (predictionsOnly && !strings.HasPrefix(base, "predictions")) {
+				continue
+			}
+			resp, err := getCloud(ctx, m.apiClient, fmt.Sprintf("%sstorage/v1/b/%s/o/%s?alt=media", gcsBaseURL, url.PathEscape(bucket), url.PathEscape(object.Name)))
+			if err != nil {
+				return err
+			}
+			err = m.readResults(resp.Body, &index, yield)
+			resp.Body.Close()
+			if err != nil {
+				return ignoreStop(err)
+			}
+		}
+		if objects.NextPageToken == "" {
+			return nil
+		}
+		pageToken = objects.NextPageToken
+	}
+}
+
+// bigqueryResults yields the results of the rows of the BigQuery table of a Vertex
+// AI batch job, one page at a time.
+func (m Batches) bigqueryResults(ctx context.Context, table string, yield func(*BatchResult) bool) error {
+	project, dataset, tableID, err := parseBigqueryTableURI(table)
+	if err != nil {
+		return err
+	}
+	tableURL := fmt.Sprintf("%sbigquery/v2/projects/%s/datasets/%s/tables/%s", bigqueryBaseURL, url.PathEscape(project), url.PathEscape(dataset), url.PathEscape(tableID))
+	var metadata struct {
+		Schema struct {
+			Fields []struct {
+				Name string `json:"name"`
+			} `json:"fields"`
+		} `json:"schema"`
+	}
+	if err := getCloudJSON(ctx, m.apiClient, tableURL, &metadata); err != nil {
+		return err
+	}
+
+	index := 0
+	pageToken := ""
+	for {
+		u := tableURL + "/data"
+		if pageToken != "" {
+			u += "?" + url.Values{"pageToken": {pageToken}}.Encode()
+		}
+		var data struct {
+			Rows []struct {
+				F []struct {
+					V any `json:"v"`
+				} `json:"f"`
+			} `json:"rows"`
+			PageToken string `json:"pageToken"`
+		}
+		if err := getCloudJSON(ctx, m.apiClient, u, &data); err != nil {
+			return err
+		}
+		for _, row := range data.Rows {
+			raw := make(map[string]any)
+			for i, cell := range row.F {
+				if i >= len(metadata.Schema.Fields) {
+					break
+				}
+				name := metadata.Schema.Fields[i].Name
+				value, _ := cell.V.(string)
+				switch name {
+				case "key", "status":
+					raw[name] = value
+				case "response":
+					if value != "" {
+						var response map[string]any
+						if err := json.Unmarshal([]byte(value), &response); err != nil {
+							return fmt.Errorf("row %d: error decoding response: %w", index, err)
+						}
+						raw[name] = response
+					}
+				}
+			}
+			result, err := m.batchResult(raw, index)
+			if err != nil {
+				return err
+			}
+			index++
+			if !yield(result) {
+				return nil
+			}
+		}
+		if data.PageToken == "" {
+			return nil
+		}
+		pageToken = data.PageToken
+	}
+}
+
+// readResults yields the result of every line of the JSONL r, numbering the results
+// from *index. It returns errStopResults if the iteration is stopped.
+func (m Batches) readResults(r io.Reader, index *int, yield func(*BatchResult) bool) error {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("error reading batch job results: %w", err)
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var raw map[string]any
+			if err := json.Unmarshal(line, &raw); err != nil {
+				return fmt.Errorf("result %d: error decoding line: %w", *index, err)
+			}
+			result, err := m.batchResult(raw, *index)
+			if err != nil {
+				return err
+			}
+			*index++
+			if !yield(result) {
+				return errStopResults
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// batchResult returns the i-th result of a batch job from its raw key, response and
+// error or status.
+func (m Batches) batchResult(raw map[string]any, i int) (*BatchResult, error) {
+	result := &BatchResult{Key: fmt.Sprintf("request-%d", i)}
+	if key, ok := raw["key"].(string); ok && key != "" {
+		result.Key = key
+	}
+	for _, name := range []string{"error", "status"} {
+		switch e := raw[name].(type) {
+		case string:
+			if e != "" {
+				result.Error = &JobError{Message: e}
+			}
+		case map[string]any:
+			result.Error = new(JobError)
+			if err := mapToStruct(e, result.Error); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if response, ok := raw["response"].(map[string]any); ok {
+		var fromConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+		if m.apiClient.clientConfig.Backend == BackendVertexAI {
+			fromConverter = generateContentResponseFromVertex
+		} else {
+			fromConverter = generateContentResponseFromMldev
+		}
+		responseMap, err := fromConverter(m.apiClient, response, nil)
+		if err != nil {
+			return nil, err
+		}
+		result.Response = new(GenerateContentResponse)
+		if err := mapToStruct(responseMap, result.Response); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// ignoreStop returns nil if err is errStopResults, and err otherwise.
+func ignoreStop(err error) error {
+	if errors.Is(err, errStopResults) {
+		return nil
+	}
+	return err
+}
+
+// parseBigqueryTableURI splits a bq://project.dataset.table URI into its parts.
+func parseBigqueryTableURI(uri string) (project, dataset, table string, err error) {
+	rest, ok := strings.CutPrefix(uri, "bq://")
+	if !ok {
+		return "", "", "", fmt.Errorf("%q is not a bq:// URI", uri)
+	}
+	i := strings.LastIndex(rest, ".")
+	j := strings.LastIndex(rest[:max(i, 0)], ".")
+	if i < 0 || j <= 0 || i == len(rest)-1 || j == i-1 {
+		return "", "", "", fmt.Errorf("%q is not a BigQuery table", uri)
+	}
+	return rest[:j], rest[j+1 : i], rest[i+1:], nil
+}
+
+// getCloud sends a GET request to a Google Cloud API other than the API of the
+// client, authenticated with the credentials of the HTTP client only.
+func getCloud(ctx context.Context, ac *apiClient, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = sdkHeader(ctx, ac)
+	req.Header.Del("x-goog-api-key")
+	resp, err := doRequest(ac, req)
+	if err != nil {
+		return nil, err
+	}
+	if !httpStatusOk(resp) {
+		defer resp.Body.Close()
+		return nil, newAPIError(resp)
+	}
+	return resp, nil
+}
+
+// getCloudJSON decodes the JSON response of [getCloud] into v.
+func getCloudJSON(ctx context.Context, ac *apiClient, u string, v any) error {
+	resp, err := getCloud(ctx, ac, u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("error decoding %s: %w", u, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// FailedRequests returns the requests of a succeeded batch job whose results
+// failed, see [Batches.Results]. The requests must be the requests of the job, in
+// the same order. The requests are matched with the results by key, and the
+// returned requests are copies whose metadata always has the key of the request,
+// so that the results of a new job for the failed requests can be merged with the
+// results of the job, see [MergeBatchResults].
+func (m Batches) FailedRequests(ctx context.Context, job *BatchJob, requests []*InlinedRequest) ([]*InlinedRequest, error) {
+	failed := make(map[string]bool)
+	for result, err := range m.Results(ctx, job) {
+		if err != nil {
+			return nil, err
+		}
+		if result.Error != nil {
+			failed[result.Key] = true
+		}
+	}
+	var failedRequests []*InlinedRequest
+	for i, r := range requests {
+		if r == nil {
+			return nil, fmt.Errorf("request %d is nil", i)
+		}
+		key := r.Metadata["key"]
+		if key == "" {
+			key = fmt.Sprintf("request-%d", i)
+		}
+		if !failed[key] {
+			continue
+		}
+		delete(failed, key)
+		c := *r
+		c.Metadata = maps.Clone(r.Metadata)
+		if c.Metadata == nil {
+			c.Metadata = make(map[string]string)
+		}
+		c.Metadata["key"] = key
+		failedRequests = append(failedRequests, &c)
+	}
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("%d failed results of batch job %s match no request", len(failed), job.Name)
+	}
+	return failedRequests, nil
+}
+
+// RetryFailed creates a batch job for the failed requests of a succeeded batch job,
+// see [Batches.FailedRequests], with the model of the job, and returns it without
+// waiting for its completion. A nil job is returned if no request failed. It is
+// only supported in the Gemini API, use [GenerateRequests] to retry the failed
+// requests of a Vertex AI job online.
+//
+//	retry, err := client.Batches.RetryFailed(ctx, job, requests, nil)
+//	// Wait for the completion of the retry job, then:
+//	results = genai.MergeBatchResults(results, retryResults)
+func (m Batches) RetryFailed(ctx context.Context, job *BatchJob, requests []*InlinedRequest, config *CreateBatchJobConfig) (*BatchJob, error) {
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		return nil, fmt.Errorf("method RetryFailed is only supported in the Gemini Developer client. You can choose to use Gemini Developer client by setting ClientConfig.Backend to BackendGeminiAPI.")
+	}
+	failed, err := m.FailedRequests(ctx, job, requests)
+	if err != nil {
+		return nil, err
+	}
+	if len(failed) == 0 {
+		return nil, nil
+	}
+	return m.Create(ctx, job.Model, &BatchJobSource{InlinedRequests: failed}, config)
+}
+
+// GenerateRequests generates content online for every batch request, as configured
+// by opts, see [GenerateMany], and returns the results in the order of the
+// requests, e.g. to retry the failed requests of a batch job without waiting for a
+// new job. The requests without a model are sent to the given model. The failure of
+// a request is reported in the Error field of its result.
+func GenerateRequests(ctx context.Context, models *Models, model string, requests []*InlinedRequest, opts ParallelOptions) []*BatchResult {
+	responses, errs := generateConcurrently(ctx, len(requests), opts, func(i int) (*GenerateContentResponse, error) {
+		r := requests[i]
+		if r == nil {
+			return nil, fmt.Errorf("request %d is nil", i)
+		}
+		requestModel := r.Model
+		if requestModel == "" {
+			requestModel = model
+		}
+		return models.GenerateContent(ctx, requestModel, r.Contents, r.Config)
+	})
+	results := make([]*BatchResult, len(requests))
+	for i, r := range requests {
+		results[i] = &BatchResult{Key: fmt.Sprintf("request-%d", i), Response: responses[i]}
+		if r != nil && r.Metadata["key"] != "" {
+			results[i].Key = r.Metadata["key"]
+		}
+		if err := errs[i]; err != nil {
+			results[i].Error = &JobError{Message: err.Error()}
+			var apiErr APIError
+			if errors.As(err, &apiErr) {
+				results[i].Error = &JobError{Code: int32(apiErr.Code), Message: apiErr.Message}
+			}
+		}
+	}
+	return results
+}
+
+// MergeBatchResults returns the results with every failed result replaced by the
+// retried result of the same key, if any.
+func MergeBatchResults(results, retried []*BatchResult) []*BatchResult {
+	byKey := make(map[string]*BatchResult, len(retried))
+	for _, r := range retried {
+		byKey[r.Key] = r
+	}
+	merged := make([]*BatchResult, len(results))
+	for i, r := range results {
+		merged[i] = r
+		if retry, ok := byKey[r.Key]; ok && r.Error != nil {
+			merged[i] = retry
+		}
+	}
+	return merged
+}
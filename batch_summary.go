@@ -0,0 +1,228 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"strings"
+)
+
+const defaultBatchPriceFactor = 0.5
+
+// ModelPrice is the price of the tokens of a model, per million tokens, in the
+// currency of choice.
+type ModelPrice struct {
+	// The price of the prompt tokens, tool use prompt tokens included.
+	InputTokens float64
+	// Optional. The price of the prompt tokens read from a cached content. Defaults
+	// to the price of the prompt tokens.
+	CachedInputTokens float64
+	// The price of the response tokens, thought tokens included.
+	OutputTokens float64
+}
+
+// Cost returns the estimated cost of the token usage.
+func (p ModelPrice) Cost(usage *BatchUsage) float64 {
+	if usage == nil {
+		return 0
+	}
+	return p.tokensCost(usage.PromptTokenCount+usage.ToolUsePromptTokenCount, usage.CachedContentTokenCount,
+		usage.CandidatesTokenCount+usage.ThoughtsTokenCount)
+}
+
+// BatchUsage is the cumulative token usage of the responses of a batch job. The
+// counts are 64-bit so that the usage of large jobs does not overflow.
+type BatchUsage struct {
+	// The number of responses.
+	ResponseCount int64
+	// The number of tokens of the prompts, cached content included.
+	PromptTokenCount int64
+	// The number of tokens of the prompts read from a cached content.
+	CachedContentTokenCount int64
+	// The number of tokens of the responses.
+	CandidatesTokenCount int64
+	// The number of tokens of the thoughts of thinking models.
+	ThoughtsTokenCount int64
+	// The number of tokens of the results of tool calls.
+	ToolUsePromptTokenCount int64
+	// The total number of tokens.
+	TotalTokenCount int64
+}
+
+// add adds the usage metadata of a response to the usage.
+func (u *BatchUsage) add(response *GenerateContentResponse) {
+	u.ResponseCount++
+	m := response.UsageMetadata
+	if m == nil {
+		return
+	}
+	u.PromptTokenCount += int64(m.PromptTokenCount)
+	u.CachedContentTokenCount += int64(m.CachedContentTokenCount)
+	u.CandidatesTokenCount += int64(m.CandidatesTokenCount)
+	u.ThoughtsTokenCount += int64(m.ThoughtsTokenCount)
+	u.ToolUsePromptTokenCount += int64(m.ToolUsePromptTokenCount)
+	u.TotalTokenCount += int64(m.TotalTokenCount)
+}
+
+// BatchModelUsage is the usage and estimated cost of the responses of a model of a
+// batch job.
+type BatchModelUsage struct {
+	BatchUsage
+	// The estimated cost of the responses. Zero if the model has no price, see
+	// [BatchJobSummaryConfig].
+	Cost float64
+}
+
+// BatchJobSummary summarizes the results of a succeeded batch job.
+type BatchJobSummary struct {
+	// The batch job.
+	Job *BatchJob
+	// The number of succeeded requests.
+	SucceededCount int64
+	// The number of failed requests.
+	FailedCount int64
+	// The number of failed requests by error code. The requests failing without a
+	// code, e.g. the requests of Vertex AI jobs, are counted under code 0.
+	FailuresByCode map[int32]int64
+	// The usage of all the responses.
+	Usage BatchUsage
+	// The usage and estimated cost of the responses by model version, or by the
+	// model of the job for the responses without a model version.
+	UsageByModel map[string]*BatchModelUsage
+	// The estimated cost of all the responses.
+	Cost float64
+}
+
+// BatchJobSummaryConfig is the optional configuration of [Batches.Summary].
+type BatchJobSummaryConfig struct {
+	// Optional. The prices of the models, by model ID, e.g. "gemini-2.0-flash". The
+	// price of a model version is the price of the longest ID prefixing it, e.g.
+	// "gemini-2.0-flash-001" has the price of "gemini-2.0-flash". As batch requests
+	// are discounted, the prices should be the prices of batch requests.
+	Prices map[string]ModelPrice
+	// Optional. The registry of the prices of the models without a price in Prices.
+	// Its prices are multiplied by BatchPriceFactor. Defaults to the prices of
+	// [DefaultModelPrices].
+	PriceRegistry *PriceRegistry
+	// Optional. The factor applied to the prices of PriceRegistry for batch
+	// requests. Defaults to 0.5, the discount of the batch requests of the Gemini
+	// API and Vertex AI.
+	BatchPriceFactor float64
+}
+
+// Summary gets the named batch job and aggregates its results, see
+// [Batches.Results], into the number of succeeded and failed requests, the token
+// usage, and the cost estimated from the prices of the config, or the batch prices
+// of [DefaultModelPrices] if config is nil.
+//
+//	summary, err := client.Batches.Summary(ctx, job.Name, &genai.BatchJobSummaryConfig{
+//		Prices: map[string]genai.ModelPrice{"gemini-2.0-flash": {InputTokens: 0.05, OutputTokens: 0.2}},
+//	})
+//	fmt.Printf("%d failed, %d tokens, $%.2f\n", summary.FailedCount, summary.Usage.TotalTokenCount, summary.Cost)
+func (m Batches) Summary(ctx context.Context, name string, config *BatchJobSummaryConfig) (*BatchJobSummary, error) {
+	if config == nil {
+		config = &BatchJobSummaryConfig{}
+	}
+	job, err := m.Get(ctx, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	summary := &BatchJobSummary{
+		Job:            job,
+		FailuresByCode: make(map[int32]int64),
+		UsageByModel:   make(map[string]*BatchModelUsage),
+	}
+	for result, err := range m.Results(ctx, job) {
+		if err != nil {
+			return nil, err
+		}
+		if result.Error != nil {
+			summary.FailedCount++
+			summary.FailuresByCode[result.Error.Code]++
+			continue
+		}
+		summary.SucceededCount++
+		if result.Response == nil {
+			continue
+		}
+		model := result.Response.ModelVersion
+		if model == "" {
+			model = job.Model
+		}
+		model = modelID(model)
+		usage, ok := summary.UsageByModel[model]
+		if !ok {
+			usage = &BatchModelUsage{}
+			summary.UsageByModel[model] = usage
+		}
+		usage.add(result.Response)
+		summary.Usage.add(result.Response)
+	}
+	for model, usage := range summary.UsageByModel {
+		if price, ok := config.price(model); ok {
+			usage.Cost = price.Cost(&usage.BatchUsage)
+			summary.Cost += usage.Cost
+		}
+	}
+	return summary, nil
+}
+
+// price returns the price of the batch requests of a model.
+func (c *BatchJobSummaryConfig) price(model string) (ModelPrice, bool) {
+	if price, ok := modelPrice(c.Prices, model); ok {
+		return price, true
+	}
+	prices := c.PriceRegistry
+	if prices == nil {
+		prices = defaultPriceRegistry
+	}
+	price, ok := prices.Price(model)
+	if !ok {
+		return ModelPrice{}, false
+	}
+	factor := c.BatchPriceFactor
+	if factor == 0 {
+		factor = defaultBatchPriceFactor
+	}
+	return price.scaled(factor), true
+}
+
+// modelID returns the ID of a model without its resource name prefix, e.g.
+// "gemini-2.0-flash" for "publishers/google/models/gemini-2.0-flash".
+func modelID(model string) string {
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		return model[i+1:]
+	}
+	return model
+}
+
+// modelPrice returns the price of the longest model ID of prices prefixing model.
+func modelPrice(prices map[string]ModelPrice, model string) (ModelPrice, bool) {
+	price, n := longestPrefixPrice(prices, model)
+	return price, n >= 0
+}
+
+// longestPrefixPrice returns the price of the longest ID prefixing model, and the
+// length of the ID, or -1 if no ID prefixes model.
+func longestPrefixPrice(prices map[string]ModelPrice, model string) (ModelPrice, int) {
+	var price ModelPrice
+	longest := -1
+	for id, p := range prices {
+		if strings.HasPrefix(model, id) && len(id) > longest {
+			price, longest = p, len(id)
+		}
+	}
+	return price, longest
+}
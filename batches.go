@@ -0,0 +1,1254 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+)
+
+func inlinedRequestToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+	request := make(map[string]any)
+
+	fromModel := getValueByPath(fromObject, []string{"model"})
+	if fromModel != nil {
+		fromModel, err = tModel(ac, fromModel)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(request, []string{"model"}, fromModel)
+	}
+
+	fromContents := getValueByPath(fromObject, []string{"contents"})
+	if fromContents != nil {
+		fromContents, err = tContents(ac, fromContents)
+		if err != nil {
+			return nil, err
+		}
+
+		fromContents, err = applyConverterToSlice(ac, fromContents.([]any), contentToMldev)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(request, []string{"contents"}, fromContents)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		fromConfig, err = generateContentConfigToMldev(ac, fromConfig.(map[string]any), request)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(request, []string{"generationConfig"}, fromConfig)
+	}
+
+	setValueByPath(toObject, []string{"request"}, request)
+
+	fromMetadata := getValueByPath(fromObject, []string{"metadata"})
+	if fromMetadata != nil {
+		setValueByPath(toObject, []string{"metadata"}, fromMetadata)
+	}
+
+	return toObject, nil
+}
+
+func batchJobSourceToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	if getValueByPath(fromObject, []string{"format"}) != nil {
+		return nil, fmt.Errorf("format parameter is not supported in Gemini API")
+	}
+
+	if getValueByPath(fromObject, []string{"gcsUri"}) != nil {
+		return nil, fmt.Errorf("gcsUri parameter is not supported in Gemini API")
+	}
+
+	if getValueByPath(fromObject, []string{"bigqueryUri"}) != nil {
+		return nil, fmt.Errorf("bigqueryUri parameter is not supported in Gemini API")
+	}
+
+	fromFileName := getValueByPath(fromObject, []string{"fileName"})
+	if fromFileName != nil {
+		setValueByPath(toObject, []string{"fileName"}, fromFileName)
+	}
+
+	fromInlinedRequests := getValueByPath(fromObject, []string{"inlinedRequests"})
+	if fromInlinedRequests != nil {
+		fromInlinedRequests, err = applyConverterToSlice(ac, fromInlinedRequests.([]any), inlinedRequestToMldev)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"requests", "requests"}, fromInlinedRequests)
+	}
+
+	return toObject, nil
+}
+
+func createBatchJobConfigToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromDisplayName := getValueByPath(fromObject, []string{"displayName"})
+	if fromDisplayName != nil {
+		setValueByPath(parentObject, []string{"batch", "displayName"}, fromDisplayName)
+	}
+
+	if getValueByPath(fromObject, []string{"dest"}) != nil {
+		return nil, fmt.Errorf("dest parameter is not supported in Gemini API")
+	}
+
+	return toObject, nil
+}
+
+func createBatchJobParametersToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromModel := getValueByPath(fromObject, []string{"model"})
+	if fromModel != nil {
+		fromModel, err = tModel(ac, fromModel)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"_url", "model"}, fromModel)
+	}
+
+	fromSrc := getValueByPath(fromObject, []string{"src"})
+	if fromSrc != nil {
+		fromSrc, err = tBatchJobSource(ac, fromSrc)
+		if err != nil {
+			return nil, err
+		}
+
+		fromSrc, err = batchJobSourceToMldev(ac, fromSrc.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"batch", "inputConfig"}, fromSrc)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		fromConfig, err = createBatchJobConfigToMldev(ac, fromConfig.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func getBatchJobParametersToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		fromName, err = tBatchJobName(ac, fromName)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"_url", "name"}, fromName)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func cancelBatchJobParametersToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		fromName, err = tBatchJobName(ac, fromName)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"_url", "name"}, fromName)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func listBatchJobsConfigToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromPageSize := getValueByPath(fromObject, []string{"pageSize"})
+	if fromPageSize != nil {
+		setValueByPath(parentObject, []string{"_query", "pageSize"}, fromPageSize)
+	}
+
+	fromPageToken := getValueByPath(fromObject, []string{"pageToken"})
+	if fromPageToken != nil {
+		setValueByPath(parentObject, []string{"_query", "pageToken"}, fromPageToken)
+	}
+
+	if getValueByPath(fromObject, []string{"filter"}) != nil {
+		return nil, fmt.Errorf("filter parameter is not supported in Gemini API")
+	}
+
+	return toObject, nil
+}
+
+func listBatchJobsParametersToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		fromConfig, err = listBatchJobsConfigToMldev(ac, fromConfig.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func deleteBatchJobParametersToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		fromName, err = tBatchJobName(ac, fromName)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"_url", "name"}, fromName)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func batchJobSourceToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromFormat := getValueByPath(fromObject, []string{"format"})
+	if fromFormat != nil {
+		setValueByPath(toObject, []string{"instancesFormat"}, fromFormat)
+	}
+
+	fromGcsUri := getValueByPath(fromObject, []string{"gcsUri"})
+	if fromGcsUri != nil {
+		setValueByPath(toObject, []string{"gcsSource", "uris"}, fromGcsUri)
+	}
+
+	fromBigqueryUri := getValueByPath(fromObject, []string{"bigqueryUri"})
+	if fromBigqueryUri != nil {
+		setValueByPath(toObject, []string{"bigquerySource", "inputUri"}, fromBigqueryUri)
+	}
+
+	if getValueByPath(fromObject, []string{"fileName"}) != nil {
+		return nil, fmt.Errorf("fileName parameter is not supported in Vertex AI")
+	}
+
+	if getValueByPath(fromObject, []string{"inlinedRequests"}) != nil {
+		return nil, fmt.Errorf("inlinedRequests parameter is not supported in Vertex AI")
+	}
+
+	return toObject, nil
+}
+
+func batchJobDestinationToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromFormat := getValueByPath(fromObject, []string{"format"})
+	if fromFormat != nil {
+		setValueByPath(toObject, []string{"predictionsFormat"}, fromFormat)
+	}
+
+	fromGcsUri := getValueByPath(fromObject, []string{"gcsUri"})
+	if fromGcsUri != nil {
+		setValueByPath(toObject, []string{"gcsDestination", "outputUriPrefix"}, fromGcsUri)
+	}
+
+	fromBigqueryUri := getValueByPath(fromObject, []string{"bigqueryUri"})
+	if fromBigqueryUri != nil {
+		setValueByPath(toObject, []string{"bigqueryDestination", "outputUri"}, fromBigqueryUri)
+	}
+
+	if getValueByPath(fromObject, []string{"fileName"}) != nil {
+		return nil, fmt.Errorf("fileName parameter is not supported in Vertex AI")
+	}
+
+	if getValueByPath(fromObject, []string{"inlinedResponses"}) != nil {
+		return nil, fmt.Errorf("inlinedResponses parameter is not supported in Vertex AI")
+	}
+
+	return toObject, nil
+}
+
+func createBatchJobConfigToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromDisplayName := getValueByPath(fromObject, []string{"displayName"})
+	if fromDisplayName != nil {
+		setValueByPath(parentObject, []string{"displayName"}, fromDisplayName)
+	}
+
+	fromDest := getValueByPath(fromObject, []string{"dest"})
+	if fromDest != nil {
+		fromDest, err = tBatchJobDestination(ac, fromDest)
+		if err != nil {
+			return nil, err
+		}
+
+		fromDest, err = batchJobDestinationToVertex(ac, fromDest.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(parentObject, []string{"outputConfig"}, fromDest)
+	}
+
+	return toObject, nil
+}
+
+func createBatchJobParametersToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromModel := getValueByPath(fromObject, []string{"model"})
+	if fromModel != nil {
+		fromModel, err = tModel(ac, fromModel)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"model"}, fromModel)
+	}
+
+	fromSrc := getValueByPath(fromObject, []string{"src"})
+	if fromSrc != nil {
+		fromSrc, err = tBatchJobSource(ac, fromSrc)
+		if err != nil {
+			return nil, err
+		}
+
+		fromSrc, err = batchJobSourceToVertex(ac, fromSrc.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"inputConfig"}, fromSrc)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		fromConfig, err = createBatchJobConfigToVertex(ac, fromConfig.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func getBatchJobParametersToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		fromName, err = tBatchJobName(ac, fromName)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"_url", "name"}, fromName)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func cancelBatchJobParametersToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		fromName, err = tBatchJobName(ac, fromName)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"_url", "name"}, fromName)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func listBatchJobsConfigToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromPageSize := getValueByPath(fromObject, []string{"pageSize"})
+	if fromPageSize != nil {
+		setValueByPath(parentObject, []string{"_query", "pageSize"}, fromPageSize)
+	}
+
+	fromPageToken := getValueByPath(fromObject, []string{"pageToken"})
+	if fromPageToken != nil {
+		setValueByPath(parentObject, []string{"_query", "pageToken"}, fromPageToken)
+	}
+
+	fromFilter := getValueByPath(fromObject, []string{"filter"})
+	if fromFilter != nil {
+		setValueByPath(parentObject, []string{"_query", "filter"}, fromFilter)
+	}
+
+	return toObject, nil
+}
+
+func listBatchJobsParametersToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		fromConfig, err = listBatchJobsConfigToVertex(ac, fromConfig.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func deleteBatchJobParametersToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		fromName, err = tBatchJobName(ac, fromName)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"_url", "name"}, fromName)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func inlinedResponseFromMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromResponse := getValueByPath(fromObject, []string{"response"})
+	if fromResponse != nil {
+		fromResponse, err = generateContentResponseFromMldev(ac, fromResponse.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"response"}, fromResponse)
+	}
+
+	fromError := getValueByPath(fromObject, []string{"error"})
+	if fromError != nil {
+		setValueByPath(toObject, []string{"error"}, fromError)
+	}
+
+	fromMetadata := getValueByPath(fromObject, []string{"metadata"})
+	if fromMetadata != nil {
+		setValueByPath(toObject, []string{"metadata"}, fromMetadata)
+	}
+
+	return toObject, nil
+}
+
+func batchJobDestinationFromMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromResponsesFile := getValueByPath(fromObject, []string{"responsesFile"})
+	if fromResponsesFile != nil {
+		setValueByPath(toObject, []string{"fileName"}, fromResponsesFile)
+	}
+
+	fromInlinedResponses := getValueByPath(fromObject, []string{"inlinedResponses", "inlinedResponses"})
+	if fromInlinedResponses != nil {
+		fromInlinedResponses, err = applyConverterToSlice(ac, fromInlinedResponses.([]any), inlinedResponseFromMldev)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"inlinedResponses"}, fromInlinedResponses)
+	}
+
+	return toObject, nil
+}
+
+func batchJobStatsFromMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromRequestCount := getValueByPath(fromObject, []string{"requestCount"})
+	if fromRequestCount != nil {
+		setValueByPath(toObject, []string{"requestCount"}, fromRequestCount)
+	}
+
+	fromSuccessfulRequestCount := getValueByPath(fromObject, []string{"successfulRequestCount"})
+	if fromSuccessfulRequestCount != nil {
+		setValueByPath(toObject, []string{"succeededRequestCount"}, fromSuccessfulRequestCount)
+	}
+
+	fromFailedRequestCount := getValueByPath(fromObject, []string{"failedRequestCount"})
+	if fromFailedRequestCount != nil {
+		setValueByPath(toObject, []string{"failedRequestCount"}, fromFailedRequestCount)
+	}
+
+	fromPendingRequestCount := getValueByPath(fromObject, []string{"pendingRequestCount"})
+	if fromPendingRequestCount != nil {
+		setValueByPath(toObject, []string{"pendingRequestCount"}, fromPendingRequestCount)
+	}
+
+	return toObject, nil
+}
+
+func batchJobFromMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		setValueByPath(toObject, []string{"name"}, fromName)
+	}
+
+	fromDisplayName := getValueByPath(fromObject, []string{"metadata", "displayName"})
+	if fromDisplayName != nil {
+		setValueByPath(toObject, []string{"displayName"}, fromDisplayName)
+	}
+
+	fromState := getValueByPath(fromObject, []string{"metadata", "state"})
+	if fromState != nil {
+		fromState, err = tJobState(ac, fromState)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"state"}, fromState)
+	}
+
+	fromError := getValueByPath(fromObject, []string{"error"})
+	if fromError != nil {
+		setValueByPath(toObject, []string{"error"}, fromError)
+	}
+
+	fromCreateTime := getValueByPath(fromObject, []string{"metadata", "createTime"})
+	if fromCreateTime != nil {
+		setValueByPath(toObject, []string{"createTime"}, fromCreateTime)
+	}
+
+	fromEndTime := getValueByPath(fromObject, []string{"metadata", "endTime"})
+	if fromEndTime != nil {
+		setValueByPath(toObject, []string{"endTime"}, fromEndTime)
+	}
+
+	fromUpdateTime := getValueByPath(fromObject, []string{"metadata", "updateTime"})
+	if fromUpdateTime != nil {
+		setValueByPath(toObject, []string{"updateTime"}, fromUpdateTime)
+	}
+
+	fromModel := getValueByPath(fromObject, []string{"metadata", "model"})
+	if fromModel != nil {
+		setValueByPath(toObject, []string{"model"}, fromModel)
+	}
+
+	fromOutput := getValueByPath(fromObject, []string{"metadata", "output"})
+	if fromOutput != nil {
+		fromOutput, err = batchJobDestinationFromMldev(ac, fromOutput.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"dest"}, fromOutput)
+	}
+
+	fromBatchStats := getValueByPath(fromObject, []string{"metadata", "batchStats"})
+	if fromBatchStats != nil {
+		fromBatchStats, err = batchJobStatsFromMldev(ac, fromBatchStats.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"stats"}, fromBatchStats)
+	}
+
+	return toObject, nil
+}
+
+func listBatchJobsResponseFromMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromNextPageToken := getValueByPath(fromObject, []string{"nextPageToken"})
+	if fromNextPageToken != nil {
+		setValueByPath(toObject, []string{"nextPageToken"}, fromNextPageToken)
+	}
+
+	fromOperations := getValueByPath(fromObject, []string{"operations"})
+	if fromOperations != nil {
+		fromOperations, err = applyConverterToSlice(ac, fromOperations.([]any), batchJobFromMldev)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"batchJobs"}, fromOperations)
+	}
+
+	return toObject, nil
+}
+
+func deleteResourceJobFromMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		setValueByPath(toObject, []string{"name"}, fromName)
+	}
+
+	fromDone := getValueByPath(fromObject, []string{"done"})
+	if fromDone != nil {
+		setValueByPath(toObject, []string{"done"}, fromDone)
+	}
+
+	fromError := getValueByPath(fromObject, []string{"error"})
+	if fromError != nil {
+		setValueByPath(toObject, []string{"error"}, fromError)
+	}
+
+	return toObject, nil
+}
+
+func batchJobSourceFromVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromInstancesFormat := getValueByPath(fromObject, []string{"instancesFormat"})
+	if fromInstancesFormat != nil {
+		setValueByPath(toObject, []string{"format"}, fromInstancesFormat)
+	}
+
+	fromUris := getValueByPath(fromObject, []string{"gcsSource", "uris"})
+	if fromUris != nil {
+		setValueByPath(toObject, []string{"gcsUri"}, fromUris)
+	}
+
+	fromInputUri := getValueByPath(fromObject, []string{"bigquerySource", "inputUri"})
+	if fromInputUri != nil {
+		setValueByPath(toObject, []string{"bigqueryUri"}, fromInputUri)
+	}
+
+	return toObject, nil
+}
+
+func batchJobDestinationFromVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromPredictionsFormat := getValueByPath(fromObject, []string{"predictionsFormat"})
+	if fromPredictionsFormat != nil {
+		setValueByPath(toObject, []string{"format"}, fromPredictionsFormat)
+	}
+
+	fromOutputUriPrefix := getValueByPath(fromObject, []string{"gcsDestination", "outputUriPrefix"})
+	if fromOutputUriPrefix != nil {
+		setValueByPath(toObject, []string{"gcsUri"}, fromOutputUriPrefix)
+	}
+
+	fromOutputUri := getValueByPath(fromObject, []string{"bigqueryDestination", "outputUri"})
+	if fromOutputUri != nil {
+		setValueByPath(toObject, []string{"bigqueryUri"}, fromOutputUri)
+	}
+
+	return toObject, nil
+}
+
+func batchJobStatsFromVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromSuccessfulCount := getValueByPath(fromObject, []string{"successfulCount"})
+	if fromSuccessfulCount != nil {
+		setValueByPath(toObject, []string{"succeededRequestCount"}, fromSuccessfulCount)
+	}
+
+	fromFailedCount := getValueByPath(fromObject, []string{"failedCount"})
+	if fromFailedCount != nil {
+		setValueByPath(toObject, []string{"failedRequestCount"}, fromFailedCount)
+	}
+
+	fromIncompleteCount := getValueByPath(fromObject, []string{"incompleteCount"})
+	if fromIncompleteCount != nil {
+		setValueByPath(toObject, []string{"pendingRequestCount"}, fromIncompleteCount)
+	}
+
+	return toObject, nil
+}
+
+func batchJobFromVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		setValueByPath(toObject, []string{"name"}, fromName)
+	}
+
+	fromDisplayName := getValueByPath(fromObject, []string{"displayName"})
+	if fromDisplayName != nil {
+		setValueByPath(toObject, []string{"displayName"}, fromDisplayName)
+	}
+
+	fromState := getValueByPath(fromObject, []string{"state"})
+	if fromState != nil {
+		fromState, err = tJobState(ac, fromState)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"state"}, fromState)
+	}
+
+	fromError := getValueByPath(fromObject, []string{"error"})
+	if fromError != nil {
+		setValueByPath(toObject, []string{"error"}, fromError)
+	}
+
+	fromCreateTime := getValueByPath(fromObject, []string{"createTime"})
+	if fromCreateTime != nil {
+		setValueByPath(toObject, []string{"createTime"}, fromCreateTime)
+	}
+
+	fromStartTime := getValueByPath(fromObject, []string{"startTime"})
+	if fromStartTime != nil {
+		setValueByPath(toObject, []string{"startTime"}, fromStartTime)
+	}
+
+	fromEndTime := getValueByPath(fromObject, []string{"endTime"})
+	if fromEndTime != nil {
+		setValueByPath(toObject, []string{"endTime"}, fromEndTime)
+	}
+
+	fromUpdateTime := getValueByPath(fromObject, []string{"updateTime"})
+	if fromUpdateTime != nil {
+		setValueByPath(toObject, []string{"updateTime"}, fromUpdateTime)
+	}
+
+	fromModel := getValueByPath(fromObject, []string{"model"})
+	if fromModel != nil {
+		setValueByPath(toObject, []string{"model"}, fromModel)
+	}
+
+	fromInputConfig := getValueByPath(fromObject, []string{"inputConfig"})
+	if fromInputConfig != nil {
+		fromInputConfig, err = batchJobSourceFromVertex(ac, fromInputConfig.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"src"}, fromInputConfig)
+	}
+
+	fromOutputConfig := getValueByPath(fromObject, []string{"outputConfig"})
+	if fromOutputConfig != nil {
+		fromOutputConfig, err = batchJobDestinationFromVertex(ac, fromOutputConfig.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"dest"}, fromOutputConfig)
+	}
+
+	fromCompletionStats := getValueByPath(fromObject, []string{"completionStats"})
+	if fromCompletionStats != nil {
+		fromCompletionStats, err = batchJobStatsFromVertex(ac, fromCompletionStats.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"stats"}, fromCompletionStats)
+	}
+
+	fromOutputInfo := getValueByPath(fromObject, []string{"outputInfo"})
+	if fromOutputInfo != nil {
+		setValueByPath(toObject, []string{"outputInfo"}, fromOutputInfo)
+	}
+
+	return toObject, nil
+}
+
+func listBatchJobsResponseFromVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromNextPageToken := getValueByPath(fromObject, []string{"nextPageToken"})
+	if fromNextPageToken != nil {
+		setValueByPath(toObject, []string{"nextPageToken"}, fromNextPageToken)
+	}
+
+	fromBatchPredictionJobs := getValueByPath(fromObject, []string{"batchPredictionJobs"})
+	if fromBatchPredictionJobs != nil {
+		fromBatchPredictionJobs, err = applyConverterToSlice(ac, fromBatchPredictionJobs.([]any), batchJobFromVertex)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"batchJobs"}, fromBatchPredictionJobs)
+	}
+
+	return toObject, nil
+}
+
+func deleteResourceJobFromVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		setValueByPath(toObject, []string{"name"}, fromName)
+	}
+
+	fromDone := getValueByPath(fromObject, []string{"done"})
+	if fromDone != nil {
+		setValueByPath(toObject, []string{"done"}, fromDone)
+	}
+
+	fromError := getValueByPath(fromObject, []string{"error"})
+	if fromError != nil {
+		setValueByPath(toObject, []string{"error"}, fromError)
+	}
+
+	return toObject, nil
+}
+
+// Batches provides methods for managing the batch jobs, which run large numbers of
+// requests asynchronously.
+// You don't need to initiate this struct. Create a client instance via NewClient, and
+// then access Batches through client.Batches field.
+type Batches struct {
+	apiClient *apiClient
+}
+
+func (m Batches) create(ctx context.Context, model string, src *BatchJobSource, config *CreateBatchJobConfig) (*BatchJob, error) {
+	parameterMap := make(map[string]any)
+
+	kwargs := map[string]any{"model": model, "src": src, "config": config}
+	deepMarshal(kwargs, &parameterMap)
+
+	var httpOptions *HTTPOptions
+	if config == nil {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, nil)
+	} else {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, config.HTTPOptions)
+		config.HTTPOptions = nil
+	}
+	var response = new(BatchJob)
+	var responseMap map[string]any
+	var fromConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	var toConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		toConverter = createBatchJobParametersToVertex
+		fromConverter = batchJobFromVertex
+	} else {
+		toConverter = createBatchJobParametersToMldev
+		fromConverter = batchJobFromMldev
+	}
+
+	body, err := toConverter(m.apiClient, parameterMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	var path string
+	var urlParams map[string]any
+	if _, ok := body["_url"]; ok {
+		urlParams = body["_url"].(map[string]any)
+		delete(body, "_url")
+	}
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		path, err = formatMap("batchPredictionJobs", urlParams)
+	} else {
+		path, err = formatMap("{model}:batchGenerateContent", urlParams)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid url params: %#v.\n%w", urlParams, err)
+	}
+	if _, ok := body["_query"]; ok {
+		query, err := createURLQuery(body["_query"].(map[string]any))
+		if err != nil {
+			return nil, err
+		}
+		path += "?" + query
+		delete(body, "_query")
+	}
+
+	if _, ok := body["config"]; ok {
+		delete(body, "config")
+	}
+	responseMap, err = sendRequest(ctx, m.apiClient, path, http.MethodPost, body, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	responseMap, err = fromConverter(m.apiClient, responseMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = mapToStruct(responseMap, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Get retrieves a batch job.
+func (m Batches) Get(ctx context.Context, name string, config *GetBatchJobConfig) (*BatchJob, error) {
+	parameterMap := make(map[string]any)
+
+	kwargs := map[string]any{"name": name, "config": config}
+	deepMarshal(kwargs, &parameterMap)
+
+	var httpOptions *HTTPOptions
+	if config == nil {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, nil)
+	} else {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, config.HTTPOptions)
+		config.HTTPOptions = nil
+	}
+	var response = new(BatchJob)
+	var responseMap map[string]any
+	var fromConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	var toConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		toConverter = getBatchJobParametersToVertex
+		fromConverter = batchJobFromVertex
+	} else {
+		toConverter = getBatchJobParametersToMldev
+		fromConverter = batchJobFromMldev
+	}
+
+	body, err := toConverter(m.apiClient, parameterMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	var path string
+	var urlParams map[string]any
+	if _, ok := body["_url"]; ok {
+		urlParams = body["_url"].(map[string]any)
+		delete(body, "_url")
+	}
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		path, err = formatMap("{name}", urlParams)
+	} else {
+		path, err = formatMap("{name}", urlParams)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid url params: %#v.\n%w", urlParams, err)
+	}
+	if _, ok := body["_query"]; ok {
+		query, err := createURLQuery(body["_query"].(map[string]any))
+		if err != nil {
+			return nil, err
+		}
+		path += "?" + query
+		delete(body, "_query")
+	}
+
+	if _, ok := body["config"]; ok {
+		delete(body, "config")
+	}
+	responseMap, err = sendRequest(ctx, m.apiClient, path, http.MethodGet, body, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	responseMap, err = fromConverter(m.apiClient, responseMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = mapToStruct(responseMap, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Cancel cancels a batch job. The cancellation is asynchronous, see [Batches.Get].
+func (m Batches) Cancel(ctx context.Context, name string, config *CancelBatchJobConfig) error {
+	parameterMap := make(map[string]any)
+
+	kwargs := map[string]any{"name": name, "config": config}
+	deepMarshal(kwargs, &parameterMap)
+
+	var httpOptions *HTTPOptions
+	if config == nil {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, nil)
+	} else {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, config.HTTPOptions)
+		config.HTTPOptions = nil
+	}
+	var toConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		toConverter = cancelBatchJobParametersToVertex
+	} else {
+		toConverter = cancelBatchJobParametersToMldev
+	}
+
+	body, err := toConverter(m.apiClient, parameterMap, nil)
+	if err != nil {
+		return err
+	}
+	var path string
+	var urlParams map[string]any
+	if _, ok := body["_url"]; ok {
+		urlParams = body["_url"].(map[string]any)
+		delete(body, "_url")
+	}
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		path, err = formatMap("{name}:cancel", urlParams)
+	} else {
+		path, err = formatMap("{name}:cancel", urlParams)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid url params: %#v.\n%w", urlParams, err)
+	}
+	if _, ok := body["_query"]; ok {
+		query, err := createURLQuery(body["_query"].(map[string]any))
+		if err != nil {
+			return err
+		}
+		path += "?" + query
+		delete(body, "_query")
+	}
+
+	if _, ok := body["config"]; ok {
+		delete(body, "config")
+	}
+	_, err = sendRequest(ctx, m.apiClient, path, http.MethodPost, body, httpOptions)
+	return err
+}
+
+func (m Batches) list(ctx context.Context, config *ListBatchJobsConfig) (*ListBatchJobsResponse, error) {
+	parameterMap := make(map[string]any)
+
+	kwargs := map[string]any{"config": config}
+	deepMarshal(kwargs, &parameterMap)
+
+	var httpOptions *HTTPOptions
+	if config == nil {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, nil)
+	} else {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, config.HTTPOptions)
+		config.HTTPOptions = nil
+	}
+	var response = new(ListBatchJobsResponse)
+	var responseMap map[string]any
+	var fromConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	var toConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		toConverter = listBatchJobsParametersToVertex
+		fromConverter = listBatchJobsResponseFromVertex
+	} else {
+		toConverter = listBatchJobsParametersToMldev
+		fromConverter = listBatchJobsResponseFromMldev
+	}
+
+	body, err := toConverter(m.apiClient, parameterMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	var path string
+	var urlParams map[string]any
+	if _, ok := body["_url"]; ok {
+		urlParams = body["_url"].(map[string]any)
+		delete(body, "_url")
+	}
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		path, err = formatMap("batchPredictionJobs", urlParams)
+	} else {
+		path, err = formatMap("batches", urlParams)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid url params: %#v.\n%w", urlParams, err)
+	}
+	if _, ok := body["_query"]; ok {
+		query, err := createURLQuery(body["_query"].(map[string]any))
+		if err != nil {
+			return nil, err
+		}
+		path += "?" + query
+		delete(body, "_query")
+	}
+
+	if _, ok := body["config"]; ok {
+		delete(body, "config")
+	}
+	responseMap, err = sendRequest(ctx, m.apiClient, path, http.MethodGet, body, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	responseMap, err = fromConverter(m.apiClient, responseMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = mapToStruct(responseMap, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Delete deletes a batch job.
+func (m Batches) Delete(ctx context.Context, name string, config *DeleteBatchJobConfig) (*DeleteResourceJob, error) {
+	parameterMap := make(map[string]any)
+
+	kwargs := map[string]any{"name": name, "config": config}
+	deepMarshal(kwargs, &parameterMap)
+
+	var httpOptions *HTTPOptions
+	if config == nil {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, nil)
+	} else {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, config.HTTPOptions)
+		config.HTTPOptions = nil
+	}
+	var response = new(DeleteResourceJob)
+	var responseMap map[string]any
+	var fromConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	var toConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		toConverter = deleteBatchJobParametersToVertex
+		fromConverter = deleteResourceJobFromVertex
+	} else {
+		toConverter = deleteBatchJobParametersToMldev
+		fromConverter = deleteResourceJobFromMldev
+	}
+
+	body, err := toConverter(m.apiClient, parameterMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	var path string
+	var urlParams map[string]any
+	if _, ok := body["_url"]; ok {
+		urlParams = body["_url"].(map[string]any)
+		delete(body, "_url")
+	}
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		path, err = formatMap("{name}", urlParams)
+	} else {
+		path, err = formatMap("{name}", urlParams)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid url params: %#v.\n%w", urlParams, err)
+	}
+	if _, ok := body["_query"]; ok {
+		query, err := createURLQuery(body["_query"].(map[string]any))
+		if err != nil {
+			return nil, err
+		}
+		path += "?" + query
+		delete(body, "_query")
+	}
+
+	if _, ok := body["config"]; ok {
+		delete(body, "config")
+	}
+	responseMap, err = sendRequest(ctx, m.apiClient, path, http.MethodDelete, body, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	responseMap, err = fromConverter(m.apiClient, responseMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = mapToStruct(responseMap, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// List retrieves a paginated list of batch jobs.
+func (m Batches) List(ctx context.Context, config *ListBatchJobsConfig) (Page[BatchJob], error) {
+	listFunc := func(ctx context.Context, config map[string]any) ([]*BatchJob, string, error) {
+		var c ListBatchJobsConfig
+		if err := mapToStruct(config, &c); err != nil {
+			return nil, "", err
+		}
+		resp, err := m.list(ctx, &c)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.BatchJobs, resp.NextPageToken, nil
+	}
+	c := make(map[string]any)
+	deepMarshal(config, &c)
+	return newPage(ctx, "batchJobs", c, listFunc)
+}
+
+// All retrieves all batch jobs.
+//
+// This method handles pagination internally, making multiple API calls as needed
+// to fetch all entries. It returns an iterator that yields each batch job one by
+// one. You do not need to manage pagination tokens or make multiple calls to
+// retrieve all data.
+func (m Batches) All(ctx context.Context) iter.Seq2[*BatchJob, error] {
+	listFunc := func(ctx context.Context, config map[string]any) ([]*BatchJob, string, error) {
+		var c ListBatchJobsConfig
+		if err := mapToStruct(config, &c); err != nil {
+			return nil, "", err
+		}
+		resp, err := m.list(ctx, &c)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.BatchJobs, resp.NextPageToken, nil
+	}
+	p, err := newPage(ctx, "batchJobs", map[string]any{}, listFunc)
+	if err != nil {
+		return yieldErrorAndEndIterator[BatchJob](err)
+	}
+	return p.All(ctx)
+}
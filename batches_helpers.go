@@ -0,0 +1,164 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Create creates a batch job generating content with the model for every request
+// of the source, and returns it without waiting for its completion.
+//
+// On BackendVertexAI, the requests are read from JSONL files in Cloud Storage or
+// from a BigQuery table, and the responses are written to the destination of the
+// config. If the config has no destination, the responses are written next to the
+// source: to the "dest" folder of the folder of the first Cloud Storage file, or
+// to a new table of the dataset of the BigQuery table.
+//
+//	job, err := client.Batches.Create(ctx, "gemini-2.0-flash-001",
+//		&genai.BatchJobSource{GCSURI: []string{"gs://my-bucket/requests.jsonl"}},
+//		&genai.CreateBatchJobConfig{Dest: &genai.BatchJobDestination{GCSURI: "gs://my-bucket/responses"}})
+//
+// On BackendGeminiAPI, the requests are either inlined in the source, or read from
+// a JSONL file uploaded with [Batches.UploadRequests]. The responses are inlined in
+// the destination of the completed job, or written to a file that can be downloaded
+// with the Files service. The config must not have a destination.
+//
+//	requests := genai.InlinedRequestsFromContents(genai.Text("Why is the sky blue?"), nil)
+//	job, err := client.Batches.Create(ctx, "gemini-2.0-flash",
+//		&genai.BatchJobSource{InlinedRequests: requests}, nil)
+func (m Batches) Create(ctx context.Context, model string, src *BatchJobSource, config *CreateBatchJobConfig) (*BatchJob, error) {
+	if src == nil {
+		return nil, fmt.Errorf("batch job source is nil")
+	}
+	if m.apiClient.clientConfig.Backend == BackendVertexAI && (config == nil || config.Dest == nil) {
+		dest, err := defaultBatchJobDestination(src)
+		if err != nil {
+			return nil, err
+		}
+		c := CreateBatchJobConfig{}
+		if config != nil {
+			c = *config
+		}
+		c.Dest = dest
+		config = &c
+	}
+	return m.create(ctx, model, src, config)
+}
+
+// defaultBatchJobDestination returns the destination of a Vertex AI batch job
+// next to its source.
+func defaultBatchJobDestination(src *BatchJobSource) (*BatchJobDestination, error) {
+	switch {
+	case len(src.GCSURI) > 0:
+		uri := src.GCSURI[0]
+		i := strings.LastIndex(uri, "/")
+		if !strings.HasPrefix(uri, "gs://") || i < len("gs://") {
+			return nil, fmt.Errorf("%q is not a Cloud Storage file, set CreateBatchJobConfig.Dest", uri)
+		}
+		return &BatchJobDestination{GCSURI: uri[:i] + "/dest"}, nil
+	case src.BigqueryURI != "":
+		i := strings.LastIndex(src.BigqueryURI, ".")
+		if !strings.HasPrefix(src.BigqueryURI, "bq://") || i < 0 {
+			return nil, fmt.Errorf("%q is not a BigQuery table, set CreateBatchJobConfig.Dest", src.BigqueryURI)
+		}
+		return &BatchJobDestination{BigqueryURI: src.BigqueryURI[:i]}, nil
+	default:
+		return nil, fmt.Errorf("batch job source has no Cloud Storage files or BigQuery table")
+	}
+}
+
+// InlinedRequestsFromContents returns a batch request generating content with the
+// config for every content. The key of the metadata of the i-th request is
+// "request-<i>", to match the request with its response.
+func InlinedRequestsFromContents(contents []*Content, config *GenerateContentConfig) []*InlinedRequest {
+	requests := make([]*InlinedRequest, len(contents))
+	for i, content := range contents {
+		requests[i] = &InlinedRequest{
+			Contents: []*Content{content},
+			Metadata: map[string]string{"key": fmt.Sprintf("request-%d", i)},
+			Config:   config,
+		}
+	}
+	return requests
+}
+
+// WriteRequests writes the requests in the JSONL format of the source files of the
+// Gemini API batch jobs: one {"key": ..., "request": ...} object per line. The key
+// of a request is the "key" value of its metadata, or "request-<i>" if unset.
+func (m Batches) WriteRequests(w io.Writer, requests []*InlinedRequest) error {
+	enc := json.NewEncoder(w)
+	for i, r := range requests {
+		if r == nil {
+			return fmt.Errorf("request %d is nil", i)
+		}
+		requestMap := make(map[string]any)
+		if err := deepMarshal(r, &requestMap); err != nil {
+			return err
+		}
+		converted, err := inlinedRequestToMldev(m.apiClient, requestMap, nil)
+		if err != nil {
+			return fmt.Errorf("request %d: %w", i, err)
+		}
+		key := r.Metadata["key"]
+		if key == "" {
+			key = fmt.Sprintf("request-%d", i)
+		}
+		line := map[string]any{"key": key, "request": converted["request"]}
+		if err := enc.Encode(line); err != nil {
+			return fmt.Errorf("request %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// UploadRequests writes the requests with [Batches.WriteRequests] and uploads them
+// as a JSONL file, to be used as the [BatchJobSource.FileName] of a batch job.
+// It is only supported in the Gemini API.
+func (m Batches) UploadRequests(ctx context.Context, requests []*InlinedRequest, config *UploadFileConfig) (*File, error) {
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		return nil, fmt.Errorf("method UploadRequests is only supported in the Gemini Developer client. You can choose to use Gemini Developer client by setting ClientConfig.Backend to BackendGeminiAPI.")
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("batch requests are empty")
+	}
+	var buf bytes.Buffer
+	if err := m.WriteRequests(&buf, requests); err != nil {
+		return nil, err
+	}
+	c := UploadFileConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.MIMEType == "" {
+		c.MIMEType = "jsonl"
+	}
+	return Files{apiClient: m.apiClient}.Upload(ctx, &buf, &c)
+}
+
+// HasEnded reports whether the batch job reached a terminal state: succeeded,
+// failed, cancelled or expired.
+func (j *BatchJob) HasEnded() bool {
+	switch j.State {
+	case JobStateSucceeded, JobStateFailed, JobStateCancelled, JobStateExpired, JobStatePartiallySucceeded:
+		return true
+	}
+	return false
+}
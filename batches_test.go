@@ -0,0 +1,559 @@
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+type batchesRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Body   map[string]any
+}
+
+// newTestBatches returns a client whose requests are recorded and answered by
+// handler.
+func newTestBatches(t *testing.T, backend Backend, requests *[]batchesRequest, handler func(w http.ResponseWriter, r *http.Request)) *Client {
+	t.Helper()
+	var mu sync.Mutex
+	return newTestClient(t, backend, func(w http.ResponseWriter, r *http.Request) {
+		req := batchesRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery}
+		json.NewDecoder(r.Body).Decode(&req.Body)
+		mu.Lock()
+		*requests = append(*requests, req)
+		mu.Unlock()
+		handler(w, r)
+	})
+}
+
+const vertexBatchJobJSON = `{
+	"name": "projects/project/locations/us-central1/batchPredictionJobs/123",
+	"displayName": "nightly",
+	"model": "publishers/google/models/gemini-2.0-flash-001",
+	"state": "JOB_STATE_SUCCEEDED",
+	"createTime": "2025-01-02T03:04:05Z",
+	"inputConfig": {"instancesFormat": "bigquery", "bigquerySource": {"inputUri": "bq://project.dataset.requests"}},
+	"outputConfig": {"predictionsFormat": "bigquery", "bigqueryDestination": {"outputUri": "bq://project.dataset"}}
+}`
+
+func TestBatchesVertexAI(t *testing.T) {
+	ctx := context.Background()
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendVertexAI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodDelete:
+			w.Write([]byte(`{"name": "projects/project/locations/us-central1/operations/1", "done": true}`))
+		case http.MethodGet:
+			if r.URL.Path == "/v1beta1/projects/project/locations/us-central1/batchPredictionJobs" {
+				w.Write([]byte(`{"batchPredictionJobs": [` + vertexBatchJobJSON + `]}`))
+				return
+			}
+			w.Write([]byte(vertexBatchJobJSON))
+		default:
+			w.Write([]byte(vertexBatchJobJSON))
+		}
+	})
+
+	wantJob := &BatchJob{
+		Name:        "projects/project/locations/us-central1/batchPredictionJobs/123",
+		DisplayName: "nightly",
+		Model:       "publishers/google/models/gemini-2.0-flash-001",
+		State:       JobStateSucceeded,
+		CreateTime:  time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Src:         &BatchJobSource{Format: "bigquery", BigqueryURI: "bq://project.dataset.requests"},
+		Dest:        &BatchJobDestination{Format: "bigquery", BigqueryURI: "bq://project.dataset"},
+	}
+	job, err := client.Batches.Create(ctx, "gemini-2.0-flash-001", &BatchJobSource{BigqueryURI: "bq://project.dataset.requests"}, &CreateBatchJobConfig{DisplayName: "nightly"})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if diff := cmp.Diff(wantJob, job); diff != "" {
+		t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+	}
+	if !job.HasEnded() {
+		t.Errorf("HasEnded() = false, want true")
+	}
+
+	if _, err := client.Batches.Create(ctx, "gemini-2.0-flash-001", &BatchJobSource{GCSURI: []string{"gs://bucket/in/a.jsonl", "gs://bucket/in/b.jsonl"}}, nil); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if _, err := client.Batches.Get(ctx, "123", nil); err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if err := client.Batches.Cancel(ctx, "batchPredictionJobs/123", nil); err != nil {
+		t.Fatalf("Cancel() failed: %v", err)
+	}
+	var names []string
+	for job, err := range client.Batches.All(ctx) {
+		if err != nil {
+			t.Fatalf("All() failed: %v", err)
+		}
+		names = append(names, job.Name)
+	}
+	if diff := cmp.Diff([]string{wantJob.Name}, names); diff != "" {
+		t.Errorf("All() mismatch (-want +got):\n%s", diff)
+	}
+	deleted, err := client.Batches.Delete(ctx, "123", nil)
+	if err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if !deleted.Done {
+		t.Errorf("Delete() = %+v, want a done deletion", deleted)
+	}
+
+	const jobPath = "/v1beta1/projects/project/locations/us-central1/batchPredictionJobs/123"
+	wantRequests := []batchesRequest{
+		{Method: http.MethodPost, Path: "/v1beta1/projects/project/locations/us-central1/batchPredictionJobs", Body: map[string]any{
+			"displayName":  "nightly",
+			"model":        "publishers/google/models/gemini-2.0-flash-001",
+			"inputConfig":  map[string]any{"instancesFormat": "bigquery", "bigquerySource": map[string]any{"inputUri": "bq://project.dataset.requests"}},
+			"outputConfig": map[string]any{"predictionsFormat": "bigquery", "bigqueryDestination": map[string]any{"outputUri": "bq://project.dataset"}},
+		}},
+		{Method: http.MethodPost, Path: "/v1beta1/projects/project/locations/us-central1/batchPredictionJobs", Body: map[string]any{
+			"model":        "publishers/google/models/gemini-2.0-flash-001",
+			"inputConfig":  map[string]any{"instancesFormat": "jsonl", "gcsSource": map[string]any{"uris": []any{"gs://bucket/in/a.jsonl", "gs://bucket/in/b.jsonl"}}},
+			"outputConfig": map[string]any{"predictionsFormat": "jsonl", "gcsDestination": map[string]any{"outputUriPrefix": "gs://bucket/in/dest"}},
+		}},
+		{Method: http.MethodGet, Path: jobPath},
+		{Method: http.MethodPost, Path: jobPath + ":cancel"},
+		{Method: http.MethodGet, Path: "/v1beta1/projects/project/locations/us-central1/batchPredictionJobs"},
+		{Method: http.MethodDelete, Path: jobPath},
+	}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+const mldevBatchJobJSON = `{
+	"name": "batches/123",
+	"metadata": {
+		"@type": "type.googleapis.com/google.ai.generativelanguage.v1main.GenerateContentBatch",
+		"model": "models/gemini-2.0-flash",
+		"displayName": "nightly",
+		"state": "BATCH_STATE_SUCCEEDED",
+		"createTime": "2025-01-02T03:04:05Z",
+		"output": {"inlinedResponses": {"inlinedResponses": [
+			{"response": {"candidates": [{"content": {"role": "model", "parts": [{"text": "Rayleigh scattering."}]}}]}, "metadata": {"key": "request-0"}},
+			{"error": {"code": 3, "message": "invalid request"}, "metadata": {"key": "request-1"}}
+		]}},
+		"batchStats": {"requestCount": "2", "successfulRequestCount": "1", "failedRequestCount": "1"}
+	},
+	"done": true
+}`
+
+func TestBatchesGeminiAPI(t *testing.T) {
+	ctx := context.Background()
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/v1beta/batches" {
+			w.Write([]byte(`{"operations": [` + mldevBatchJobJSON + `]}`))
+			return
+		}
+		w.Write([]byte(mldevBatchJobJSON))
+	})
+
+	wantJob := &BatchJob{
+		Name:        "batches/123",
+		DisplayName: "nightly",
+		Model:       "models/gemini-2.0-flash",
+		State:       JobStateSucceeded,
+		CreateTime:  time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Dest: &BatchJobDestination{InlinedResponses: []*InlinedResponse{
+			{
+				Response: &GenerateContentResponse{Candidates: []*Candidate{{Content: NewContentFromText("Rayleigh scattering.", RoleModel)}}},
+				Metadata: map[string]string{"key": "request-0"},
+			},
+			{
+				Error:    &JobError{Code: 3, Message: "invalid request"},
+				Metadata: map[string]string{"key": "request-1"},
+			},
+		}},
+		Stats: &BatchJobStats{RequestCount: 2, SucceededRequestCount: 1, FailedRequestCount: 1},
+	}
+	inlined := InlinedRequestsFromContents(Text("Why is the sky blue?"), &GenerateContentConfig{Temperature: Ptr[float32](0.5)})
+	job, err := client.Batches.Create(ctx, "gemini-2.0-flash", &BatchJobSource{InlinedRequests: inlined}, &CreateBatchJobConfig{DisplayName: "nightly"})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if diff := cmp.Diff(wantJob, job); diff != "" {
+		t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+	}
+	if !job.HasEnded() {
+		t.Errorf("HasEnded() = false, want true")
+	}
+
+	if _, err := client.Batches.Create(ctx, "gemini-2.0-flash", &BatchJobSource{FileName: "files/requests"}, nil); err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if _, err := client.Batches.Get(ctx, "123", nil); err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if err := client.Batches.Cancel(ctx, "batches/123", nil); err != nil {
+		t.Fatalf("Cancel() failed: %v", err)
+	}
+	var names []string
+	for job, err := range client.Batches.All(ctx) {
+		if err != nil {
+			t.Fatalf("All() failed: %v", err)
+		}
+		names = append(names, job.Name)
+	}
+	if diff := cmp.Diff([]string{wantJob.Name}, names); diff != "" {
+		t.Errorf("All() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantRequests := []batchesRequest{
+		{Method: http.MethodPost, Path: "/v1beta/models/gemini-2.0-flash:batchGenerateContent", Body: map[string]any{
+			"batch": map[string]any{
+				"displayName": "nightly",
+				"inputConfig": map[string]any{"requests": map[string]any{"requests": []any{
+					map[string]any{
+						"request": map[string]any{
+							"contents":         []any{map[string]any{"role": "user", "parts": []any{map[string]any{"text": "Why is the sky blue?"}}}},
+							"generationConfig": map[string]any{"temperature": 0.5},
+						},
+						"metadata": map[string]any{"key": "request-0"},
+					},
+				}}},
+			},
+		}},
+		{Method: http.MethodPost, Path: "/v1beta/models/gemini-2.0-flash:batchGenerateContent", Body: map[string]any{
+			"batch": map[string]any{"inputConfig": map[string]any{"fileName": "files/requests"}},
+		}},
+		{Method: http.MethodGet, Path: "/v1beta/batches/123"},
+		{Method: http.MethodPost, Path: "/v1beta/batches/123:cancel"},
+		{Method: http.MethodGet, Path: "/v1beta/batches"},
+	}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBatchesWriteRequests(t *testing.T) {
+	client, err := NewClient(context.Background(), &ClientConfig{Backend: BackendGeminiAPI, APIKey: "test-api-key"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	requests := InlinedRequestsFromContents(Text("a"), nil)
+	requests = append(requests, &InlinedRequest{
+		Contents: Text("b"),
+		Metadata: map[string]string{"key": "custom"},
+		Config:   &GenerateContentConfig{SystemInstruction: NewContentFromText("Be brief.", RoleUser)},
+	}, &InlinedRequest{Contents: Text("c")})
+
+	var buf bytes.Buffer
+	if err := client.Batches.WriteRequests(&buf, requests); err != nil {
+		t.Fatalf("WriteRequests() failed: %v", err)
+	}
+	want := `{"key":"request-0","request":{"contents":[{"parts":[{"text":"a"}],"role":"user"}]}}
+{"key":"custom","request":{"contents":[{"parts":[{"text":"b"}],"role":"user"}],"generationConfig":{},"systemInstruction":{"parts":[{"text":"Be brief."}],"role":"user"}}}
+{"key":"request-2","request":{"contents":[{"parts":[{"text":"c"}],"role":"user"}]}}
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteRequests() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBatchesCreateInvalidSource(t *testing.T) {
+	ctx := context.Background()
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendVertexAI, &requests, func(w http.ResponseWriter, r *http.Request) {})
+	for _, tt := range []struct {
+		name   string
+		src    *BatchJobSource
+		config *CreateBatchJobConfig
+	}{
+		{name: "NoSource", src: &BatchJobSource{}},
+		{name: "TwoSources", src: &BatchJobSource{GCSURI: []string{"gs://b/a.jsonl"}, BigqueryURI: "bq://p.d.t"}, config: &CreateBatchJobConfig{Dest: &BatchJobDestination{GCSURI: "gs://b/out"}}},
+		{name: "NotGCS", src: &BatchJobSource{GCSURI: []string{"a.jsonl"}}},
+		{name: "TwoDestinations", src: &BatchJobSource{GCSURI: []string{"gs://b/a.jsonl"}}, config: &CreateBatchJobConfig{Dest: &BatchJobDestination{GCSURI: "gs://b/out", BigqueryURI: "bq://p.d"}}},
+		{name: "InlinedRequests", src: &BatchJobSource{InlinedRequests: InlinedRequestsFromContents(Text("a"), nil)}, config: &CreateBatchJobConfig{Dest: &BatchJobDestination{GCSURI: "gs://b/out"}}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.Batches.Create(ctx, "gemini-2.0-flash-001", tt.src, tt.config); err == nil {
+				t.Errorf("Create() succeeded, want error")
+			}
+		})
+	}
+	if len(requests) != 0 {
+		t.Errorf("Create() sent %d requests, want 0", len(requests))
+	}
+}
+
+func TestBatchesResults(t *testing.T) {
+	ctx := context.Background()
+	collect := func(t *testing.T, client *Client, job *BatchJob) []*BatchResult {
+		t.Helper()
+		var results []*BatchResult
+		for result, err := range client.Batches.Results(ctx, job) {
+			if err != nil {
+				t.Fatalf("Results() failed: %v", err)
+			}
+			results = append(results, result)
+		}
+		return results
+	}
+	wantResults := []*BatchResult{
+		{Key: "request-0", Response: &GenerateContentResponse{Candidates: []*Candidate{{Content: NewContentFromText("Rayleigh scattering.", RoleModel)}}}},
+		{Key: "request-1", Error: &JobError{Code: 3, Message: "invalid request"}},
+	}
+
+	t.Run("Inlined", func(t *testing.T) {
+		var requests []batchesRequest
+		client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(mldevBatchJobJSON))
+		})
+		job, err := client.Batches.Get(ctx, "123", nil)
+		if err != nil {
+			t.Fatalf("Get() failed: %v", err)
+		}
+		if diff := cmp.Diff(wantResults, collect(t, client, job)); diff != "" {
+			t.Errorf("Results() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("File", func(t *testing.T) {
+		var requests []batchesRequest
+		client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"key": "request-0", "response": {"candidates": [{"content": {"role": "model", "parts": [{"text": "Rayleigh scattering."}]}}]}}` + "\n\n" +
+				`{"key": "request-1", "error": {"code": 3, "message": "invalid request"}}`))
+		})
+		job := &BatchJob{Name: "batches/123", State: JobStateSucceeded, Dest: &BatchJobDestination{FileName: "files/batch-123"}}
+		if diff := cmp.Diff(wantResults, collect(t, client, job)); diff != "" {
+			t.Errorf("Results() mismatch (-want +got):\n%s", diff)
+		}
+		wantRequests := []batchesRequest{{Method: http.MethodGet, Path: "/v1beta/files/batch-123:download", Query: "alt=media"}}
+		if diff := cmp.Diff(wantRequests, requests); diff != "" {
+			t.Errorf("requests mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("GCS", func(t *testing.T) {
+		var requests []batchesRequest
+		client := newTestBatches(t, BackendVertexAI, &requests, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.EscapedPath() {
+			case "/storage/v1/b/bucket/o":
+				if r.URL.Query().Get("pageToken") == "" {
+					w.Write([]byte(`{"items": [{"name": "out/prediction-1/incremental.jsonl"}, {"name": "out/prediction-1/predictions_0.jsonl"}], "nextPageToken": "next"}`))
+					return
+				}
+				w.Write([]byte(`{"items": [{"name": "out/prediction-1/predictions_1.jsonl"}]}`))
+			case "/storage/v1/b/bucket/o/out%2Fprediction-1%2Fpredictions_0.jsonl":
+				w.Write([]byte(`{"status": "", "request": {}, "response": {"candidates": [{"content": {"role": "model", "parts": [{"text": "Rayleigh scattering."}]}}]}}` + "\n"))
+			case "/storage/v1/b/bucket/o/out%2Fprediction-1%2Fpredictions_1.jsonl":
+				w.Write([]byte(`{"status": "invalid request", "request": {}}` + "\n"))
+			default:
+				t.Errorf("unexpected request %s", r.URL)
+			}
+		})
+		defer func(u string) { gcsBaseURL = u }(gcsBaseURL)
+		gcsBaseURL = client.Batches.apiClient.clientConfig.HTTPOptions.BaseURL + "/"
+		job := &BatchJob{
+			State:      JobStateSucceeded,
+			Dest:       &BatchJobDestination{GCSURI: "gs://bucket/out"},
+			OutputInfo: &BatchJobOutputInfo{GCSOutputDirectory: "gs://bucket/out/prediction-1"},
+		}
+		want := []*BatchResult{wantResults[0], {Key: "request-1", Error: &JobError{Message: "invalid request"}}}
+		if diff := cmp.Diff(want, collect(t, client, job)); diff != "" {
+			t.Errorf("Results() mismatch (-want +got):\n%s", diff)
+		}
+		if len(requests) != 4 {
+			t.Errorf("Results() sent %d requests, want 4", len(requests))
+		}
+	})
+
+	t.Run("BigQuery", func(t *testing.T) {
+		var requests []batchesRequest
+		client := newTestBatches(t, BackendVertexAI, &requests, func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/bigquery/v2/projects/project/datasets/dataset/tables/predictions_1":
+				w.Write([]byte(`{"schema": {"fields": [{"name": "request"}, {"name": "status"}, {"name": "response"}]}}`))
+			case "/bigquery/v2/projects/project/datasets/dataset/tables/predictions_1/data":
+				if r.URL.Query().Get("pageToken") == "" {
+					w.Write([]byte(`{"rows": [{"f": [{"v": "{}"}, {"v": ""}, {"v": "{\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"Rayleigh scattering.\"}]}}]}"}]}], "pageToken": "next"}`))
+					return
+				}
+				w.Write([]byte(`{"rows": [{"f": [{"v": "{}"}, {"v": "invalid request"}, {"v": null}]}]}`))
+			default:
+				t.Errorf("unexpected request %s", r.URL)
+			}
+		})
+		defer func(u string) { bigqueryBaseURL = u }(bigqueryBaseURL)
+		bigqueryBaseURL = client.Batches.apiClient.clientConfig.HTTPOptions.BaseURL + "/"
+		job := &BatchJob{
+			State:      JobStateSucceeded,
+			Dest:       &BatchJobDestination{BigqueryURI: "bq://project.dataset"},
+			OutputInfo: &BatchJobOutputInfo{BigqueryOutputDataset: "bq://project.dataset", BigqueryOutputTable: "predictions_1"},
+		}
+		want := []*BatchResult{wantResults[0], {Key: "request-1", Error: &JobError{Message: "invalid request"}}}
+		if diff := cmp.Diff(want, collect(t, client, job)); diff != "" {
+			t.Errorf("Results() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("NotSucceeded", func(t *testing.T) {
+		var requests []batchesRequest
+		client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {})
+		for _, err := range client.Batches.Results(ctx, &BatchJob{State: JobStateRunning}) {
+			if err == nil {
+				t.Errorf("Results() succeeded, want error")
+			}
+		}
+	})
+}
+
+func TestBatchesRetryFailed(t *testing.T) {
+	ctx := context.Background()
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1beta/models/gemini-2.0-flash:generateContent" {
+			w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"text": "Retried."}]}}]}`))
+			return
+		}
+		w.Write([]byte(mldevBatchJobJSON))
+	})
+	job, err := client.Batches.Get(ctx, "123", nil)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	inlined := InlinedRequestsFromContents([]*Content{NewContentFromText("a", RoleUser), NewContentFromText("b", RoleUser)}, nil)
+	inlined[1].Metadata = nil
+
+	failed, err := client.Batches.FailedRequests(ctx, job, inlined)
+	if err != nil {
+		t.Fatalf("FailedRequests() failed: %v", err)
+	}
+	wantFailed := []*InlinedRequest{{Contents: []*Content{NewContentFromText("b", RoleUser)}, Metadata: map[string]string{"key": "request-1"}}}
+	if diff := cmp.Diff(wantFailed, failed); diff != "" {
+		t.Errorf("FailedRequests() mismatch (-want +got):\n%s", diff)
+	}
+	if inlined[1].Metadata != nil {
+		t.Errorf("FailedRequests() modified the metadata of the request")
+	}
+
+	requests = nil
+	if _, err := client.Batches.RetryFailed(ctx, job, inlined, nil); err != nil {
+		t.Fatalf("RetryFailed() failed: %v", err)
+	}
+	wantRequests := []batchesRequest{
+		{Method: http.MethodPost, Path: "/v1beta/models/gemini-2.0-flash:batchGenerateContent", Body: map[string]any{
+			"batch": map[string]any{"inputConfig": map[string]any{"requests": map[string]any{"requests": []any{
+				map[string]any{
+					"request":  map[string]any{"contents": []any{map[string]any{"role": "user", "parts": []any{map[string]any{"text": "b"}}}}},
+					"metadata": map[string]any{"key": "request-1"},
+				},
+			}}}},
+		}},
+	}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+
+	var results []*BatchResult
+	for result, err := range client.Batches.Results(ctx, job) {
+		if err != nil {
+			t.Fatalf("Results() failed: %v", err)
+		}
+		results = append(results, result)
+	}
+	retried := GenerateRequests(ctx, client.Models, "gemini-2.0-flash", failed, ParallelOptions{})
+	merged := MergeBatchResults(results, retried)
+	wantMerged := []*BatchResult{
+		results[0],
+		{Key: "request-1", Response: &GenerateContentResponse{Candidates: []*Candidate{{Content: NewContentFromText("Retried.", RoleModel)}}}},
+	}
+	if diff := cmp.Diff(wantMerged, merged); diff != "" {
+		t.Errorf("MergeBatchResults() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBatchesSummary(t *testing.T) {
+	ctx := context.Background()
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"name": "batches/123",
+			"metadata": {
+				"model": "models/gemini-2.0-flash",
+				"state": "BATCH_STATE_SUCCEEDED",
+				"output": {"inlinedResponses": {"inlinedResponses": [
+					{"response": {"modelVersion": "gemini-2.0-flash-001", "usageMetadata": {"promptTokenCount": 1000000, "cachedContentTokenCount": 400000, "candidatesTokenCount": 500000, "totalTokenCount": 1500000}}},
+					{"response": {"usageMetadata": {"promptTokenCount": 10, "candidatesTokenCount": 5, "thoughtsTokenCount": 5, "totalTokenCount": 20}}},
+					{"response": {"modelVersion": "gemma-3", "usageMetadata": {"promptTokenCount": 1, "totalTokenCount": 1}}},
+					{"error": {"code": 3, "message": "invalid request"}},
+					{"error": {"code": 3, "message": "invalid request"}},
+					{"error": {"code": 13, "message": "internal error"}}
+				]}}
+			}
+		}`))
+	})
+	summary, err := client.Batches.Summary(ctx, "123", &BatchJobSummaryConfig{Prices: map[string]ModelPrice{
+		"gemini":           {InputTokens: 100},
+		"gemini-2.0-flash": {InputTokens: 1, CachedInputTokens: 0.25, OutputTokens: 4},
+	}})
+	if err != nil {
+		t.Fatalf("Summary() failed: %v", err)
+	}
+	want := &BatchJobSummary{
+		SucceededCount: 3,
+		FailedCount:    3,
+		FailuresByCode: map[int32]int64{3: 2, 13: 1},
+		Usage:          BatchUsage{ResponseCount: 3, PromptTokenCount: 1000011, CachedContentTokenCount: 400000, CandidatesTokenCount: 500005, ThoughtsTokenCount: 5, TotalTokenCount: 1500021},
+		UsageByModel: map[string]*BatchModelUsage{
+			"gemini-2.0-flash-001": {BatchUsage: BatchUsage{ResponseCount: 1, PromptTokenCount: 1000000, CachedContentTokenCount: 400000, CandidatesTokenCount: 500000, TotalTokenCount: 1500000}, Cost: 2.7},
+			"gemini-2.0-flash":     {BatchUsage: BatchUsage{ResponseCount: 1, PromptTokenCount: 10, CandidatesTokenCount: 5, ThoughtsTokenCount: 5, TotalTokenCount: 20}, Cost: 50e-6},
+			"gemma-3":              {BatchUsage: BatchUsage{ResponseCount: 1, PromptTokenCount: 1, TotalTokenCount: 1}},
+		},
+		Cost: 2.7 + 50e-6,
+	}
+	if diff := cmp.Diff(want, summary, cmpopts.IgnoreFields(BatchJobSummary{}, "Job"), cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Errorf("Summary() mismatch (-want +got):\n%s", diff)
+	}
+	if summary.Job == nil || summary.Job.Name != "batches/123" {
+		t.Errorf("Summary().Job = %+v, want batches/123", summary.Job)
+	}
+
+	t.Run("PriceRegistry", func(t *testing.T) {
+		prices := NewPriceRegistry(nil)
+		prices.Override("gemini-2.0-flash", ModelPrice{InputTokens: 2, OutputTokens: 8})
+		summary, err := client.Batches.Summary(ctx, "123", &BatchJobSummaryConfig{
+			Prices:        map[string]ModelPrice{"gemma": {InputTokens: 2}},
+			PriceRegistry: prices,
+		})
+		if err != nil {
+			t.Fatalf("Summary() failed: %v", err)
+		}
+		wantCosts := map[string]float64{"gemini-2.0-flash-001": 3, "gemini-2.0-flash": 50e-6, "gemma-3": 2e-6}
+		gotCosts := map[string]float64{}
+		for model, usage := range summary.UsageByModel {
+			gotCosts[model] = usage.Cost
+		}
+		if diff := cmp.Diff(wantCosts, gotCosts, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+			t.Errorf("Summary() costs mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("DefaultPrices", func(t *testing.T) {
+		summary, err := client.Batches.Summary(ctx, "123", nil)
+		if err != nil {
+			t.Fatalf("Summary() failed: %v", err)
+		}
+		wantCosts := map[string]float64{"gemini-2.0-flash-001": 0.135, "gemini-2.0-flash": 2.5e-6, "gemma-3": 0}
+		gotCosts := map[string]float64{}
+		for model, usage := range summary.UsageByModel {
+			gotCosts[model] = usage.Cost
+		}
+		if diff := cmp.Diff(wantCosts, gotCosts, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+			t.Errorf("Summary() costs mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
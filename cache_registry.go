@@ -0,0 +1,193 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// cacheExpiryMargin is the minimum remaining lifetime of a registered cached
+// content for it to be reused, so that it doesn't expire during a request.
+const cacheExpiryMargin = time.Minute
+
+// CacheRegistry reuses cached contents by the hash of their content, so that the
+// same large document or system instruction is cached once instead of once per
+// request or per process. It is safe for concurrent use.
+//
+//	registry := genai.NewCacheRegistry(client.Caches, time.Hour)
+//	cache, err := registry.GetOrCreate(ctx, "gemini-2.0-flash", &genai.CreateCachedContentConfig{
+//		Contents: genai.Text(manual),
+//	})
+//	resp, err := client.Models.GenerateContent(ctx, "gemini-2.0-flash", genai.Text(question),
+//		&genai.GenerateContentConfig{CachedContent: cache.Name})
+//
+// Call [CacheRegistry.GC] periodically to delete the cached contents which are no
+// longer used.
+type CacheRegistry struct {
+	caches *Caches
+	ttl    time.Duration
+
+	mu sync.Mutex
+	// The registered cached contents by the hash of their content.
+	entries map[string]*cacheRegistryEntry
+}
+
+type cacheRegistryEntry struct {
+	// Closed once the cached content is created.
+	ready chan struct{}
+	cache *CachedContent
+	err   error
+	// The last time the cached content was returned by GetOrCreate.
+	lastUsed time.Time
+}
+
+// NewCacheRegistry returns an empty registry creating cached contents with the
+// given caches service. The cached contents are created with the given TTL unless
+// their config sets one.
+func NewCacheRegistry(caches *Caches, ttl time.Duration) *CacheRegistry {
+	return &CacheRegistry{caches: caches, ttl: ttl, entries: map[string]*cacheRegistryEntry{}}
+}
+
+// GetOrCreate returns the registered cached content of the model with the same
+// contents, system instruction, tools and tool config as config, or else creates it
+// and registers it. Expired cached contents are created again. Concurrent calls with
+// the same content create a single cached content.
+func (r *CacheRegistry) GetOrCreate(ctx context.Context, model string, config *CreateCachedContentConfig) (*CachedContent, error) {
+	if config == nil {
+		config = &CreateCachedContentConfig{}
+	}
+	key, err := cacheContentHash(model, config)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		r.mu.Lock()
+		entry, ok := r.entries[key]
+		if !ok {
+			entry = &cacheRegistryEntry{ready: make(chan struct{}), lastUsed: time.Now()}
+			r.entries[key] = entry
+			r.mu.Unlock()
+			r.create(ctx, key, entry, model, config)
+			if entry.err != nil {
+				return nil, entry.err
+			}
+			return entry.cache, nil
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-entry.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		r.mu.Lock()
+		if entry.err == nil && time.Until(entry.cache.ExpireTime) > cacheExpiryMargin {
+			entry.lastUsed = time.Now()
+			r.mu.Unlock()
+			return entry.cache, nil
+		}
+		// The creation failed or the cached content expired: create it again.
+		if r.entries[key] == entry {
+			delete(r.entries, key)
+		}
+		r.mu.Unlock()
+	}
+}
+
+// create creates the cached content of an entry, unregistering the entry if the
+// creation fails.
+func (r *CacheRegistry) create(ctx context.Context, key string, entry *cacheRegistryEntry, model string, config *CreateCachedContentConfig) {
+	defer close(entry.ready)
+	c := *config
+	if c.TTL == 0 && c.ExpireTime.IsZero() {
+		c.TTL = r.ttl
+	}
+	requested := time.Now().Add(c.TTL)
+	cache, err := r.caches.Create(ctx, model, &c)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		entry.err = err
+		delete(r.entries, key)
+		return
+	}
+	if cache.ExpireTime.IsZero() {
+		cache.ExpireTime = requested
+		if !c.ExpireTime.IsZero() {
+			cache.ExpireTime = c.ExpireTime
+		}
+	}
+	entry.cache = cache
+}
+
+// GC deletes the registered cached contents not returned by
+// [CacheRegistry.GetOrCreate] for maxIdle, and unregisters them along with the
+// expired ones. It returns the names of the deleted cached contents.
+func (r *CacheRegistry) GC(ctx context.Context, maxIdle time.Duration) ([]string, error) {
+	r.mu.Lock()
+	var idle []*CachedContent
+	now := time.Now()
+	for key, entry := range r.entries {
+		select {
+		case <-entry.ready:
+		default:
+			// Being created.
+			continue
+		}
+		switch {
+		case entry.err != nil || !now.Before(entry.cache.ExpireTime):
+			delete(r.entries, key)
+		case now.Sub(entry.lastUsed) >= maxIdle:
+			delete(r.entries, key)
+			idle = append(idle, entry.cache)
+		}
+	}
+	r.mu.Unlock()
+
+	var deleted []string
+	var errs []error
+	for _, cache := range idle {
+		if _, err := r.caches.Delete(ctx, cache.Name, nil); err != nil && !isNotFound(err) {
+			errs = append(errs, fmt.Errorf("%s: %w", cache.Name, err))
+			continue
+		}
+		deleted = append(deleted, cache.Name)
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// cacheContentHash returns the hash identifying the content of a cached content.
+func cacheContentHash(model string, config *CreateCachedContentConfig) (string, error) {
+	data, err := json.Marshal(struct {
+		Model             string      `json:"model"`
+		Contents          []*Content  `json:"contents,omitempty"`
+		SystemInstruction *Content    `json:"systemInstruction,omitempty"`
+		Tools             []*Tool     `json:"tools,omitempty"`
+		ToolConfig        *ToolConfig `json:"toolConfig,omitempty"`
+		KmsKeyName        string      `json:"kmsKeyName,omitempty"`
+	}{model, config.Contents, config.SystemInstruction, config.Tools, config.ToolConfig, config.KmsKeyName})
+	if err != nil {
+		return "", fmt.Errorf("error hashing cached content: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheRegistry(t *testing.T) {
+	ctx := context.Background()
+	var created, deleted atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			n := created.Add(1)
+			// Slow down the creation so that concurrent calls wait for it.
+			time.Sleep(5 * time.Millisecond)
+			fmt.Fprintf(w, `{"name": "cachedContents/%d"}`, n)
+		case http.MethodDelete:
+			deleted.Add(1)
+			fmt.Fprint(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		APIKey:      "test-api-key",
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := NewCacheRegistry(client.Caches, time.Hour)
+
+	manual := &CreateCachedContentConfig{Contents: Text("The manual."), DisplayName: "manual"}
+	var wg sync.WaitGroup
+	names := make([]string, 10)
+	for i := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cache, err := registry.GetOrCreate(ctx, "gemini-2.0-flash", manual)
+			if err != nil {
+				t.Errorf("GetOrCreate() failed: %v", err)
+				return
+			}
+			names[i] = cache.Name
+		}()
+	}
+	wg.Wait()
+	if got := created.Load(); got != 1 {
+		t.Errorf("created %d cached contents for the same content, want 1", got)
+	}
+	if compact := slices.Compact(slices.Clone(names)); len(compact) != 1 || compact[0] != "cachedContents/1" {
+		t.Errorf("GetOrCreate() names = %q, want the same cached content", names)
+	}
+
+	// The display name and TTL don't change the content.
+	if cache, err := registry.GetOrCreate(ctx, "gemini-2.0-flash", &CreateCachedContentConfig{Contents: Text("The manual."), TTL: time.Minute}); err != nil || cache.Name != "cachedContents/1" {
+		t.Errorf("GetOrCreate() = %v, %v, want the registered cached content", cache, err)
+	}
+	if cache, err := registry.GetOrCreate(ctx, "gemini-2.0-flash", &CreateCachedContentConfig{Contents: Text("Another manual.")}); err != nil || cache.Name != "cachedContents/2" {
+		t.Errorf("GetOrCreate() with another content = %v, %v, want a new cached content", cache, err)
+	}
+	if cache, err := registry.GetOrCreate(ctx, "gemini-2.5-pro", manual); err != nil || cache.Name != "cachedContents/3" {
+		t.Errorf("GetOrCreate() with another model = %v, %v, want a new cached content", cache, err)
+	}
+
+	if gone, err := registry.GC(ctx, time.Hour); err != nil || len(gone) != 0 {
+		t.Errorf("GC() of used cached contents = %q, %v, want none deleted", gone, err)
+	}
+	gone, err := registry.GC(ctx, 0)
+	if err != nil {
+		t.Fatalf("GC() failed: %v", err)
+	}
+	slices.Sort(gone)
+	if want := []string{"cachedContents/1", "cachedContents/2", "cachedContents/3"}; !slices.Equal(gone, want) {
+		t.Errorf("GC() = %q, want %q", gone, want)
+	}
+	if cache, err := registry.GetOrCreate(ctx, "gemini-2.0-flash", manual); err != nil || cache.Name != "cachedContents/4" {
+		t.Errorf("GetOrCreate() after GC() = %v, %v, want a new cached content", cache, err)
+	}
+}
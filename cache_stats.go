@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+// CacheStats is the usage of a cached content by the GenerateContent requests of a
+// client, to quantify the savings of explicit caching.
+type CacheStats struct {
+	// The resource name of the cached content.
+	Name string
+	// The number of requests referencing the cached content.
+	Requests int
+	// The total number of prompt tokens of the requests, including the cached ones.
+	PromptTokens int64
+	// The total number of prompt tokens read from the cached content, which are
+	// billed at a reduced rate.
+	CachedTokens int64
+}
+
+// CachedFraction returns the fraction of the prompt tokens read from the cached
+// content, between 0 and 1.
+func (s CacheStats) CachedFraction() float64 {
+	if s.PromptTokens == 0 {
+		return 0
+	}
+	return float64(s.CachedTokens) / float64(s.PromptTokens)
+}
+
+// cacheStatsRegistry aggregates the usage of the cached contents by the requests of
+// a client.
+type cacheStatsRegistry struct {
+	mu    sync.Mutex
+	stats map[string]*CacheStats
+}
+
+// Stats returns the usage of every cached content referenced by the GenerateContent
+// requests of the client, including the requests of its chats, sorted by name. The
+// usage is aggregated from the usage metadata of the responses since the client was
+// created or [Caches.ResetStats] was called.
+func (m Caches) Stats() []CacheStats {
+	r := &m.apiClient.cacheStats
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := make([]CacheStats, 0, len(r.stats))
+	for _, s := range r.stats {
+		stats = append(stats, *s)
+	}
+	slices.SortFunc(stats, func(a, b CacheStats) int { return cmp.Compare(a.Name, b.Name) })
+	return stats
+}
+
+// ResetStats clears the usage returned by [Caches.Stats].
+func (m Caches) ResetStats() {
+	r := &m.apiClient.cacheStats
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats = nil
+}
+
+// recordCacheUsage adds the usage of a response to the stats of the cached content
+// referenced by its request, if any.
+func (ac *apiClient) recordCacheUsage(config *GenerateContentConfig, usage *GenerateContentResponseUsageMetadata) {
+	if config == nil || config.CachedContent == "" || usage == nil {
+		return
+	}
+	name, _ := tCachedContentName(ac, config.CachedContent)
+	r := &ac.cacheStats
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stats == nil {
+		r.stats = map[string]*CacheStats{}
+	}
+	s, ok := r.stats[name]
+	if !ok {
+		s = &CacheStats{Name: name}
+		r.stats[name] = s
+	}
+	s.Requests++
+	s.PromptTokens += int64(usage.PromptTokenCount)
+	s.CachedTokens += int64(usage.CachedContentTokenCount)
+}
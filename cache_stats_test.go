@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCachesStats(t *testing.T) {
+	ctx := context.Background()
+	const response = `{"candidates": [{"content": {"role": "model", "parts": [{"text": "Yes."}]}}], "usageMetadata": {"promptTokenCount": 100, "cachedContentTokenCount": 80}}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ":streamGenerateContent") {
+			// Every chunk reports the usage of the whole stream.
+			io.WriteString(w, "data: "+response+"\n\ndata: "+response+"\n\n")
+			return
+		}
+		io.WriteString(w, response)
+	}))
+	defer ts.Close()
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		APIKey:      "test-api-key",
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, cache := range []string{"abc", "cachedContents/abc", "def", ""} {
+		if _, err := client.Models.GenerateContent(ctx, "gemini-2.0-flash", Text("Hi"), &GenerateContentConfig{CachedContent: cache}); err != nil {
+			t.Fatalf("GenerateContent() failed: %v", err)
+		}
+	}
+	for _, err := range client.Models.GenerateContentStream(ctx, "gemini-2.0-flash", Text("Hi"), &GenerateContentConfig{CachedContent: "def"}) {
+		if err != nil {
+			t.Fatalf("GenerateContentStream() failed: %v", err)
+		}
+	}
+
+	want := []CacheStats{
+		{Name: "cachedContents/abc", Requests: 2, PromptTokens: 200, CachedTokens: 160},
+		{Name: "cachedContents/def", Requests: 2, PromptTokens: 200, CachedTokens: 160},
+	}
+	stats := client.Caches.Stats()
+	if diff := cmp.Diff(want, stats); diff != "" {
+		t.Errorf("Stats() mismatch (-want +got):\n%s", diff)
+	}
+	if got := stats[0].CachedFraction(); got != 0.8 {
+		t.Errorf("CachedFraction() = %v, want 0.8", got)
+	}
+
+	client.Caches.ResetStats()
+	if stats := client.Caches.Stats(); len(stats) != 0 {
+		t.Errorf("Stats() after ResetStats() = %v, want none", stats)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+// CachedTokenCount returns the number of prompt tokens of the response read from a
+// cache, see [GenerateContentResponseUsageMetadata.CachedContentTokenCount].
+func (r *GenerateContentResponse) CachedTokenCount() int32 {
+	if r == nil || r.UsageMetadata == nil {
+		return 0
+	}
+	return r.UsageMetadata.CachedContentTokenCount
+}
+
+// CacheHit reports whether part of the prompt of the response was read from a
+// cache. If the request didn't reference a cached content, a hit comes from
+// implicit caching: the models cache the prompt prefixes shared by recent requests,
+// so putting the large and stable content at the start of the prompt, and the
+// question at the end, increases the chance of a hit:
+//
+//	resp, _ := client.Models.GenerateContent(ctx, model, contents, nil)
+//	log.Printf("cache hit: %v, %.0f%% of the prompt cached", resp.CacheHit(), 100*resp.UsageMetadata.CachedFraction())
+func (r *GenerateContentResponse) CacheHit() bool {
+	return r.CachedTokenCount() > 0
+}
+
+// CachedFraction returns the fraction of the prompt tokens read from a cache,
+// between 0 and 1.
+func (u *GenerateContentResponseUsageMetadata) CachedFraction() float64 {
+	if u == nil || u.PromptTokenCount == 0 {
+		return 0
+	}
+	return float64(u.CachedContentTokenCount) / float64(u.PromptTokenCount)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResponseCacheHit(t *testing.T) {
+	tests := []struct {
+		name         string
+		resp         *GenerateContentResponse
+		wantHit      bool
+		wantTokens   int32
+		wantFraction float64
+	}{
+		{name: "NoUsage", resp: &GenerateContentResponse{}},
+		{name: "Miss", resp: &GenerateContentResponse{UsageMetadata: &GenerateContentResponseUsageMetadata{PromptTokenCount: 100}}},
+		{
+			name:         "Hit",
+			resp:         &GenerateContentResponse{UsageMetadata: &GenerateContentResponseUsageMetadata{PromptTokenCount: 100, CachedContentTokenCount: 25}},
+			wantHit:      true,
+			wantTokens:   25,
+			wantFraction: 0.25,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.resp.CacheHit(); got != tt.wantHit {
+				t.Errorf("CacheHit() = %v, want %v", got, tt.wantHit)
+			}
+			if got := tt.resp.CachedTokenCount(); got != tt.wantTokens {
+				t.Errorf("CachedTokenCount() = %v, want %v", got, tt.wantTokens)
+			}
+			if got := tt.resp.UsageMetadata.CachedFraction(); got != tt.wantFraction {
+				t.Errorf("CachedFraction() = %v, want %v", got, tt.wantFraction)
+			}
+		})
+	}
+}
+
+func TestCollectStreamKeepsCachedTokens(t *testing.T) {
+	chunks := []*GenerateContentResponse{
+		{UsageMetadata: &GenerateContentResponseUsageMetadata{PromptTokenCount: 100, CachedContentTokenCount: 80}},
+		{UsageMetadata: &GenerateContentResponseUsageMetadata{PromptTokenCount: 100, CandidatesTokenCount: 10, TotalTokenCount: 110}},
+	}
+	resp, err := CollectStream(func(yield func(*GenerateContentResponse, error) bool) {
+		for _, chunk := range chunks {
+			if !yield(chunk, nil) {
+				return
+			}
+		}
+	})
+	if err != nil {
+		t.Fatalf("CollectStream() failed: %v", err)
+	}
+	want := &GenerateContentResponseUsageMetadata{PromptTokenCount: 100, CachedContentTokenCount: 80, CandidatesTokenCount: 10, TotalTokenCount: 110}
+	if diff := cmp.Diff(want, resp.UsageMetadata); diff != "" {
+		t.Errorf("UsageMetadata mismatch (-want +got):\n%s", diff)
+	}
+	if !resp.CacheHit() {
+		t.Errorf("CacheHit() = false, want true")
+	}
+}
@@ -995,5 +995,5 @@ func (m Caches) All(ctx context.Context) iter.Seq2[*CachedContent, error] {
 	if err != nil {
 		return yieldErrorAndEndIterator[CachedContent](err)
 	}
-	return p.all(ctx)
+	return p.All(ctx)
 }
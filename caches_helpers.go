@@ -0,0 +1,343 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// KeepAliveConfig configures [Caches.KeepAlive].
+type KeepAliveConfig struct {
+	// Optional. The interval between the refreshes of the expiration time. Defaults
+	// to half the TTL. A negative interval disables the scheduled refreshes, so that
+	// the cache is only refreshed on use, see [CacheKeeper.Touch].
+	Interval time.Duration
+	// Optional. Called with the errors of the scheduled refreshes, which are
+	// otherwise only reported by [CacheKeeper.Err].
+	OnError func(error)
+}
+
+// CacheKeeper keeps a cached content alive by extending its expiration time until
+// it is released. It is safe for concurrent use.
+type CacheKeeper struct {
+	caches Caches
+	name   string
+	ttl    time.Duration
+
+	mu         sync.Mutex
+	expireTime time.Time
+	err        error
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// KeepAlive sets the TTL of the named cached content, then refreshes it on a
+// schedule so that the cache doesn't expire while in use, e.g. in a long-running
+// service sharing a cached system context between requests. The refreshes stop
+// when the context is done or the keeper is released:
+//
+//	keeper, err := client.Caches.KeepAlive(ctx, cache.Name, 10*time.Minute, nil)
+//	if err != nil {
+//		return err
+//	}
+//	defer keeper.Release()
+func (m Caches) KeepAlive(ctx context.Context, name string, ttl time.Duration, config *KeepAliveConfig) (*CacheKeeper, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("ttl must be positive, got %v", ttl)
+	}
+	if config == nil {
+		config = &KeepAliveConfig{}
+	}
+	k := &CacheKeeper{caches: m, name: name, ttl: ttl, done: make(chan struct{})}
+	if err := k.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	interval := config.Interval
+	if interval == 0 {
+		interval = ttl / 2
+	}
+	ctx, k.cancel = context.WithCancel(ctx)
+	if interval < 0 {
+		close(k.done)
+		return k, nil
+	}
+	go func() {
+		defer close(k.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := k.refresh(ctx); err != nil && ctx.Err() == nil && config.OnError != nil {
+					config.OnError(err)
+				}
+			}
+		}
+	}()
+	return k, nil
+}
+
+// Name returns the name of the kept cached content.
+func (k *CacheKeeper) Name() string {
+	return k.name
+}
+
+// ExpireTime returns the expiration time of the cached content set by the last
+// successful refresh.
+func (k *CacheKeeper) ExpireTime() time.Time {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.expireTime
+}
+
+// Err returns the error of the last refresh, or nil if it succeeded.
+func (k *CacheKeeper) Err() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.err
+}
+
+// Touch refreshes the expiration time of the cached content if less than half of
+// its TTL remains. Call it before using the cache, e.g. when refreshing on use only.
+func (k *CacheKeeper) Touch(ctx context.Context) error {
+	if time.Until(k.ExpireTime()) > k.ttl/2 {
+		return nil
+	}
+	return k.refresh(ctx)
+}
+
+// Release stops refreshing the cached content, which then expires at the end of its
+// TTL. Delete it with [Caches.Delete] to free it immediately.
+func (k *CacheKeeper) Release() {
+	k.cancel()
+	<-k.done
+}
+
+// refresh sets the expiration time of the cached content to the TTL from now.
+func (k *CacheKeeper) refresh(ctx context.Context) error {
+	requested := time.Now().Add(k.ttl)
+	cache, err := k.caches.Update(ctx, k.name, &UpdateCachedContentConfig{TTL: k.ttl})
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if err != nil {
+		k.err = fmt.Errorf("error refreshing cached content %s: %w", k.name, err)
+		return k.err
+	}
+	k.err = nil
+	k.expireTime = requested
+	if !cache.ExpireTime.IsZero() {
+		k.expireTime = cache.ExpireTime
+	}
+	return nil
+}
+
+// chatCache is a cached content created from the history of a chat.
+type chatCache struct {
+	// The contents of the history in the cache.
+	history []*Content
+	// The system instruction, tools and tool config of the chat, which are part of
+	// the cache and removed from the config of the chat.
+	systemInstruction *Content
+	tools             []*Tool
+	toolConfig        *ToolConfig
+}
+
+// CreateFromChat caches the history of the chat, along with its system instruction,
+// tools and tool config, and makes the following messages of the chat reference the
+// cached content instead of resending the cached history. Only the turns added
+// afterwards are sent with the messages, cutting the cost of long-running sessions.
+//
+// The cached content expires after ttl: keep it alive with [Caches.KeepAlive], or
+// call CreateFromChat again to cache the longer history of the chat. The previous
+// cached content of the chat is not deleted. Note that the API requires a minimum
+// number of tokens in a cached content.
+func (m Caches) CreateFromChat(ctx context.Context, chat *Chat, ttl time.Duration) (*CachedContent, error) {
+	if err := chat.loadHistory(ctx); err != nil {
+		return nil, err
+	}
+	history := curatedHistory(chat.comprehensiveHistory)
+	if len(history) == 0 {
+		return nil, fmt.Errorf("chat has no history to cache")
+	}
+	snapshot := &chatCache{history: history}
+	switch {
+	case chat.cache != nil:
+		snapshot.systemInstruction = chat.cache.systemInstruction
+		snapshot.tools = chat.cache.tools
+		snapshot.toolConfig = chat.cache.toolConfig
+	case chat.config != nil && chat.config.CachedContent != "":
+		return nil, fmt.Errorf("chat already uses cached content %s", chat.config.CachedContent)
+	case chat.config != nil:
+		snapshot.systemInstruction = chat.config.SystemInstruction
+		snapshot.tools = chat.config.Tools
+		snapshot.toolConfig = chat.config.ToolConfig
+	}
+
+	cache, err := m.Create(ctx, chat.model, &CreateCachedContentConfig{
+		TTL:               ttl,
+		Contents:          history,
+		SystemInstruction: snapshot.systemInstruction,
+		Tools:             snapshot.tools,
+		ToolConfig:        snapshot.toolConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+	chat.SetCachedContent(cache.Name)
+	chat.cache = snapshot
+	return cache, nil
+}
+
+const defaultMaxConcurrentCreates = 4
+
+// WarmCachesConfig configures [Caches.Warm].
+type WarmCachesConfig struct {
+	// Optional. The maximum number of cached contents created at a time. Defaults
+	// to 4.
+	MaxConcurrentCreates int
+	// Optional. The TTL of the cached contents.
+	TTL time.Duration
+	// Optional. The system instruction cached along with every document.
+	SystemInstruction *Content
+	// Optional. Registers the cached contents, reusing the ones already registered
+	// with the same content, see [CacheRegistry].
+	Registry *CacheRegistry
+}
+
+// CacheManifestEntry is the cached content created for a document by
+// [Caches.Warm].
+type CacheManifestEntry struct {
+	// The name of the cached content, or empty if its creation failed.
+	Name string `json:"name,omitempty"`
+	// The expiration time of the cached content.
+	ExpireTime time.Time `json:"expireTime,omitempty"`
+}
+
+// Warm creates a cached content of the model for every document concurrently, e.g.
+// ahead of a traffic spike, and returns the manifest of the created cached contents
+// in the order of the documents. The manifest can be serialized as JSON to be
+// shared with the instances serving the traffic.
+//
+// If some creations fail, the manifest has empty entries for their documents, and
+// the returned error joins the error of every failed creation.
+func (m Caches) Warm(ctx context.Context, model string, documents []*Content, config *WarmCachesConfig) ([]CacheManifestEntry, error) {
+	if config == nil {
+		config = &WarmCachesConfig{}
+	}
+	maxConcurrent := config.MaxConcurrentCreates
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrentCreates
+	}
+	manifest := make([]CacheManifestEntry, len(documents))
+	errs := runConcurrently(ctx, len(documents), maxConcurrent, func(i int) error {
+		createConfig := &CreateCachedContentConfig{
+			TTL:               config.TTL,
+			Contents:          []*Content{documents[i]},
+			SystemInstruction: config.SystemInstruction,
+		}
+		var cache *CachedContent
+		var err error
+		if config.Registry != nil {
+			cache, err = config.Registry.GetOrCreate(ctx, model, createConfig)
+		} else {
+			cache, err = m.Create(ctx, model, createConfig)
+		}
+		if err != nil {
+			return fmt.Errorf("documents[%d]: %w", i, err)
+		}
+		manifest[i] = CacheManifestEntry{Name: cache.Name, ExpireTime: cache.ExpireTime}
+		return nil
+	})
+	return manifest, errors.Join(errs...)
+}
+
+// CacheRef is a stable reference to a cached content whose content can be
+// replaced, e.g. to track a slowly-changing document. The API only allows updating
+// the expiration time of a cached content: replacing its content creates a new
+// cached content and swaps the reference. It is safe for concurrent use.
+//
+//	ref, err := client.Caches.CreateRef(ctx, "gemini-2.0-flash", &genai.CreateCachedContentConfig{
+//		TTL:      time.Hour,
+//		Contents: genai.Text(document),
+//	})
+//	// Every request reads the current cached content.
+//	config := &genai.GenerateContentConfig{CachedContent: ref.Name()}
+//	// When the document changes:
+//	err = ref.Replace(ctx, &genai.CreateCachedContentConfig{TTL: time.Hour, Contents: genai.Text(updated)}, time.Minute)
+type CacheRef struct {
+	caches *Caches
+	model  string
+
+	mu    sync.RWMutex
+	cache *CachedContent
+}
+
+// CreateRef creates a cached content and returns a reference to it.
+func (m Caches) CreateRef(ctx context.Context, model string, config *CreateCachedContentConfig) (*CacheRef, error) {
+	cache, err := m.Create(ctx, model, config)
+	if err != nil {
+		return nil, err
+	}
+	return &CacheRef{caches: &m, model: model, cache: cache}, nil
+}
+
+// Name returns the name of the current cached content.
+func (r *CacheRef) Name() string {
+	return r.Cached().Name
+}
+
+// Cached returns the current cached content.
+func (r *CacheRef) Cached() *CachedContent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cache
+}
+
+// Replace creates a cached content of the same model with the given config and
+// makes the reference point to it. The previous cached content expires after the
+// grace period, so that the requests already referencing it can complete, or is
+// deleted immediately if grace is zero.
+//
+// If the new cached content cannot be created, the reference is unchanged. If the
+// previous cached content cannot be expired, the reference points to the new one
+// and the error is returned.
+func (r *CacheRef) Replace(ctx context.Context, config *CreateCachedContentConfig, grace time.Duration) error {
+	cache, err := r.caches.Create(ctx, r.model, config)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	previous := r.cache
+	r.cache = cache
+	r.mu.Unlock()
+
+	if grace > 0 {
+		_, err = r.caches.Update(ctx, previous.Name, &UpdateCachedContentConfig{TTL: grace})
+	} else {
+		_, err = r.caches.Delete(ctx, previous.Name, nil)
+	}
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("error expiring the replaced cached content %s: %w", previous.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTestRefreshedCaches returns a Caches whose server counts the refreshes of the
+// cached content "cachedContents/abc", failing them if fail is set.
+func newTestRefreshedCaches(t *testing.T, refreshes *atomic.Int32, fail *atomic.Bool) Caches {
+	t.Helper()
+	client := newTestClient(t, BackendGeminiAPI, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/v1beta/cachedContents/abc" {
+			http.NotFound(w, r)
+			return
+		}
+		refreshes.Add(1)
+		if fail != nil && fail.Load() {
+			http.Error(w, `{"error": {"code": 500, "message": "internal", "status": "INTERNAL"}}`, http.StatusInternalServerError)
+			return
+		}
+		io.WriteString(w, `{"name": "cachedContents/abc"}`)
+	})
+	return *client.Caches
+}
+
+func TestCachesKeepAlive(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Scheduled", func(t *testing.T) {
+		var refreshes atomic.Int32
+		var fail atomic.Bool
+		caches := newTestRefreshedCaches(t, &refreshes, &fail)
+		errs := make(chan error, 1)
+		keeper, err := caches.KeepAlive(ctx, "abc", time.Hour, &KeepAliveConfig{
+			Interval: time.Millisecond,
+			OnError: func(err error) {
+				select {
+				case errs <- err:
+				default:
+				}
+			},
+		})
+		if err != nil {
+			t.Fatalf("KeepAlive() failed: %v", err)
+		}
+		if until := time.Until(keeper.ExpireTime()); until < 59*time.Minute {
+			t.Errorf("ExpireTime() is in %v, want about an hour", until)
+		}
+		for refreshes.Load() < 3 {
+			time.Sleep(time.Millisecond)
+		}
+		fail.Store(true)
+		if err := <-errs; err == nil {
+			t.Errorf("OnError() got nil error")
+		}
+		keeper.Release()
+		if keeper.Err() == nil {
+			t.Errorf("Err() = nil after a failed refresh")
+		}
+		// A refresh cancelled by Release may still reach the server.
+		time.Sleep(10 * time.Millisecond)
+		n := refreshes.Load()
+		time.Sleep(10 * time.Millisecond)
+		if got := refreshes.Load(); got != n {
+			t.Errorf("got %d refreshes after Release(), want none", got-n)
+		}
+	})
+
+	t.Run("OnUse", func(t *testing.T) {
+		var refreshes atomic.Int32
+		caches := newTestRefreshedCaches(t, &refreshes, nil)
+		keeper, err := caches.KeepAlive(ctx, "abc", time.Hour, &KeepAliveConfig{Interval: -1})
+		if err != nil {
+			t.Fatalf("KeepAlive() failed: %v", err)
+		}
+		defer keeper.Release()
+		if err := keeper.Touch(ctx); err != nil {
+			t.Fatalf("Touch() failed: %v", err)
+		}
+		if got := refreshes.Load(); got != 1 {
+			t.Errorf("got %d refreshes, want 1 as the TTL is mostly remaining", got)
+		}
+		keeper.mu.Lock()
+		keeper.expireTime = time.Now().Add(time.Minute)
+		keeper.mu.Unlock()
+		if err := keeper.Touch(ctx); err != nil {
+			t.Fatalf("Touch() failed: %v", err)
+		}
+		if got := refreshes.Load(); got != 2 {
+			t.Errorf("got %d refreshes, want 2 once the cache is about to expire", got)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		var refreshes atomic.Int32
+		caches := newTestRefreshedCaches(t, &refreshes, nil)
+		if _, err := caches.KeepAlive(ctx, "missing", time.Hour, nil); err == nil {
+			t.Errorf("KeepAlive() of a missing cache succeeded, want error")
+		}
+	})
+}
+
+func TestCachesCreateFromChat(t *testing.T) {
+	ctx := context.Background()
+	var requests []cachesRequest
+	client := newTestCaches(t, BackendGeminiAPI, &requests)
+	history := []*Content{
+		NewContentFromText("Here is the contract.", RoleUser),
+		NewContentFromText("I read it.", RoleModel),
+	}
+	chat, err := client.Chats.Create(ctx, "gemini-2.0-flash", &GenerateContentConfig{
+		SystemInstruction: NewContentFromText("You are a lawyer.", RoleUser),
+	}, history)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err := client.Caches.CreateFromChat(ctx, chat, time.Hour)
+	if err != nil {
+		t.Fatalf("CreateFromChat() failed: %v", err)
+	}
+	if _, err := chat.Send(ctx, NewPartFromText("Is it valid?")); err != nil {
+		t.Fatalf("Send() failed: %v", err)
+	}
+	if _, err := client.Caches.CreateFromChat(ctx, chat, time.Hour); err != nil {
+		t.Fatalf("second CreateFromChat() failed: %v", err)
+	}
+
+	if len(requests) != 3 {
+		t.Fatalf("got %d requests, want 3", len(requests))
+	}
+	create := requests[0].Body
+	if contents, _ := create["contents"].([]any); len(contents) != 2 || create["systemInstruction"] == nil {
+		t.Errorf("first cache = %v, want the history and the system instruction", create)
+	}
+	send := requests[1].Body
+	if contents, _ := send["contents"].([]any); len(contents) != 1 {
+		t.Errorf("message contents = %v, want only the new message", send["contents"])
+	}
+	if send["cachedContent"] != cache.Name || send["systemInstruction"] != nil {
+		t.Errorf("message = %v, want a reference to %s and no system instruction", send, cache.Name)
+	}
+	if contents, _ := requests[2].Body["contents"].([]any); len(contents) != 4 || requests[2].Body["systemInstruction"] == nil {
+		t.Errorf("second cache = %v, want the whole history and the system instruction", requests[2].Body)
+	}
+
+	if err := chat.Rewind(ctx, 2); err != nil {
+		t.Fatalf("Rewind() failed: %v", err)
+	}
+	if _, err := chat.Send(ctx, NewPartFromText("Is it valid?")); err == nil {
+		t.Errorf("Send() after rewinding the cached history succeeded, want error")
+	}
+}
+
+func TestCachesWarm(t *testing.T) {
+	ctx := context.Background()
+	var inFlight, maxInFlight atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for m := maxInFlight.Load(); n > m && !maxInFlight.CompareAndSwap(m, n); m = maxInFlight.Load() {
+		}
+		time.Sleep(5 * time.Millisecond)
+		var body struct {
+			Contents []*Content `json:"contents"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		text := body.Contents[0].Parts[0].Text
+		if text == "bad" {
+			http.Error(w, `{"error": {"code": 400, "message": "too few tokens", "status": "INVALID_ARGUMENT"}}`, http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"name": "cachedContents/%s", "expireTime": "2025-01-01T00:00:00Z"}`, text)
+	}))
+	defer ts.Close()
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		APIKey:      "test-api-key",
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var documents []*Content
+	for _, text := range []string{"a", "b", "bad", "c", "d", "e"} {
+		documents = append(documents, NewContentFromText(text, RoleUser))
+	}
+	manifest, err := client.Caches.Warm(ctx, "gemini-2.0-flash", documents, &WarmCachesConfig{MaxConcurrentCreates: 2, TTL: time.Hour})
+	if err == nil || !strings.Contains(err.Error(), "documents[2]") {
+		t.Errorf("Warm() error = %v, want an error for documents[2]", err)
+	}
+	var names []string
+	for _, entry := range manifest {
+		names = append(names, entry.Name)
+	}
+	want := []string{"cachedContents/a", "cachedContents/b", "", "cachedContents/c", "cachedContents/d", "cachedContents/e"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("Warm() manifest mismatch (-want +got):\n%s", diff)
+	}
+	if got := maxInFlight.Load(); got > 2 {
+		t.Errorf("got %d concurrent creations, want at most 2", got)
+	}
+}
+
+func TestCacheRefReplace(t *testing.T) {
+	ctx := context.Background()
+	var created atomic.Int32
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, body["ttl"]))
+		if r.Method == http.MethodPost {
+			if body["contents"] == nil {
+				http.Error(w, `{"error": {"code": 400, "message": "no contents", "status": "INVALID_ARGUMENT"}}`, http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintf(w, `{"name": "cachedContents/%d"}`, created.Add(1))
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		APIKey:      "test-api-key",
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ref, err := client.Caches.CreateRef(ctx, "gemini-2.0-flash", &CreateCachedContentConfig{Contents: Text("v1")})
+	if err != nil {
+		t.Fatalf("CreateRef() failed: %v", err)
+	}
+	if err := ref.Replace(ctx, &CreateCachedContentConfig{Contents: Text("v2")}, time.Minute); err != nil {
+		t.Fatalf("Replace() failed: %v", err)
+	}
+	if got, want := ref.Name(), "cachedContents/2"; got != want {
+		t.Errorf("Name() = %q, want %q", got, want)
+	}
+	if err := ref.Replace(ctx, &CreateCachedContentConfig{}, 0); err == nil {
+		t.Errorf("Replace() with an invalid config succeeded, want error")
+	}
+	if err := ref.Replace(ctx, &CreateCachedContentConfig{Contents: Text("v3")}, 0); err != nil {
+		t.Fatalf("Replace() failed: %v", err)
+	}
+	if got, want := ref.Name(), "cachedContents/3"; got != want {
+		t.Errorf("Name() = %q, want %q", got, want)
+	}
+
+	want := []string{
+		"POST /v1beta/cachedContents <nil>",
+		"POST /v1beta/cachedContents <nil>",
+		"PATCH /v1beta/cachedContents/1 60s",
+		"POST /v1beta/cachedContents <nil>",
+		"POST /v1beta/cachedContents <nil>",
+		"DELETE /v1beta/cachedContents/2 <nil>",
+	}
+	if diff := cmp.Diff(want, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -3,9 +3,13 @@ package genai
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -119,3 +123,116 @@ func TestCachesAll(t *testing.T) {
 		})
 	}
 }
+
+// cachesRequest is a request received by newTestCaches.
+type cachesRequest struct {
+	Method, Path string
+	Body         map[string]any
+}
+
+// newTestCaches returns a client whose server records the requests and replies to
+// the cache requests with a cached content named after the path, and to the other
+// requests with a text response.
+func newTestCaches(t *testing.T, backend Backend, requests *[]cachesRequest) *Client {
+	t.Helper()
+	var mu sync.Mutex
+	return newTestClient(t, backend, func(w http.ResponseWriter, r *http.Request) {
+		req := cachesRequest{Method: r.Method, Path: r.URL.Path}
+		json.NewDecoder(r.Body).Decode(&req.Body)
+		mu.Lock()
+		*requests = append(*requests, req)
+		mu.Unlock()
+		switch {
+		case strings.Contains(r.URL.Path, ":generateContent"):
+			io.WriteString(w, finalTextResponseJSON)
+		case r.Method == http.MethodDelete:
+			io.WriteString(w, `{}`)
+		default:
+			_, name, _ := strings.Cut(r.URL.Path[1:], "/")
+			if r.Method == http.MethodPost {
+				name += "/abc"
+			}
+			json.NewEncoder(w).Encode(map[string]any{"name": name, "model": req.Body["model"]})
+		}
+	})
+}
+
+func TestCachesLifecycle(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		backend      Backend
+		collection   string
+		modelPrefix  string
+		wantCacheRef string
+	}{
+		{
+			backend:      BackendGeminiAPI,
+			collection:   "/v1beta/cachedContents",
+			modelPrefix:  "models/",
+			wantCacheRef: "cachedContents/abc",
+		},
+		{
+			backend:      BackendVertexAI,
+			collection:   "/v1beta1/projects/project/locations/us-central1/cachedContents",
+			modelPrefix:  "projects/project/locations/us-central1/publishers/google/models/",
+			wantCacheRef: "projects/project/locations/us-central1/cachedContents/abc",
+		},
+	} {
+		t.Run(tt.backend.String(), func(t *testing.T) {
+			var requests []cachesRequest
+			client := newTestCaches(t, tt.backend, &requests)
+
+			cache, err := client.Caches.Create(ctx, "gemini-2.0-flash", &CreateCachedContentConfig{
+				TTL:               time.Hour,
+				SystemInstruction: NewContentFromText("You are a lawyer.", RoleUser),
+				Contents:          Text("The contract."),
+			})
+			if err != nil {
+				t.Fatalf("Create() failed: %v", err)
+			}
+			if _, err := client.Caches.Get(ctx, "abc", nil); err != nil {
+				t.Fatalf("Get() failed: %v", err)
+			}
+			if _, err := client.Caches.Update(ctx, cache.Name, &UpdateCachedContentConfig{TTL: 2 * time.Hour}); err != nil {
+				t.Fatalf("Update() failed: %v", err)
+			}
+			if _, err := client.Models.GenerateContent(ctx, "gemini-2.0-flash", Text("Summarize the contract."), &GenerateContentConfig{CachedContent: "abc"}); err != nil {
+				t.Fatalf("GenerateContent() failed: %v", err)
+			}
+			if _, err := client.Caches.Delete(ctx, "abc", nil); err != nil {
+				t.Fatalf("Delete() failed: %v", err)
+			}
+
+			if len(requests) != 5 {
+				t.Fatalf("got %d requests, want 5", len(requests))
+			}
+			want := []cachesRequest{
+				{Method: http.MethodPost, Path: tt.collection},
+				{Method: http.MethodGet, Path: tt.collection + "/abc"},
+				{Method: http.MethodPatch, Path: tt.collection + "/abc"},
+				{Method: http.MethodPost, Path: requests[3].Path},
+				{Method: http.MethodDelete, Path: tt.collection + "/abc"},
+			}
+			for i := range want {
+				if got := requests[i]; got.Method != want[i].Method || got.Path != want[i].Path {
+					t.Errorf("request %d = %s %s, want %s %s", i, got.Method, got.Path, want[i].Method, want[i].Path)
+				}
+			}
+			if got, want := requests[0].Body["model"], tt.modelPrefix+"gemini-2.0-flash"; got != want {
+				t.Errorf("Create() model = %v, want %v", got, want)
+			}
+			if got, want := requests[0].Body["ttl"], "3600s"; got != want {
+				t.Errorf("Create() ttl = %v, want %v", got, want)
+			}
+			if requests[0].Body["systemInstruction"] == nil || requests[0].Body["contents"] == nil {
+				t.Errorf("Create() body = %v, want a system instruction and contents", requests[0].Body)
+			}
+			if diff := cmp.Diff(map[string]any{"ttl": "7200s"}, requests[2].Body); diff != "" {
+				t.Errorf("Update() body mismatch (-want +got):\n%s", diff)
+			}
+			if got := requests[3].Body["cachedContent"]; got != tt.wantCacheRef {
+				t.Errorf("GenerateContent() cachedContent = %v, want %v", got, tt.wantCacheRef)
+			}
+		})
+	}
+}
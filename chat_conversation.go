@@ -0,0 +1,256 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Participant is a named chat session taking part in a [Conversation]. Every
+// participant can use its own model and config, e.g. a system instruction
+// describing its role in the conversation.
+type Participant struct {
+	// Required. The name of the participant, unique in the conversation.
+	Name string
+	// Required. The chat session of the participant.
+	Chat *Chat
+}
+
+// ConversationTurn is a message of a [Conversation].
+type ConversationTurn struct {
+	// The name of the participant who sent the message, or the speaker given to
+	// [Conversation.Add] for the messages not sent by a participant.
+	Speaker string
+	// The content of the message.
+	Content *Content
+}
+
+// NextSpeakerFunc returns the name of the participant speaking next in a
+// [Conversation] given the transcript so far. An empty name ends the conversation.
+type NextSpeakerFunc func(ctx context.Context, transcript []*ConversationTurn) (string, error)
+
+// Conversation orchestrates a conversation between several chat sessions, e.g. a
+// debate or a critic refining the answers of a writer. Every participant receives
+// the messages sent since its last turn as a single user message, each prefixed
+// with the name of its speaker:
+//
+//	conversation, _ := genai.NewConversation(
+//		genai.Participant{Name: "Writer", Chat: writer},
+//		genai.Participant{Name: "Critic", Chat: critic},
+//	)
+//	conversation.Add("Moderator", genai.NewPartFromText("Write a haiku about the sea."))
+//	err := conversation.RunRoundRobin(ctx, 3)
+//	transcript := conversation.Transcript()
+type Conversation struct {
+	participants []Participant
+	transcript   []*ConversationTurn
+	// The length of the transcript at the last turn of every participant.
+	seen map[string]int
+}
+
+// NewConversation returns a conversation between the given participants.
+func NewConversation(participants ...Participant) (*Conversation, error) {
+	if len(participants) == 0 {
+		return nil, fmt.Errorf("at least one participant is required")
+	}
+	seen := make(map[string]int, len(participants))
+	for i, p := range participants {
+		if p.Name == "" {
+			return nil, fmt.Errorf("participants[%d] has no name", i)
+		}
+		if p.Chat == nil {
+			return nil, fmt.Errorf("participant %q has no chat", p.Name)
+		}
+		if _, ok := seen[p.Name]; ok {
+			return nil, fmt.Errorf("participant %q is not unique", p.Name)
+		}
+		seen[p.Name] = 0
+	}
+	return &Conversation{participants: slices.Clone(participants), seen: seen}, nil
+}
+
+// Add adds a message not sent by a participant to the conversation, e.g. the topic
+// of a debate or an intervention of a human. The participants receive it at their
+// next turn.
+func (c *Conversation) Add(speaker string, parts ...*Part) {
+	c.transcript = append(c.transcript, &ConversationTurn{
+		Speaker: speaker,
+		Content: NewContentFromParts(parts, RoleUser),
+	})
+}
+
+// Speak sends the messages of the conversation received since the last turn of the
+// named participant to its chat, and adds the response to the conversation.
+func (c *Conversation) Speak(ctx context.Context, name string) (*ConversationTurn, error) {
+	p, ok := c.participant(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown participant %q", name)
+	}
+	input := c.unseenContent(name)
+	if input == nil {
+		return nil, fmt.Errorf("participant %q has no new message to respond to", name)
+	}
+	resp, err := p.Chat.SendContents(ctx, []*Content{input}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("participant %q: %w", name, err)
+	}
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+		return nil, fmt.Errorf("participant %q: response has no content", name)
+	}
+	turn := &ConversationTurn{Speaker: name, Content: resp.Candidates[0].Content}
+	c.transcript = append(c.transcript, turn)
+	c.seen[name] = len(c.transcript)
+	return turn, nil
+}
+
+// RunRoundRobin runs the given number of rounds of the conversation, in which every
+// participant speaks once in the order given to [NewConversation].
+func (c *Conversation) RunRoundRobin(ctx context.Context, rounds int) error {
+	for range rounds {
+		for _, p := range c.participants {
+			if _, err := c.Speak(ctx, p.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Run runs the conversation, letting next choose the participant speaking at every
+// turn, until next returns an empty name or maxTurns turns have been taken.
+func (c *Conversation) Run(ctx context.Context, next NextSpeakerFunc, maxTurns int) error {
+	for range maxTurns {
+		name, err := next(ctx, c.Transcript())
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			return nil
+		}
+		if _, err := c.Speak(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Transcript returns the messages of the conversation.
+func (c *Conversation) Transcript() []*ConversationTurn {
+	return slices.Clone(c.transcript)
+}
+
+// NewModerator returns a [NextSpeakerFunc] asking the model of the given chat to
+// choose the next speaker among the participants of the conversation, or to end it.
+// The moderator receives the messages of the conversation like a participant.
+func (c *Conversation) NewModerator(moderator *Chat) NextSpeakerFunc {
+	const done = "DONE"
+	var seen int
+	return func(ctx context.Context, transcript []*ConversationTurn) (string, error) {
+		names := make([]string, len(c.participants))
+		for i, p := range c.participants {
+			names[i] = p.Name
+		}
+		var parts []*Part
+		if content := conversationContent(transcript[min(seen, len(transcript)):]); content != nil {
+			parts = content.Parts
+		}
+		seen = len(transcript)
+		parts = append(parts, NewPartFromText(fmt.Sprintf(
+			"Reply with the name of the participant who should speak next among %s, or with %s to end the conversation.",
+			strings.Join(names, ", "), done)))
+		resp, err := moderator.Send(ctx, parts...)
+		if err != nil {
+			return "", fmt.Errorf("moderator: %w", err)
+		}
+		name := strings.Trim(strings.TrimSpace(resp.Text()), ".\"'")
+		if name == done {
+			return "", nil
+		}
+		if _, ok := c.participant(name); !ok {
+			return "", fmt.Errorf("moderator chose unknown participant %q", name)
+		}
+		return name, nil
+	}
+}
+
+func (c *Conversation) participant(name string) (Participant, bool) {
+	for _, p := range c.participants {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Participant{}, false
+}
+
+// unseenContent returns the messages received by the named participant since its
+// last turn as a single user content, or nil if there is none.
+func (c *Conversation) unseenContent(name string) *Content {
+	var turns []*ConversationTurn
+	for _, turn := range c.transcript[c.seen[name]:] {
+		if turn.Speaker != name {
+			turns = append(turns, turn)
+		}
+	}
+	return conversationContent(turns)
+}
+
+// conversationContent merges the given turns into a single user content. The text
+// of every turn is prefixed with the name of its speaker, and adjacent text is
+// merged into a single part. The function calls and responses of the speakers are
+// dropped: they belong to the model turns of their own chats, and the API rejects
+// function calls in user contents.
+func conversationContent(turns []*ConversationTurn) *Content {
+	var parts []*Part
+	for _, turn := range turns {
+		if turn.Content == nil {
+			continue
+		}
+		prefix := turn.Speaker + ":"
+		text := prefix
+		for _, part := range turn.Content.Parts {
+			if part == nil || part.Thought || part.FunctionCall != nil || part.FunctionResponse != nil {
+				continue
+			}
+			if isPlainTextPart(part) {
+				text += " " + part.Text
+				continue
+			}
+			parts = appendConversationText(parts, text)
+			parts = append(parts, part)
+			text = ""
+		}
+		if text != prefix {
+			parts = appendConversationText(parts, text)
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	return NewContentFromParts(parts, RoleUser)
+}
+
+func appendConversationText(parts []*Part, text string) []*Part {
+	if text == "" {
+		return parts
+	}
+	if n := len(parts); n > 0 && isPlainTextPart(parts[n-1]) {
+		parts[n-1] = NewPartFromText(parts[n-1].Text + "\n\n" + text)
+		return parts
+	}
+	return append(parts, NewPartFromText(text))
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func textResponseJSON(text string) string {
+	return fmt.Sprintf(`{"candidates": [{"content": {"role": "model", "parts": [{"text": %q}]}, "finishReason": "STOP"}]}`, text)
+}
+
+func TestConversation(t *testing.T) {
+	ctx := context.Background()
+
+	newParticipants := func(t *testing.T, chats *Chats) []Participant {
+		t.Helper()
+		var participants []Participant
+		for _, name := range []string{"Writer", "Critic"} {
+			config := &GenerateContentConfig{SystemInstruction: NewContentFromText("You are the "+name+".", RoleUser)}
+			chat, err := chats.Create(ctx, "gemini-2.0-flash", config, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			participants = append(participants, Participant{Name: name, Chat: chat})
+		}
+		return participants
+	}
+
+	t.Run("RoundRobin", func(t *testing.T) {
+		var requests []map[string]any
+		chats := newTestChats(t, []string{
+			textResponseJSON("draft 1"),
+			textResponseJSON("too long"),
+			textResponseJSON("draft 2"),
+			textResponseJSON("good"),
+		}, &requests)
+		participants := newParticipants(t, chats)
+		conversation, err := NewConversation(participants...)
+		if err != nil {
+			t.Fatalf("NewConversation() failed: %v", err)
+		}
+		conversation.Add("Moderator", NewPartFromText("Write a haiku."))
+		if err := conversation.RunRoundRobin(ctx, 2); err != nil {
+			t.Fatalf("RunRoundRobin() failed: %v", err)
+		}
+
+		var got []string
+		for _, turn := range conversation.Transcript() {
+			got = append(got, turn.Speaker+"="+turn.Content.Parts[0].Text)
+		}
+		want := []string{"Moderator=Write a haiku.", "Writer=draft 1", "Critic=too long", "Writer=draft 2", "Critic=good"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Transcript() mismatch (-want +got):\n%s", diff)
+		}
+		wantRequests := [][]string{
+			{"Moderator: Write a haiku."},
+			{"Moderator: Write a haiku.\n\nWriter: draft 1"},
+			{"Moderator: Write a haiku.", "draft 1", "Critic: too long"},
+			{"Moderator: Write a haiku.\n\nWriter: draft 1", "too long", "Writer: draft 2"},
+		}
+		for i, want := range wantRequests {
+			if diff := cmp.Diff(want, requestTexts(requests[i])); diff != "" {
+				t.Errorf("request %d contents mismatch (-want +got):\n%s", i, diff)
+			}
+		}
+		if got, want := len(participants[0].Chat.History(false)), 4; got != want {
+			t.Errorf("len(Writer history) = %d, want %d", got, want)
+		}
+	})
+
+	t.Run("Moderator", func(t *testing.T) {
+		var requests []map[string]any
+		chats := newTestChats(t, []string{
+			textResponseJSON("Critic"),
+			textResponseJSON("needs a title"),
+			textResponseJSON("DONE"),
+		}, &requests)
+		conversation, err := NewConversation(newParticipants(t, chats)...)
+		if err != nil {
+			t.Fatalf("NewConversation() failed: %v", err)
+		}
+		moderator, err := chats.Create(ctx, "gemini-2.0-flash", nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conversation.Add("User", NewPartFromText("Review my poem."))
+		if err := conversation.Run(ctx, conversation.NewModerator(moderator), 5); err != nil {
+			t.Fatalf("Run() failed: %v", err)
+		}
+		if got, want := len(conversation.Transcript()), 2; got != want {
+			t.Fatalf("len(Transcript()) = %d, want %d", got, want)
+		}
+		if got, want := conversation.Transcript()[1].Speaker, "Critic"; got != want {
+			t.Errorf("Speaker = %q, want %q", got, want)
+		}
+		if got, want := requestTexts(requests[2])[2], "Critic: needs a title"; got != want {
+			t.Errorf("moderator request text = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		chats := newTestChats(t, []string{textResponseJSON("Nobody")}, new([]map[string]any))
+		participants := newParticipants(t, chats)
+		if _, err := NewConversation(participants[0], participants[0]); err == nil {
+			t.Errorf("NewConversation() with duplicate names succeeded, want error")
+		}
+		conversation, err := NewConversation(participants...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := conversation.Speak(ctx, "Writer"); err == nil {
+			t.Errorf("Speak() without a message succeeded, want error")
+		}
+		if _, err := conversation.Speak(ctx, "Editor"); err == nil {
+			t.Errorf("Speak() with unknown participant succeeded, want error")
+		}
+		conversation.Add("User", NewPartFromText("Hi"))
+		moderator, _ := chats.Create(ctx, "gemini-2.0-flash", nil, nil)
+		if err := conversation.Run(ctx, conversation.NewModerator(moderator), 1); err == nil {
+			t.Errorf("Run() with unknown moderator choice succeeded, want error")
+		}
+	})
+}
+
+func TestConversationContentToolCalls(t *testing.T) {
+	turns := []*ConversationTurn{
+		{Speaker: "Moderator", Content: NewContentFromText("What is the weather in Paris?", RoleUser)},
+		{Speaker: "Forecaster", Content: &Content{Role: RoleModel, Parts: []*Part{
+			{Text: "Let me check."},
+			{FunctionCall: &FunctionCall{ID: "call-1", Name: "get_weather", Args: map[string]any{"city": "Paris"}}},
+		}}},
+		{Speaker: "Forecaster", Content: &Content{Role: RoleUser, Parts: []*Part{
+			{FunctionResponse: &FunctionResponse{ID: "call-1", Name: "get_weather", Response: map[string]any{"weather": "sunny"}}},
+		}}},
+		{Speaker: "Forecaster", Content: NewContentFromText("It is sunny.", RoleModel)},
+	}
+	want := &Content{Role: RoleUser, Parts: []*Part{
+		{Text: "Moderator: What is the weather in Paris?\n\nForecaster: Let me check.\n\nForecaster: It is sunny."},
+	}}
+	if diff := cmp.Diff(want, conversationContent(turns)); diff != "" {
+		t.Errorf("conversationContent() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ChatHistoryPolicy bounds the history sent with every message of a chat session,
+// to prevent long conversations from overflowing the context window of the model.
+// Old turns are left out of the requests, but are kept in [Chat.History].
+type ChatHistoryPolicy struct {
+	// Optional. The maximum number of tokens of the contents sent with a message,
+	// including the message itself. Zero means no limit.
+	MaxTokens int32
+	// Optional. The number of contents at the start of the history that are always
+	// sent, e.g. a turn setting up the context of the conversation.
+	KeepFirstN int
+	// Optional. The minimum number of most recent contents sent with a message, even
+	// if they exceed MaxTokens.
+	KeepLastN int
+	// Optional. Counts the tokens of the contents. Defaults to calling
+	// [Models.CountTokens] with the model of the chat.
+	CountTokens func(ctx context.Context, contents []*Content) (int32, error)
+	// Optional. The model summarizing the turns left out of the requests. If set,
+	// the left out turns are replaced with a single user turn holding their summary,
+	// preserving the continuity of the conversation. The summary is extended as more
+	// turns are left out, and is not counted against MaxTokens.
+	SummaryModel string
+	// Optional. The instructions given to SummaryModel, followed by the transcript of
+	// the turns to summarize. Defaults to a generic summarization prompt.
+	SummaryPrompt string
+}
+
+const defaultSummaryPrompt = "Summarize the following conversation between a user and an AI model. " +
+	"Keep the facts, decisions and open questions needed to continue the conversation, and be concise."
+
+// chatSummary is the summary of the turns left out of the requests.
+type chatSummary struct {
+	// The summarized contents.
+	covered []*Content
+	// The user turn holding the summary.
+	content *Content
+}
+
+// SetHistoryPolicy sets the policy bounding the history sent with every message.
+// A nil policy sends the whole history.
+func (c *Chat) SetHistoryPolicy(policy *ChatHistoryPolicy) {
+	c.historyPolicy = policy
+	c.tokenCounts = nil
+	c.summary = nil
+}
+
+// requestContents returns the contents to send for the given user input, applying
+// the history policy of the chat.
+func (c *Chat) requestContents(ctx context.Context, inputContents []*Content) ([]*Content, error) {
+	history := curatedHistory(c.comprehensiveHistory)
+	if c.cache != nil {
+		// The beginning of the history is part of the cached content.
+		n := len(c.cache.history)
+		if len(history) < n || !reflect.DeepEqual(history[:n], c.cache.history) {
+			return nil, fmt.Errorf("the chat history no longer starts with the history cached in %s", c.config.CachedContent)
+		}
+		history = history[n:]
+	}
+	contents := append(slices.Clone(history), inputContents...)
+	policy := c.historyPolicy
+	if policy == nil || policy.MaxTokens <= 0 {
+		return contents, nil
+	}
+
+	// Only keep the token counts of the current contents in the cache.
+	cache := make(map[*Content]int32, len(contents))
+	counts := make([]int32, len(contents))
+	var total int32
+	for i, content := range contents {
+		n, err := c.countContentTokens(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("error counting chat history tokens: %w", err)
+		}
+		cache[content] = n
+		counts[i] = n
+		total += n
+	}
+	c.tokenCounts = cache
+
+	// Leave out the oldest turns, after the pinned ones, until the contents fit. The
+	// history is only cut before a user message, so that function calls are never
+	// separated from their responses.
+	first := min(max(policy.KeepFirstN, 0), len(history))
+	last := max(len(contents)-max(policy.KeepLastN, len(inputContents)), first)
+	cut := first
+	for i := first; i <= last; i++ {
+		if i > first {
+			total -= counts[i-1]
+		}
+		if i == len(history) || isUserMessage(contents[i]) {
+			cut = i
+			if total <= policy.MaxTokens {
+				break
+			}
+		}
+	}
+	if cut == first {
+		return contents, nil
+	}
+	kept := contents[:first:first]
+	if policy.SummaryModel != "" {
+		summary, err := c.summarize(ctx, contents[first:cut])
+		if err != nil {
+			return nil, fmt.Errorf("error summarizing chat history: %w", err)
+		}
+		kept = append(kept, summary)
+	}
+	return append(kept, contents[cut:]...), nil
+}
+
+// summarize returns a user turn summarizing the contents. The previous summary is
+// reused if it covers the beginning of the contents.
+func (c *Chat) summarize(ctx context.Context, contents []*Content) (*Content, error) {
+	previous := c.summary
+	if previous != nil && (len(previous.covered) > len(contents) || !reflect.DeepEqual(previous.covered, contents[:len(previous.covered)])) {
+		previous = nil
+	}
+	if previous != nil && len(previous.covered) == len(contents) {
+		return previous.content, nil
+	}
+
+	var transcript strings.Builder
+	remaining := contents
+	if previous != nil {
+		fmt.Fprintf(&transcript, "%s\n", previous.content.Parts[0].Text)
+		remaining = contents[len(previous.covered):]
+	}
+	for _, content := range remaining {
+		writeTranscriptTurn(&transcript, content)
+	}
+	prompt := c.historyPolicy.SummaryPrompt
+	if prompt == "" {
+		prompt = defaultSummaryPrompt
+	}
+	resp, err := c.GenerateContent(ctx, c.historyPolicy.SummaryModel, Text(prompt+"\n\n"+transcript.String()), nil)
+	if err != nil {
+		return nil, err
+	}
+	c.addUsage(resp)
+	c.summary = &chatSummary{
+		covered: slices.Clone(contents),
+		content: NewContentFromText("Summary of the earlier conversation: "+resp.Text(), RoleUser),
+	}
+	return c.summary.content, nil
+}
+
+// writeTranscriptTurn writes a plain text rendering of the content, one line per
+// part, prefixed with its role.
+func writeTranscriptTurn(w *strings.Builder, content *Content) {
+	for _, part := range content.Parts {
+		switch {
+		case part.Thought:
+			continue
+		case part.Text != "":
+			fmt.Fprintf(w, "%s: %s\n", content.Role, part.Text)
+		case part.FunctionCall != nil:
+			args, _ := json.Marshal(part.FunctionCall.Args)
+			fmt.Fprintf(w, "%s: [called function %s(%s)]\n", content.Role, part.FunctionCall.Name, args)
+		case part.FunctionResponse != nil:
+			response, _ := json.Marshal(part.FunctionResponse.Response)
+			fmt.Fprintf(w, "%s: [function %s returned %s]\n", content.Role, part.FunctionResponse.Name, response)
+		case part.InlineData != nil:
+			fmt.Fprintf(w, "%s: [%s data]\n", content.Role, part.InlineData.MIMEType)
+		case part.FileData != nil:
+			fmt.Fprintf(w, "%s: [%s file %s]\n", content.Role, part.FileData.MIMEType, part.FileData.FileURI)
+		}
+	}
+}
+
+// countContentTokens counts the tokens of a content, using the counts cached by the
+// previous message as the contents of the history are immutable.
+func (c *Chat) countContentTokens(ctx context.Context, content *Content) (int32, error) {
+	if n, ok := c.tokenCounts[content]; ok {
+		return n, nil
+	}
+	var n int32
+	var err error
+	if c.historyPolicy.CountTokens != nil {
+		n, err = c.historyPolicy.CountTokens(ctx, []*Content{content})
+	} else {
+		var resp *CountTokensResponse
+		resp, err = c.CountTokens(ctx, c.model, []*Content{content}, nil)
+		if resp != nil {
+			n = resp.TotalTokens
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// curatedHistory returns the exchanges of the history whose model turns are all
+// valid, leaving out e.g. the blocked prompts and the empty responses of the model
+// so that they don't affect the following requests.
+func curatedHistory(history []*Content) []*Content {
+	curated := make([]*Content, 0, len(history))
+	for start := 0; start < len(history); {
+		end := start + 1
+		for end < len(history) && !isUserMessage(history[end]) {
+			end++
+		}
+		valid := true
+		for _, content := range history[start:end] {
+			if content.Role == RoleModel && !isValidModelContent(content) {
+				valid = false
+				break
+			}
+		}
+		if valid {
+			curated = append(curated, history[start:end]...)
+		}
+		start = end
+	}
+	return curated
+}
+
+// isValidModelContent reports whether a model turn has parts, none of them empty.
+func isValidModelContent(content *Content) bool {
+	if len(content.Parts) == 0 {
+		return false
+	}
+	for _, part := range content.Parts {
+		if part == nil || reflect.ValueOf(*part).IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// isUserMessage reports whether the content is a message of the user, as opposed to
+// a model turn or the responses to function calls.
+func isUserMessage(content *Content) bool {
+	if content.Role != RoleUser {
+		return false
+	}
+	for _, part := range content.Parts {
+		if part.FunctionResponse != nil {
+			return false
+		}
+	}
+	return true
+}
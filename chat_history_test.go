@@ -0,0 +1,179 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// requestTexts returns the text of the first part of every content of a recorded
+// request.
+func requestTexts(request map[string]any) []string {
+	var texts []string
+	for _, content := range request["contents"].([]any) {
+		part := content.(map[string]any)["parts"].([]any)[0].(map[string]any)
+		if text, ok := part["text"].(string); ok {
+			texts = append(texts, text)
+		} else if call, ok := part["functionCall"].(map[string]any); ok {
+			texts = append(texts, "call:"+call["name"].(string))
+		} else if response, ok := part["functionResponse"].(map[string]any); ok {
+			texts = append(texts, "response:"+response["name"].(string))
+		}
+	}
+	return texts
+}
+
+func TestChatHistoryPolicy(t *testing.T) {
+	ctx := context.Background()
+	history := []*Content{
+		NewContentFromText("u1", RoleUser),
+		NewContentFromText("m1", RoleModel),
+		NewContentFromText("u2", RoleUser),
+		NewContentFromText("m2", RoleModel),
+	}
+	toolHistory := []*Content{
+		NewContentFromText("u1", RoleUser),
+		NewContentFromFunctionCall("get_weather", nil, RoleModel),
+		NewContentFromFunctionResponse("get_weather", map[string]any{"weather": "sunny"}, RoleUser),
+		NewContentFromText("m1", RoleModel),
+	}
+	// Every content counts for 10 tokens.
+	countTokens := func(ctx context.Context, contents []*Content) (int32, error) {
+		return int32(10 * len(contents)), nil
+	}
+
+	tests := []struct {
+		name    string
+		history []*Content
+		policy  *ChatHistoryPolicy
+		want    []string
+	}{
+		{
+			name:    "NoPolicy",
+			history: history,
+			want:    []string{"u1", "m1", "u2", "m2", "u3"},
+		},
+		{
+			name:    "WithinBudget",
+			history: history,
+			policy:  &ChatHistoryPolicy{MaxTokens: 50, CountTokens: countTokens},
+			want:    []string{"u1", "m1", "u2", "m2", "u3"},
+		},
+		{
+			name:    "DropsOldestTurns",
+			history: history,
+			policy:  &ChatHistoryPolicy{MaxTokens: 30, CountTokens: countTokens},
+			want:    []string{"u2", "m2", "u3"},
+		},
+		{
+			name:    "KeepFirstN",
+			history: history,
+			policy:  &ChatHistoryPolicy{MaxTokens: 30, KeepFirstN: 2, CountTokens: countTokens},
+			want:    []string{"u1", "m1", "u3"},
+		},
+		{
+			name:    "KeepLastN",
+			history: history,
+			policy:  &ChatHistoryPolicy{MaxTokens: 10, KeepLastN: 3, CountTokens: countTokens},
+			want:    []string{"u2", "m2", "u3"},
+		},
+		{
+			name:    "KeepsFunctionCallsWithResponses",
+			history: toolHistory,
+			policy:  &ChatHistoryPolicy{MaxTokens: 30, CountTokens: countTokens},
+			want:    []string{"u3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []map[string]any
+			chats := newTestChats(t, []string{finalTextResponseJSON}, &requests)
+			chat, err := chats.Create(ctx, "gemini-2.0-flash", nil, tt.history)
+			if err != nil {
+				t.Fatal(err)
+			}
+			chat.SetHistoryPolicy(tt.policy)
+			if _, err := chat.SendMessage(ctx, Part{Text: "u3"}); err != nil {
+				t.Fatalf("SendMessage() failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, requestTexts(requests[0])); diff != "" {
+				t.Errorf("request contents mismatch (-want +got):\n%s", diff)
+			}
+			if got, want := len(chat.History(false)), len(tt.history)+2; got != want {
+				t.Errorf("len(History()) = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestChatHistoryPolicySummary(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	chats := newTestChats(t, []string{finalTextResponseJSON}, &requests)
+	history := []*Content{
+		NewContentFromText("u1", RoleUser),
+		NewContentFromText("m1", RoleModel),
+		NewContentFromText("u2", RoleUser),
+		NewContentFromText("m2", RoleModel),
+	}
+	chat, err := chats.Create(ctx, "gemini-2.0-flash", nil, history)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chat.SetHistoryPolicy(&ChatHistoryPolicy{
+		MaxTokens:     30,
+		SummaryModel:  "gemini-2.0-flash-lite",
+		SummaryPrompt: "Summarize:",
+		CountTokens: func(ctx context.Context, contents []*Content) (int32, error) {
+			return int32(10 * len(contents)), nil
+		},
+	})
+
+	if _, err := chat.SendMessage(ctx, Part{Text: "u3"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	if diff := cmp.Diff([]string{"Summarize:\n\nuser: u1\nmodel: m1\n"}, requestTexts(requests[0])); diff != "" {
+		t.Errorf("summary request mismatch (-want +got):\n%s", diff)
+	}
+	want := []string{"Summary of the earlier conversation: It is sunny.", "u2", "m2", "u3"}
+	if diff := cmp.Diff(want, requestTexts(requests[1])); diff != "" {
+		t.Errorf("request contents mismatch (-want +got):\n%s", diff)
+	}
+
+	// The previous summary is extended with the newly left out turns.
+	if _, err := chat.SendMessage(ctx, Part{Text: "u4"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	wantSummary := "Summarize:\n\nSummary of the earlier conversation: It is sunny.\nuser: u2\nmodel: m2\n"
+	if diff := cmp.Diff([]string{wantSummary}, requestTexts(requests[2])); diff != "" {
+		t.Errorf("summary request mismatch (-want +got):\n%s", diff)
+	}
+	want = []string{"Summary of the earlier conversation: It is sunny.", "u3", "It is sunny.", "u4"}
+	if diff := cmp.Diff(want, requestTexts(requests[3])); diff != "" {
+		t.Errorf("request contents mismatch (-want +got):\n%s", diff)
+	}
+
+	// The summary is reused while the same turns are left out.
+	chat.comprehensiveHistory = chat.comprehensiveHistory[:len(chat.comprehensiveHistory)-2]
+	if _, err := chat.SendMessage(ctx, Part{Text: "u4"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	if got := len(requests); got != 5 {
+		t.Errorf("sent %d requests, want 5", got)
+	}
+}
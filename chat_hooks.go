@@ -0,0 +1,89 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"maps"
+)
+
+// ChatHooks are called at the different stages of the messages of a chat session,
+// e.g. to log, moderate or modify the turns of every conversation in a central
+// place. All hooks are optional.
+type ChatHooks struct {
+	// OnBeforeSend is called with the contents of a new message before it is sent.
+	// The returned contents are sent and recorded in the history instead. An error
+	// aborts the message.
+	OnBeforeSend func(ctx context.Context, contents []*Content) ([]*Content, error)
+	// OnAfterResponse is called with the response of the model, merged from its
+	// chunks in streaming mode, before it is recorded in the history. The response
+	// can be modified in place. An error is returned to the caller, and the message
+	// is not recorded.
+	OnAfterResponse func(ctx context.Context, response *GenerateContentResponse) error
+	// OnToolCall is called before the handler of a function call is executed, see
+	// [Chat.SetAutomaticFunctionCalling]. The arguments of the call can be modified
+	// in place. An error is reported to the model instead of executing the handler.
+	OnToolCall func(ctx context.Context, call *FunctionCall) error
+}
+
+// SetHooks sets the hooks called for the following messages of the chat. A nil
+// value removes the hooks.
+func (c *Chat) SetHooks(hooks *ChatHooks) {
+	c.hooks = hooks
+}
+
+// beforeSend applies the OnBeforeSend hook to the contents of a new message.
+func (c *Chat) beforeSend(ctx context.Context, contents []*Content) ([]*Content, error) {
+	if c.hooks == nil || c.hooks.OnBeforeSend == nil {
+		return contents, nil
+	}
+	contents, err := c.hooks.OnBeforeSend(ctx, contents)
+	if err != nil {
+		return nil, err
+	}
+	return chatInputContents(contents)
+}
+
+// afterResponse applies the OnAfterResponse hook to the response of the model.
+func (c *Chat) afterResponse(ctx context.Context, response *GenerateContentResponse) error {
+	if c.hooks == nil || c.hooks.OnAfterResponse == nil {
+		return nil
+	}
+	return c.hooks.OnAfterResponse(ctx, response)
+}
+
+// automaticFunctionCalling returns the automatic function calling config of the
+// chat, with the handlers wrapped to call the OnToolCall hook.
+func (c *Chat) automaticFunctionCalling() *AutomaticFunctionCallingConfig {
+	if c.afc == nil || c.hooks == nil || c.hooks.OnToolCall == nil {
+		return c.afc
+	}
+	afc := *c.afc
+	afc.Handlers = maps.Clone(c.afc.Handlers)
+	onToolCall := c.hooks.OnToolCall
+	for name, handler := range afc.Handlers {
+		afc.Handlers[name] = func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			call, ok := functionCallFromContext(ctx)
+			if !ok {
+				call = &FunctionCall{Name: name, Args: args}
+			}
+			if err := onToolCall(ctx, call); err != nil {
+				return nil, err
+			}
+			return handler(ctx, call.Args)
+		}
+	}
+	return &afc
+}
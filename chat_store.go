@@ -0,0 +1,195 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ChatStore persists the history of chat sessions, identified by a session ID.
+// Implementations backed by a shared database, e.g. Redis or SQL, allow several
+// server instances to serve the same chat session. See [Chats.CreateWithStore].
+//
+// Implementations must be safe for concurrent use.
+type ChatStore interface {
+	// Load returns the history of the session, or an empty history if the session
+	// is unknown.
+	Load(ctx context.Context, sessionID string) ([]*Content, error)
+	// Append adds the contents at the end of the history of the session.
+	Append(ctx context.Context, sessionID string, contents ...*Content) error
+	// Trim truncates the history of the session to its first n contents.
+	Trim(ctx context.Context, sessionID string, n int) error
+}
+
+// InMemoryChatStore is a [ChatStore] keeping the histories in memory. It is
+// useful for tests and single-instance servers.
+type InMemoryChatStore struct {
+	mu       sync.Mutex
+	sessions map[string][]*Content
+}
+
+// NewInMemoryChatStore returns an empty [InMemoryChatStore].
+func NewInMemoryChatStore() *InMemoryChatStore {
+	return &InMemoryChatStore{sessions: make(map[string][]*Content)}
+}
+
+// Load returns the history of the session.
+func (s *InMemoryChatStore) Load(ctx context.Context, sessionID string) ([]*Content, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.sessions[sessionID]), nil
+}
+
+// Append adds the contents at the end of the history of the session.
+func (s *InMemoryChatStore) Append(ctx context.Context, sessionID string, contents ...*Content) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[sessionID] = append(s.sessions[sessionID], contents...)
+	return nil
+}
+
+// Trim truncates the history of the session to its first n contents.
+func (s *InMemoryChatStore) Trim(ctx context.Context, sessionID string, n int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if history, ok := s.sessions[sessionID]; ok && n < len(history) {
+		s.sessions[sessionID] = history[:max(n, 0):max(n, 0)]
+	}
+	return nil
+}
+
+// FileChatStore is a [ChatStore] keeping the history of every session in a JSON
+// Lines file, one content per line, named after the session ID in a directory.
+type FileChatStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileChatStore returns a [FileChatStore] storing the histories in dir, which is
+// created if needed.
+func NewFileChatStore(dir string) (*FileChatStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("NewFileChatStore: error creating directory: %w", err)
+	}
+	return &FileChatStore{dir: dir}, nil
+}
+
+func (s *FileChatStore) path(sessionID string) (string, error) {
+	if sessionID == "" || sessionID == "." || sessionID == ".." || strings.ContainsAny(sessionID, `/\`) {
+		return "", fmt.Errorf("invalid session ID %q", sessionID)
+	}
+	return filepath.Join(s.dir, sessionID+".jsonl"), nil
+}
+
+// Load returns the history of the session.
+func (s *FileChatStore) Load(ctx context.Context, sessionID string) ([]*Content, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load(sessionID)
+}
+
+func (s *FileChatStore) load(sessionID string) ([]*Content, error) {
+	path, err := s.path(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var history []*Content
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxChunkSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		content := &Content{}
+		if err := json.Unmarshal(scanner.Bytes(), content); err != nil {
+			return nil, fmt.Errorf("FileChatStore: error unmarshalling history of session %q: %w", sessionID, err)
+		}
+		history = append(history, content)
+	}
+	return history, scanner.Err()
+}
+
+// Append adds the contents at the end of the history of the session.
+func (s *FileChatStore) Append(ctx context.Context, sessionID string, contents ...*Content) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	path, err := s.path(sessionID)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := writeContentLines(f, contents); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Trim truncates the history of the session to its first n contents.
+func (s *FileChatStore) Trim(ctx context.Context, sessionID string, n int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	history, err := s.load(sessionID)
+	if err != nil || n >= len(history) {
+		return err
+	}
+	path, _ := s.path(sessionID)
+	f, err := os.CreateTemp(s.dir, sessionID+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := writeContentLines(f, history[:max(n, 0)]); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func writeContentLines(f *os.File, contents []*Content) error {
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, content := range contents {
+		if err := enc.Encode(content); err != nil {
+			return fmt.Errorf("FileChatStore: error encoding content: %w", err)
+		}
+	}
+	return w.Flush()
+}
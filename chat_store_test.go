@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestChatStores(t *testing.T) {
+	ctx := context.Background()
+	fileStore, err := NewFileChatStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	stores := []struct {
+		name  string
+		store ChatStore
+	}{
+		{name: "InMemory", store: NewInMemoryChatStore()},
+		{name: "File", store: fileStore},
+	}
+	for _, tt := range stores {
+		t.Run(tt.name, func(t *testing.T) {
+			history, err := tt.store.Load(ctx, "session")
+			if err != nil || len(history) != 0 {
+				t.Fatalf("Load() of an unknown session = %v, %v, want empty history", history, err)
+			}
+
+			contents := []*Content{
+				NewContentFromText("Hi", RoleUser),
+				NewContentFromText("Hello!", RoleModel),
+				NewContentFromText("How are you?", RoleUser),
+			}
+			if err := tt.store.Append(ctx, "session", contents[:2]...); err != nil {
+				t.Fatalf("Append() failed: %v", err)
+			}
+			if err := tt.store.Append(ctx, "session", contents[2]); err != nil {
+				t.Fatalf("Append() failed: %v", err)
+			}
+			if err := tt.store.Append(ctx, "other", contents[0]); err != nil {
+				t.Fatalf("Append() failed: %v", err)
+			}
+			history, err = tt.store.Load(ctx, "session")
+			if err != nil {
+				t.Fatalf("Load() failed: %v", err)
+			}
+			if diff := cmp.Diff(contents, history); diff != "" {
+				t.Errorf("Load() mismatch (-want +got):\n%s", diff)
+			}
+
+			if err := tt.store.Trim(ctx, "session", 1); err != nil {
+				t.Fatalf("Trim() failed: %v", err)
+			}
+			history, err = tt.store.Load(ctx, "session")
+			if err != nil {
+				t.Fatalf("Load() failed: %v", err)
+			}
+			if diff := cmp.Diff(contents[:1], history); diff != "" {
+				t.Errorf("Load() after Trim() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if err := fileStore.Append(ctx, "../escape", NewContentFromText("Hi", RoleUser)); err == nil {
+		t.Errorf("FileChatStore.Append() succeeded with an invalid session ID, want error")
+	}
+}
+
+func TestChatsCreateWithStore(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	chats := newTestChats(t, []string{finalTextResponseJSON}, &requests)
+	store := NewInMemoryChatStore()
+
+	// Two chats sharing a store, e.g. on two server instances.
+	first, err := chats.CreateWithStore(ctx, "gemini-2.0-flash", nil, store, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := chats.CreateWithStore(ctx, "gemini-2.0-flash", nil, store, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := first.SendMessage(ctx, Part{Text: "What is the weather in Boston?"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	if _, err := second.SendMessage(ctx, Part{Text: "And tomorrow?"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	if got := len(requests[1]["contents"].([]any)); got != 3 {
+		t.Errorf("second chat sent %d contents, want 3", got)
+	}
+	history, err := store.Load(ctx, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(second.History(false), history); diff != "" {
+		t.Errorf("stored history mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// recordCitations keeps the cited text of the response if it references sources,
+// for the model turn just recorded in the history.
+func (c *Chat) recordCitations(response *GenerateContentResponse) {
+	if len(response.Candidates) == 0 || len(c.comprehensiveHistory) == 0 {
+		return
+	}
+	cited := response.Candidates[0].CitedText()
+	if len(cited.Sources) == 0 {
+		return
+	}
+	if c.citations == nil {
+		c.citations = map[*Content]*CitedText{}
+	}
+	c.citations[c.comprehensiveHistory[len(c.comprehensiveHistory)-1]] = cited
+}
+
+// WriteMarkdown renders the comprehensive history of the chat as Markdown, e.g. for
+// audit logs or shareable transcripts. Every turn starts with a heading naming its
+// role. Code is rendered as fenced code blocks, inline images as data URIs, and the
+// text of the responses referencing sources includes citation markers followed by
+// the list of the sources. Thoughts are left out.
+//
+// Citations are only known for the responses received by this chat value, not for
+// the history it was created with or loaded from its store.
+func (c *Chat) WriteMarkdown(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for i, content := range c.comprehensiveHistory {
+		if i > 0 {
+			bw.WriteString("\n")
+		}
+		fmt.Fprintf(bw, "### %s\n", transcriptRole(content.Role))
+		cited := c.citations[content]
+		textWritten := false
+		for _, part := range content.Parts {
+			if part == nil || part.Thought {
+				continue
+			}
+			bw.WriteString("\n")
+			switch {
+			case part.Text != "":
+				if cited == nil {
+					bw.WriteString(part.Text)
+				} else if !textWritten {
+					bw.WriteString(cited.Text)
+				}
+				textWritten = true
+			case part.ExecutableCode != nil:
+				fmt.Fprintf(bw, "%s\n", markdownCodeBlock(strings.ToLower(string(part.ExecutableCode.Language)), part.ExecutableCode.Code))
+				continue
+			case part.CodeExecutionResult != nil:
+				fmt.Fprintf(bw, "Output (%s):\n\n%s\n", part.CodeExecutionResult.Outcome, markdownCodeBlock("", part.CodeExecutionResult.Output))
+				continue
+			case part.FunctionCall != nil:
+				args, _ := json.Marshal(part.FunctionCall.Args)
+				fmt.Fprintf(bw, "Function call: `%s(%s)`", part.FunctionCall.Name, args)
+			case part.FunctionResponse != nil:
+				response, _ := json.MarshalIndent(part.FunctionResponse.Response, "", "  ")
+				fmt.Fprintf(bw, "Function response of `%s`:\n\n%s\n", part.FunctionResponse.Name, markdownCodeBlock("json", string(response)))
+				continue
+			case part.InlineData != nil:
+				if strings.HasPrefix(part.InlineData.MIMEType, "image/") {
+					fmt.Fprintf(bw, "![%s](%s)", part.InlineData.MIMEType, dataURI(part.InlineData))
+				} else {
+					fmt.Fprintf(bw, "[%s data]", part.InlineData.MIMEType)
+				}
+			case part.FileData != nil:
+				fmt.Fprintf(bw, "[%s file](%s)", part.FileData.MIMEType, part.FileData.FileURI)
+			}
+			bw.WriteString("\n")
+		}
+		if cited != nil {
+			bw.WriteString("\nSources:\n\n")
+			for _, s := range cited.Sources {
+				fmt.Fprintf(bw, "%d. %s\n", s.Number, markdownSourceLink(s))
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteHTML renders the comprehensive history of the chat as a standalone HTML
+// document. It renders the same elements as [Chat.WriteMarkdown].
+func (c *Chat) WriteHTML(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Chat transcript</title>
+<style>
+.text { white-space: pre-wrap; }
+img { max-width: 100%; }
+</style>
+</head>
+<body>
+`)
+	for _, content := range c.comprehensiveHistory {
+		fmt.Fprintf(bw, "<div class=\"turn %s\">\n<h3>%s</h3>\n", html.EscapeString(content.Role), transcriptRole(content.Role))
+		cited := c.citations[content]
+		textWritten := false
+		for _, part := range content.Parts {
+			if part == nil || part.Thought {
+				continue
+			}
+			switch {
+			case part.Text != "":
+				if cited == nil {
+					fmt.Fprintf(bw, "<p class=\"text\">%s</p>\n", html.EscapeString(part.Text))
+				} else if !textWritten {
+					fmt.Fprintf(bw, "<p class=\"text\">%s</p>\n", html.EscapeString(cited.Text))
+				}
+				textWritten = true
+			case part.ExecutableCode != nil:
+				fmt.Fprintf(bw, "<pre><code class=\"language-%s\">%s</code></pre>\n",
+					html.EscapeString(strings.ToLower(string(part.ExecutableCode.Language))), html.EscapeString(part.ExecutableCode.Code))
+			case part.CodeExecutionResult != nil:
+				fmt.Fprintf(bw, "<p>Output (%s):</p>\n<pre><code>%s</code></pre>\n",
+					html.EscapeString(string(part.CodeExecutionResult.Outcome)), html.EscapeString(part.CodeExecutionResult.Output))
+			case part.FunctionCall != nil:
+				args, _ := json.Marshal(part.FunctionCall.Args)
+				fmt.Fprintf(bw, "<p>Function call: <code>%s(%s)</code></p>\n", html.EscapeString(part.FunctionCall.Name), html.EscapeString(string(args)))
+			case part.FunctionResponse != nil:
+				response, _ := json.MarshalIndent(part.FunctionResponse.Response, "", "  ")
+				fmt.Fprintf(bw, "<p>Function response of <code>%s</code>:</p>\n<pre><code class=\"language-json\">%s</code></pre>\n",
+					html.EscapeString(part.FunctionResponse.Name), html.EscapeString(string(response)))
+			case part.InlineData != nil:
+				if strings.HasPrefix(part.InlineData.MIMEType, "image/") {
+					fmt.Fprintf(bw, "<img src=\"%s\" alt=\"%s\">\n", html.EscapeString(dataURI(part.InlineData)), html.EscapeString(part.InlineData.MIMEType))
+				} else {
+					fmt.Fprintf(bw, "<p>[%s data]</p>\n", html.EscapeString(part.InlineData.MIMEType))
+				}
+			case part.FileData != nil:
+				fmt.Fprintf(bw, "<p>%s</p>\n", htmlLink(part.FileData.FileURI, part.FileData.MIMEType+" file"))
+			}
+		}
+		if cited != nil {
+			bw.WriteString("<p>Sources:</p>\n<ol class=\"sources\">\n")
+			for _, s := range cited.Sources {
+				title := s.Title
+				if title == "" {
+					title = s.URI
+				}
+				fmt.Fprintf(bw, "<li value=\"%d\">%s</li>\n", s.Number, htmlLink(s.URI, title))
+			}
+			bw.WriteString("</ol>\n")
+		}
+		bw.WriteString("</div>\n")
+	}
+	bw.WriteString("</body>\n</html>\n")
+	return bw.Flush()
+}
+
+func transcriptRole(role string) string {
+	switch role {
+	case RoleUser:
+		return "User"
+	case RoleModel:
+		return "Model"
+	default:
+		return html.EscapeString(role)
+	}
+}
+
+func dataURI(blob *Blob) string {
+	return "data:" + blob.MIMEType + ";base64," + base64.StdEncoding.EncodeToString(blob.Data)
+}
+
+// markdownCodeBlock returns a fenced code block, using a fence longer than any
+// backtick sequence of the code.
+func markdownCodeBlock(language, code string) string {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + language + "\n" + strings.TrimSuffix(code, "\n") + "\n" + fence
+}
+
+func markdownSourceLink(s *CitationSource) string {
+	switch {
+	case s.Title != "" && s.URI != "":
+		return fmt.Sprintf("[%s](%s)", s.Title, s.URI)
+	case s.Title != "":
+		return s.Title
+	default:
+		return s.URI
+	}
+}
+
+// htmlLink returns a link to uri, or only the escaped text if uri is not an HTTP
+// URL, so that a transcript never contains script URLs.
+func htmlLink(uri, text string) string {
+	if !strings.HasPrefix(uri, "https://") && !strings.HasPrefix(uri, "http://") {
+		return html.EscapeString(text)
+	}
+	return fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(uri), html.EscapeString(text))
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func newTestTranscriptChat(t *testing.T) *Chat {
+	t.Helper()
+	ctx := context.Background()
+	groundedResponse := `{
+		"candidates": [{
+			"content": {"role": "model", "parts": [{"text": "Go was released in 2009."}]},
+			"finishReason": "STOP",
+			"groundingMetadata": {
+				"groundingChunks": [{"web": {"uri": "https://go.dev/doc/", "title": "Go <docs>"}}],
+				"groundingSupports": [{"segment": {"startIndex": 0, "endIndex": 24, "text": "Go was released in 2009."}, "groundingChunkIndices": [0]}]
+			}
+		}]
+	}`
+	chats := newTestChats(t, []string{groundedResponse}, new([]map[string]any))
+	history := []*Content{
+		NewContentFromParts([]*Part{
+			NewPartFromText("What is in <this> image?"),
+			NewPartFromBytes([]byte("png"), "image/png"),
+		}, RoleUser),
+		NewContentFromParts([]*Part{
+			{Text: "Let me think.", Thought: true},
+			NewPartFromExecutableCode("print(1 + 1)", LanguagePython),
+			NewPartFromCodeExecutionResult(OutcomeOK, "2\n"),
+			NewPartFromText("A chart."),
+		}, RoleModel),
+	}
+	chat, err := chats.Create(ctx, "gemini-2.0-flash", nil, history)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chat.SendMessage(ctx, Part{Text: "When was Go released?"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	return chat
+}
+
+func TestChatWriteMarkdown(t *testing.T) {
+	chat := newTestTranscriptChat(t)
+	var b strings.Builder
+	if err := chat.WriteMarkdown(&b); err != nil {
+		t.Fatalf("WriteMarkdown() failed: %v", err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"### User\n\nWhat is in <this> image?\n\n![image/png](data:image/png;base64,cG5n)\n",
+		"### Model\n\n```python\nprint(1 + 1)\n```\n\nOutput (OUTCOME_OK):\n\n```\n2\n```\n\nA chart.\n",
+		"### Model\n\nGo was released in 2009.[1]\n\nSources:\n\n1. [Go <docs>](https://go.dev/doc/)\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("WriteMarkdown() = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "Let me think.") {
+		t.Errorf("WriteMarkdown() = %q, want thoughts left out", got)
+	}
+}
+
+func TestChatWriteHTML(t *testing.T) {
+	chat := newTestTranscriptChat(t)
+	var b strings.Builder
+	if err := chat.WriteHTML(&b); err != nil {
+		t.Fatalf("WriteHTML() failed: %v", err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		`<p class="text">What is in &lt;this&gt; image?</p>`,
+		`<img src="data:image/png;base64,cG5n" alt="image/png">`,
+		`<pre><code class="language-python">print(1 + 1)</code></pre>`,
+		`<p class="text">Go was released in 2009.[1]</p>`,
+		`<li value="1"><a href="https://go.dev/doc/">Go &lt;docs&gt;</a></li>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("WriteHTML() = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "Let me think.") {
+		t.Errorf("WriteHTML() = %q, want thoughts left out", got)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+// Usage returns the cumulative token usage of the chat session: the sum of the
+// usage metadata of every request sent by the chat, including the intermediate
+// requests of automatic function calling and the summaries of the history. It can
+// be used to display the cost of a conversation or to enforce a per-session budget:
+//
+//	if chat.Usage().TotalTokenCount > budget {
+//		return errBudgetExceeded
+//	}
+//
+// The usage of a chat created with [Chats.CreateFromHistory] or
+// [Chats.CreateWithStore] only covers the requests sent by the chat itself.
+func (c *Chat) Usage() *GenerateContentResponseUsageMetadata {
+	usage := c.usage
+	usage.PromptTokensDetails = cloneModalityTokenCounts(c.usage.PromptTokensDetails)
+	usage.CacheTokensDetails = cloneModalityTokenCounts(c.usage.CacheTokensDetails)
+	usage.CandidatesTokensDetails = cloneModalityTokenCounts(c.usage.CandidatesTokensDetails)
+	usage.ToolUsePromptTokensDetails = cloneModalityTokenCounts(c.usage.ToolUsePromptTokensDetails)
+	return &usage
+}
+
+// Cost returns the estimated cost of the cumulative token usage of the chat
+// session, see [Chat.Usage], with the prices of the model of the chat, and
+// whether the model has a price. A nil registry uses the prices of
+// [DefaultModelPrices]:
+//
+//	if cost, ok := chat.Cost(nil); ok {
+//		fmt.Printf("$%.4f\n", cost)
+//	}
+func (c *Chat) Cost(prices *PriceRegistry) (float64, bool) {
+	if prices == nil {
+		prices = defaultPriceRegistry
+	}
+	return prices.Cost(c.model, &c.usage)
+}
+
+// addUsage adds the usage metadata of a response to the usage of the chat.
+func (c *Chat) addUsage(response *GenerateContentResponse) {
+	if response == nil || response.UsageMetadata == nil {
+		return
+	}
+	u := response.UsageMetadata
+	c.usage.PromptTokenCount += u.PromptTokenCount
+	c.usage.CachedContentTokenCount += u.CachedContentTokenCount
+	c.usage.CandidatesTokenCount += u.CandidatesTokenCount
+	c.usage.ThoughtsTokenCount += u.ThoughtsTokenCount
+	c.usage.ToolUsePromptTokenCount += u.ToolUsePromptTokenCount
+	c.usage.TotalTokenCount += u.TotalTokenCount
+	c.usage.PromptTokensDetails = addModalityTokenCounts(c.usage.PromptTokensDetails, u.PromptTokensDetails)
+	c.usage.CacheTokensDetails = addModalityTokenCounts(c.usage.CacheTokensDetails, u.CacheTokensDetails)
+	c.usage.CandidatesTokensDetails = addModalityTokenCounts(c.usage.CandidatesTokensDetails, u.CandidatesTokensDetails)
+	c.usage.ToolUsePromptTokensDetails = addModalityTokenCounts(c.usage.ToolUsePromptTokensDetails, u.ToolUsePromptTokensDetails)
+}
+
+// addModalityTokenCounts adds the token counts of counts to the ones of total with
+// the same modality.
+func addModalityTokenCounts(total, counts []*ModalityTokenCount) []*ModalityTokenCount {
+	for _, count := range counts {
+		if count == nil {
+			continue
+		}
+		found := false
+		for _, t := range total {
+			if t.Modality == count.Modality {
+				t.TokenCount += count.TokenCount
+				found = true
+				break
+			}
+		}
+		if !found {
+			total = append(total, &ModalityTokenCount{Modality: count.Modality, TokenCount: count.TokenCount})
+		}
+	}
+	return total
+}
+
+func cloneModalityTokenCounts(counts []*ModalityTokenCount) []*ModalityTokenCount {
+	if counts == nil {
+		return nil
+	}
+	clone := make([]*ModalityTokenCount, len(counts))
+	for i, count := range counts {
+		c := *count
+		clone[i] = &c
+	}
+	return clone
+}
@@ -18,9 +18,14 @@ package genai
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"iter"
-	"log"
+	"maps"
+	"reflect"
+	"slices"
 )
 
 // Chats provides util functions for creating a new chat session.
@@ -42,6 +47,25 @@ type Chat struct {
 	config    *GenerateContentConfig
 	// History of the chat.
 	comprehensiveHistory []*Content
+	// Optional store persisting the history, and the ID of the session in the store.
+	store     ChatStore
+	sessionID string
+	// Optional policy bounding the history sent with every message, the cached token
+	// counts of the contents of the history and the summary of the left out turns.
+	historyPolicy *ChatHistoryPolicy
+	tokenCounts   map[*Content]int32
+	summary       *chatSummary
+	// Optional handlers of the functions called by the model.
+	afc *AutomaticFunctionCallingConfig
+	// Optional hooks called for every message.
+	hooks *ChatHooks
+	// Cumulative usage of the chat.
+	usage GenerateContentResponseUsageMetadata
+	// The cited text of the model turns of the history referencing sources.
+	citations map[*Content]*CitedText
+	// Optional cached content holding the beginning of the history, created with
+	// [Caches.CreateFromChat].
+	cache *chatCache
 }
 
 // Create initializes a new chat session.
@@ -56,12 +80,242 @@ func (c *Chats) Create(ctx context.Context, model string, config *GenerateConten
 	return chat, nil
 }
 
-func (c *Chat) recordHistory(ctx context.Context, inputContent *Content, outputContents []*Content) {
-	c.comprehensiveHistory = append(c.comprehensiveHistory, inputContent)
+// CreateWithStore initializes a chat session whose history is persisted in store
+// under sessionID. The history is loaded from the store before every message, so
+// that several server instances sharing the store can serve the same session.
+func (c *Chats) CreateWithStore(ctx context.Context, model string, config *GenerateContentConfig, store ChatStore, sessionID string) (*Chat, error) {
+	if store == nil {
+		return nil, fmt.Errorf("store is required")
+	}
+	history, err := store.Load(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("CreateWithStore: error loading history: %w", err)
+	}
+	chat, err := c.Create(ctx, model, config, history)
+	if err != nil {
+		return nil, err
+	}
+	chat.store = store
+	chat.sessionID = sessionID
+	return chat, nil
+}
+
+// CreateFromHistory initializes a chat session resuming a previous conversation,
+// e.g. one persisted with [Chat.MarshalJSON] or built from [Chat.History]. Every
+// content of the history must have either the user or the model role.
+func (c *Chats) CreateFromHistory(ctx context.Context, model string, history []*Content, config *GenerateContentConfig) (*Chat, error) {
+	for i, content := range history {
+		if content == nil {
+			return nil, fmt.Errorf("history[%d] is nil", i)
+		}
+		if content.Role != RoleUser && content.Role != RoleModel {
+			return nil, fmt.Errorf("history[%d] has role %q, want %q or %q", i, content.Role, RoleUser, RoleModel)
+		}
+	}
+	return c.Create(ctx, model, config, slices.Clone(history))
+}
+
+// CreateFromJSON initializes a chat session from the JSON encoding of a chat, as
+// returned by [Chat.MarshalJSON].
+func (c *Chats) CreateFromJSON(ctx context.Context, data []byte) (*Chat, error) {
+	var state chatJSON
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("CreateFromJSON: error unmarshalling chat: %w", err)
+	}
+	return c.CreateFromHistory(ctx, state.Model, state.History, state.Config)
+}
+
+// chatJSON is the JSON encoding of a chat session.
+type chatJSON struct {
+	Model   string                 `json:"model"`
+	Config  *GenerateContentConfig `json:"config,omitempty"`
+	History []*Content             `json:"history,omitempty"`
+}
+
+// MarshalJSON encodes the model, config and history of the chat session so that it
+// can be persisted and resumed later with [Chats.CreateFromJSON].
+func (c *Chat) MarshalJSON() ([]byte, error) {
+	return json.Marshal(chatJSON{Model: c.model, Config: c.config, History: c.comprehensiveHistory})
+}
+
+// Fork returns a new chat session starting from the current history of the chat,
+// e.g. to regenerate a response or explore an alternative continuation without
+// modifying the conversation. The fork shares the model, config, history policy
+// and function handlers of the chat, but not its store: the history of the fork
+// is only kept in memory.
+func (c *Chat) Fork() *Chat {
+	fork := *c
+	if c.config != nil {
+		config := *c.config
+		fork.config = &config
+	}
+	fork.comprehensiveHistory = slices.Clone(c.comprehensiveHistory)
+	fork.tokenCounts = maps.Clone(c.tokenCounts)
+	fork.usage = *c.Usage()
+	fork.citations = maps.Clone(c.citations)
+	fork.store = nil
+	fork.sessionID = ""
+	return &fork
+}
+
+// SetSystemInstruction sets the system instruction of the following messages of
+// the chat, e.g. to adapt the persona of the assistant to the intent of the user.
+// The history of the chat is kept. A nil content removes the system instruction.
+func (c *Chat) SetSystemInstruction(content *Content) {
+	config := &GenerateContentConfig{}
+	if c.config != nil {
+		*config = *c.config
+	}
+	config.SystemInstruction = content
+	c.config = config
+}
+
+// SetCachedContent makes the following messages of the chat reference the given
+// cached content, e.g. a large manual or codebase cached with [Caches.Create],
+// instead of resending it. An empty name stops using the cached content.
+//
+// The API rejects requests setting a system instruction, tools or a tool config
+// along with a cached content: they must be part of the cached content, and are
+// removed from the config of the chat. The cached tokens of every message are
+// reported in [GenerateContentResponseUsageMetadata.CachedContentTokenCount].
+func (c *Chat) SetCachedContent(name string) {
+	config := &GenerateContentConfig{}
+	if c.config != nil {
+		*config = *c.config
+	}
+	config.CachedContent = name
+	if name != "" {
+		config.SystemInstruction = nil
+		config.Tools = nil
+		config.ToolConfig = nil
+	}
+	c.config = config
+	c.cache = nil
+}
+
+// Rewind removes the last n exchanges from the history of the chat. An exchange
+// starts with a user message and includes the model turns, function calls and
+// function responses following it.
+func (c *Chat) Rewind(ctx context.Context, n int) error {
+	if err := c.loadHistory(ctx); err != nil {
+		return err
+	}
+	start, err := c.exchangeStart(n)
+	if err != nil {
+		return err
+	}
+	return c.truncateHistory(ctx, start)
+}
+
+// ReplaceLastUserMessage replaces the last exchange of the chat with the given user
+// message and sends it, returning the new response of the model. It implements
+// "edit my last message and regenerate". If sending the message fails, the
+// previous exchange is restored.
+func (c *Chat) ReplaceLastUserMessage(ctx context.Context, parts ...*Part) (*GenerateContentResponse, error) {
+	if err := c.loadHistory(ctx); err != nil {
+		return nil, err
+	}
+	start, err := c.exchangeStart(1)
+	if err != nil {
+		return nil, err
+	}
+	removed := slices.Clone(c.comprehensiveHistory[start:])
+	if err := c.truncateHistory(ctx, start); err != nil {
+		return nil, err
+	}
+	resp, err := c.Send(ctx, parts...)
+	if err != nil {
+		restoreErr := c.truncateHistory(ctx, start)
+		if restoreErr == nil {
+			restoreErr = c.appendHistory(ctx, removed...)
+		}
+		if restoreErr != nil {
+			return nil, fmt.Errorf("%w; %w", err, restoreErr)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// exchangeStart returns the index in the history of the start of the n-th last
+// exchange.
+func (c *Chat) exchangeStart(n int) (int, error) {
+	if n <= 0 {
+		return len(c.comprehensiveHistory), nil
+	}
+	found := 0
+	for i := len(c.comprehensiveHistory) - 1; i >= 0; i-- {
+		if isUserMessage(c.comprehensiveHistory[i]) {
+			found++
+			if found == n {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("cannot rewind %d exchanges, the chat history has %d", n, found)
+}
+
+// truncateHistory truncates the history, and its store if any, to its first n
+// contents.
+func (c *Chat) truncateHistory(ctx context.Context, n int) error {
+	if n >= len(c.comprehensiveHistory) {
+		return nil
+	}
+	if c.store != nil {
+		if err := c.store.Trim(ctx, c.sessionID, n); err != nil {
+			return fmt.Errorf("error trimming chat history: %w", err)
+		}
+	}
+	c.comprehensiveHistory = c.comprehensiveHistory[:n:n]
+	return nil
+}
+
+// loadHistory refreshes the history from the store of the chat, if any.
+func (c *Chat) loadHistory(ctx context.Context) error {
+	if c.store == nil {
+		return nil
+	}
+	history, err := c.store.Load(ctx, c.sessionID)
+	if err != nil {
+		return fmt.Errorf("error loading chat history: %w", err)
+	}
+	c.comprehensiveHistory = history
+	return nil
+}
+
+// SetAutomaticFunctionCalling registers the Go handlers of the functions declared in
+// the config of the chat. [Chat.Send] and [Chat.SendMessage] then execute the
+// function calls of the model and send their results back, as
+// [Models.GenerateContentWithTools] does, and record the function calls and
+// responses in the history. A nil config disables the automatic execution.
+func (c *Chat) SetAutomaticFunctionCalling(afc *AutomaticFunctionCallingConfig) {
+	c.afc = afc
+}
 
+// recordHistory records the user input, the function calls and responses exchanged
+// by automatic function calling, if any, and the model output.
+func (c *Chat) recordHistory(ctx context.Context, inputContents []*Content, afcContents []*Content, outputContents []*Content) error {
+	recorded := append(slices.Clone(inputContents), afcContents...)
 	for _, outputContent := range outputContents {
-		c.comprehensiveHistory = append(c.comprehensiveHistory, copySanitizedModelContent(outputContent))
+		recorded = append(recorded, copySanitizedModelContent(outputContent))
 	}
+	if len(outputContents) == 0 && len(afcContents) == 0 {
+		// Record an empty model turn, so that the exchange is left out of the curated
+		// history.
+		recorded = append(recorded, &Content{Role: RoleModel})
+	}
+	return c.appendHistory(ctx, recorded...)
+}
+
+// appendHistory appends the contents to the history, and to its store if any.
+func (c *Chat) appendHistory(ctx context.Context, contents ...*Content) error {
+	c.comprehensiveHistory = append(c.comprehensiveHistory, contents...)
+	if c.store != nil {
+		if err := c.store.Append(ctx, c.sessionID, contents...); err != nil {
+			return fmt.Errorf("error saving chat history: %w", err)
+		}
+	}
+	return nil
 }
 
 // copySanitizedModelContent creates a (shallow) copy of modelContent with role set to
@@ -72,11 +326,13 @@ func copySanitizedModelContent(modelContent *Content) *Content {
 	return newContent
 }
 
-// History returns the chat history. Curated (valid only) history is not supported yet.
+// History returns the chat history. The comprehensive history includes every
+// exchange of the chat, including the blocked prompts and the invalid or empty
+// responses of the model. The curated history leaves out those exchanges, and is
+// the history sent with the following messages.
 func (c *Chat) History(curated bool) []*Content {
 	if curated {
-		log.Println("curated history is not supported yet")
-		return nil
+		return curatedHistory(c.comprehensiveHistory)
 	}
 	return c.comprehensiveHistory
 }
@@ -93,25 +349,139 @@ func (c *Chat) SendMessage(ctx context.Context, parts ...Part) (*GenerateContent
 
 // Send function sends the conversation history with the additional user's message and returns the model's response.
 func (c *Chat) Send(ctx context.Context, parts ...*Part) (*GenerateContentResponse, error) {
-	inputContent := &Content{Parts: parts, Role: RoleUser}
+	return c.SendContents(ctx, []*Content{{Parts: parts, Role: RoleUser}}, nil)
+}
+
+// SendContents sends the conversation history with the additional contents and
+// returns the model's response. Unlike [Chat.Send], it accepts several contents,
+// e.g. pre-built multi-part contents or explicit user and model turns. Contents
+// without a role are sent as user turns. All the contents are recorded in the
+// history.
+//
+// The optional config overrides the config of the chat for this message only: its
+// non-zero fields replace the ones of the chat config, e.g. to request JSON output
+// or to enable a tool for one message.
+func (c *Chat) SendContents(ctx context.Context, inputContents []*Content, config *GenerateContentConfig) (*GenerateContentResponse, error) {
+	inputContents, err := chatInputContents(inputContents)
+	if err != nil {
+		return nil, err
+	}
+	if inputContents, err = c.beforeSend(ctx, inputContents); err != nil {
+		return nil, err
+	}
+	if err := c.loadHistory(ctx); err != nil {
+		return nil, err
+	}
 
 	// Combine history with input content to send to model
-	contents := append(c.comprehensiveHistory, inputContent)
+	contents, err := c.requestContents(ctx, inputContents)
+	if err != nil {
+		return nil, err
+	}
 
 	// Generate Content
-	modelOutput, err := c.GenerateContent(ctx, c.model, contents, c.config)
+	modelOutput, err := c.generateContentWithTools(ctx, c.model, contents, mergeGenerateContentConfig(c.config, config), c.automaticFunctionCalling(), c.addUsage)
+	if errors.As(err, &SafetyBlockedError{}) {
+		// Keep the blocked prompt in the comprehensive history.
+		if recordErr := c.recordHistory(ctx, inputContents, nil, nil); recordErr != nil {
+			return nil, fmt.Errorf("%w; %w", err, recordErr)
+		}
+		return nil, err
+	}
 	if err != nil {
 		return nil, err
 	}
+	if err := c.afterResponse(ctx, modelOutput); err != nil {
+		return nil, err
+	}
+	var afcContents []*Content
+	if len(modelOutput.AutomaticFunctionCallingHistory) > len(contents) {
+		afcContents = modelOutput.AutomaticFunctionCallingHistory[len(contents):]
+	}
 
 	// Record history. By default, use the first candidate for history.
 	var outputContents []*Content
 	if len(modelOutput.Candidates) > 0 && modelOutput.Candidates[0].Content != nil {
 		outputContents = append(outputContents, modelOutput.Candidates[0].Content)
 	}
-	c.recordHistory(ctx, inputContent, outputContents)
+	if err := c.recordHistory(ctx, inputContents, afcContents, outputContents); err != nil {
+		return nil, err
+	}
+	c.recordCitations(modelOutput)
 
-	return modelOutput, err
+	return modelOutput, nil
+}
+
+// SendMessageAs sends a message in the chat and unmarshals the JSON response of the
+// model into a value of type T. The response schema of this message only is built
+// from T with [NewSchemaFromType], and both the message and the response are
+// recorded in the history. As methods cannot have type parameters, it takes the
+// chat as argument:
+//
+//	recipe, result, err := genai.SendMessageAs[Recipe](ctx, chat, genai.Part{Text: "Make it vegan."})
+//
+// If the response cannot be unmarshalled, the response is returned with the error.
+func SendMessageAs[T any](ctx context.Context, chat *Chat, parts ...Part) (T, *GenerateContentResponse, error) {
+	var value T
+	schema, err := NewSchemaFromType[T]()
+	if err != nil {
+		return value, nil, fmt.Errorf("SendMessageAs: error building response schema: %w", err)
+	}
+	p := make([]*Part, len(parts))
+	for i, part := range parts {
+		p[i] = &part
+	}
+	config := &GenerateContentConfig{ResponseMIMEType: "application/json", ResponseSchema: schema}
+	result, err := chat.SendContents(ctx, []*Content{{Parts: p, Role: RoleUser}}, config)
+	if err != nil {
+		return value, nil, err
+	}
+	if err := json.Unmarshal([]byte(result.Text()), &value); err != nil {
+		return value, result, fmt.Errorf("SendMessageAs: error unmarshalling response: %w", err)
+	}
+	return value, result, nil
+}
+
+// mergeGenerateContentConfig returns a copy of base with the non-zero fields of
+// override, or base itself if override is nil.
+func mergeGenerateContentConfig(base, override *GenerateContentConfig) *GenerateContentConfig {
+	if override == nil {
+		return base
+	}
+	if base == nil {
+		return override
+	}
+	merged := *base
+	mergedValue := reflect.ValueOf(&merged).Elem()
+	overrideValue := reflect.ValueOf(override).Elem()
+	for i := range overrideValue.NumField() {
+		if field := overrideValue.Field(i); !field.IsZero() {
+			mergedValue.Field(i).Set(field)
+		}
+	}
+	return &merged
+}
+
+// chatInputContents validates the contents sent in a chat session, returning copies
+// of the contents without a role with the user role.
+func chatInputContents(inputContents []*Content) ([]*Content, error) {
+	if len(inputContents) == 0 {
+		return nil, fmt.Errorf("at least one content is required")
+	}
+	result := make([]*Content, len(inputContents))
+	for i, content := range inputContents {
+		if content == nil {
+			return nil, fmt.Errorf("content[%d] is nil", i)
+		}
+		if content.Role == "" {
+			content = &Content{Parts: content.Parts, Role: RoleUser}
+		}
+		if content.Role != RoleUser && content.Role != RoleModel {
+			return nil, fmt.Errorf("content[%d] has role %q, want %q or %q", i, content.Role, RoleUser, RoleModel)
+		}
+		result[i] = content
+	}
+	return result, nil
 }
 
 // SendMessageStream is a wrapper around SendStream.
@@ -125,34 +495,76 @@ func (c *Chat) SendMessageStream(ctx context.Context, parts ...Part) iter.Seq2[*
 }
 
 // SendStream function sends the conversation history with the additional user's message and returns the model's response.
+//
+// Function calls are not executed automatically in streaming mode, see
+// [Chat.SetAutomaticFunctionCalling].
 func (c *Chat) SendStream(ctx context.Context, parts ...*Part) iter.Seq2[*GenerateContentResponse, error] {
-	inputContent := &Content{Parts: parts, Role: RoleUser}
+	return c.SendContentsStream(ctx, []*Content{{Parts: parts, Role: RoleUser}}, nil)
+}
+
+// SendContentsStream is the streaming version of [Chat.SendContents].
+func (c *Chat) SendContentsStream(ctx context.Context, inputContents []*Content, config *GenerateContentConfig) iter.Seq2[*GenerateContentResponse, error] {
+	inputContents, err := chatInputContents(inputContents)
+	if err != nil {
+		return yieldErrorAndEndIterator[GenerateContentResponse](err)
+	}
+	if inputContents, err = c.beforeSend(ctx, inputContents); err != nil {
+		return yieldErrorAndEndIterator[GenerateContentResponse](err)
+	}
+	if err := c.loadHistory(ctx); err != nil {
+		return yieldErrorAndEndIterator[GenerateContentResponse](err)
+	}
 
 	// Combine history with input content to send to model
-	contents := append(c.comprehensiveHistory, inputContent)
+	contents, err := c.requestContents(ctx, inputContents)
+	if err != nil {
+		return yieldErrorAndEndIterator[GenerateContentResponse](err)
+	}
 
 	// Generate Content
-	response := c.GenerateContentStream(ctx, c.model, contents, c.config)
+	response := c.GenerateContentStream(ctx, c.model, contents, mergeGenerateContentConfig(c.config, config))
 
 	// Return a new iterator that will yield the responses and record history with merged response.
 	return func(yield func(*GenerateContentResponse, error) bool) {
-		var outputContents []*Content
+		merged := &GenerateContentResponse{}
+		var usage StreamUsage
+		// The usage metadata accumulated from the chunks covers the whole response,
+		// including when the stream is interrupted.
+		defer c.addUsage(merged)
 		for chunk, err := range response {
 			if err == io.EOF {
 				break
 			}
+			if errors.As(err, &SafetyBlockedError{}) {
+				// Keep the blocked prompt in the comprehensive history.
+				if recordErr := c.recordHistory(ctx, inputContents, nil, nil); recordErr != nil {
+					err = fmt.Errorf("%w; %w", err, recordErr)
+				}
+			}
 			if err != nil {
 				yield(nil, err)
 				return
 			}
-			if len(chunk.Candidates) > 0 && chunk.Candidates[0].Content != nil {
-				outputContents = append(outputContents, chunk.Candidates[0].Content)
-			}
+			// Merge the chunks into a single model turn, keeping the text, function
+			// call and code execution parts in the order they were streamed.
+			mergeResponseChunk(merged, chunk, &usage)
 			if !yield(chunk, nil) {
 				return
 			}
 		}
+		if err := c.afterResponse(ctx, merged); err != nil {
+			yield(nil, err)
+			return
+		}
 		// Record history. By default, use the first candidate for history.
-		c.recordHistory(ctx, inputContent, outputContents)
+		var outputContents []*Content
+		if len(merged.Candidates) > 0 && merged.Candidates[0].Content != nil {
+			outputContents = append(outputContents, merged.Candidates[0].Content)
+		}
+		if err := c.recordHistory(ctx, inputContents, nil, outputContents); err != nil {
+			yield(nil, err)
+			return
+		}
+		c.recordCitations(merged)
 	}
 }
@@ -16,13 +16,20 @@ package genai
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 
 	"cloud.google.com/go/auth"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestChatsUnitTest(t *testing.T) {
@@ -423,17 +430,13 @@ data:{
 			}
 		}
 
-		expectedResponses := []string{"1 + ", "2", " = 3"}
-		history := chat.History(false)
-		expectedUserMessage := "What is 1 + 2?"
-		if history[0].Parts[0].Text != expectedUserMessage {
-			t.Errorf("Expected history to start with %s, got %s", expectedUserMessage, history[0].Parts[0].Text)
+		// The streamed chunks are merged into a single model turn.
+		expectedHistory := []*Content{
+			{Role: RoleUser, Parts: []*Part{{Text: "What is 1 + 2?"}}},
+			{Role: RoleModel, Parts: []*Part{{Text: "1 + 2 = 3"}}},
 		}
-		for i, expectedResponse := range expectedResponses {
-			gotResponse := history[i+1].Parts[0].Text
-			if gotResponse != expectedResponse {
-				t.Errorf("Expected model response to be %s, got %s", expectedResponse, gotResponse)
-			}
+		if diff := cmp.Diff(expectedHistory, chat.History(false)); diff != "" {
+			t.Errorf("History() mismatch (-want +got):\n%s", diff)
 		}
 	})
 }
@@ -508,24 +511,618 @@ data:{
 			}
 		}
 
-		var expectedResponses []*Content
-		expectedResponses = append(expectedResponses, &Content{Role: "model", Parts: []*Part{&Part{Text: "text1_candidate1"}}})
-		expectedResponses = append(expectedResponses, &Content{Role: "model", Parts: []*Part{&Part{Text: " "}}})
-		expectedResponses = append(expectedResponses, &Content{Role: "model", Parts: []*Part{&Part{Text: "text3_candidate1"}, &Part{Text: " additional text3_candidate1 "}}})
-		expectedResponses = append(expectedResponses, &Content{Role: "model", Parts: []*Part{&Part{Text: "text4_candidate1"}, &Part{Text: " additional text4_candidate1"}}})
+		// The text of the first candidate is joined into a single model turn.
+		expectedHistory := []*Content{
+			{Role: RoleUser, Parts: []*Part{{Text: "What is 1 + 2?"}}},
+			{Role: RoleModel, Parts: []*Part{{Text: "text1_candidate1 text3_candidate1 additional text3_candidate1 text4_candidate1 additional text4_candidate1"}}},
+		}
+		if diff := cmp.Diff(expectedHistory, chat.History(false)); diff != "" {
+			t.Errorf("History() mismatch (-want +got):\n%s", diff)
+		}
+
+	})
+}
+
+// newTestChats returns a Chats backed by a test server, see replyInOrder.
+func newTestChats(t *testing.T, responses []string, requests *[]map[string]any) *Chats {
+	t.Helper()
+	return newTestClient(t, BackendGeminiAPI, replyInOrder(t, responses, requests)).Chats
+}
+
+func TestChatsCreateFromJSON(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	chats := newTestChats(t, []string{finalTextResponseJSON}, &requests)
+	config := &GenerateContentConfig{Temperature: Ptr[float32](0.5)}
+	chat, err := chats.Create(ctx, "gemini-2.0-flash", config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chat.SendMessage(ctx, Part{Text: "What is the weather in Boston?"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+
+	data, err := json.Marshal(chat)
+	if err != nil {
+		t.Fatalf("MarshalJSON() failed: %v", err)
+	}
+	resumed, err := chats.CreateFromJSON(ctx, data)
+	if err != nil {
+		t.Fatalf("CreateFromJSON() failed: %v", err)
+	}
+	if diff := cmp.Diff(chat.History(false), resumed.History(false)); diff != "" {
+		t.Errorf("History() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(config, resumed.config); diff != "" {
+		t.Errorf("config mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := resumed.SendMessage(ctx, Part{Text: "And tomorrow?"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	if got := len(requests[1]["contents"].([]any)); got != 3 {
+		t.Errorf("resumed chat sent %d contents, want 3", got)
+	}
+	if got := len(chat.History(false)); got != 2 {
+		t.Errorf("sending to the resumed chat changed the original history to %d contents, want 2", got)
+	}
+
+	if _, err := chats.CreateFromHistory(ctx, "gemini-2.0-flash", []*Content{{Role: "system"}}, nil); err == nil {
+		t.Errorf("CreateFromHistory() succeeded with an invalid role, want error")
+	}
+}
+
+func TestChatAutomaticFunctionCalling(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	chats := newTestChats(t, []string{functionCallResponseJSON, finalTextResponseJSON}, &requests)
+	chat, err := chats.Create(ctx, "gemini-2.0-flash", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chat.SetAutomaticFunctionCalling(&AutomaticFunctionCallingConfig{Handlers: map[string]FunctionHandler{
+		"get_weather": func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			return map[string]any{"weather": "sunny"}, nil
+		},
+		"get_time": func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			return map[string]any{"time": "noon"}, nil
+		},
+	}})
+
+	result, err := chat.SendMessage(ctx, Part{Text: "What is the weather in Paris?"})
+	if err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	if got, want := result.Text(), "It is sunny."; got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+
+	want := []*Content{
+		NewContentFromText("What is the weather in Paris?", RoleUser),
+		{Role: RoleModel, Parts: []*Part{
+			{FunctionCall: &FunctionCall{ID: "call-1", Name: "get_weather", Args: map[string]any{"city": "Paris"}}},
+			{FunctionCall: &FunctionCall{ID: "call-2", Name: "get_time", Args: map[string]any{}}},
+		}},
+		{Role: RoleUser, Parts: []*Part{
+			{FunctionResponse: &FunctionResponse{ID: "call-1", Name: "get_weather", Response: map[string]any{"weather": "sunny"}}},
+			{FunctionResponse: &FunctionResponse{ID: "call-2", Name: "get_time", Response: map[string]any{"time": "noon"}}},
+		}},
+		NewContentFromText("It is sunny.", RoleModel),
+	}
+	if diff := cmp.Diff(want, chat.History(false)); diff != "" {
+		t.Errorf("History() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := chat.SendMessage(ctx, Part{Text: "Thanks!"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	if got := len(requests[2]["contents"].([]any)); got != 5 {
+		t.Errorf("follow-up message sent %d contents, want 5", got)
+	}
+}
+
+func TestChatSendContents(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	chats := newTestChats(t, []string{finalTextResponseJSON}, &requests)
+	chat, err := chats.Create(ctx, "gemini-2.0-flash", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := []*Content{
+		{Parts: []*Part{NewPartFromText("What is in this image?"), NewPartFromBytes([]byte("png"), "image/png")}},
+		NewContentFromText("A cat.", RoleModel),
+		NewContentFromText("What color is it?", RoleUser),
+	}
+	if _, err := chat.SendContents(ctx, inputs, nil); err != nil {
+		t.Fatalf("SendContents() failed: %v", err)
+	}
+	if diff := cmp.Diff([]string{"What is in this image?", "A cat.", "What color is it?"}, requestTexts(requests[0])); diff != "" {
+		t.Errorf("request contents mismatch (-want +got):\n%s", diff)
+	}
+	history := chat.History(false)
+	if got := len(history); got != 4 {
+		t.Fatalf("len(History()) = %d, want 4", got)
+	}
+	if history[0].Role != RoleUser || len(history[0].Parts) != 2 {
+		t.Errorf("History()[0] = %+v, want a user content with 2 parts", history[0])
+	}
+	if inputs[0].Role != "" {
+		t.Errorf("SendContents() modified the role of its input to %q", inputs[0].Role)
+	}
+
+	for range chat.SendContentsStream(ctx, Text("And its name?"), nil) {
+	}
+	if got := len(chat.History(false)); got != 6 {
+		t.Errorf("len(History()) = %d after SendContentsStream(), want 6", got)
+	}
+
+	if _, err := chat.SendContents(ctx, nil, nil); err == nil {
+		t.Errorf("SendContents() succeeded without contents, want error")
+	}
+	if _, err := chat.SendContents(ctx, []*Content{{Role: "system"}}, nil); err == nil {
+		t.Errorf("SendContents() succeeded with an invalid role, want error")
+	}
+}
+
+func TestChatFork(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	chats := newTestChats(t, []string{finalTextResponseJSON}, &requests)
+	chat, err := chats.CreateWithStore(ctx, "gemini-2.0-flash", &GenerateContentConfig{Temperature: Ptr[float32](0.5)}, NewInMemoryChatStore(), "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chat.SendMessage(ctx, Part{Text: "Tell me a story."}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+
+	fork := chat.Fork()
+	if _, err := fork.SendMessage(ctx, Part{Text: "Make it shorter."}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	if _, err := chat.SendMessage(ctx, Part{Text: "Make it longer."}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"Tell me a story.", "It is sunny.", "Make it shorter."}, requestTexts(requests[1])); diff != "" {
+		t.Errorf("fork request mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Tell me a story.", "It is sunny.", "Make it longer."}, requestTexts(requests[2])); diff != "" {
+		t.Errorf("chat request mismatch (-want +got):\n%s", diff)
+	}
+	if got := len(fork.History(false)); got != 4 {
+		t.Errorf("len(fork.History()) = %d, want 4", got)
+	}
+	if got := len(chat.History(false)); got != 4 {
+		t.Errorf("len(chat.History()) = %d, want 4", got)
+	}
+	if diff := cmp.Diff(chat.config, fork.config); diff != "" || chat.config == fork.config {
+		t.Errorf("fork config should be an equal copy of the chat config (-want +got):\n%s", diff)
+	}
+}
 
-		history := chat.History(false)
-		expectedUserMessage := "What is 1 + 2?"
-		if history[0].Parts[0].Text != expectedUserMessage {
-			t.Errorf("Expected history to start with %s, got %s", expectedUserMessage, history[0].Parts[0].Text)
+func TestChatRewind(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	chats := newTestChats(t, []string{finalTextResponseJSON}, &requests)
+	store := NewInMemoryChatStore()
+	chat, err := chats.CreateWithStore(ctx, "gemini-2.0-flash", nil, store, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, text := range []string{"First", "Second"} {
+		if _, err := chat.SendMessage(ctx, Part{Text: text}); err != nil {
+			t.Fatalf("SendMessage() failed: %v", err)
 		}
-		for i, expectedResponse := range expectedResponses {
-			for j, expectedPart := range history[i+1].Parts {
-				if expectedPart.Text != expectedResponse.Parts[j].Text {
-					t.Errorf("Expected model response to be %s, got %s", expectedResponse.Parts[j].Text, part.Text)
-				}
-			}
+	}
+
+	if err := chat.Rewind(ctx, 1); err != nil {
+		t.Fatalf("Rewind() failed: %v", err)
+	}
+	want := []*Content{NewContentFromText("First", RoleUser), NewContentFromText("It is sunny.", RoleModel)}
+	if diff := cmp.Diff(want, chat.History(false)); diff != "" {
+		t.Errorf("History() after Rewind() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := chat.ReplaceLastUserMessage(ctx, NewPartFromText("Edited")); err != nil {
+		t.Fatalf("ReplaceLastUserMessage() failed: %v", err)
+	}
+	if diff := cmp.Diff([]string{"Edited"}, requestTexts(requests[len(requests)-1])); diff != "" {
+		t.Errorf("request contents mismatch (-want +got):\n%s", diff)
+	}
+	want = []*Content{NewContentFromText("Edited", RoleUser), NewContentFromText("It is sunny.", RoleModel)}
+	if diff := cmp.Diff(want, chat.History(false)); diff != "" {
+		t.Errorf("History() after ReplaceLastUserMessage() mismatch (-want +got):\n%s", diff)
+	}
+	stored, err := store.Load(ctx, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, stored); diff != "" {
+		t.Errorf("stored history mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := chat.Rewind(ctx, 2); err == nil {
+		t.Errorf("Rewind() succeeded past the start of the history, want error")
+	}
+}
+
+func TestChatCuratedHistory(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	safetyStop := `{"candidates": [{"finishReason": "SAFETY"}]}`
+	chats := newTestChats(t, []string{finalTextResponseJSON, promptBlockedResponseJSON, safetyStop, finalTextResponseJSON}, &requests)
+	chat, err := chats.Create(ctx, "gemini-2.0-flash", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := chat.SendMessage(ctx, Part{Text: "A"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	if _, err := chat.SendMessage(ctx, Part{Text: "B"}); !errors.As(err, &SafetyBlockedError{}) {
+		t.Fatalf("SendMessage() error = %v, want SafetyBlockedError", err)
+	}
+	if _, err := chat.SendMessage(ctx, Part{Text: "C"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	if _, err := chat.SendMessage(ctx, Part{Text: "D"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"A", "It is sunny.", "D"}, requestTexts(requests[3])); diff != "" {
+		t.Errorf("request contents mismatch (-want +got):\n%s", diff)
+	}
+	wantCurated := []*Content{
+		NewContentFromText("A", RoleUser),
+		NewContentFromText("It is sunny.", RoleModel),
+		NewContentFromText("D", RoleUser),
+		NewContentFromText("It is sunny.", RoleModel),
+	}
+	if diff := cmp.Diff(wantCurated, chat.History(true)); diff != "" {
+		t.Errorf("History(true) mismatch (-want +got):\n%s", diff)
+	}
+	wantComprehensive := []*Content{
+		NewContentFromText("A", RoleUser),
+		NewContentFromText("It is sunny.", RoleModel),
+		NewContentFromText("B", RoleUser),
+		{Role: RoleModel},
+		NewContentFromText("C", RoleUser),
+		{Role: RoleModel},
+		NewContentFromText("D", RoleUser),
+		NewContentFromText("It is sunny.", RoleModel),
+	}
+	if diff := cmp.Diff(wantComprehensive, chat.History(false)); diff != "" {
+		t.Errorf("History(false) mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestChatSendContentsConfigOverride(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	chats := newTestChats(t, []string{finalTextResponseJSON}, &requests)
+	config := &GenerateContentConfig{Temperature: Ptr[float32](0.5), ResponseMIMEType: "text/plain"}
+	chat, err := chats.Create(ctx, "gemini-2.0-flash", config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	override := &GenerateContentConfig{ResponseMIMEType: "application/json", CandidateCount: 1}
+	if _, err := chat.SendContents(ctx, Text("List three colors."), override); err != nil {
+		t.Fatalf("SendContents() failed: %v", err)
+	}
+	if _, err := chat.SendMessage(ctx, Part{Text: "Thanks!"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+
+	want := []map[string]any{
+		{"temperature": 0.5, "responseMimeType": "application/json", "candidateCount": 1.0},
+		{"temperature": 0.5, "responseMimeType": "text/plain"},
+	}
+	for i, request := range requests {
+		if diff := cmp.Diff(want[i], request["generationConfig"]); diff != "" {
+			t.Errorf("request %d generationConfig mismatch (-want +got):\n%s", i, diff)
 		}
+	}
+	if config.ResponseMIMEType != "text/plain" || config.CandidateCount != 0 {
+		t.Errorf("SendContents() modified the chat config: %+v", config)
+	}
+}
+
+func TestChatStreamMultiPartAggregation(t *testing.T) {
+	ctx := context.Background()
+	chunks := []string{
+		`{"candidates":[{"content":{"role":"model","parts":[{"text":"Let me "}]}}]}`,
+		`{"candidates":[{"content":{"role":"model","parts":[{"text":"compute it."},{"executableCode":{"language":"PYTHON","code":"print(1 + 2)"}}]}}]}`,
+		`{"candidates":[{"content":{"role":"model","parts":[{"codeExecutionResult":{"outcome":"OUTCOME_OK","output":"3"}},{"text":"The answer"}]}}]}`,
+		`{"candidates":[{"content":{"role":"model","parts":[{"text":" is 3."},{"functionCall":{"name":"notify","args":{"result":3}}}]},"finishReason":"STOP"}]}`,
+	}
+	chats := &Chats{apiClient: newTestStreamModels(t, chunks, false).apiClient}
+	chat, err := chats.Create(ctx, "gemini-2.0-flash", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, err := range chat.SendMessageStream(ctx, Part{Text: "What is 1 + 2?"}) {
+		if err != nil {
+			t.Fatalf("SendMessageStream() failed: %v", err)
+		}
+	}
+
+	want := []*Content{
+		{Role: RoleUser, Parts: []*Part{{Text: "What is 1 + 2?"}}},
+		{Role: RoleModel, Parts: []*Part{
+			{Text: "Let me compute it."},
+			{ExecutableCode: &ExecutableCode{Language: LanguagePython, Code: "print(1 + 2)"}},
+			{CodeExecutionResult: &CodeExecutionResult{Outcome: OutcomeOK, Output: "3"}},
+			{Text: "The answer is 3."},
+			{FunctionCall: &FunctionCall{Name: "notify", Args: map[string]any{"result": 3.0}}},
+		}},
+	}
+	if diff := cmp.Diff(want, chat.History(false)); diff != "" {
+		t.Errorf("History() mismatch (-want +got):\n%s", diff)
+	}
+}
 
+func TestChatHooks(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	chats := newTestChats(t, []string{functionCallResponseJSON, finalTextResponseJSON}, &requests)
+	chat, err := chats.Create(ctx, "gemini-2.0-flash", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var weatherArgs map[string]any
+	var (
+		mu      sync.Mutex
+		callIDs []string
+	)
+	chat.SetAutomaticFunctionCalling(&AutomaticFunctionCallingConfig{Handlers: map[string]FunctionHandler{
+		"get_weather": func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			weatherArgs = args
+			return map[string]any{"weather": "sunny"}, nil
+		},
+		"get_time": func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			t.Errorf("get_time handler called, want it denied by the OnToolCall hook")
+			return nil, nil
+		},
+	}})
+	chat.SetHooks(&ChatHooks{
+		OnBeforeSend: func(ctx context.Context, contents []*Content) ([]*Content, error) {
+			return append([]*Content{NewContentFromText("[user 42]", RoleUser)}, contents...), nil
+		},
+		OnAfterResponse: func(ctx context.Context, response *GenerateContentResponse) error {
+			response.Candidates[0].Content.Parts[0].Text = "[redacted]"
+			return nil
+		},
+		OnToolCall: func(ctx context.Context, call *FunctionCall) error {
+			mu.Lock()
+			callIDs = append(callIDs, call.ID)
+			mu.Unlock()
+			if call.Name == "get_time" {
+				return errors.New("denied")
+			}
+			call.Args["units"] = "metric"
+			return nil
+		},
 	})
+
+	result, err := chat.SendMessage(ctx, Part{Text: "What is the weather in Paris?"})
+	if err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	if got, want := result.Text(), "[redacted]"; got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+	if diff := cmp.Diff(map[string]any{"city": "Paris", "units": "metric"}, weatherArgs); diff != "" {
+		t.Errorf("get_weather args mismatch (-want +got):\n%s", diff)
+	}
+	slices.Sort(callIDs)
+	if diff := cmp.Diff([]string{"call-1", "call-2"}, callIDs); diff != "" {
+		t.Errorf("OnToolCall call IDs mismatch (-want +got):\n%s", diff)
+	}
+	history := chat.History(false)
+	if got, want := history[0].Parts[0].Text, "[user 42]"; got != want {
+		t.Errorf("History()[0] text = %q, want %q", got, want)
+	}
+	responses := history[3].Parts
+	if got := responses[1].FunctionResponse.Response["error"]; got != "denied" {
+		t.Errorf("get_time response error = %v, want %q", got, "denied")
+	}
+	if got, want := history[len(history)-1].Parts[0].Text, "[redacted]"; got != want {
+		t.Errorf("recorded model text = %q, want %q", got, want)
+	}
+
+	chat.SetHooks(&ChatHooks{OnBeforeSend: func(ctx context.Context, contents []*Content) ([]*Content, error) {
+		return nil, errors.New("profanity")
+	}})
+	if _, err := chat.SendMessage(ctx, Part{Text: "Bad words"}); err == nil {
+		t.Errorf("SendMessage() succeeded, want the OnBeforeSend error")
+	}
+	if got := len(requests); got != 2 {
+		t.Errorf("sent %d requests, want 2", got)
+	}
+}
+
+func TestChatSetCachedContent(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	cachedResponse := `{
+		"candidates": [{"content": {"role": "model", "parts": [{"text": "See section 3."}]}, "finishReason": "STOP"}],
+		"usageMetadata": {"promptTokenCount": 10010, "cachedContentTokenCount": 10000, "candidatesTokenCount": 4}
+	}`
+	chats := newTestChats(t, []string{cachedResponse}, &requests)
+	config := &GenerateContentConfig{
+		SystemInstruction: NewContentFromText("You answer questions about the manual.", RoleUser),
+		Temperature:       Ptr[float32](0.5),
+	}
+	chat, err := chats.Create(ctx, "gemini-2.0-flash", config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chat.SetCachedContent("cachedContents/manual")
+
+	result, err := chat.SendMessage(ctx, Part{Text: "How do I reset the device?"})
+	if err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	if got, want := requests[0]["cachedContent"], "cachedContents/manual"; got != want {
+		t.Errorf("cachedContent = %v, want %v", got, want)
+	}
+	if _, ok := requests[0]["systemInstruction"]; ok {
+		t.Errorf("request has a systemInstruction along with the cached content")
+	}
+	if got, want := result.UsageMetadata.CachedContentTokenCount, int32(10000); got != want {
+		t.Errorf("CachedContentTokenCount = %d, want %d", got, want)
+	}
+	if config.SystemInstruction == nil {
+		t.Errorf("SetCachedContent() modified the config given to Create()")
+	}
+}
+
+func TestChatSetSystemInstruction(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	chats := newTestChats(t, []string{finalTextResponseJSON, finalTextResponseJSON, finalTextResponseJSON}, &requests)
+	config := &GenerateContentConfig{SystemInstruction: NewContentFromText("You are a helpful assistant.", RoleUser)}
+	chat, err := chats.Create(ctx, "gemini-2.0-flash", config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chat.SendMessage(ctx, Part{Text: "Hi"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	chat.SetSystemInstruction(NewContentFromText("You are a billing expert.", RoleUser))
+	if _, err := chat.SendMessage(ctx, Part{Text: "Why was I charged twice?"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	chat.SetSystemInstruction(nil)
+	if _, err := chat.SendMessage(ctx, Part{Text: "Thanks"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+
+	systemInstructionText := func(request map[string]any) any {
+		systemInstruction, ok := request["systemInstruction"].(map[string]any)
+		if !ok {
+			return nil
+		}
+		return systemInstruction["parts"].([]any)[0].(map[string]any)["text"]
+	}
+	wantInstructions := []any{"You are a helpful assistant.", "You are a billing expert.", nil}
+	for i, want := range wantInstructions {
+		if got := systemInstructionText(requests[i]); got != want {
+			t.Errorf("request %d systemInstruction = %v, want %v", i, got, want)
+		}
+	}
+	if got, want := len(requestTexts(requests[2])), 5; got != want {
+		t.Errorf("len(request contents) = %d, want %d", got, want)
+	}
+	if got, want := config.SystemInstruction.Parts[0].Text, "You are a helpful assistant."; got != want {
+		t.Errorf("SetSystemInstruction() modified the config given to Create(): %q", got)
+	}
+}
+
+func TestChatUsage(t *testing.T) {
+	ctx := context.Background()
+	withUsage := func(response, usage string) string {
+		return strings.TrimSuffix(strings.TrimSpace(response), "}") + `, "usageMetadata": ` + usage + `}`
+	}
+	var requests []map[string]any
+	chats := newTestChats(t, []string{
+		withUsage(functionCallResponseJSON, `{"promptTokenCount": 10, "candidatesTokenCount": 5, "totalTokenCount": 15, "promptTokensDetails": [{"modality": "TEXT", "tokenCount": 10}]}`),
+		withUsage(finalTextResponseJSON, `{"promptTokenCount": 30, "candidatesTokenCount": 4, "totalTokenCount": 34, "promptTokensDetails": [{"modality": "TEXT", "tokenCount": 30}]}`),
+		withUsage(finalTextResponseJSON, `{"promptTokenCount": 40, "cachedContentTokenCount": 25, "candidatesTokenCount": 4, "totalTokenCount": 44}`),
+	}, &requests)
+	chat, err := chats.Create(ctx, "gemini-2.0-flash", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chat.SetAutomaticFunctionCalling(&AutomaticFunctionCallingConfig{Handlers: map[string]FunctionHandler{
+		"get_weather": func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			return map[string]any{"weather": "sunny"}, nil
+		},
+		"get_time": func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			return map[string]any{"time": "noon"}, nil
+		},
+	}})
+	if _, err := chat.SendMessage(ctx, Part{Text: "What is the weather in Paris?"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+	fork := chat.Fork()
+	for range chat.SendMessageStream(ctx, Part{Text: "And tomorrow?"}) {
+	}
+
+	want := &GenerateContentResponseUsageMetadata{
+		PromptTokenCount:        80,
+		CachedContentTokenCount: 25,
+		CandidatesTokenCount:    13,
+		TotalTokenCount:         93,
+		PromptTokensDetails:     []*ModalityTokenCount{{Modality: MediaModalityText, TokenCount: 40}},
+	}
+	if diff := cmp.Diff(want, chat.Usage()); diff != "" {
+		t.Errorf("Usage() mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := fork.Usage().TotalTokenCount, int32(49); got != want {
+		t.Errorf("fork Usage().TotalTokenCount = %d, want %d", got, want)
+	}
+	if got, want := fork.Usage().PromptTokensDetails[0].TokenCount, int32(40); got != want {
+		t.Errorf("fork Usage().PromptTokensDetails[0].TokenCount = %d, want %d", got, want)
+	}
+
+	prices := NewPriceRegistry(map[string]ModelPrice{"gemini-2.0-flash": {InputTokens: 1, CachedInputTokens: 0.5, OutputTokens: 2}})
+	if cost, ok := chat.Cost(prices); !ok || math.Abs(cost-93.5e-6) > 1e-12 {
+		t.Errorf("Cost() = %v, %v, want %v, true", cost, ok, 93.5e-6)
+	}
+	if cost, ok := chat.Cost(nil); !ok || math.Abs(cost-11.325e-6) > 1e-12 {
+		t.Errorf("Cost(nil) = %v, %v, want %v, true", cost, ok, 11.325e-6)
+	}
+}
+
+func TestSendMessageAs(t *testing.T) {
+	ctx := context.Background()
+	type recipe struct {
+		Name        string   `json:"name"`
+		Ingredients []string `json:"ingredients"`
+	}
+	var requests []map[string]any
+	chats := newTestChats(t, []string{
+		textResponseJSON(`{"name": "Pancakes", "ingredients": ["flour", "milk"]}`),
+		textResponseJSON(`not json`),
+	}, &requests)
+	chat, err := chats.Create(ctx, "gemini-2.0-flash", &GenerateContentConfig{Temperature: Ptr[float32](0.5)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, result, err := SendMessageAs[recipe](ctx, chat, Part{Text: "Suggest a breakfast recipe."})
+	if err != nil {
+		t.Fatalf("SendMessageAs() failed: %v", err)
+	}
+	if diff := cmp.Diff(recipe{Name: "Pancakes", Ingredients: []string{"flour", "milk"}}, got); diff != "" {
+		t.Errorf("SendMessageAs() mismatch (-want +got):\n%s", diff)
+	}
+	if result == nil {
+		t.Errorf("SendMessageAs() returned a nil response")
+	}
+	generationConfig := requests[0]["generationConfig"].(map[string]any)
+	if got, want := generationConfig["responseMimeType"], "application/json"; got != want {
+		t.Errorf("responseMimeType = %v, want %v", got, want)
+	}
+	if generationConfig["responseSchema"] == nil {
+		t.Errorf("request has no responseSchema")
+	}
+	if got, want := generationConfig["temperature"], 0.5; got != want {
+		t.Errorf("temperature = %v, want %v", got, want)
+	}
+	if got, want := len(chat.History(false)), 2; got != want {
+		t.Errorf("len(History()) = %d, want %d", got, want)
+	}
+
+	if _, result, err := SendMessageAs[recipe](ctx, chat, Part{Text: "Another one."}); err == nil || result == nil {
+		t.Errorf("SendMessageAs() = %v, %v, want a response and an error", result, err)
+	}
+	if chat.config.ResponseSchema != nil {
+		t.Errorf("SendMessageAs() modified the config of the chat")
+	}
 }
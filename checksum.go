@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"strings"
+)
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Checksums are the checksums of some content, e.g. of a local file, to compare
+// with the hashes reported by the server for its uploaded copy.
+type Checksums struct {
+	// The SHA-256 digest of the content. The Files API reports it in
+	// [File.Sha256Hash] as the base64 encoding of the hex digest.
+	SHA256 []byte
+	// The CRC32C checksum of the content, as reported by Cloud Storage.
+	CRC32C uint32
+}
+
+// ComputeChecksums reads r until EOF and returns the checksums of its content.
+func ComputeChecksums(r io.Reader) (*Checksums, error) {
+	w := newChecksumWriter()
+	if _, err := io.Copy(w, r); err != nil {
+		return nil, err
+	}
+	return w.checksums(), nil
+}
+
+// VerifyFile compares the SHA-256 hash of the content with the hash of the file
+// computed by the server, and returns a [ChecksumMismatchError] if they differ.
+func (c *Checksums) VerifyFile(file *File) error {
+	if file == nil || file.Sha256Hash == "" {
+		return fmt.Errorf("file has no SHA-256 hash to verify")
+	}
+	got, err := base64.StdEncoding.DecodeString(file.Sha256Hash)
+	if err != nil {
+		return fmt.Errorf("invalid SHA-256 hash of file %s: %w", file.Name, err)
+	}
+	// The server reports the base64 encoding of the hex digest. The raw digest is
+	// accepted too.
+	want := hex.EncodeToString(c.SHA256)
+	if !strings.EqualFold(string(got), want) && !bytes.Equal(got, c.SHA256) {
+		return ChecksumMismatchError{
+			Name:      file.Name,
+			Algorithm: "SHA-256",
+			Want:      base64.StdEncoding.EncodeToString([]byte(want)),
+			Got:       file.Sha256Hash,
+		}
+	}
+	return nil
+}
+
+// verifyCRC32C compares the CRC32C checksum of the content with the checksum of a
+// Cloud Storage object, base64 encoded in big-endian byte order.
+func (c *Checksums) verifyCRC32C(name, crc32c string) error {
+	want := make([]byte, 4)
+	binary.BigEndian.PutUint32(want, c.CRC32C)
+	if got, err := base64.StdEncoding.DecodeString(crc32c); err != nil || !bytes.Equal(got, want) {
+		return ChecksumMismatchError{
+			Name:      name,
+			Algorithm: "CRC32C",
+			Want:      base64.StdEncoding.EncodeToString(want),
+			Got:       crc32c,
+		}
+	}
+	return nil
+}
+
+// ChecksumMismatchError is returned when the hash of an uploaded file computed by
+// the server differs from the hash of the local content, i.e. the file was
+// corrupted during the upload.
+type ChecksumMismatchError struct {
+	// The name of the uploaded file or object.
+	Name string
+	// The hash algorithm, "SHA-256" or "CRC32C".
+	Algorithm string
+	// The base64 encoded hash of the local content.
+	Want string
+	// The base64 encoded hash computed by the server.
+	Got string
+}
+
+// Error returns a string representation of the ChecksumMismatchError.
+func (e ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("%s checksum mismatch for %s: uploaded content has %s, server has %s", e.Algorithm, e.Name, e.Want, e.Got)
+}
+
+// checksumWriter computes the checksums of the content written to it.
+type checksumWriter struct {
+	sha256 hash.Hash
+	crc32c hash.Hash32
+}
+
+func newChecksumWriter() *checksumWriter {
+	return &checksumWriter{sha256: sha256.New(), crc32c: crc32.New(crc32cTable)}
+}
+
+func (w *checksumWriter) Write(p []byte) (int, error) {
+	w.sha256.Write(p)
+	w.crc32c.Write(p)
+	return len(p), nil
+}
+
+func (w *checksumWriter) checksums() *Checksums {
+	return &Checksums{SHA256: w.sha256.Sum(nil), CRC32C: w.crc32c.Sum32()}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// serverSHA256Hash returns a SHA-256 digest in the format of [File.Sha256Hash].
+func serverSHA256Hash(sum []byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(hex.EncodeToString(sum)))
+}
+
+func TestComputeChecksums(t *testing.T) {
+	checksums, err := ComputeChecksums(strings.NewReader("123456789"))
+	if err != nil {
+		t.Fatalf("ComputeChecksums() failed: %v", err)
+	}
+	if got, want := checksums.CRC32C, uint32(0xe3069283); got != want {
+		t.Errorf("CRC32C = %#x, want %#x", got, want)
+	}
+	sum := sha256.Sum256([]byte("123456789"))
+	if !bytes.Equal(checksums.SHA256, sum[:]) {
+		t.Errorf("SHA256 = %x, want %x", checksums.SHA256, sum)
+	}
+
+	// The Files API reports the base64 encoding of the hex digest.
+	if err := checksums.VerifyFile(&File{Name: "files/a", Sha256Hash: serverSHA256Hash(sum[:])}); err != nil {
+		t.Errorf("VerifyFile() with the same hash failed: %v", err)
+	}
+	if err := checksums.VerifyFile(&File{Name: "files/a", Sha256Hash: base64.StdEncoding.EncodeToString(sum[:])}); err != nil {
+		t.Errorf("VerifyFile() with the same raw hash failed: %v", err)
+	}
+	other := sha256.Sum256([]byte("other"))
+	var mismatch ChecksumMismatchError
+	if err := checksums.VerifyFile(&File{Name: "files/a", Sha256Hash: serverSHA256Hash(other[:])}); !errors.As(err, &mismatch) {
+		t.Errorf("VerifyFile() with another hash error = %v, want a ChecksumMismatchError", err)
+	}
+	if err := checksums.VerifyFile(&File{Name: "files/a"}); err == nil {
+		t.Errorf("VerifyFile() without hash succeeded, want error")
+	}
+}
+
+func TestFilesUploadVerifyChecksum(t *testing.T) {
+	ctx := context.Background()
+	content := []byte("hello world")
+	sum := sha256.Sum256(content)
+
+	for _, tt := range []struct {
+		name       string
+		serverHash string
+		wantErr    bool
+	}{
+		{name: "Match", serverHash: serverSHA256Hash(sum[:])},
+		{name: "Mismatch", serverHash: serverSHA256Hash(make([]byte, sha256.Size)), wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mockServer := NewMockUploadServer(t)
+			// The upload response has no hash, which is then read from the stored file.
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1beta/files/") {
+					fmt.Fprintf(w, `{"name": %q, "sha256Hash": %q}`, strings.TrimPrefix(r.URL.Path, "/v1beta/"), tt.serverHash)
+					return
+				}
+				mockServer.ServeHTTP(w, r)
+			}))
+			defer ts.Close()
+			mockServer.baseURL = ts.URL
+			client, err := NewClient(ctx, &ClientConfig{
+				Backend:     BackendGeminiAPI,
+				APIKey:      "test-api-key",
+				HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+				HTTPClient:  ts.Client(),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			file, err := client.Files.Upload(ctx, bytes.NewReader(content), &UploadFileConfig{MIMEType: "text/plain", VerifyChecksum: true})
+			var mismatch ChecksumMismatchError
+			if tt.wantErr {
+				if !errors.As(err, &mismatch) {
+					t.Errorf("Upload() error = %v, want a ChecksumMismatchError", err)
+				}
+			} else if err != nil {
+				t.Errorf("Upload() failed: %v", err)
+			}
+			if file == nil || file.Sha256Hash != tt.serverHash {
+				t.Errorf("Upload() = %+v, want the file with the server hash", file)
+			}
+		})
+	}
+}
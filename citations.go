@@ -0,0 +1,187 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CitationSource is a source referenced by the text of a candidate, either through
+// its [GroundingMetadata] or its [CitationMetadata].
+type CitationSource struct {
+	// The number of the source, as used in the inline citation markers.
+	Number int
+	// The title of the source, if known.
+	Title string
+	// The URI of the source, if known.
+	URI string
+	// The highest confidence score of the grounding supports referencing the source.
+	// Zero if unknown.
+	Confidence float32
+}
+
+// CitedText is the text of a candidate annotated with inline citation markers such
+// as "[1]" or "[1][3]", along with the list of the referenced sources.
+type CitedText struct {
+	// The text with the inline citation markers.
+	Text string
+	// The sources, ordered by number.
+	Sources []*CitationSource
+}
+
+// String returns the text followed by the numbered list of sources.
+func (t *CitedText) String() string {
+	if t == nil {
+		return ""
+	}
+	if len(t.Sources) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	b.WriteString(t.Text)
+	b.WriteString("\n\nSources:\n")
+	for _, s := range t.Sources {
+		fmt.Fprintf(&b, "[%d] ", s.Number)
+		switch {
+		case s.Title != "" && s.URI != "":
+			fmt.Fprintf(&b, "%s (%s)", s.Title, s.URI)
+		case s.Title != "":
+			b.WriteString(s.Title)
+		default:
+			b.WriteString(s.URI)
+		}
+		if s.Confidence > 0 {
+			fmt.Fprintf(&b, " - confidence %.2f", s.Confidence)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// CitedText returns the text of the first candidate annotated with inline citation
+// markers. See [Candidate.CitedText].
+func (r *GenerateContentResponse) CitedText() *CitedText {
+	if r == nil || len(r.Candidates) == 0 {
+		return &CitedText{}
+	}
+	return r.Candidates[0].CitedText()
+}
+
+// CitedText returns the text of the candidate, excluding thoughts, with a citation
+// marker inserted at the end of every segment supported by its grounding metadata
+// or quoted from a source listed in its citation metadata. Grounding chunks are
+// numbered first, in order, followed by the citations.
+func (c *Candidate) CitedText() *CitedText {
+	result := &CitedText{}
+	if c == nil || c.Content == nil {
+		return result
+	}
+
+	// Concatenate the text parts, remembering where each part starts.
+	var text strings.Builder
+	partStart := make(map[int]int)
+	for i, part := range c.Content.Parts {
+		if part.Text == "" || part.Thought {
+			continue
+		}
+		partStart[i] = text.Len()
+		text.WriteString(part.Text)
+	}
+	full := text.String()
+
+	// markers maps a byte offset in the text to the source numbers cited there.
+	markers := make(map[int][]int)
+	addMarker := func(offset, number int) {
+		offset = max(0, min(offset, len(full)))
+		for _, n := range markers[offset] {
+			if n == number {
+				return
+			}
+		}
+		markers[offset] = append(markers[offset], number)
+	}
+
+	if gm := c.GroundingMetadata; gm != nil {
+		// chunkSources maps the index of a grounding chunk to its source, nil for
+		// a nil chunk.
+		chunkSources := make([]*CitationSource, len(gm.GroundingChunks))
+		for i, chunk := range gm.GroundingChunks {
+			if chunk == nil {
+				continue
+			}
+			source := &CitationSource{Number: len(result.Sources) + 1}
+			switch {
+			case chunk.Web != nil:
+				source.Title, source.URI = chunk.Web.Title, chunk.Web.URI
+			case chunk.RetrievedContext != nil:
+				source.Title, source.URI = chunk.RetrievedContext.Title, chunk.RetrievedContext.URI
+			}
+			result.Sources = append(result.Sources, source)
+			chunkSources[i] = source
+		}
+		for _, support := range gm.GroundingSupports {
+			if support == nil || support.Segment == nil {
+				continue
+			}
+			start, ok := partStart[int(support.Segment.PartIndex)]
+			if !ok {
+				continue
+			}
+			for j, index := range support.GroundingChunkIndices {
+				if index < 0 || int(index) >= len(chunkSources) || chunkSources[index] == nil {
+					continue
+				}
+				source := chunkSources[index]
+				if j < len(support.ConfidenceScores) {
+					source.Confidence = max(source.Confidence, support.ConfidenceScores[j])
+				}
+				addMarker(start+int(support.Segment.EndIndex), source.Number)
+			}
+		}
+	}
+
+	if cm := c.CitationMetadata; cm != nil {
+		for _, citation := range cm.Citations {
+			if citation == nil {
+				continue
+			}
+			source := &CitationSource{Number: len(result.Sources) + 1, Title: citation.Title, URI: citation.URI}
+			result.Sources = append(result.Sources, source)
+			addMarker(int(citation.EndIndex), source.Number)
+		}
+	}
+
+	offsets := make([]int, 0, len(markers))
+	for offset := range markers {
+		offsets = append(offsets, offset)
+	}
+	sort.Ints(offsets)
+	var b strings.Builder
+	last := 0
+	for _, offset := range offsets {
+		b.WriteString(full[last:offset])
+		numbers := markers[offset]
+		sort.Ints(numbers)
+		for _, n := range numbers {
+			fmt.Fprintf(&b, "[%d]", n)
+		}
+		last = offset
+	}
+	b.WriteString(full[last:])
+	result.Text = b.String()
+	return result
+}
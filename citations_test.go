@@ -0,0 +1,114 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCitedText(t *testing.T) {
+	candidate := &Candidate{
+		Content: &Content{Parts: []*Part{
+			{Text: "thinking...", Thought: true},
+			{Text: "Paris is the capital of France. It has 2 million inhabitants."},
+		}},
+		GroundingMetadata: &GroundingMetadata{
+			GroundingChunks: []*GroundingChunk{
+				{Web: &GroundingChunkWeb{Title: "Wikipedia", URI: "https://en.wikipedia.org/wiki/Paris"}},
+				{RetrievedContext: &GroundingChunkRetrievedContext{Title: "Census", URI: "gs://bucket/census.txt"}},
+			},
+			GroundingSupports: []*GroundingSupport{
+				{
+					Segment:               &Segment{PartIndex: 1, StartIndex: 0, EndIndex: 31},
+					GroundingChunkIndices: []int32{0},
+					ConfidenceScores:      []float32{0.9},
+				},
+				{
+					Segment:               &Segment{PartIndex: 1, StartIndex: 32, EndIndex: 61},
+					GroundingChunkIndices: []int32{1, 0},
+					ConfidenceScores:      []float32{0.8, 0.95},
+				},
+			},
+		},
+	}
+
+	got := (&GenerateContentResponse{Candidates: []*Candidate{candidate}}).CitedText()
+	want := &CitedText{
+		Text: "Paris is the capital of France.[1] It has 2 million inhabitants.[1][2]",
+		Sources: []*CitationSource{
+			{Number: 1, Title: "Wikipedia", URI: "https://en.wikipedia.org/wiki/Paris", Confidence: 0.95},
+			{Number: 2, Title: "Census", URI: "gs://bucket/census.txt", Confidence: 0.8},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CitedText() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantString := "Paris is the capital of France.[1] It has 2 million inhabitants.[1][2]\n\n" +
+		"Sources:\n" +
+		"[1] Wikipedia (https://en.wikipedia.org/wiki/Paris) - confidence 0.95\n" +
+		"[2] Census (gs://bucket/census.txt) - confidence 0.80\n"
+	if diff := cmp.Diff(wantString, got.String()); diff != "" {
+		t.Errorf("String() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCitedTextCitationMetadata(t *testing.T) {
+	candidate := &Candidate{
+		Content: &Content{Parts: []*Part{{Text: "To be, or not to be."}}},
+		CitationMetadata: &CitationMetadata{Citations: []*Citation{
+			{StartIndex: 0, EndIndex: 19, URI: "https://example.com/hamlet"},
+		}},
+	}
+	got := candidate.CitedText()
+	want := &CitedText{
+		Text:    "To be, or not to be[1].",
+		Sources: []*CitationSource{{Number: 1, URI: "https://example.com/hamlet"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CitedText() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := (&GenerateContentResponse{}).CitedText().String(); got != "" {
+		t.Errorf("CitedText() of an empty response = %q, want empty", got)
+	}
+}
+
+func TestCitedTextNilEntries(t *testing.T) {
+	candidate := &Candidate{
+		Content: &Content{Parts: []*Part{{Text: "Paris is the capital of France."}}},
+		GroundingMetadata: &GroundingMetadata{
+			GroundingChunks: []*GroundingChunk{
+				nil,
+				{Web: &GroundingChunkWeb{Title: "Wikipedia", URI: "https://en.wikipedia.org/wiki/Paris"}},
+			},
+			GroundingSupports: []*GroundingSupport{
+				nil,
+				{Segment: &Segment{EndIndex: 31}, GroundingChunkIndices: []int32{0, 1}},
+			},
+		},
+		CitationMetadata: &CitationMetadata{Citations: []*Citation{nil}},
+	}
+	got := candidate.CitedText()
+	want := &CitedText{
+		Text:    "Paris is the capital of France.[1]",
+		Sources: []*CitationSource{{Number: 1, Title: "Wikipedia", URI: "https://en.wikipedia.org/wiki/Paris"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CitedText() mismatch (-want +got):\n%s", diff)
+	}
+}
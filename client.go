@@ -41,6 +41,14 @@ type Client struct {
 	Files *Files
 	// Operations provides access to long-running operations.
 	Operations *Operations
+	// Tunings provides access to the Tunings service.
+	Tunings *Tunings
+	// Batches provides access to the Batches service.
+	Batches *Batches
+	// AuthTokens provides access to the AuthTokens service.
+	AuthTokens *AuthTokens
+	// Corpora provides access to the semantic retrieval Corpora service.
+	Corpora *Corpora
 }
 
 // Backend is the GenAI backend to use for the client.
@@ -57,6 +65,11 @@ const (
 	BackendGeminiAPI
 	// BackendVertexAI is the Vertex AI backend.
 	BackendVertexAI
+	// BackendCustom sends the requests of all services to [HTTPOptions.BaseURL],
+	// which serves the REST protocol of the Gemini API, e.g. a local emulator or a
+	// gateway. The base URL is required, the API key is optional and no Google
+	// credentials are used unless set in ClientConfig.
+	BackendCustom
 )
 
 // The Stringer interface for Backend.
@@ -66,6 +79,8 @@ func (t Backend) String() string {
 		return "BackendGeminiAPI"
 	case BackendVertexAI:
 		return "BackendVertexAI"
+	case BackendCustom:
+		return "BackendCustom"
 	default:
 		return "BackendUnspecified"
 	}
@@ -78,8 +93,8 @@ type ClientConfig struct {
 	// Get a Gemini API key: https://ai.google.dev/gemini-api/docs/api-key
 	APIKey string
 
-	// Optional. Backend for GenAI. See Backend constants. Defaults to BackendGeminiAPI unless explicitly set to BackendVertexAI,
-	// or the environment variable GOOGLE_GENAI_USE_VERTEXAI is set to "1" or "true".
+	// Optional. Backend for GenAI. See Backend constants. Defaults to BackendGeminiAPI unless explicitly set to BackendVertexAI
+	// or BackendCustom, or the environment variable GOOGLE_GENAI_USE_VERTEXAI is set to "1" or "true".
 	Backend Backend
 
 	// Optional. GCP Project ID for Vertex AI. Required for BackendVertexAI.
@@ -104,6 +119,22 @@ type ClientConfig struct {
 	// Optional HTTP options to override.
 	HTTPOptions HTTPOptions
 
+	// Optional. Default labels attached to the GenerateContent requests sent to
+	// Vertex AI, e.g. to break down billed charges by team or feature. Labels set in
+	// [GenerateContentConfig.Labels] take precedence over the default labels with
+	// the same key. Ignored for BackendGeminiAPI, which does not support labels.
+	Labels map[string]string
+
+	// Optional. Uploads the inline data of the GenerateContent requests exceeding
+	// the request size limit. By default, such requests fail.
+	InlineDataOffload *InlineDataOffload
+
+	// Optional. The Cloud Storage location to which [Files.UploadToGCS] uploads
+	// local files, and InlineDataOffload uploads inline data on BackendVertexAI, as
+	// "gs://bucket" or "gs://bucket/prefix". Useful with BackendVertexAI, which has
+	// no Files API and reads files from Cloud Storage.
+	GCSStagingURI string
+
 	envVarProvider func() map[string]string
 }
 
@@ -156,6 +187,14 @@ func defaultEnvVarProvider() map[string]string {
 //
 // If using the Vertex AI backend and no credentials are provided in the
 // ClientConfig, the client will attempt to use application default credentials.
+//
+// With BackendCustom, the client sends its requests to the base URL of the HTTP
+// options, e.g. to an emulator of the Gemini API in integration tests:
+//
+//	client, err := genai.NewClient(ctx, &genai.ClientConfig{
+//		Backend:     genai.BackendCustom,
+//		HTTPOptions: genai.HTTPOptions{BaseURL: "http://localhost:8080"},
+//	})
 func NewClient(ctx context.Context, cc *ClientConfig) (*Client, error) {
 	if cc == nil {
 		cc = &ClientConfig{}
@@ -166,6 +205,10 @@ func NewClient(ctx context.Context, cc *ClientConfig) (*Client, error) {
 	}
 	envVars := cc.envVarProvider()
 
+	if cc.Backend == BackendCustom {
+		return newCustomClient(cc, envVars)
+	}
+
 	if cc.Project != "" && cc.APIKey != "" {
 		return nil, fmt.Errorf("project and API key are mutually exclusive in the client initializer. ClientConfig: %#v", cc)
 	}
@@ -229,11 +272,7 @@ func NewClient(ctx context.Context, cc *ClientConfig) (*Client, error) {
 		cc.HTTPOptions.BaseURL = baseURL
 	}
 	if cc.HTTPOptions.BaseURL == "" && cc.Backend == BackendVertexAI {
-		if cc.Location == "global" {
-			cc.HTTPOptions.BaseURL = "https://aiplatform.googleapis.com/"
-		} else {
-			cc.HTTPOptions.BaseURL = fmt.Sprintf("https://%s-aiplatform.googleapis.com/", cc.Location)
-		}
+		cc.HTTPOptions.BaseURL = vertexBaseURL(cc.Location)
 	} else if cc.HTTPOptions.BaseURL == "" {
 		cc.HTTPOptions.BaseURL = "https://generativelanguage.googleapis.com/"
 	}
@@ -276,17 +315,53 @@ func NewClient(ctx context.Context, cc *ClientConfig) (*Client, error) {
 		}
 	}
 
+	return newClient(cc), nil
+}
+
+// newCustomClient creates a client of BackendCustom, whose base URL is set in
+// the HTTP options or, like the one of the Gemini API, by [SetDefaultBaseURLs] or
+// the GOOGLE_GEMINI_BASE_URL environment variable.
+func newCustomClient(cc *ClientConfig, envVars map[string]string) (*Client, error) {
+	cc.HTTPOptions.BaseURL = getBaseURL(cc.Backend, &cc.HTTPOptions, envVars)
+	if cc.HTTPOptions.BaseURL == "" {
+		return nil, fmt.Errorf("base URL is required for the custom backend. You can set it with ClientConfig.HTTPOptions.BaseURL")
+	}
+	if cc.HTTPOptions.APIVersion == "" {
+		cc.HTTPOptions.APIVersion = "v1beta"
+	}
+	if cc.HTTPClient == nil {
+		if cc.Credentials != nil {
+			client, err := httptransport.NewClient(&httptransport.Options{
+				Credentials: cc.Credentials,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+			}
+			cc.HTTPClient = client
+		} else {
+			cc.HTTPClient = &http.Client{}
+		}
+	}
+	return newClient(cc), nil
+}
+
+// newClient creates a client whose services share the config.
+func newClient(cc *ClientConfig) *Client {
 	ac := &apiClient{clientConfig: cc}
 	c := &Client{
 		clientConfig: *cc,
 		Models:       &Models{apiClient: ac},
-		Live:         &Live{apiClient: ac},
+		Live:         &Live{apiClient: ac, Music: &LiveMusic{apiClient: ac}},
 		Caches:       &Caches{apiClient: ac},
 		Chats:        &Chats{apiClient: ac},
 		Operations:   &Operations{apiClient: ac},
 		Files:        &Files{apiClient: ac},
+		Tunings:      &Tunings{apiClient: ac},
+		Batches:      &Batches{apiClient: ac},
+		AuthTokens:   &AuthTokens{apiClient: ac},
+		Corpora:      &Corpora{apiClient: ac, Documents: &Documents{apiClient: ac}, Chunks: &Chunks{apiClient: ac}},
 	}
-	return c, nil
+	return c
 }
 
 // ClientConfig returns the ClientConfig for the client.
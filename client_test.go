@@ -15,8 +15,12 @@
 package genai
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
@@ -636,3 +640,123 @@ func TestClientConfigHTTPOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestNewClientCustomBackend(t *testing.T) {
+	ctx := context.Background()
+	noEnv := func() map[string]string { return map[string]string{} }
+
+	t.Run("MissingBaseURL", func(t *testing.T) {
+		if _, err := NewClient(ctx, &ClientConfig{Backend: BackendCustom, envVarProvider: noEnv}); err == nil {
+			t.Errorf("NewClient() succeeded without base URL, want error")
+		}
+	})
+
+	t.Run("BaseURLFromEnv", func(t *testing.T) {
+		client, err := NewClient(ctx, &ClientConfig{Backend: BackendCustom, envVarProvider: func() map[string]string {
+			return map[string]string{"GOOGLE_GEMINI_BASE_URL": "http://localhost:8080", "GOOGLE_API_KEY": "real-key"}
+		}})
+		if err != nil {
+			t.Fatalf("NewClient() failed: %v", err)
+		}
+		cc := client.ClientConfig()
+		if cc.HTTPOptions.BaseURL != "http://localhost:8080" || cc.HTTPOptions.APIVersion != "v1beta" || cc.APIKey != "" {
+			t.Errorf("NewClient() config = %+v, want the base URL of the environment, v1beta and no API key", cc)
+		}
+		if got := cc.Backend.String(); got != "BackendCustom" {
+			t.Errorf("Backend.String() = %q, want %q", got, "BackendCustom")
+		}
+	})
+
+	t.Run("Requests", func(t *testing.T) {
+		var requests []string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, fmt.Sprintf("%s %s key=%q", r.Method, r.URL.RequestURI(), r.Header.Get("x-goog-api-key")))
+			response := `{"candidates": [{"content": {"role": "model", "parts": [{"text": "Hi"}]}}]}`
+			if r.URL.Query().Get("alt") == "sse" {
+				fmt.Fprintf(w, "data: %s\n\n", response)
+				return
+			}
+			fmt.Fprintln(w, response)
+		}))
+		t.Cleanup(ts.Close)
+		client, err := NewClient(ctx, &ClientConfig{
+			Backend:        BackendCustom,
+			HTTPOptions:    HTTPOptions{BaseURL: ts.URL},
+			envVarProvider: noEnv,
+		})
+		if err != nil {
+			t.Fatalf("NewClient() failed: %v", err)
+		}
+		resp, err := client.Models.GenerateContent(ctx, "gemini-2.5-flash", Text("Hello"), nil)
+		if err != nil {
+			t.Fatalf("GenerateContent() failed: %v", err)
+		}
+		if resp.Text() != "Hi" {
+			t.Errorf("GenerateContent() = %q, want %q", resp.Text(), "Hi")
+		}
+		for resp, err := range client.Models.GenerateContentStream(ctx, "gemini-2.5-flash", Text("Hello"), nil) {
+			if err != nil {
+				t.Fatalf("GenerateContentStream() failed: %v", err)
+			}
+			if resp.Text() != "Hi" {
+				t.Errorf("GenerateContentStream() = %q, want %q", resp.Text(), "Hi")
+			}
+		}
+		want := []string{
+			`POST /v1beta/models/gemini-2.5-flash:generateContent key=""`,
+			`POST /v1beta/models/gemini-2.5-flash:streamGenerateContent?alt=sse key=""`,
+		}
+		if diff := cmp.Diff(want, requests); diff != "" {
+			t.Errorf("requests mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+// newTestClient returns a client of backend whose requests are answered by
+// handler. The Vertex AI client uses project "project" and location
+// "us-central1".
+func newTestClient(t *testing.T, backend Backend, handler http.HandlerFunc) *Client {
+	t.Helper()
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	cc := &ClientConfig{
+		Backend:     backend,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}
+	if backend == BackendVertexAI {
+		cc.Project = "project"
+		cc.Location = "us-central1"
+		cc.Credentials = &auth.Credentials{}
+	} else {
+		cc.APIKey = "test-api-key"
+	}
+	client, err := NewClient(context.Background(), cc)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return client
+}
+
+// replyInOrder returns a handler replying with the given responses in order,
+// repeating the last one, and recording each request body. Streaming requests
+// get the response as a single server-sent event.
+func replyInOrder(t *testing.T, responses []string, requests *[]map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Error decoding request body: %v", err)
+		}
+		*requests = append(*requests, body)
+		i := min(len(*requests)-1, len(responses)-1)
+		if r.URL.Query().Get("alt") == "sse" {
+			var b bytes.Buffer
+			if err := json.Compact(&b, []byte(responses[i])); err != nil {
+				t.Errorf("Error compacting response: %v", err)
+			}
+			fmt.Fprintf(w, "data: %s\n\n", b.String())
+			return
+		}
+		fmt.Fprintln(w, responses[i])
+	}
+}
@@ -281,13 +281,15 @@ func mergeHTTPOptions(clientConfig *ClientConfig, configHTTPOptions *HTTPOptions
 		return nil
 	} else if clientHTTPOptions == nil {
 		result = HTTPOptions{
-			BaseURL:    configHTTPOptions.BaseURL,
-			APIVersion: configHTTPOptions.APIVersion,
+			BaseURL:               configHTTPOptions.BaseURL,
+			APIVersion:            configHTTPOptions.APIVersion,
+			ProvisionedThroughput: configHTTPOptions.ProvisionedThroughput,
 		}
 	} else {
 		result = HTTPOptions{
-			BaseURL:    clientHTTPOptions.BaseURL,
-			APIVersion: clientHTTPOptions.APIVersion,
+			BaseURL:               clientHTTPOptions.BaseURL,
+			APIVersion:            clientHTTPOptions.APIVersion,
+			ProvisionedThroughput: clientHTTPOptions.ProvisionedThroughput,
 		}
 	}
 
@@ -298,6 +300,9 @@ func mergeHTTPOptions(clientConfig *ClientConfig, configHTTPOptions *HTTPOptions
 		if configHTTPOptions.APIVersion != "" {
 			result.APIVersion = configHTTPOptions.APIVersion
 		}
+		if configHTTPOptions.ProvisionedThroughput != "" {
+			result.ProvisionedThroughput = configHTTPOptions.ProvisionedThroughput
+		}
 	}
 	result.Headers = mergeHeaders(clientHTTPOptions, configHTTPOptions)
 	return &result
@@ -80,6 +80,23 @@ func TestMergeHTTPOptions(t *testing.T) {
 				Headers:    http.Header{},
 			},
 		},
+		{
+			name: "request overrides provisioned throughput",
+			clientConfig: &ClientConfig{
+				HTTPOptions: HTTPOptions{
+					BaseURL:               "https://client.com",
+					ProvisionedThroughput: ProvisionedThroughputModeDedicated,
+				},
+			},
+			requestHTTPOptions: &HTTPOptions{
+				ProvisionedThroughput: ProvisionedThroughputModeShared,
+			},
+			want: &HTTPOptions{
+				BaseURL:               "https://client.com",
+				Headers:               http.Header{},
+				ProvisionedThroughput: ProvisionedThroughputModeShared,
+			},
+		},
 		{
 			name: "both have values, request only updates some",
 			clientConfig: &ClientConfig{
@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCorpora(t *testing.T) {
+	ctx := context.Background()
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1beta/corpora":
+			if r.Method == http.MethodGet {
+				w.Write([]byte(`{"corpora": [{"name": "corpora/c1"}, {"name": "corpora/c2"}]}`))
+				return
+			}
+			w.Write([]byte(`{"name": "corpora/c1", "displayName": "Manuals", "createTime": "2025-01-02T03:04:05Z"}`))
+		case "/v1beta/corpora/c1:query":
+			w.Write([]byte(`{"relevantChunks": [{"chunkRelevanceScore": 0.75, "chunk": {"name": "corpora/c1/documents/d1/chunks/k1", "data": {"stringValue": "Press the red button."}, "state": "STATE_ACTIVE"}}]}`))
+		case "/v1beta/corpora/c1/documents":
+			if r.Method == http.MethodGet {
+				w.Write([]byte(`{"documents": [{"name": "corpora/c1/documents/d1"}], "nextPageToken": "next"}`))
+				return
+			}
+			w.Write([]byte(`{"name": "corpora/c1/documents/d1", "displayName": "Printer", "customMetadata": [{"key": "year", "numericValue": 2024}]}`))
+		case "/v1beta/corpora/c1/documents/d1/chunks":
+			w.Write([]byte(`{"name": "corpora/c1/documents/d1/chunks/k1", "data": {"stringValue": "Press the red button."}, "state": "STATE_PENDING_PROCESSING"}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	})
+
+	corpus, err := client.Corpora.Create(ctx, &CreateCorpusConfig{DisplayName: "Manuals"})
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	wantCorpus := &Corpus{Name: "corpora/c1", DisplayName: "Manuals", CreateTime: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)}
+	if diff := cmp.Diff(wantCorpus, corpus); diff != "" {
+		t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := client.Corpora.Update(ctx, "corpora/c1", &UpdateCorpusConfig{DisplayName: "Printer manuals"}); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+	var corpora []string
+	for c, err := range client.Corpora.All(ctx) {
+		if err != nil {
+			t.Fatalf("All() failed: %v", err)
+		}
+		corpora = append(corpora, c.Name)
+	}
+	if diff := cmp.Diff([]string{"corpora/c1", "corpora/c2"}, corpora); diff != "" {
+		t.Errorf("All() mismatch (-want +got):\n%s", diff)
+	}
+
+	document, err := client.Corpora.Documents.Create(ctx, "corpora/c1", &CreateDocumentConfig{
+		DisplayName:    "Printer",
+		CustomMetadata: []*CustomMetadata{{Key: "year", NumericValue: Ptr[float32](2024)}},
+	})
+	if err != nil {
+		t.Fatalf("Documents.Create() failed: %v", err)
+	}
+	wantDocument := &Document{Name: "corpora/c1/documents/d1", DisplayName: "Printer", CustomMetadata: []*CustomMetadata{{Key: "year", NumericValue: Ptr[float32](2024)}}}
+	if diff := cmp.Diff(wantDocument, document); diff != "" {
+		t.Errorf("Documents.Create() mismatch (-want +got):\n%s", diff)
+	}
+	page, err := client.Corpora.Documents.List(ctx, "corpora/c1", &ListDocumentsConfig{PageSize: 10})
+	if err != nil {
+		t.Fatalf("Documents.List() failed: %v", err)
+	}
+	if len(page.Items) != 1 || page.NextPageToken != "next" {
+		t.Errorf("Documents.List() = %+v, want 1 document and a next page", page)
+	}
+
+	chunk, err := client.Corpora.Chunks.Create(ctx, "corpora/c1/documents/d1", "Press the red button.", nil)
+	if err != nil {
+		t.Fatalf("Chunks.Create() failed: %v", err)
+	}
+	if chunk.State != ChunkStatePendingProcessing {
+		t.Errorf("Chunks.Create() state = %q, want %q", chunk.State, ChunkStatePendingProcessing)
+	}
+	if _, err := client.Corpora.Chunks.Update(ctx, "corpora/c1/documents/d1/chunks/k1", &UpdateChunkConfig{Data: &ChunkData{StringValue: "Press the blue button."}}); err != nil {
+		t.Fatalf("Chunks.Update() failed: %v", err)
+	}
+
+	response, err := client.Corpora.Query(ctx, "corpora/c1", "How do I print?", &QueryCorpusConfig{
+		ResultsCount:    5,
+		MetadataFilters: []*MetadataFilter{{Key: "year", Conditions: []*Condition{{Operation: ConditionOperatorGreaterEqual, NumericValue: Ptr[float32](2020)}}}},
+	})
+	if err != nil {
+		t.Fatalf("Query() failed: %v", err)
+	}
+	wantResponse := &QueryCorpusResponse{RelevantChunks: []*RelevantChunk{{
+		ChunkRelevanceScore: 0.75,
+		Chunk:               &Chunk{Name: "corpora/c1/documents/d1/chunks/k1", Data: &ChunkData{StringValue: "Press the red button."}, State: ChunkStateActive},
+	}}}
+	if diff := cmp.Diff(wantResponse, response); diff != "" {
+		t.Errorf("Query() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := client.Corpora.Chunks.Delete(ctx, "corpora/c1/documents/d1/chunks/k1", nil); err != nil {
+		t.Fatalf("Chunks.Delete() failed: %v", err)
+	}
+	if err := client.Corpora.Documents.Delete(ctx, "corpora/c1/documents/d1", &DeleteDocumentConfig{Force: true}); err != nil {
+		t.Fatalf("Documents.Delete() failed: %v", err)
+	}
+	if err := client.Corpora.Delete(ctx, "corpora/c1", &DeleteCorpusConfig{Force: true}); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+
+	want := []batchesRequest{
+		{Method: "POST", Path: "/v1beta/corpora", Body: map[string]any{"displayName": "Manuals"}},
+		{Method: "PATCH", Path: "/v1beta/corpora/c1", Query: "updateMask=displayName", Body: map[string]any{"displayName": "Printer manuals"}},
+		{Method: "GET", Path: "/v1beta/corpora"},
+		{Method: "POST", Path: "/v1beta/corpora/c1/documents", Body: map[string]any{"displayName": "Printer", "customMetadata": []any{map[string]any{"key": "year", "numericValue": 2024.0}}}},
+		{Method: "GET", Path: "/v1beta/corpora/c1/documents", Query: "pageSize=10"},
+		{Method: "POST", Path: "/v1beta/corpora/c1/documents/d1/chunks", Body: map[string]any{"data": map[string]any{"stringValue": "Press the red button."}}},
+		{Method: "PATCH", Path: "/v1beta/corpora/c1/documents/d1/chunks/k1", Query: "updateMask=data", Body: map[string]any{"data": map[string]any{"stringValue": "Press the blue button."}}},
+		{Method: "POST", Path: "/v1beta/corpora/c1:query", Body: map[string]any{
+			"query":           "How do I print?",
+			"resultsCount":    5.0,
+			"metadataFilters": []any{map[string]any{"key": "year", "conditions": []any{map[string]any{"operation": "GREATER_EQUAL", "numericValue": 2020.0}}}},
+		}},
+		{Method: "DELETE", Path: "/v1beta/corpora/c1/documents/d1/chunks/k1"},
+		{Method: "DELETE", Path: "/v1beta/corpora/c1/documents/d1", Query: "force=true"},
+		{Method: "DELETE", Path: "/v1beta/corpora/c1", Query: "force=true"},
+	}
+	if diff := cmp.Diff(want, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCorporaVertex(t *testing.T) {
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendVertexAI, &requests, func(w http.ResponseWriter, r *http.Request) {})
+	if _, err := client.Corpora.Create(context.Background(), nil); err == nil {
+		t.Errorf("Create() succeeded, want error")
+	}
+	if err := client.Corpora.Chunks.Delete(context.Background(), "corpora/c1/documents/d1/chunks/k1", nil); err == nil {
+		t.Errorf("Chunks.Delete() succeeded, want error")
+	}
+	if len(requests) != 0 {
+		t.Errorf("sent %d requests, want 0", len(requests))
+	}
+}
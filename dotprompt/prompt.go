@@ -0,0 +1,402 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dotprompt loads prompt templates from prompt files in the format of
+// Dotprompt, and renders them into requests of the Models service of a
+// [genai.Client]:
+//
+//	prompt, err := dotprompt.Load(os.DirFS("prompts"), "summarize.prompt")
+//	if err != nil {
+//		return err
+//	}
+//	resp, err := prompt.Generate(ctx, client.Models, map[string]any{"document": document})
+//
+// It is a separate module, so that the SDK does not depend on a YAML parser.
+package dotprompt
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"google.golang.org/genai"
+	"gopkg.in/yaml.v3"
+)
+
+// Prompt is a prompt template loaded from a prompt file, in the format of
+// Dotprompt: a YAML frontmatter holding the model and the config of the request,
+// followed by a templated body. For example:
+//
+//	---
+//	model: gemini-2.5-flash
+//	config:
+//	  temperature: 0.4
+//	  maxOutputTokens: 400
+//	input:
+//	  default:
+//	    language: English
+//	output:
+//	  format: json
+//	  schema:
+//	    type: object
+//	    properties:
+//	      summary: {type: string}
+//	---
+//	{{role "system"}}
+//	You summarize documents in {{.language}}.
+//	{{role "user"}}
+//	Summarize this document: {{.document}}
+//	{{media .attachment "application/pdf"}}
+//
+// The frontmatter supports the following keys, the other ones are ignored:
+//   - model: the model of the request.
+//   - config: the [genai.GenerateContentConfig] of the request, with the field names of
+//     its JSON encoding.
+//   - input.default: the default values of the input variables.
+//   - output.format: "json" or "text".
+//   - output.schema: the JSON Schema of the output, which implies the json format.
+//   - tools: the names of the tools used by the prompt.
+//
+// The body is a [text/template] template, executed with the input variables as
+// dot. Unlike in Dotprompt, variables are referenced as {{.name}}. The template
+// can call the following functions:
+//   - role: starts a message with the given role, "system", "user" or "model". The
+//     system message becomes the system instruction of the request. Text before
+//     the first role is a user message. The white space around text is trimmed.
+//   - media: adds a media part with the given URL and optional MIME type. Data URLs
+//     are sent as inline data, and other URLs as file data.
+//   - json: encodes a value as JSON.
+type Prompt struct {
+	// The name of the prompt, e.g. the name of its file without the ".prompt"
+	// extension.
+	Name string
+	// The model of the request.
+	Model string
+	// The config of the request, without the system instruction set by the body.
+	Config *genai.GenerateContentConfig
+	// The default values of the input variables.
+	InputDefaults map[string]any
+	// The names of the tools used by the prompt. The function declarations of the
+	// tools must be added to the config by the caller.
+	Tools []string
+
+	template *template.Template
+}
+
+// Request is a request rendered from a [Prompt], ready to be sent with
+// [genai.Models.GenerateContent].
+type Request struct {
+	Model    string
+	Contents []*genai.Content
+	Config   *genai.GenerateContentConfig
+}
+
+// Load reads and parses the prompt file at the given path of fsys, e.g. an
+// [os.DirFS] or an [embed.FS]. The name of the prompt is the base name of the file
+// without its ".prompt" extension.
+func Load(fsys fs.FS, name string) (*Prompt, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(strings.TrimSuffix(path.Base(name), ".prompt"), data)
+}
+
+// Parse parses the content of a prompt file. See [Prompt] for its format.
+func Parse(name string, data []byte) (*Prompt, error) {
+	frontmatter, body, err := splitFrontmatter(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("prompt %s: %w", name, err)
+	}
+	p := &Prompt{Name: name, Config: &genai.GenerateContentConfig{}}
+	if err := p.parseFrontmatter(frontmatter); err != nil {
+		return nil, fmt.Errorf("prompt %s: %w", name, err)
+	}
+	p.template, err = template.New(name).Option("missingkey=error").Funcs(promptFuncs(nil)).Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("prompt %s: %w", name, err)
+	}
+	return p, nil
+}
+
+// Render executes the body of the prompt with the input variables, merged with the
+// default values of the prompt, and returns the request. Referencing a variable
+// which is neither in input nor in the default values is an error.
+func (p *Prompt) Render(input map[string]any) (*Request, error) {
+	vars := maps.Clone(p.InputDefaults)
+	if vars == nil {
+		vars = map[string]any{}
+	}
+	maps.Copy(vars, input)
+
+	// The template functions write markers to the output, which are replaced with
+	// role changes and media parts once the template is executed.
+	var directives []promptDirective
+	t, err := p.template.Clone()
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := t.Funcs(promptFuncs(&directives)).Execute(&out, vars); err != nil {
+		return nil, fmt.Errorf("prompt %s: %w", p.Name, err)
+	}
+
+	config := &genai.GenerateContentConfig{}
+	if p.Config != nil {
+		*config = *p.Config
+	}
+	req := &Request{Model: p.Model, Config: config}
+	var (
+		role   = "user"
+		parts  []*genai.Part
+		system *genai.Content
+	)
+	flush := func() {
+		parts = trimPromptText(parts)
+		if len(parts) == 0 {
+			return
+		}
+		if role == "system" {
+			if system == nil {
+				system = &genai.Content{}
+			}
+			system.Parts = append(system.Parts, parts...)
+		} else {
+			req.Contents = append(req.Contents, genai.NewContentFromParts(parts, genai.Role(role)))
+		}
+		parts = nil
+	}
+	for i, segment := range strings.Split(out.String(), promptMarker) {
+		if i%2 == 0 {
+			if segment != "" {
+				parts = append(parts, genai.NewPartFromText(segment))
+			}
+			continue
+		}
+		n, err := strconv.Atoi(segment)
+		if err != nil || n >= len(directives) {
+			return nil, fmt.Errorf("prompt %s: invalid directive marker %q", p.Name, segment)
+		}
+		switch d := directives[n]; {
+		case d.role != "":
+			flush()
+			role = d.role
+		case d.part != nil:
+			parts = append(parts, d.part)
+		}
+	}
+	flush()
+	if system != nil {
+		config.SystemInstruction = system
+	}
+	return req, nil
+}
+
+// Generate renders the prompt with the input variables and generates content with
+// the request. See [Prompt.Render].
+func (p *Prompt) Generate(ctx context.Context, models *genai.Models, input map[string]any) (*genai.GenerateContentResponse, error) {
+	req, err := p.Render(input)
+	if err != nil {
+		return nil, err
+	}
+	return models.GenerateContent(ctx, req.Model, req.Contents, req.Config)
+}
+
+func (p *Prompt) parseFrontmatter(frontmatter string) error {
+	var fields map[string]any
+	if err := yaml.Unmarshal([]byte(frontmatter), &fields); err != nil {
+		return fmt.Errorf("frontmatter: %w", err)
+	}
+	if v, ok := fields["model"]; ok {
+		model, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("frontmatter: model must be a string, got %T", v)
+		}
+		p.Model = model
+	}
+	if v, ok := fields["config"]; ok && v != nil {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("frontmatter: config: %w", err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(p.Config); err != nil {
+			return fmt.Errorf("frontmatter: config: %w", err)
+		}
+	}
+	if v, ok := fields["input"]; ok && v != nil {
+		input, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("frontmatter: input must be a mapping, got %T", v)
+		}
+		if v, ok := input["default"]; ok && v != nil {
+			defaults, ok := v.(map[string]any)
+			if !ok {
+				return fmt.Errorf("frontmatter: input.default must be a mapping, got %T", v)
+			}
+			p.InputDefaults = defaults
+		}
+	}
+	if v, ok := fields["output"]; ok && v != nil {
+		output, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("frontmatter: output must be a mapping, got %T", v)
+		}
+		switch format := output["format"]; format {
+		case nil, "text":
+		case "json":
+			p.Config.ResponseMIMEType = "application/json"
+		default:
+			return fmt.Errorf("frontmatter: unsupported output.format %v", format)
+		}
+		if schema, ok := output["schema"]; ok && schema != nil {
+			if _, ok := schema.(map[string]any); !ok {
+				return fmt.Errorf("frontmatter: output.schema must be a mapping, got %T", schema)
+			}
+			data, err := json.Marshal(schema)
+			if err != nil {
+				return fmt.Errorf("frontmatter: output.schema: %w", err)
+			}
+			p.Config.ResponseMIMEType = "application/json"
+			p.Config.ResponseJSONSchema = data
+		}
+	}
+	if v, ok := fields["tools"]; ok && v != nil {
+		tools, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("frontmatter: tools must be a sequence, got %T", v)
+		}
+		for _, tool := range tools {
+			name, ok := tool.(string)
+			if !ok {
+				return fmt.Errorf("frontmatter: tools must be names, got %T", tool)
+			}
+			p.Tools = append(p.Tools, name)
+		}
+	}
+	return nil
+}
+
+// splitFrontmatter splits a prompt file into its frontmatter, delimited by "---"
+// lines, and its body. The frontmatter is optional.
+func splitFrontmatter(s string) (frontmatter, body string, err error) {
+	s = strings.TrimPrefix(s, "\ufeff")
+	first, rest, _ := strings.Cut(s, "\n")
+	if strings.TrimRight(first, " \t\r") != "---" {
+		return "", s, nil
+	}
+	for offset := 0; offset < len(rest); {
+		line, _, _ := strings.Cut(rest[offset:], "\n")
+		if strings.TrimRight(line, " \t\r") == "---" {
+			body := rest[min(offset+len(line)+1, len(rest)):]
+			return rest[:offset], body, nil
+		}
+		offset += len(line) + 1
+	}
+	return "", "", fmt.Errorf("unterminated frontmatter")
+}
+
+// promptMarker delimits the index of a directive in the output of a prompt
+// template.
+const promptMarker = "\x00"
+
+// promptDirective is a role change or a media part requested by a prompt
+// template.
+type promptDirective struct {
+	role string
+	part *genai.Part
+}
+
+// promptFuncs returns the functions of prompt templates, recording their
+// directives in directives.
+func promptFuncs(directives *[]promptDirective) template.FuncMap {
+	add := func(d promptDirective) string {
+		*directives = append(*directives, d)
+		return promptMarker + strconv.Itoa(len(*directives)-1) + promptMarker
+	}
+	return template.FuncMap{
+		"role": func(role string) (string, error) {
+			switch role {
+			case "system", "user", "model":
+				return add(promptDirective{role: role}), nil
+			default:
+				return "", fmt.Errorf("unsupported role %q", role)
+			}
+		},
+		"media": func(url string, mimeType ...string) (string, error) {
+			if len(mimeType) > 1 {
+				return "", fmt.Errorf("media: too many arguments")
+			}
+			part, err := promptMediaPart(url, strings.Join(mimeType, ""))
+			if err != nil {
+				return "", err
+			}
+			return add(promptDirective{part: part}), nil
+		},
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}
+}
+
+// promptMediaPart returns the part of a media URL. The MIME type of data URLs is
+// read from the URL, and the one of other URLs defaults to the one of their
+// extension.
+func promptMediaPart(url, mimeType string) (*genai.Part, error) {
+	if data, ok := strings.CutPrefix(url, "data:"); ok {
+		meta, payload, ok := strings.Cut(data, ",")
+		if !ok || !strings.HasSuffix(meta, ";base64") {
+			return nil, fmt.Errorf("media: unsupported data URL, want base64 data")
+		}
+		b, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return nil, fmt.Errorf("media: %w", err)
+		}
+		if mimeType == "" {
+			mimeType = strings.TrimSuffix(meta, ";base64")
+		}
+		return genai.NewPartFromBytes(b, mimeType), nil
+	}
+	if url == "" {
+		return nil, fmt.Errorf("media: empty URL")
+	}
+	if mimeType == "" {
+		mimeType = genai.DetectMIMEType(url, nil)
+	}
+	return genai.NewPartFromURI(url, mimeType), nil
+}
+
+// trimPromptText trims the white space around the text parts of a message and
+// removes the empty ones.
+func trimPromptText(parts []*genai.Part) []*genai.Part {
+	var trimmed []*genai.Part
+	for _, part := range parts {
+		if part.InlineData != nil || part.FileData != nil {
+			trimmed = append(trimmed, part)
+		} else if text := strings.TrimSpace(part.Text); text != "" {
+			trimmed = append(trimmed, genai.NewPartFromText(text))
+		}
+	}
+	return trimmed
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dotprompt
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+)
+
+const testPromptFile = `---
+model: gemini-2.5-flash
+config:
+  temperature: 0.4
+  maxOutputTokens: 400
+input:
+  default:
+    language: English
+output:
+  format: json
+  schema:
+    type: object
+    properties:
+      summary: {type: string}
+tools: [search]
+---
+{{role "system"}}
+You summarize documents in {{.language}}.
+
+{{role "user"}}
+Summarize this document: {{.document}}
+{{media .attachment}}
+Tags: {{json .tags}}
+`
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{"prompts/summarize.prompt": {Data: []byte(testPromptFile)}}
+	prompt, err := Load(fsys, "prompts/summarize.prompt")
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if prompt.Name != "summarize" || prompt.Model != "gemini-2.5-flash" {
+		t.Errorf("Load() = name %q model %q, want summarize gemini-2.5-flash", prompt.Name, prompt.Model)
+	}
+	if diff := cmp.Diff([]string{"search"}, prompt.Tools); diff != "" {
+		t.Errorf("Load() tools mismatch (-want +got):\n%s", diff)
+	}
+
+	req, err := prompt.Render(map[string]any{
+		"language":   "French",
+		"document":   "Lorem ipsum.",
+		"attachment": "gs://bucket/doc.pdf",
+		"tags":       []string{"a", "b"},
+	})
+	if err != nil {
+		t.Fatalf("Render() failed: %v", err)
+	}
+	want := &Request{
+		Model: "gemini-2.5-flash",
+		Contents: []*genai.Content{{
+			Role: genai.RoleUser,
+			Parts: []*genai.Part{
+				{Text: "Summarize this document: Lorem ipsum."},
+				{FileData: &genai.FileData{FileURI: "gs://bucket/doc.pdf", MIMEType: "application/pdf"}},
+				{Text: `Tags: ["a","b"]`},
+			},
+		}},
+		Config: &genai.GenerateContentConfig{
+			Temperature:        genai.Ptr[float32](0.4),
+			MaxOutputTokens:    400,
+			ResponseMIMEType:   "application/json",
+			ResponseJSONSchema: json.RawMessage(`{"properties":{"summary":{"type":"string"}},"type":"object"}`),
+			SystemInstruction:  &genai.Content{Parts: []*genai.Part{{Text: "You summarize documents in French."}}},
+		},
+	}
+	if diff := cmp.Diff(want, req); diff != "" {
+		t.Errorf("Render() mismatch (-want +got):\n%s", diff)
+	}
+	if prompt.Config.SystemInstruction != nil {
+		t.Errorf("Render() modified the config of the prompt")
+	}
+}
+
+func TestPromptRender(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		input map[string]any
+		want  []*genai.Content
+	}{
+		{
+			name:  "NoFrontmatter",
+			data:  "Hello {{.name}}!\n",
+			input: map[string]any{"name": "Gemini"},
+			want:  []*genai.Content{{Role: genai.RoleUser, Parts: []*genai.Part{{Text: "Hello Gemini!"}}}},
+		},
+		{
+			name: "Turns",
+			data: "---\n---\nQuestion?\n{{role \"model\"}}\nAnswer.\n{{role \"user\"}}{{media .image \"image/png\"}}",
+			input: map[string]any{
+				"image": "data:image/jpeg;base64,AQI=",
+			},
+			want: []*genai.Content{
+				{Role: genai.RoleUser, Parts: []*genai.Part{{Text: "Question?"}}},
+				{Role: genai.RoleModel, Parts: []*genai.Part{{Text: "Answer."}}},
+				{Role: genai.RoleUser, Parts: []*genai.Part{{InlineData: &genai.Blob{Data: []byte{1, 2}, MIMEType: "image/png"}}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt, err := Parse(tt.name, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("Parse() failed: %v", err)
+			}
+			req, err := prompt.Render(tt.input)
+			if err != nil {
+				t.Fatalf("Render() failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, req.Contents); diff != "" {
+				t.Errorf("Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPromptErrors(t *testing.T) {
+	parseTests := []struct {
+		name string
+		data string
+	}{
+		{name: "UnterminatedFrontmatter", data: "---\nmodel: gemini-2.5-flash\n"},
+		{name: "UnknownConfigField", data: "---\nconfig:\n  temprature: 1\n---\nHi"},
+		{name: "UnsupportedFormat", data: "---\noutput:\n  format: media\n---\nHi"},
+		{name: "InvalidTemplate", data: "Hi {{.name"},
+	}
+	for _, tt := range parseTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.name, []byte(tt.data)); err == nil {
+				t.Errorf("Parse() succeeded, want error")
+			}
+		})
+	}
+
+	renderTests := []struct {
+		name string
+		data string
+	}{
+		{name: "MissingVariable", data: "Hi {{.name}}"},
+		{name: "UnsupportedRole", data: `{{role "tool"}}Hi`},
+		{name: "InvalidDataURL", data: `{{media "data:image/png,abc"}}`},
+	}
+	for _, tt := range renderTests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt, err := Parse(tt.name, []byte(tt.data))
+			if err != nil {
+				t.Fatalf("Parse() failed: %v", err)
+			}
+			if _, err := prompt.Render(nil); err == nil {
+				t.Errorf("Render() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestPromptGenerate(t *testing.T) {
+	ctx := context.Background()
+	var (
+		paths  []string
+		bodies []map[string]any
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Error decoding request body: %v", err)
+		}
+		paths = append(paths, r.URL.Path)
+		bodies = append(bodies, body)
+		w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"text": "Bonjour"}]}}]}`))
+	}))
+	defer ts.Close()
+	client, err := genai.NewClient(ctx, &genai.ClientConfig{
+		APIKey:      "test-api-key",
+		Backend:     genai.BackendGeminiAPI,
+		HTTPClient:  ts.Client(),
+		HTTPOptions: genai.HTTPOptions{BaseURL: ts.URL},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+
+	prompt, err := Parse("greet", []byte("---\nmodel: gemini-2.5-flash\n---\nSay hello in {{.language}}."))
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	resp, err := prompt.Generate(ctx, client.Models, map[string]any{"language": "French"})
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	if got := resp.Text(); got != "Bonjour" {
+		t.Errorf("Generate() text = %q, want %q", got, "Bonjour")
+	}
+	if len(paths) != 1 || paths[0] != "/v1beta/models/gemini-2.5-flash:generateContent" {
+		t.Fatalf("request paths = %v, want one generateContent request", paths)
+	}
+	wantBody := map[string]any{
+		"contents":         []any{map[string]any{"role": "user", "parts": []any{map[string]any{"text": "Say hello in French."}}}},
+		"generationConfig": map[string]any{},
+	}
+	if diff := cmp.Diff(wantBody, bodies[0]); diff != "" {
+		t.Errorf("request body mismatch (-want +got):\n%s", diff)
+	}
+}
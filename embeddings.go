@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DotProduct returns the dot product of two embeddings of the same dimension.
+func DotProduct(a, b []float32) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("embeddings have different dimensions: %d and %d", len(a), len(b))
+	}
+	var dot float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+	}
+	return dot, nil
+}
+
+// CosineSimilarity returns the cosine of the angle between two embeddings of the
+// same dimension, from -1 for opposite embeddings to 1 for embeddings in the same
+// direction. It is 0 if either embedding is zero.
+func CosineSimilarity(a, b []float32) (float64, error) {
+	dot, err := DotProduct(a, b)
+	if err != nil {
+		return 0, err
+	}
+	norms := norm(a) * norm(b)
+	if norms == 0 {
+		return 0, nil
+	}
+	return dot / norms, nil
+}
+
+// Normalize returns a copy of the embedding scaled to unit length, so that the
+// dot product of normalized embeddings is their cosine similarity. A zero
+// embedding is returned as is.
+//
+// The embeddings of a reduced [EmbedContentConfig.OutputDimensionality] are not
+// normalized by the API.
+func Normalize(v []float32) []float32 {
+	n := norm(v)
+	out := make([]float32, len(v))
+	if n == 0 {
+		copy(out, v)
+		return out
+	}
+	for i, x := range v {
+		out[i] = float32(float64(x) / n)
+	}
+	return out
+}
+
+// norm returns the Euclidean norm of an embedding.
+func norm(v []float32) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	return math.Sqrt(sum)
+}
+
+// EmbeddingMatch is a candidate embedding returned by [NearestEmbeddings].
+type EmbeddingMatch struct {
+	// The index of the embedding in the candidates.
+	Index int
+	// The cosine similarity of the embedding with the query.
+	Score float64
+}
+
+// NearestEmbeddings returns the k candidate embeddings most similar to the query
+// by cosine similarity, from the most similar, or all of them if there are fewer.
+// Candidates of equal similarity are returned in order.
+//
+//	result, err := client.Models.EmbedContent(ctx, model, genai.Text("What is your name?"), &genai.EmbedContentConfig{TaskType: genai.TaskTypeRetrievalQuery})
+//	if err != nil {
+//		return err
+//	}
+//	matches, err := genai.NearestEmbeddings(result.Embeddings[0].Values, documents, 3)
+func NearestEmbeddings(query []float32, candidates [][]float32, k int) ([]EmbeddingMatch, error) {
+	if k <= 0 {
+		return nil, nil
+	}
+	matches := make([]EmbeddingMatch, len(candidates))
+	queryNorm := norm(query)
+	for i, c := range candidates {
+		dot, err := DotProduct(query, c)
+		if err != nil {
+			return nil, fmt.Errorf("candidate %d: %w", i, err)
+		}
+		matches[i] = EmbeddingMatch{Index: i}
+		if norms := queryNorm * norm(c); norms != 0 {
+			matches[i].Score = dot / norms
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	return matches[:min(k, len(matches))], nil
+}
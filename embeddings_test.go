@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestEmbeddingMath(t *testing.T) {
+	approx := cmpopts.EquateApprox(0, 1e-6)
+	for _, tt := range []struct {
+		name       string
+		a, b       []float32
+		wantDot    float64
+		wantCosine float64
+	}{
+		{"Same", []float32{1, 2, 2}, []float32{2, 4, 4}, 18, 1},
+		{"Opposite", []float32{1, 0}, []float32{-3, 0}, -3, -1},
+		{"Orthogonal", []float32{1, 0}, []float32{0, 5}, 0, 0},
+		{"Zero", []float32{0, 0}, []float32{1, 1}, 0, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dot, err := DotProduct(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("DotProduct() failed: %v", err)
+			}
+			if !cmp.Equal(dot, tt.wantDot, approx) {
+				t.Errorf("DotProduct() = %v, want %v", dot, tt.wantDot)
+			}
+			cosine, err := CosineSimilarity(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("CosineSimilarity() failed: %v", err)
+			}
+			if !cmp.Equal(cosine, tt.wantCosine, approx) {
+				t.Errorf("CosineSimilarity() = %v, want %v", cosine, tt.wantCosine)
+			}
+		})
+	}
+
+	if _, err := CosineSimilarity([]float32{1}, []float32{1, 2}); err == nil {
+		t.Errorf("CosineSimilarity() succeeded for different dimensions, want error")
+	}
+
+	v := []float32{3, 4}
+	if diff := cmp.Diff([]float32{0.6, 0.8}, Normalize(v), cmpopts.EquateApprox(0, 1e-6)); diff != "" {
+		t.Errorf("Normalize() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]float32{3, 4}, v); diff != "" {
+		t.Errorf("Normalize() modified its input (-want +got):\n%s", diff)
+	}
+	if got := Normalize([]float32{0, 0}); got[0] != 0 || got[1] != 0 {
+		t.Errorf("Normalize() = %v, want zero embedding", got)
+	}
+}
+
+func TestNearestEmbeddings(t *testing.T) {
+	query := []float32{1, 0}
+	candidates := [][]float32{{0, 1}, {1, 1}, {2, 0}, {-1, 0}, {1, 1}}
+	matches, err := NearestEmbeddings(query, candidates, 3)
+	if err != nil {
+		t.Fatalf("NearestEmbeddings() failed: %v", err)
+	}
+	want := []EmbeddingMatch{{Index: 2, Score: 1}, {Index: 1, Score: math.Sqrt2 / 2}, {Index: 4, Score: math.Sqrt2 / 2}}
+	if diff := cmp.Diff(want, matches, cmpopts.EquateApprox(0, 1e-6)); diff != "" {
+		t.Errorf("NearestEmbeddings() mismatch (-want +got):\n%s", diff)
+	}
+
+	if matches, err := NearestEmbeddings(query, candidates, 10); err != nil || len(matches) != len(candidates) {
+		t.Errorf("NearestEmbeddings() = %v, %v, want all %d candidates", matches, err, len(candidates))
+	}
+	if _, err := NearestEmbeddings(query, [][]float32{{1, 2, 3}}, 1); err == nil {
+		t.Errorf("NearestEmbeddings() succeeded for different dimensions, want error")
+	}
+}
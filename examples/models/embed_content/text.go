@@ -38,14 +38,14 @@ func run(ctx context.Context) {
 		fmt.Println("Calling GeminiAPI Backend...")
 	}
 	fmt.Println("Embed content RETRIEVAL_QUERY task type example.")
-	result, err := client.Models.EmbedContent(ctx, *model, genai.Text("What is your name?"), &genai.EmbedContentConfig{TaskType: "RETRIEVAL_QUERY"})
+	result, err := client.Models.EmbedContent(ctx, *model, genai.Text("What is your name?"), &genai.EmbedContentConfig{TaskType: genai.TaskTypeRetrievalQuery})
 	if err != nil {
 		log.Fatal(err)
 	}
 	fmt.Printf("%#v\n", result.Embeddings[0])
 
 	fmt.Println("Embed content RETRIEVAL_DOCUMENT task type example.")
-	result, err = client.Models.EmbedContent(ctx, *model, genai.Text("What is your name?"), &genai.EmbedContentConfig{TaskType: "RETRIEVAL_DOCUMENT"})
+	result, err = client.Models.EmbedContent(ctx, *model, genai.Text("What is your name?"), &genai.EmbedContentConfig{TaskType: genai.TaskTypeRetrievalDocument})
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -17,14 +17,13 @@
 package genai
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"io"
 	"iter"
-	"mime"
 	"net/http"
 	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -694,7 +693,7 @@ func (m Files) All(ctx context.Context) iter.Seq2[*File, error] {
 	if err != nil {
 		return yieldErrorAndEndIterator[File](err)
 	}
-	return p.all(ctx)
+	return p.All(ctx)
 }
 
 // Download function downloads a file from the specified URI.
@@ -728,8 +727,48 @@ func (m Files) Download(ctx context.Context, uri DownloadURI, config *DownloadFi
 	return data, nil
 }
 
+// DownloadTo streams a file from the specified URI to w without holding it in
+// memory, e.g. to save a generated video to disk, and returns the number of bytes
+// written. Use [NewDownloadURIFromName] to download a file by name:
+//
+//	f, _ := os.Create("video.mp4")
+//	defer f.Close()
+//	_, err := client.Files.DownloadTo(ctx, genai.NewDownloadURIFromName("files/abc"), f, nil)
+//
+// Unlike [Files.Download], the VideoBytes field of a video is not populated.
+func (m Files) DownloadTo(ctx context.Context, uri DownloadURI, w io.Writer, config *DownloadFileConfig) (int64, error) {
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		return 0, fmt.Errorf("method DownloadTo is only supported in the Gemini Developer client. You can choose to use Gemini Developer client by setting ClientConfig.Backend to BackendGeminiAPI.")
+	}
+	if uri.uri() == "" {
+		return 0, fmt.Errorf("the resource doesn't support download")
+	}
+	fileName, err := tFileName(m.apiClient, uri.uri())
+	if err != nil {
+		return 0, err
+	}
+	path := fmt.Sprintf("files/%s:download?alt=media", fileName)
+
+	var httpOptions *HTTPOptions
+	if config == nil {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, nil)
+	} else {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, config.HTTPOptions)
+		config.HTTPOptions = nil
+	}
+	return downloadFileTo(ctx, m.apiClient, path, httpOptions, w)
+}
+
 // Upload copies the contents of the given io.Reader to file storage associated
-// with the service, and returns information about the resulting file.
+// with the service, and returns information about the resulting file. If
+// config.MIMEType is empty, the MIME type is detected from the beginning of the
+// contents.
+//
+// The contents are sent with the resumable upload protocol, in chunks of 8 MB
+// read one at a time, so that large files such as multi-GB videos are never held
+// in memory. Chunks failing with a network error, a rate limiting error or a server
+// error are retried with an exponential backoff, resuming from the last byte
+// persisted by the server.
 func (m Files) Upload(ctx context.Context, r io.Reader, config *UploadFileConfig) (*File, error) {
 	if m.apiClient.clientConfig.Backend == BackendVertexAI {
 		return nil, fmt.Errorf("This method is only supported in the Gemini Developer client.")
@@ -741,6 +780,14 @@ func (m Files) Upload(ctx context.Context, r io.Reader, config *UploadFileConfig
 		fileToUpload.Name = config.Name
 		fileToUpload.DisplayName = config.DisplayName
 	}
+	totalBytes := uploadSize(r, config)
+	if fileToUpload.MIMEType == "" {
+		// Detect the MIME type from the beginning of the contents.
+		br := bufio.NewReaderSize(r, sniffLen)
+		head, _ := br.Peek(sniffLen)
+		fileToUpload.MIMEType = mimeTypeFromContent(head)
+		r = br
+	}
 
 	if fileToUpload.Name != "" && !strings.HasPrefix(fileToUpload.Name, "files/") {
 		fileToUpload.Name = "files/" + fileToUpload.Name
@@ -772,7 +819,45 @@ func (m Files) Upload(ctx context.Context, r io.Reader, config *UploadFileConfig
 	}
 
 	uploadURL := resp.HTTPHeaders.Get("x-goog-upload-url")
-	return m.apiClient.uploadFile(ctx, r, uploadURL, &httpOptions)
+	var checksums *checksumWriter
+	if config != nil && config.VerifyChecksum {
+		checksums = newChecksumWriter()
+		r = io.TeeReader(r, checksums)
+	}
+	var progress func(UploadProgress)
+	if config != nil && config.Progress != nil {
+		progress = func(p UploadProgress) {
+			p.TotalBytes = totalBytes
+			config.Progress(p)
+		}
+	}
+	file, err := m.apiClient.uploadFile(ctx, r, uploadURL, &httpOptions, progress)
+	if err != nil || checksums == nil {
+		return file, err
+	}
+	if file.Sha256Hash == "" {
+		// The hash may only be known once the file is stored.
+		got, err := m.Get(ctx, file.Name, nil)
+		if err != nil {
+			return file, fmt.Errorf("error getting the hash of the uploaded file: %w", err)
+		}
+		file = got
+	}
+	return file, checksums.checksums().VerifyFile(file)
+}
+
+// uploadSize returns the size of the uploaded contents, as set by
+// [Files.UploadFromPath] or known from an in-memory reader, or 0 if unknown.
+func uploadSize(r io.Reader, config *UploadFileConfig) int64 {
+	if config != nil && config.HTTPOptions != nil {
+		if size, err := strconv.ParseInt(config.HTTPOptions.Headers.Get("X-Goog-Upload-Header-Content-Length"), 10, 64); err == nil {
+			return size
+		}
+	}
+	if l, ok := r.(interface{ Len() int }); ok {
+		return int64(l.Len())
+	}
+	return 0
 }
 
 // UploadFromPath uploads a file from the specified path and returns information
@@ -795,9 +880,13 @@ func (m Files) UploadFromPath(ctx context.Context, path string, config *UploadFi
 
 	var copiedCfg UploadFileConfig
 	deepCopy(*config, &copiedCfg)
+	copiedCfg.Progress = config.Progress
+	copiedCfg.VerifyChecksum = config.VerifyChecksum
 
 	if copiedCfg.MIMEType == "" {
-		copiedCfg.MIMEType = mime.TypeByExtension(filepath.Ext(path))
+		head := make([]byte, sniffLen)
+		n, _ := osf.ReadAt(head, 0)
+		copiedCfg.MIMEType = DetectMIMEType(path, head[:n])
 		if copiedCfg.MIMEType == "" {
 			return nil, fmt.Errorf("Unknown mime type: Could not determine the mimetype for your file please set the `MIMEType` argument")
 		}
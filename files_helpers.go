@@ -0,0 +1,262 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFilePollInterval = time.Second
+	maxFilePollInterval     = 30 * time.Second
+
+	defaultMaxConcurrentUploads = 4
+	defaultMaxUploadAttempts    = 3
+)
+
+// FileProcessingError is returned when a file reaches the FAILED state.
+type FileProcessingError struct {
+	// The failed file.
+	File *File
+}
+
+// Error returns a string representation of the FileProcessingError.
+func (e FileProcessingError) Error() string {
+	if e.File.Error != nil && e.File.Error.Message != "" {
+		return fmt.Sprintf("file %s failed processing: %s", e.File.Name, e.File.Error.Message)
+	}
+	return fmt.Sprintf("file %s failed processing", e.File.Name)
+}
+
+// WaitUntilActive polls the state of a file until it leaves the PROCESSING state,
+// e.g. after uploading a video, and returns the active file. A
+// [FileProcessingError] is returned if the file reaches the FAILED state.
+//
+// The file is first polled after pollInterval, which defaults to 1 second, and the
+// interval then grows by half at every poll, up to 30 seconds. Use the context to
+// bound the total wait.
+func (m Files) WaitUntilActive(ctx context.Context, name string, pollInterval time.Duration) (*File, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultFilePollInterval
+	}
+	maxInterval := max(pollInterval, maxFilePollInterval)
+	for {
+		file, err := m.Get(ctx, name, nil)
+		if err != nil {
+			return nil, err
+		}
+		switch file.State {
+		case FileStateActive:
+			return file, nil
+		case FileStateFailed:
+			return nil, FileProcessingError{File: file}
+		}
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("WaitUntilActive: file %s is still %s: %w", file.Name, file.State, ctx.Err())
+		case <-timer.C:
+		}
+		pollInterval = min(pollInterval*3/2, maxInterval)
+	}
+}
+
+// UploadManyConfig configures [Files.UploadMany].
+type UploadManyConfig struct {
+	// Optional. The maximum number of files uploaded at a time. Defaults to 4.
+	MaxConcurrentUploads int
+	// Optional. The maximum number of attempts to upload a file, including the
+	// first one. Failed chunks are also retried within an attempt. Defaults to 3.
+	MaxAttempts int
+	// Optional. The initial interval between the polls of the state of the
+	// uploaded files, see [Files.WaitUntilActive]. Defaults to 1 second.
+	PollInterval time.Duration
+	// Optional. The config of every upload. The MIME types are detected from the
+	// files, see [DetectMIMEType]. Name and DisplayName can only be set when
+	// uploading a single file.
+	UploadConfig *UploadFileConfig
+}
+
+// UploadMany uploads the files at the given local paths concurrently, waits until
+// they are active, and returns [FileData] parts referencing them, in the order of
+// the paths, ready to be sent to a model:
+//
+//	parts, err := client.Files.UploadMany(ctx, []string{"a.mp4", "b.mp4"}, nil)
+//	contents := []*genai.Content{genai.NewContentFromParts(append(parts, genai.NewPartFromText("Compare the videos.")), genai.RoleUser)}
+//
+// If some files fail, the parts of the other files are returned, with nil parts for
+// the failed ones, along with an error joining the error of every failed file.
+func (m Files) UploadMany(ctx context.Context, paths []string, config *UploadManyConfig) ([]*Part, error) {
+	if config == nil {
+		config = &UploadManyConfig{}
+	}
+	if uc := config.UploadConfig; uc != nil && len(paths) > 1 && (uc.Name != "" || uc.DisplayName != "") {
+		return nil, fmt.Errorf("UploadMany: UploadConfig.Name and UploadConfig.DisplayName must be empty when uploading %d files", len(paths))
+	}
+	maxConcurrent := config.MaxConcurrentUploads
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrentUploads
+	}
+
+	parts := make([]*Part, len(paths))
+	errs := runConcurrently(ctx, len(paths), maxConcurrent, func(i int) error {
+		part, err := m.uploadAndWait(ctx, paths[i], config)
+		if err != nil {
+			return fmt.Errorf("%s: %w", paths[i], err)
+		}
+		parts[i] = part
+		return nil
+	})
+	return parts, errors.Join(errs...)
+}
+
+// uploadAndWait uploads a file, retrying failed uploads, and waits until it is
+// active.
+func (m Files) uploadAndWait(ctx context.Context, path string, config *UploadManyConfig) (*Part, error) {
+	maxAttempts := config.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxUploadAttempts
+	}
+	var file *File
+	var err error
+	delay := initialRetryDelay
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("upload aborted while waiting to retry after error %v: %w", err, ctx.Err())
+			case <-time.After(delay):
+			}
+			delay *= delayMultiplier
+		}
+		if file, err = m.UploadFromPath(ctx, path, config.UploadConfig); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if file.State != FileStateActive {
+		if file, err = m.WaitUntilActive(ctx, file.Name, config.PollInterval); err != nil {
+			return nil, err
+		}
+	}
+	return NewPartFromURI(file.URI, file.MIMEType), nil
+}
+
+// ExpiresWithin reports whether the file expires within d from now, or has already
+// expired. Files without an expiration time never expire.
+func (f *File) ExpiresWithin(d time.Duration) bool {
+	return !f.ExpirationTime.IsZero() && time.Until(f.ExpirationTime) <= d
+}
+
+// ListExpiring returns the files expiring within d from now, soonest first, e.g. to
+// upload again the files still needed by long-lived application state.
+func (m Files) ListExpiring(ctx context.Context, within time.Duration) ([]*File, error) {
+	var expiring []*File
+	for file, err := range m.All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if file.ExpiresWithin(within) {
+			expiring = append(expiring, file)
+		}
+	}
+	slices.SortStableFunc(expiring, func(a, b *File) int { return a.ExpirationTime.Compare(b.ExpirationTime) })
+	return expiring, nil
+}
+
+// DeleteMany deletes the named files concurrently. The files which no longer exist,
+// e.g. because they expired, are ignored. The returned error joins the errors of
+// every file which could not be deleted.
+func (m Files) DeleteMany(ctx context.Context, names []string) error {
+	errs := runConcurrently(ctx, len(names), defaultMaxConcurrentUploads, func(i int) error {
+		if _, err := m.Delete(ctx, names[i], nil); err != nil && !isNotFound(err) {
+			return fmt.Errorf("%s: %w", names[i], err)
+		}
+		return nil
+	})
+	return errors.Join(errs...)
+}
+
+// PruneExpired returns the names of the given files which still exist and do not
+// expire within margin from now, so that references to expired files can be
+// dropped from application state before they are sent to a model.
+func (m Files) PruneExpired(ctx context.Context, names []string, margin time.Duration) ([]string, error) {
+	alive := make([]bool, len(names))
+	errs := runConcurrently(ctx, len(names), defaultMaxConcurrentUploads, func(i int) error {
+		file, err := m.Get(ctx, names[i], nil)
+		if err != nil {
+			if isNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("%s: %w", names[i], err)
+		}
+		alive[i] = !file.ExpiresWithin(margin)
+		return nil
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	var kept []string
+	for i, name := range names {
+		if alive[i] {
+			kept = append(kept, name)
+		}
+	}
+	return kept, nil
+}
+
+// isNotFound reports whether err is an API error for a missing resource.
+func isNotFound(err error) bool {
+	var apiErr APIError
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
+
+// runConcurrently calls f for every index up to n, with at most limit calls at a
+// time, and returns the errors of the calls by index. The calls not started before
+// the context is done fail with the error of the context.
+func runConcurrently(ctx context.Context, n, limit int, f func(i int) error) []error {
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, limit)
+	for i := range n {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < n; j++ {
+				errs[j] = ctx.Err()
+			}
+			wg.Wait()
+			return errs
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = f(i)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
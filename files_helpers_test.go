@@ -0,0 +1,249 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestFiles returns a Files whose server replies to the requests of each path
+// with the given responses in order, repeating the last one.
+func newTestFiles(t *testing.T, responses map[string][]string) Files {
+	t.Helper()
+	served := map[string]int{}
+	client := newTestClient(t, BackendGeminiAPI, func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		bodies, ok := responses[key]
+		if !ok {
+			http.Error(w, `{"error": {"code": 404, "message": "not found", "status": "NOT_FOUND"}}`, http.StatusNotFound)
+			return
+		}
+		i := min(served[key], len(bodies)-1)
+		served[key]++
+		io.WriteString(w, bodies[i])
+	})
+	return *client.Files
+}
+
+func TestFilesWaitUntilActive(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Active", func(t *testing.T) {
+		files := newTestFiles(t, map[string][]string{"GET /v1beta/files/video": {
+			`{"name": "files/video", "state": "PROCESSING"}`,
+			`{"name": "files/video", "state": "PROCESSING"}`,
+			`{"name": "files/video", "state": "ACTIVE", "uri": "https://example.com/files/video"}`,
+		}})
+		file, err := files.WaitUntilActive(ctx, "files/video", time.Millisecond)
+		if err != nil {
+			t.Fatalf("WaitUntilActive() failed: %v", err)
+		}
+		if file.State != FileStateActive || file.URI == "" {
+			t.Errorf("WaitUntilActive() = %+v, want an active file", file)
+		}
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		files := newTestFiles(t, map[string][]string{"GET /v1beta/files/video": {
+			`{"name": "files/video", "state": "PROCESSING"}`,
+			`{"name": "files/video", "state": "FAILED", "error": {"code": 3, "message": "unsupported codec"}}`,
+		}})
+		_, err := files.WaitUntilActive(ctx, "files/video", time.Millisecond)
+		var processingErr FileProcessingError
+		if !errors.As(err, &processingErr) {
+			t.Fatalf("WaitUntilActive() error = %v, want a FileProcessingError", err)
+		}
+		if got, want := err.Error(), "file files/video failed processing: unsupported codec"; got != want {
+			t.Errorf("Error() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		files := newTestFiles(t, map[string][]string{"GET /v1beta/files/video": {`{"name": "files/video", "state": "PROCESSING"}`}})
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		if _, err := files.WaitUntilActive(ctx, "files/video", time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("WaitUntilActive() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		files := newTestFiles(t, nil)
+		if _, err := files.WaitUntilActive(ctx, "files/video", time.Millisecond); err == nil {
+			t.Errorf("WaitUntilActive() succeeded, want error")
+		}
+	})
+}
+
+func TestFilesUploadMany(t *testing.T) {
+	ctx := context.Background()
+	defer func(d time.Duration) { initialRetryDelay = d }(initialRetryDelay)
+	initialRetryDelay = time.Millisecond
+
+	mockServer := NewMockUploadServer(t)
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	mockServer.createHandler = func(w http.ResponseWriter, r *http.Request) {
+		name := r.Header.Get("X-Goog-Upload-Header-Content-Type")
+		mu.Lock()
+		attempts[name]++
+		n := attempts[name]
+		mu.Unlock()
+		// The PDF upload always fails, the text one fails once.
+		if name == "application/pdf" || (name == "text/plain" && n == 1) {
+			http.Error(w, `{"error": {"code": 400, "message": "bad request", "status": "INVALID_ARGUMENT"}}`, http.StatusBadRequest)
+			return
+		}
+		mockServer.handleCreate(w, r)
+	}
+	ts := httptest.NewServer(mockServer)
+	defer ts.Close()
+	mockServer.baseURL = ts.URL
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		APIKey:      "test-api-key",
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	dir := t.TempDir()
+	var paths []string
+	for name, content := range map[string]string{"a.png": string(pngHeader), "b.txt": "hello", "c.pdf": "%PDF-1.7"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	parts, err := client.Files.UploadMany(ctx, paths, &UploadManyConfig{MaxConcurrentUploads: 2, PollInterval: time.Millisecond})
+	if err == nil || !strings.Contains(err.Error(), "c.pdf") {
+		t.Errorf("UploadMany() error = %v, want an error for c.pdf", err)
+	}
+	var mimeTypes []string
+	for _, part := range parts {
+		if part == nil {
+			mimeTypes = append(mimeTypes, "")
+			continue
+		}
+		mimeTypes = append(mimeTypes, part.FileData.MIMEType)
+	}
+	if want := []string{"image/png", "text/plain", ""}; !slices.Equal(mimeTypes, want) {
+		t.Errorf("UploadMany() MIME types = %q, want %q", mimeTypes, want)
+	}
+	if got, want := attempts["application/pdf"], defaultMaxUploadAttempts; got != want {
+		t.Errorf("PDF upload attempts = %d, want %d", got, want)
+	}
+	if got, want := attempts["text/plain"], 2; got != want {
+		t.Errorf("text upload attempts = %d, want %d", got, want)
+	}
+
+	for _, uc := range []*UploadFileConfig{{Name: "files/shared"}, {DisplayName: "shared"}} {
+		if _, err := client.Files.UploadMany(ctx, paths, &UploadManyConfig{UploadConfig: uc}); err == nil {
+			t.Errorf("UploadMany() with UploadConfig %+v for %d files succeeded, want error", uc, len(paths))
+		}
+	}
+}
+
+func TestFilesUploadManyCanceled(t *testing.T) {
+	defer func(d time.Duration) { initialRetryDelay = d }(initialRetryDelay)
+	initialRetryDelay = time.Hour
+
+	mockServer := NewMockUploadServer(t)
+	mockServer.createHandler = func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"code": 503, "message": "unavailable", "status": "UNAVAILABLE"}}`, http.StatusServiceUnavailable)
+	}
+	ts := httptest.NewServer(mockServer)
+	defer ts.Close()
+	mockServer.baseURL = ts.URL
+	client, err := NewClient(context.Background(), &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		APIKey:      "test-api-key",
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "a.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.Files.UploadMany(ctx, []string{path}, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("UploadMany() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestFilesExpiry(t *testing.T) {
+	ctx := context.Background()
+	soon := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	sooner := time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+	later := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+	expired := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	files := newTestFiles(t, map[string][]string{
+		"GET /v1beta/files": {`{"files": [
+			{"name": "files/a", "expirationTime": "` + soon + `"},
+			{"name": "files/b", "expirationTime": "` + later + `"},
+			{"name": "files/c", "expirationTime": "` + sooner + `"},
+			{"name": "files/d"}
+		]}`},
+		"GET /v1beta/files/a":    {`{"name": "files/a", "expirationTime": "` + later + `"}`},
+		"GET /v1beta/files/b":    {`{"name": "files/b", "expirationTime": "` + expired + `"}`},
+		"DELETE /v1beta/files/a": {`{}`},
+	})
+
+	expiring, err := files.ListExpiring(ctx, 2*time.Hour)
+	if err != nil {
+		t.Fatalf("ListExpiring() failed: %v", err)
+	}
+	var names []string
+	for _, f := range expiring {
+		names = append(names, f.Name)
+	}
+	if want := []string{"files/c", "files/a"}; !slices.Equal(names, want) {
+		t.Errorf("ListExpiring() = %q, want %q", names, want)
+	}
+
+	kept, err := files.PruneExpired(ctx, []string{"files/a", "files/b", "files/gone"}, time.Hour)
+	if err != nil {
+		t.Fatalf("PruneExpired() failed: %v", err)
+	}
+	if want := []string{"files/a"}; !slices.Equal(kept, want) {
+		t.Errorf("PruneExpired() = %q, want %q", kept, want)
+	}
+
+	if err := files.DeleteMany(ctx, []string{"files/a", "files/gone"}); err != nil {
+		t.Errorf("DeleteMany() failed: %v", err)
+	}
+}
@@ -752,9 +752,9 @@ func TestFilesUploadFromPath(t *testing.T) {
 		},
 		{
 			name: "Error - Unknown MIME Type",
-			path: func() string { // Create a file with an unknown extension
+			path: func() string { // Create a file with an unknown extension and binary content
 				p := filepath.Join(tempDir, "file.unknownext")
-				_ = os.WriteFile(p, []byte("data"), 0644)
+				_ = os.WriteFile(p, []byte{0x00, 0x01, 0x02, 0x03}, 0644)
 				return p
 			}(),
 			config:     nil, // No MIME override
@@ -831,3 +831,170 @@ type errorReader struct{}
 func (r *errorReader) Read(p []byte) (n int, err error) {
 	return 0, fmt.Errorf("intentional read error")
 }
+
+func TestFilesUploadProgress(t *testing.T) {
+	ctx := context.Background()
+	mockServer := NewMockUploadServer(t)
+	ts := httptest.NewServer(mockServer)
+	defer ts.Close()
+	mockServer.baseURL = ts.URL
+
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		APIKey:      "test-api-key",
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	size := int64(maxChunkSize + 100)
+	want := []UploadProgress{
+		{BytesSent: maxChunkSize, TotalBytes: size, Chunk: 0, ChunkBytes: maxChunkSize},
+		{BytesSent: size, TotalBytes: size, Chunk: 1, ChunkBytes: 100},
+	}
+
+	t.Run("Upload", func(t *testing.T) {
+		var got []UploadProgress
+		config := &UploadFileConfig{MIMEType: "text/plain", Progress: func(p UploadProgress) { got = append(got, p) }}
+		if _, err := client.Files.Upload(ctx, strings.NewReader(strings.Repeat("A", int(size))), config); err != nil {
+			t.Fatalf("Upload() failed: %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("progress mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("UploadFromPath", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "video.txt")
+		if err := os.WriteFile(path, []byte(strings.Repeat("B", int(size))), 0644); err != nil {
+			t.Fatal(err)
+		}
+		var got []UploadProgress
+		config := &UploadFileConfig{Progress: func(p UploadProgress) { got = append(got, p) }}
+		if _, err := client.Files.UploadFromPath(ctx, path, config); err != nil {
+			t.Fatalf("UploadFromPath() failed: %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("progress mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestFilesDownloadTo(t *testing.T) {
+	content := strings.Repeat("video bytes ", 1000)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/test-version/files/filename:download" || r.URL.Query().Get("alt") != "media" {
+			http.Error(w, `{"error": {"code": 404, "message": "not found", "status": "NOT_FOUND"}}`, http.StatusNotFound)
+			return
+		}
+		io.WriteString(w, content)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(context.Background(), &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		APIKey:      "test-api-key",
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "test-version"},
+		HTTPClient:  ts.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		uri     DownloadURI
+		wantErr bool
+	}{
+		{name: "Name", uri: NewDownloadURIFromName("files/filename")},
+		{name: "GeneratedVideo", uri: &GeneratedVideo{Video: &Video{URI: ts.URL + "/test-version/files/filename:download?alt=media"}}},
+		{name: "NotFound", uri: NewDownloadURIFromName("files/missing"), wantErr: true},
+		{name: "EmptyURI", uri: &File{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			n, err := client.Files.DownloadTo(context.Background(), tt.uri, &b, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DownloadTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if b.Len() != 0 {
+					t.Errorf("DownloadTo() wrote %q, want nothing", b.String())
+				}
+				return
+			}
+			if b.String() != content || n != int64(len(content)) {
+				t.Errorf("DownloadTo() = %d, %d bytes written, want %d", n, b.Len(), len(content))
+			}
+		})
+	}
+}
+
+func TestFilesListAllGetDelete(t *testing.T) {
+	ctx := context.Background()
+	pages := map[string]string{
+		"":   `{"files": [{"name": "files/a"}], "nextPageToken": "p1"}`,
+		"p1": `{"files": [{"name": "files/b"}], "nextPageToken": "p2"}`,
+		"p2": `{"files": [{"name": "files/c"}]}`,
+	}
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1beta/files":
+			io.WriteString(w, pages[r.URL.Query().Get("pageToken")])
+		case r.Method == http.MethodGet && r.URL.Path == "/v1beta/files/a":
+			io.WriteString(w, `{"name": "files/a", "state": "ACTIVE"}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1beta/files/a":
+			io.WriteString(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		APIKey:      "test-api-key",
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	page, err := client.Files.List(ctx, &ListFilesConfig{PageSize: 1, PageToken: "p1"})
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	var names []string
+	for file, err := range page.All(ctx) {
+		if err != nil {
+			t.Fatalf("All() failed: %v", err)
+		}
+		names = append(names, file.Name)
+	}
+	if diff := cmp.Diff([]string{"files/b", "files/c"}, names); diff != "" {
+		t.Errorf("All() mismatch (-want +got):\n%s", diff)
+	}
+	wantRequests := []string{"GET /v1beta/files?pageSize=1&pageToken=p1", "GET /v1beta/files?pageSize=1&pageToken=p2"}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+
+	file, err := client.Files.Get(ctx, "files/a", nil)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if file.State != FileStateActive {
+		t.Errorf("Get() State = %q, want %q", file.State, FileStateActive)
+	}
+	if _, err := client.Files.Delete(ctx, "a", nil); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if _, err := client.Files.Get(ctx, "files/missing", nil); err == nil {
+		t.Errorf("Get() of a missing file succeeded, want error")
+	}
+}
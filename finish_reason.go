@@ -0,0 +1,128 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors wrapped by [FinishReasonError], one per abnormal [FinishReason].
+// Use [errors.Is] to branch on the outcome of a generation:
+//
+//	if errors.Is(resp.Err(), genai.ErrMaxTokens) {
+//		// Ask the model to continue.
+//	}
+var (
+	// ErrMaxTokens indicates that generation reached the configured maximum output tokens.
+	ErrMaxTokens = errors.New("generation reached the maximum output tokens")
+	// ErrSafety indicates that generation stopped because of safety violations.
+	ErrSafety = errors.New("generation stopped because of safety violations")
+	// ErrRecitation indicates that generation stopped because of potential recitation.
+	ErrRecitation = errors.New("generation stopped because of potential recitation")
+	// ErrLanguage indicates that generation stopped because of an unsupported language.
+	ErrLanguage = errors.New("generation stopped because of an unsupported language")
+	// ErrBlocklist indicates that generation stopped because of forbidden terms.
+	ErrBlocklist = errors.New("generation stopped because of forbidden terms")
+	// ErrProhibitedContent indicates that generation stopped because of prohibited content.
+	ErrProhibitedContent = errors.New("generation stopped because of prohibited content")
+	// ErrSPII indicates that generation stopped because of sensitive personally
+	// identifiable information.
+	ErrSPII = errors.New("generation stopped because of sensitive personally identifiable information")
+	// ErrMalformedFunctionCall indicates that the model generated an invalid function call.
+	ErrMalformedFunctionCall = errors.New("generation stopped because of a malformed function call")
+	// ErrImageSafety indicates that generated images have safety violations.
+	ErrImageSafety = errors.New("generation stopped because of image safety violations")
+	// ErrUnexpectedToolCall indicates that the model generated an invalid tool call.
+	ErrUnexpectedToolCall = errors.New("generation stopped because of an unexpected tool call")
+	// ErrOtherFinishReason indicates that generation stopped for another reason.
+	ErrOtherFinishReason = errors.New("generation stopped for an unknown reason")
+)
+
+var finishReasonErrors = map[FinishReason]error{
+	FinishReasonMaxTokens:             ErrMaxTokens,
+	FinishReasonSafety:                ErrSafety,
+	FinishReasonRecitation:            ErrRecitation,
+	FinishReasonLanguage:              ErrLanguage,
+	FinishReasonBlocklist:             ErrBlocklist,
+	FinishReasonProhibitedContent:     ErrProhibitedContent,
+	FinishReasonSPII:                  ErrSPII,
+	FinishReasonMalformedFunctionCall: ErrMalformedFunctionCall,
+	FinishReasonImageSafety:           ErrImageSafety,
+	FinishReasonUnexpectedToolCall:    ErrUnexpectedToolCall,
+	FinishReasonOther:                 ErrOtherFinishReason,
+}
+
+// FinishReasonError describes a candidate that stopped for a reason other than a
+// natural stopping point. It wraps the sentinel error matching its [FinishReason].
+type FinishReasonError struct {
+	// The reason why the model stopped generating tokens.
+	FinishReason FinishReason
+	// The message describing the finish reason, if any.
+	FinishMessage string
+	// The candidate that stopped.
+	Candidate *Candidate
+}
+
+// Error returns a string representation of the FinishReasonError.
+func (e FinishReasonError) Error() string {
+	if e.FinishMessage != "" {
+		return fmt.Sprintf("%v: %s", e.Unwrap(), e.FinishMessage)
+	}
+	return e.Unwrap().Error()
+}
+
+// Unwrap returns the sentinel error matching the finish reason.
+func (e FinishReasonError) Unwrap() error {
+	if err, ok := finishReasonErrors[e.FinishReason]; ok {
+		return err
+	}
+	return ErrOtherFinishReason
+}
+
+// Err returns a [FinishReasonError] if the candidate stopped for a reason other
+// than a natural stopping point, nil otherwise. Candidates that have not finished
+// yet, e.g. intermediate stream chunks, return nil.
+func (c *Candidate) Err() error {
+	if c == nil {
+		return nil
+	}
+	switch c.FinishReason {
+	case "", FinishReasonUnspecified, FinishReasonStop:
+		return nil
+	}
+	return FinishReasonError{FinishReason: c.FinishReason, FinishMessage: c.FinishMessage, Candidate: c}
+}
+
+// Err returns a [SafetyBlockedError] if the prompt was blocked, or the error of
+// the first candidate as reported by [Candidate.Err].
+func (r *GenerateContentResponse) Err() error {
+	if r == nil {
+		return nil
+	}
+	if err := promptBlockedError(r); err != nil {
+		return err
+	}
+	if len(r.Candidates) == 0 {
+		return nil
+	}
+	return r.Candidates[0].Err()
+}
+
+// IsTruncated reports whether the first candidate of the response stopped because
+// it reached the maximum number of output tokens.
+func IsTruncated(r *GenerateContentResponse) bool {
+	return errors.Is(r.Err(), ErrMaxTokens)
+}
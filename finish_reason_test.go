@@ -0,0 +1,98 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGenerateContentResponseErr(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  *GenerateContentResponse
+		wantErr   error
+		truncated bool
+	}{
+		{
+			name:     "Nil",
+			response: nil,
+		},
+		{
+			name:     "Stop",
+			response: createGenerateContentResponse([]*Candidate{{FinishReason: FinishReasonStop}}),
+		},
+		{
+			name:     "InProgress",
+			response: createGenerateContentResponse([]*Candidate{{Content: &Content{Parts: []*Part{{Text: "Hel"}}}}}),
+		},
+		{
+			name:      "MaxTokens",
+			response:  createGenerateContentResponse([]*Candidate{{FinishReason: FinishReasonMaxTokens}}),
+			wantErr:   ErrMaxTokens,
+			truncated: true,
+		},
+		{
+			name:     "Recitation",
+			response: createGenerateContentResponse([]*Candidate{{FinishReason: FinishReasonRecitation}}),
+			wantErr:  ErrRecitation,
+		},
+		{
+			name:     "UnknownReason",
+			response: createGenerateContentResponse([]*Candidate{{FinishReason: "SOMETHING_NEW"}}),
+			wantErr:  ErrOtherFinishReason,
+		},
+		{
+			name:     "FirstCandidateOnly",
+			response: createGenerateContentResponse([]*Candidate{{FinishReason: FinishReasonStop}, {FinishReason: FinishReasonSafety}}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.response.Err()
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("Err() = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Err() = %v, want %v", err, tt.wantErr)
+			}
+			if got := IsTruncated(tt.response); got != tt.truncated {
+				t.Errorf("IsTruncated() = %v, want %v", got, tt.truncated)
+			}
+		})
+	}
+
+	t.Run("FinishReasonError", func(t *testing.T) {
+		candidate := &Candidate{FinishReason: FinishReasonSafety, FinishMessage: "unsafe"}
+		var finishErr FinishReasonError
+		if !errors.As(candidate.Err(), &finishErr) {
+			t.Fatalf("Err() = %v, want FinishReasonError", candidate.Err())
+		}
+		if finishErr.Candidate != candidate {
+			t.Errorf("FinishReasonError.Candidate = %v, want %v", finishErr.Candidate, candidate)
+		}
+		if got, want := finishErr.Error(), ErrSafety.Error()+": unsafe"; got != want {
+			t.Errorf("Error() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("PromptBlocked", func(t *testing.T) {
+		response := &GenerateContentResponse{PromptFeedback: &GenerateContentResponsePromptFeedback{BlockReason: BlockedReasonOther}}
+		var blockedErr SafetyBlockedError
+		if !errors.As(response.Err(), &blockedErr) {
+			t.Errorf("Err() = %v, want SafetyBlockedError", response.Err())
+		}
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// FunctionHandler executes a [FunctionCall] with the given args and returns the
+// payload for the matching [FunctionResponse].
+type FunctionHandler func(ctx context.Context, args map[string]any) (map[string]any, error)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// NewFunctionDeclarationFromFunc builds a [FunctionDeclaration] from a Go function
+// and returns a [FunctionHandler] that invokes it.
+//
+// fn must be a function with one of the following shapes:
+//
+//	func([ctx context.Context,] [args T]) ([result R,] [err error])
+//
+// where T is a struct or a pointer to a struct. The parameters schema is derived
+// from the exported fields of T: the property names follow the `json` struct tag,
+// fields tagged with `omitempty` are optional and the `description` struct tag is
+// used as the property description. For example:
+//
+//	type weatherArgs struct {
+//		City string `json:"city" description:"The city to get the weather for."`
+//		Unit string `json:"unit,omitempty" description:"Either celsius or fahrenheit."`
+//	}
+//
+//	decl, handler, err := genai.NewFunctionDeclarationFromFunc("get_weather", "Returns the current weather.",
+//		func(ctx context.Context, args weatherArgs) (map[string]any, error) { ... })
+//
+// The handler unmarshals the [FunctionCall] args into T before calling fn. If R
+// marshals to a JSON object, it is used as the response as is. Otherwise the value
+// is returned under the "output" key.
+func NewFunctionDeclarationFromFunc(name, description string, fn any) (*FunctionDeclaration, FunctionHandler, error) {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func || fv.IsNil() {
+		return nil, nil, fmt.Errorf("NewFunctionDeclarationFromFunc: fn must be a non-nil function, got %T", fn)
+	}
+	ft := fv.Type()
+	if ft.IsVariadic() {
+		return nil, nil, fmt.Errorf("NewFunctionDeclarationFromFunc: variadic function %s is not supported", ft)
+	}
+
+	hasContext := ft.NumIn() > 0 && ft.In(0) == contextType
+	var argsType reflect.Type
+	switch n := ft.NumIn(); {
+	case hasContext && n == 2:
+		argsType = ft.In(1)
+	case !hasContext && n == 1:
+		argsType = ft.In(0)
+	case n > 2 || (!hasContext && n == 2):
+		return nil, nil, fmt.Errorf("NewFunctionDeclarationFromFunc: function %s must take at most a context.Context and an args struct", ft)
+	}
+	if argsType != nil {
+		structType := argsType
+		if structType.Kind() == reflect.Pointer {
+			structType = structType.Elem()
+		}
+		if structType.Kind() != reflect.Struct {
+			return nil, nil, fmt.Errorf("NewFunctionDeclarationFromFunc: args type %s must be a struct or a pointer to a struct", argsType)
+		}
+	}
+
+	hasResult, hasError := false, false
+	switch ft.NumOut() {
+	case 0:
+	case 1:
+		if ft.Out(0) == errorType {
+			hasError = true
+		} else {
+			hasResult = true
+		}
+	case 2:
+		if ft.Out(1) != errorType {
+			return nil, nil, fmt.Errorf("NewFunctionDeclarationFromFunc: the second result of function %s must be an error", ft)
+		}
+		hasResult, hasError = true, true
+	default:
+		return nil, nil, fmt.Errorf("NewFunctionDeclarationFromFunc: function %s must return at most a result and an error", ft)
+	}
+
+	decl := &FunctionDeclaration{Name: name, Description: description}
+	if argsType != nil {
+		schema, err := schemaFromType(argsType)
+		if err != nil {
+			return nil, nil, fmt.Errorf("NewFunctionDeclarationFromFunc: %w", err)
+		}
+		decl.Parameters = schema
+	}
+
+	handler := func(ctx context.Context, args map[string]any) (map[string]any, error) {
+		var in []reflect.Value
+		if hasContext {
+			in = append(in, reflect.ValueOf(&ctx).Elem())
+		}
+		if argsType != nil {
+			argsValue := reflect.New(argsType)
+			b, err := json.Marshal(args)
+			if err != nil {
+				return nil, fmt.Errorf("function %s: error marshalling args: %w", name, err)
+			}
+			if err := json.Unmarshal(b, argsValue.Interface()); err != nil {
+				return nil, fmt.Errorf("function %s: error unmarshalling args %s: %w", name, b, err)
+			}
+			in = append(in, argsValue.Elem())
+		}
+		out := fv.Call(in)
+		if hasError {
+			if err, _ := out[len(out)-1].Interface().(error); err != nil {
+				return nil, err
+			}
+		}
+		if !hasResult {
+			return map[string]any{}, nil
+		}
+		return functionResponseFromValue(out[0].Interface())
+	}
+	return decl, handler, nil
+}
+
+// functionResponseFromValue converts the result of a Go function to a [FunctionResponse]
+// payload.
+func functionResponseFromValue(v any) (map[string]any, error) {
+	if m, ok := v.(map[string]any); ok {
+		return m, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("functionResponseFromValue: error marshalling result %#v: %w", v, err)
+	}
+	var decoded any
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return nil, fmt.Errorf("functionResponseFromValue: error unmarshalling result %s: %w", b, err)
+	}
+	if m, ok := decoded.(map[string]any); ok {
+		return m, nil
+	}
+	return map[string]any{"output": decoded}, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testWeatherArgs struct {
+	City  string   `json:"city" description:"The city name."`
+	Unit  string   `json:"unit,omitempty"`
+	Days  int      `json:"days"`
+	Tags  []string `json:"tags,omitempty"`
+	inner string
+}
+
+type testWeatherResult struct {
+	Temperature float64 `json:"temperature"`
+}
+
+func TestNewFunctionDeclarationFromFunc(t *testing.T) {
+	t.Run("Declaration", func(t *testing.T) {
+		decl, _, err := NewFunctionDeclarationFromFunc("get_weather", "Gets the weather.",
+			func(ctx context.Context, args testWeatherArgs) (testWeatherResult, error) {
+				return testWeatherResult{}, nil
+			})
+		if err != nil {
+			t.Fatalf("NewFunctionDeclarationFromFunc() failed: %v", err)
+		}
+		want := &FunctionDeclaration{
+			Name:        "get_weather",
+			Description: "Gets the weather.",
+			Parameters: &Schema{
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"city": {Type: TypeString, Description: "The city name."},
+					"unit": {Type: TypeString},
+					"days": {Type: TypeInteger, Format: "int64"},
+					"tags": {Type: TypeArray, Items: &Schema{Type: TypeString}},
+				},
+				PropertyOrdering: []string{"city", "unit", "days", "tags"},
+				Required:         []string{"city", "days"},
+			},
+		}
+		if diff := cmp.Diff(want, decl); diff != "" {
+			t.Errorf("NewFunctionDeclarationFromFunc() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("HandlerStructResult", func(t *testing.T) {
+		_, handler, err := NewFunctionDeclarationFromFunc("get_weather", "",
+			func(ctx context.Context, args *testWeatherArgs) (testWeatherResult, error) {
+				if args.City != "Paris" || args.Days != 2 {
+					t.Errorf("unexpected args: %#v", args)
+				}
+				return testWeatherResult{Temperature: 21.5}, nil
+			})
+		if err != nil {
+			t.Fatalf("NewFunctionDeclarationFromFunc() failed: %v", err)
+		}
+		got, err := handler(context.Background(), map[string]any{"city": "Paris", "days": 2.0})
+		if err != nil {
+			t.Fatalf("handler() failed: %v", err)
+		}
+		if diff := cmp.Diff(map[string]any{"temperature": 21.5}, got); diff != "" {
+			t.Errorf("handler() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("HandlerScalarResult", func(t *testing.T) {
+		_, handler, err := NewFunctionDeclarationFromFunc("now", "", func() string { return "noon" })
+		if err != nil {
+			t.Fatalf("NewFunctionDeclarationFromFunc() failed: %v", err)
+		}
+		got, err := handler(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("handler() failed: %v", err)
+		}
+		if diff := cmp.Diff(map[string]any{"output": "noon"}, got); diff != "" {
+			t.Errorf("handler() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("HandlerError", func(t *testing.T) {
+		wantErr := errors.New("boom")
+		_, handler, err := NewFunctionDeclarationFromFunc("fail", "", func(ctx context.Context) error { return wantErr })
+		if err != nil {
+			t.Fatalf("NewFunctionDeclarationFromFunc() failed: %v", err)
+		}
+		if _, err := handler(context.Background(), nil); !errors.Is(err, wantErr) {
+			t.Errorf("handler() error = %v, want %v", err, wantErr)
+		}
+	})
+
+	t.Run("InvalidFunctions", func(t *testing.T) {
+		for _, fn := range []any{
+			nil,
+			"not a function",
+			func(a, b string) {},
+			func(s string) {},
+			func() (int, int) { return 0, 0 },
+			func(args struct{ C chan int }) {},
+		} {
+			if _, _, err := NewFunctionDeclarationFromFunc("f", "", fn); err == nil {
+				t.Errorf("NewFunctionDeclarationFromFunc(%T) succeeded, want error", fn)
+			}
+		}
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// gcsBaseURL is the endpoint of the Cloud Storage JSON API.
+var gcsBaseURL = "https://storage.googleapis.com/"
+
+// UploadToGCSConfig is the optional configuration of [Files.UploadToGCS].
+type UploadToGCSConfig struct {
+	// Optional. The name of the object, relative to the prefix of the staging
+	// location. Defaults to the SHA-256 hash of the content followed by the base name
+	// of the file, so that uploading the same file again overwrites the same object.
+	ObjectName string
+	// Optional. The MIME type of the file. Detected from the file if empty, see
+	// [DetectMIMEType].
+	MIMEType string
+}
+
+// UploadToGCS uploads a local file to the Cloud Storage staging location of the
+// client, see [ClientConfig.GCSStagingURI], and returns a [FileData] part
+// referencing its gs:// URI. The upload is authenticated with the HTTP client of
+// the client, i.e. with its credentials on BackendVertexAI. A
+// [ChecksumMismatchError] is returned if the CRC32C checksum of the stored object
+// differs from the checksum of the file.
+//
+//	client, _ := genai.NewClient(ctx, &genai.ClientConfig{
+//		Backend:       genai.BackendVertexAI,
+//		Project:       "my-project",
+//		Location:      "us-central1",
+//		GCSStagingURI: "gs://my-bucket/genai",
+//	})
+//	part, _ := client.Files.UploadToGCS(ctx, "video.mp4", nil)
+func (m Files) UploadToGCS(ctx context.Context, filePath string, config *UploadToGCSConfig) (*Part, error) {
+	if config == nil {
+		config = &UploadToGCSConfig{}
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is not a valid file path", filePath)
+	}
+
+	mimeType := config.MIMEType
+	if mimeType == "" {
+		head := make([]byte, sniffLen)
+		n, _ := f.ReadAt(head, 0)
+		if mimeType = DetectMIMEType(filePath, head[:n]); mimeType == "" {
+			return nil, fmt.Errorf("could not determine the MIME type of %s, set UploadToGCSConfig.MIMEType", filePath)
+		}
+	}
+	objectName := config.ObjectName
+	if objectName == "" {
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err != nil {
+			return nil, err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		objectName = hex.EncodeToString(h.Sum(nil)) + "-" + filepath.Base(filePath)
+	}
+
+	fileData, err := m.uploadToGCS(ctx, f, info.Size(), objectName, mimeType)
+	if err != nil {
+		return nil, err
+	}
+	return &Part{FileData: fileData}, nil
+}
+
+// UploadBlobToGCS uploads the data of a blob to the Cloud Storage staging location
+// of the client, see [ClientConfig.GCSStagingURI], and returns the reference to the
+// uploaded object. The object is named after the SHA-256 hash of the data. It is
+// the default upload of an [InlineDataOffload] policy on BackendVertexAI:
+//
+//	client, _ := genai.NewClient(ctx, &genai.ClientConfig{
+//		Backend:           genai.BackendVertexAI,
+//		Project:           "my-project",
+//		Location:          "us-central1",
+//		GCSStagingURI:     "gs://my-bucket/genai",
+//		InlineDataOffload: &genai.InlineDataOffload{},
+//	})
+func (m Files) UploadBlobToGCS(ctx context.Context, blob *Blob) (*FileData, error) {
+	if blob == nil {
+		return nil, fmt.Errorf("blob is nil")
+	}
+	sum := sha256.Sum256(blob.Data)
+	return m.uploadToGCS(ctx, bytes.NewReader(blob.Data), int64(len(blob.Data)), hex.EncodeToString(sum[:]), blob.MIMEType)
+}
+
+// uploadToGCS uploads the content of r to the given object of the staging location
+// with a single media upload request of the Cloud Storage JSON API, and verifies the
+// CRC32C checksum of the stored object.
+func (m Files) uploadToGCS(ctx context.Context, r io.Reader, size int64, objectName, mimeType string) (*FileData, error) {
+	bucket, prefix, err := parseGCSURI(m.apiClient.clientConfig.GCSStagingURI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ClientConfig.GCSStagingURI: %w", err)
+	}
+	if prefix != "" {
+		objectName = path.Join(prefix, objectName)
+	}
+
+	u := fmt.Sprintf("%supload/storage/v1/b/%s/o?%s", gcsBaseURL, url.PathEscape(bucket),
+		url.Values{"uploadType": {"media"}, "name": {objectName}}.Encode())
+	checksums := newChecksumWriter()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, io.TeeReader(r, checksums))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	req.Header = sdkHeader(ctx, m.apiClient)
+	// Cloud Storage authenticates with the credentials of the HTTP client only.
+	req.Header.Del("x-goog-api-key")
+	req.Header.Set("Content-Type", mimeType)
+	resp, err := doRequest(m.apiClient, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if !httpStatusOk(resp) {
+		return nil, newAPIError(resp)
+	}
+	var object struct {
+		Bucket      string `json:"bucket"`
+		Name        string `json:"name"`
+		ContentType string `json:"contentType"`
+		CRC32C      string `json:"crc32c"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&object); err != nil {
+		return nil, fmt.Errorf("error decoding Cloud Storage object: %w", err)
+	}
+	if object.Bucket == "" || object.Name == "" {
+		return nil, fmt.Errorf("Cloud Storage response has no object name")
+	}
+	if object.CRC32C != "" {
+		if err := checksums.checksums().verifyCRC32C("gs://"+object.Bucket+"/"+object.Name, object.CRC32C); err != nil {
+			return nil, err
+		}
+	}
+	if object.ContentType != "" {
+		mimeType = object.ContentType
+	}
+	return &FileData{FileURI: "gs://" + object.Bucket + "/" + object.Name, MIMEType: mimeType}, nil
+}
+
+// parseGCSURI splits a gs:// URI into its bucket and object prefix.
+func parseGCSURI(uri string) (bucket, prefix string, err error) {
+	rest, ok := strings.CutPrefix(uri, "gs://")
+	if !ok {
+		return "", "", fmt.Errorf("%q is not a gs:// URI", uri)
+	}
+	bucket, prefix, _ = strings.Cut(rest, "/")
+	if bucket == "" {
+		return "", "", fmt.Errorf("%q has no bucket", uri)
+	}
+	return bucket, strings.Trim(prefix, "/"), nil
+}
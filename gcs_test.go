@@ -0,0 +1,108 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFilesUploadToGCS(t *testing.T) {
+	ctx := context.Background()
+	type object struct {
+		bucket, name, contentType, data string
+	}
+	var objects []object
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bucket, ok := strings.CutPrefix(r.URL.Path, "/upload/storage/v1/b/")
+		if r.Method != http.MethodPost || !ok || r.URL.Query().Get("uploadType") != "media" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("x-goog-api-key") != "" {
+			t.Errorf("request has an API key header")
+		}
+		data, _ := io.ReadAll(r.Body)
+		o := object{bucket: strings.TrimSuffix(bucket, "/o"), name: r.URL.Query().Get("name"), contentType: r.Header.Get("Content-Type"), data: string(data)}
+		objects = append(objects, o)
+		crc := make([]byte, 4)
+		binary.BigEndian.PutUint32(crc, crc32.Checksum(data, crc32cTable))
+		json.NewEncoder(w).Encode(map[string]string{"bucket": o.bucket, "name": o.name, "contentType": o.contentType, "crc32c": base64.StdEncoding.EncodeToString(crc)})
+	}))
+	defer ts.Close()
+	defer func(u string) { gcsBaseURL = u }(gcsBaseURL)
+	gcsBaseURL = ts.URL + "/"
+
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:       BackendGeminiAPI,
+		APIKey:        "test-api-key",
+		HTTPClient:    ts.Client(),
+		GCSStagingURI: "gs://staging/genai/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	part, err := client.Files.UploadToGCS(ctx, path, nil)
+	if err != nil {
+		t.Fatalf("UploadToGCS() failed: %v", err)
+	}
+	sum := sha256.Sum256([]byte("hello"))
+	name := "genai/" + hex.EncodeToString(sum[:]) + "-notes.txt"
+	want := &Part{FileData: &FileData{FileURI: "gs://staging/" + name, MIMEType: "text/plain"}}
+	if diff := cmp.Diff(want, part); diff != "" {
+		t.Errorf("UploadToGCS() mismatch (-want +got):\n%s", diff)
+	}
+
+	fileData, err := client.Files.UploadBlobToGCS(ctx, &Blob{Data: pngHeader, MIMEType: "image/png"})
+	if err != nil {
+		t.Fatalf("UploadBlobToGCS() failed: %v", err)
+	}
+	sum = sha256.Sum256(pngHeader)
+	if diff := cmp.Diff(&FileData{FileURI: "gs://staging/genai/" + hex.EncodeToString(sum[:]), MIMEType: "image/png"}, fileData); diff != "" {
+		t.Errorf("UploadBlobToGCS() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantObjects := []object{
+		{bucket: "staging", name: name, contentType: "text/plain", data: "hello"},
+		{bucket: "staging", name: "genai/" + hex.EncodeToString(sum[:]), contentType: "image/png", data: string(pngHeader)},
+	}
+	if diff := cmp.Diff(wantObjects, objects, cmp.AllowUnexported(object{})); diff != "" {
+		t.Errorf("uploaded objects mismatch (-want +got):\n%s", diff)
+	}
+
+	client.Files.apiClient.clientConfig.GCSStagingURI = "staging"
+	if _, err := client.Files.UploadToGCS(ctx, path, nil); err == nil {
+		t.Errorf("UploadToGCS() with an invalid staging URI succeeded, want error")
+	}
+}
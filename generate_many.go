@@ -0,0 +1,167 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxConcurrentRequests = 8
+	defaultMaxRequestAttempts    = 3
+)
+
+// ParallelOptions configures [GenerateMany].
+type ParallelOptions struct {
+	// Optional. The maximum number of requests in flight at a time. Defaults to 8.
+	MaxConcurrency int
+	// Optional. The maximum number of requests started per second, retries
+	// included, shared by all the concurrent requests. Unlimited if zero.
+	RequestsPerSecond float64
+	// Optional. The maximum number of attempts of a request, including the first
+	// one. Only requests failing with a rate limiting error or a server error are
+	// retried. Defaults to 3.
+	MaxAttempts int
+	// Optional. The delay before the first retry of a request, doubled at every
+	// retry. Defaults to 1 second.
+	InitialRetryDelay time.Duration
+}
+
+// GenerateMany generates content with the model for every contents of the slice,
+// with the same config, and returns the responses in the order of the contents. It
+// is meant for offline enrichment jobs too small or too urgent for a batch job, see
+// [Batches.Create]:
+//
+//	contents := [][]*genai.Content{genai.Text("Summarize: ..."), genai.Text("Summarize: ...")}
+//	responses, err := genai.GenerateMany(ctx, client.Models, "gemini-2.0-flash", contents, nil,
+//		genai.ParallelOptions{MaxConcurrency: 4, RequestsPerSecond: 10})
+//
+// If some requests fail, the responses of the other requests are returned, with nil
+// responses for the failed ones, along with an error joining the error of every
+// failed request. The requests not started before the context is done fail with
+// the error of the context.
+func GenerateMany(ctx context.Context, models *Models, model string, contents [][]*Content, config *GenerateContentConfig, opts ParallelOptions) ([]*GenerateContentResponse, error) {
+	responses, errs := generateConcurrently(ctx, len(contents), opts, func(i int) (*GenerateContentResponse, error) {
+		return models.GenerateContent(ctx, model, contents[i], config)
+	})
+	for i, err := range errs {
+		if err != nil {
+			errs[i] = fmt.Errorf("request %d: %w", i, err)
+		}
+	}
+	return responses, errors.Join(errs...)
+}
+
+// generateConcurrently calls generate for every index up to n as configured by opts,
+// and returns the responses and the errors of the calls by index.
+func generateConcurrently(ctx context.Context, n int, opts ParallelOptions, generate func(i int) (*GenerateContentResponse, error)) ([]*GenerateContentResponse, []error) {
+	maxConcurrency := opts.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultMaxConcurrentRequests
+	}
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxRequestAttempts
+	}
+	retryDelay := opts.InitialRetryDelay
+	if retryDelay <= 0 {
+		retryDelay = initialRetryDelay
+	}
+	limiter := newRateLimiter(opts.RequestsPerSecond)
+
+	responses := make([]*GenerateContentResponse, n)
+	errs := runConcurrently(ctx, n, maxConcurrency, func(i int) error {
+		var err error
+		delay := retryDelay
+		for attempt := 0; attempt < maxAttempts; attempt++ {
+			if attempt > 0 {
+				select {
+				case <-ctx.Done():
+					return fmt.Errorf("aborted while waiting to retry: %w", err)
+				case <-time.After(delay):
+				}
+				delay *= delayMultiplier
+			}
+			if err = limiter.wait(ctx); err != nil {
+				return err
+			}
+			var resp *GenerateContentResponse
+			if resp, err = generate(i); err == nil {
+				responses[i] = resp
+				return nil
+			}
+			if !isRetryable(err) || ctx.Err() != nil {
+				break
+			}
+		}
+		return err
+	})
+	return responses, errs
+}
+
+// isRetryable reports whether err is an API error for a rate limited request or a
+// server error.
+func isRetryable(err error) bool {
+	var apiErr APIError
+	return errors.As(err, &apiErr) && (apiErr.Code == http.StatusTooManyRequests || apiErr.Code >= http.StatusInternalServerError)
+}
+
+// rateLimiter spaces the requests of concurrent callers evenly over time.
+type rateLimiter struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// newRateLimiter returns a limiter allowing perSecond requests per second, or an
+// unlimited one if perSecond is not positive.
+func newRateLimiter(perSecond float64) *rateLimiter {
+	if perSecond <= 0 {
+		return &rateLimiter{}
+	}
+	return &rateLimiter{interval: time.Duration(float64(time.Second) / perSecond)}
+}
+
+// wait blocks until the next request is allowed, or the context is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l.interval == 0 {
+		return ctx.Err()
+	}
+	l.mu.Lock()
+	now := time.Now()
+	slot := now
+	if l.next.After(now) {
+		slot = l.next
+	}
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	if d := slot.Sub(now); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,118 @@
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateMany(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	var inFlight, maxInFlight atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		var body struct {
+			Contents []*Content `json:"contents"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		text := body.Contents[0].Parts[0].Text
+		mu.Lock()
+		attempts[text]++
+		attempt := attempts[text]
+		mu.Unlock()
+		switch {
+		case text == "flaky" && attempt == 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error": {"code": 429, "message": "quota exceeded"}}`))
+		case text == "invalid":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"code": 400, "message": "invalid argument"}}`))
+		default:
+			fmt.Fprintf(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "echo %s"}]}}]}`, text)
+		}
+	}))
+	defer ts.Close()
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		APIKey:      "test-api-key",
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	texts := []string{"a", "flaky", "b", "invalid", "c", "d"}
+	var contents [][]*Content
+	for _, text := range texts {
+		contents = append(contents, Text(text))
+	}
+	responses, err := GenerateMany(ctx, client.Models, "gemini-2.0-flash", contents, nil, ParallelOptions{
+		MaxConcurrency:    2,
+		RequestsPerSecond: 1000,
+		InitialRetryDelay: time.Millisecond,
+	})
+	var apiErr APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		t.Errorf("GenerateMany() error = %v, want a 400 API error", err)
+	}
+
+	var got []string
+	for _, resp := range responses {
+		if resp == nil {
+			got = append(got, "")
+			continue
+		}
+		got = append(got, resp.Text())
+	}
+	want := []string{"echo a", "echo flaky", "echo b", "", "echo c", "echo d"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateMany() mismatch (-want +got):\n%s", diff)
+	}
+	if attempts["flaky"] != 2 || attempts["invalid"] != 1 {
+		t.Errorf("attempts = %v, want 2 attempts of flaky and 1 of invalid", attempts)
+	}
+	if m := maxInFlight.Load(); m > 2 {
+		t.Errorf("%d requests in flight, want at most 2", m)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	ctx := context.Background()
+	l := newRateLimiter(100)
+	start := time.Now()
+	for range 5 {
+		if err := l.wait(ctx); err != nil {
+			t.Fatalf("wait() failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("5 waits took %v, want at least 40ms", elapsed)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := newRateLimiter(0).wait(cancelled); !errors.Is(err, context.Canceled) {
+		t.Errorf("wait() = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"google.golang.org/genai"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Client calls a ModelProxy service with the types of the SDK:
+//
+//	conn, err := grpc.NewClient("model-proxy.internal:443", grpc.WithTransportCredentials(creds))
+//	if err != nil {
+//		return err
+//	}
+//	defer conn.Close()
+//	proxy := grpcproxy.NewClient(conn)
+//	resp, err := proxy.GenerateContent(ctx, "gemini-2.5-flash", genai.Text("Hello"), nil)
+//
+// The errors of the server are returned as gRPC status errors.
+type Client struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewClient returns a client calling the service through cc.
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{cc: cc}
+}
+
+// GenerateContent calls [genai.Models.GenerateContent] through the service.
+func (c *Client) GenerateContent(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig, opts ...grpc.CallOption) (*genai.GenerateContentResponse, error) {
+	in, err := encodeMessage(&generateContentRequest{Model: model, Contents: contents, Config: config})
+	if err != nil {
+		return nil, err
+	}
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, generateContentMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	resp := new(genai.GenerateContentResponse)
+	if err := decodeMessage(out, resp); err != nil {
+		return nil, fmt.Errorf("GenerateContent: error decoding response: %w", err)
+	}
+	return resp, nil
+}
+
+// GenerateContentStream calls [genai.Models.GenerateContentStream] through the
+// service.
+func (c *Client) GenerateContentStream(ctx context.Context, model string, contents []*genai.Content, config *genai.GenerateContentConfig, opts ...grpc.CallOption) iter.Seq2[*genai.GenerateContentResponse, error] {
+	return func(yield func(*genai.GenerateContentResponse, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		// Cancelling the context closes the stream if the caller stops early.
+		defer cancel()
+		in, err := encodeMessage(&generateContentRequest{Model: model, Contents: contents, Config: config})
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		stream, err := c.cc.NewStream(ctx, &ModelProxy_ServiceDesc.Streams[0], generateContentStreamMethod, opts...)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		if err := stream.SendMsg(in); err != nil {
+			yield(nil, err)
+			return
+		}
+		if err := stream.CloseSend(); err != nil {
+			yield(nil, err)
+			return
+		}
+		for {
+			out := new(structpb.Struct)
+			if err := stream.RecvMsg(out); err != nil {
+				if !errors.Is(err, io.EOF) {
+					yield(nil, err)
+				}
+				return
+			}
+			resp := new(genai.GenerateContentResponse)
+			if err := decodeMessage(out, resp); err != nil {
+				yield(nil, fmt.Errorf("GenerateContentStream: error decoding response: %w", err))
+				return
+			}
+			if !yield(resp, nil) {
+				return
+			}
+		}
+	}
+}
+
+// EmbedContent calls [genai.Models.EmbedContent] through the service.
+func (c *Client) EmbedContent(ctx context.Context, model string, contents []*genai.Content, config *genai.EmbedContentConfig, opts ...grpc.CallOption) (*genai.EmbedContentResponse, error) {
+	in, err := encodeMessage(&embedContentRequest{Model: model, Contents: contents, Config: config})
+	if err != nil {
+		return nil, err
+	}
+	out := new(structpb.Struct)
+	if err := c.cc.Invoke(ctx, embedContentMethod, in, out, opts...); err != nil {
+		return nil, err
+	}
+	resp := new(genai.EmbedContentResponse)
+	if err := decodeMessage(out, resp); err != nil {
+		return nil, fmt.Errorf("EmbedContent: error decoding response: %w", err)
+	}
+	return resp, nil
+}
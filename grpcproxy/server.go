@@ -0,0 +1,241 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpcproxy serves the models of a [genai.Client] over gRPC, so that an
+// organization can keep its API keys, credentials and quota behind an internal
+// service:
+//
+//	client, err := genai.NewClient(ctx, nil)
+//	if err != nil {
+//		return err
+//	}
+//	s := grpc.NewServer()
+//	grpcproxy.RegisterModelProxyServer(s, grpcproxy.NewServer(client, &grpcproxy.ServerOptions{
+//		AllowedModels: []string{"gemini-2.5-flash", "text-embedding-004"},
+//	}))
+//	s.Serve(lis)
+//
+// The service is defined in proxy.proto. Its messages are google.protobuf.Struct
+// values holding the JSON encoding of the requests and responses of the SDK, so
+// that clients can be generated in any language without a copy of the API
+// definitions. Go programs can call the service with a [Client].
+package grpcproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"google.golang.org/genai"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ServerOptions configures a [Server].
+type ServerOptions struct {
+	// Optional. The models which can be called through the server. All the models
+	// can be called if empty.
+	AllowedModels []string
+	// Optional. Authorizes a call before it is forwarded to the model. The method is
+	// the name of the RPC, e.g. "GenerateContent". A returned gRPC status error is
+	// returned to the client as is, other errors are returned as PermissionDenied.
+	Authorize func(ctx context.Context, method, model string) error
+}
+
+// Server implements [ModelProxyServer] by forwarding the calls to a [genai.Client].
+type Server struct {
+	client *genai.Client
+	opts   ServerOptions
+}
+
+var _ ModelProxyServer = (*Server)(nil)
+
+// NewServer returns a server forwarding the calls to client. opts may be nil.
+func NewServer(client *genai.Client, opts *ServerOptions) *Server {
+	s := &Server{client: client}
+	if opts != nil {
+		s.opts = *opts
+	}
+	return s
+}
+
+type generateContentRequest struct {
+	Model    string                       `json:"model"`
+	Contents []*genai.Content             `json:"contents"`
+	Config   *genai.GenerateContentConfig `json:"config,omitempty"`
+}
+
+type embedContentRequest struct {
+	Model    string                    `json:"model"`
+	Contents []*genai.Content          `json:"contents"`
+	Config   *genai.EmbedContentConfig `json:"config,omitempty"`
+}
+
+// GenerateContent implements [ModelProxyServer].
+func (s *Server) GenerateContent(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	var req generateContentRequest
+	if err := s.decodeRequest(ctx, "GenerateContent", in, &req, &req.Model); err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Models.GenerateContent(ctx, req.Model, req.Contents, req.Config)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return encodeMessage(resp)
+}
+
+// GenerateContentStream implements [ModelProxyServer].
+func (s *Server) GenerateContentStream(in *structpb.Struct, stream ModelProxy_GenerateContentStreamServer) error {
+	ctx := stream.Context()
+	var req generateContentRequest
+	if err := s.decodeRequest(ctx, "GenerateContentStream", in, &req, &req.Model); err != nil {
+		return err
+	}
+	for resp, err := range s.client.Models.GenerateContentStream(ctx, req.Model, req.Contents, req.Config) {
+		if err != nil {
+			return statusError(err)
+		}
+		out, err := encodeMessage(resp)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EmbedContent implements [ModelProxyServer].
+func (s *Server) EmbedContent(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	var req embedContentRequest
+	if err := s.decodeRequest(ctx, "EmbedContent", in, &req, &req.Model); err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Models.EmbedContent(ctx, req.Model, req.Contents, req.Config)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return encodeMessage(resp)
+}
+
+// decodeRequest decodes the request of a call to req, and checks that the call
+// to its model is allowed. Requests setting the HTTP options of their config are
+// refused: the options would let callers send the requests, and the credentials
+// of the server, to another host.
+func (s *Server) decodeRequest(ctx context.Context, method string, in *structpb.Struct, req any, model *string) error {
+	if config := in.GetFields()["config"].GetStructValue(); config != nil {
+		if _, ok := config.GetFields()["httpOptions"]; ok {
+			return status.Error(codes.InvalidArgument, "invalid request: config.httpOptions is not allowed")
+		}
+	}
+	if err := decodeMessage(in, req); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+	if *model == "" {
+		return status.Error(codes.InvalidArgument, "invalid request: model is required")
+	}
+	if len(s.opts.AllowedModels) > 0 && !slices.Contains(s.opts.AllowedModels, *model) {
+		return status.Errorf(codes.PermissionDenied, "model %s is not allowed", *model)
+	}
+	if s.opts.Authorize != nil {
+		if err := s.opts.Authorize(ctx, method, *model); err != nil {
+			if _, ok := status.FromError(err); ok {
+				return err
+			}
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+	}
+	return nil
+}
+
+// decodeMessage decodes the JSON encoding of a message to v.
+func decodeMessage(in *structpb.Struct, v any) error {
+	data, err := json.Marshal(in.AsMap())
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// encodeMessage returns the message holding the JSON encoding of v.
+func encodeMessage(v any) (*structpb.Struct, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error encoding message: %v", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, status.Errorf(codes.Internal, "error encoding message: %v", err)
+	}
+	out, err := structpb.NewStruct(m)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error encoding message: %v", err)
+	}
+	return out, nil
+}
+
+// statusError converts an error of the client to a gRPC status error. The code
+// of an [genai.APIError] is its status, e.g. RESOURCE_EXHAUSTED, or is derived
+// from its HTTP status code.
+func statusError(err error) error {
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) {
+		var code codes.Code
+		if code.UnmarshalJSON([]byte(strconv.Quote(apiErr.Status))) != nil || apiErr.Status == "" {
+			code = httpStatusCode(apiErr.Code)
+		}
+		return status.Error(code, apiErr.Message)
+	}
+	if s := status.FromContextError(err); s.Code() != codes.Unknown {
+		return s.Err()
+	}
+	return status.Error(codes.Internal, fmt.Sprintf("error calling the model: %v", err))
+}
+
+// httpStatusCode returns the gRPC code of an HTTP status code, following
+// https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto.
+func httpStatusCode(code int) codes.Code {
+	switch code {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case 499:
+		return codes.Canceled
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	if code >= 500 {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
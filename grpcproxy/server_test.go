@@ -0,0 +1,227 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type upstreamRequest struct {
+	Path string
+	Body map[string]any
+}
+
+// newTestProxy starts a proxy server in front of a fake Gemini API served by
+// handler, and returns a client of the proxy.
+func newTestProxy(t *testing.T, opts *ServerOptions, requests *[]upstreamRequest, handler http.HandlerFunc) *Client {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := upstreamRequest{Path: r.URL.Path}
+		json.NewDecoder(r.Body).Decode(&req.Body)
+		*requests = append(*requests, req)
+		handler(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+		Backend:     genai.BackendGeminiAPI,
+		APIKey:      "test-api-key",
+		HTTPOptions: genai.HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	RegisterModelProxyServer(s, NewServer(client, opts))
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to connect to the proxy: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewClient(conn)
+}
+
+func TestGenerateContent(t *testing.T) {
+	ctx := context.Background()
+	var requests []upstreamRequest
+	proxy := newTestProxy(t, nil, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"text": "Hello!"}]}, "finishReason": "STOP"}],
+			"usageMetadata": {"promptTokenCount": 2, "candidatesTokenCount": 3, "totalTokenCount": 5}}`))
+	})
+	resp, err := proxy.GenerateContent(ctx, "gemini-2.5-flash", genai.Text("Hi"), &genai.GenerateContentConfig{MaxOutputTokens: 100})
+	if err != nil {
+		t.Fatalf("GenerateContent() failed: %v", err)
+	}
+	if got := resp.Text(); got != "Hello!" {
+		t.Errorf("GenerateContent() text = %q, want %q", got, "Hello!")
+	}
+	if resp.UsageMetadata == nil || resp.UsageMetadata.TotalTokenCount != 5 {
+		t.Errorf("GenerateContent() usage = %+v, want 5 total tokens", resp.UsageMetadata)
+	}
+	wantRequests := []upstreamRequest{{
+		Path: "/v1beta/models/gemini-2.5-flash:generateContent",
+		Body: map[string]any{
+			"contents":         []any{map[string]any{"role": "user", "parts": []any{map[string]any{"text": "Hi"}}}},
+			"generationConfig": map[string]any{"maxOutputTokens": 100.0},
+		},
+	}}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateContentStream(t *testing.T) {
+	ctx := context.Background()
+	var requests []upstreamRequest
+	proxy := newTestProxy(t, nil, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"Hel\"}]}}]}\n\n" +
+			"data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"lo\"}]}, \"finishReason\": \"STOP\"}]}\n\n"))
+	})
+	var texts []string
+	for resp, err := range proxy.GenerateContentStream(ctx, "gemini-2.5-flash", genai.Text("Hi"), nil) {
+		if err != nil {
+			t.Fatalf("GenerateContentStream() failed: %v", err)
+		}
+		texts = append(texts, resp.Text())
+	}
+	if diff := cmp.Diff([]string{"Hel", "lo"}, texts); diff != "" {
+		t.Errorf("GenerateContentStream() mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := requests[0].Path, "/v1beta/models/gemini-2.5-flash:streamGenerateContent"; got != want {
+		t.Errorf("GenerateContentStream() requested %q, want %q", got, want)
+	}
+}
+
+func TestEmbedContent(t *testing.T) {
+	ctx := context.Background()
+	var requests []upstreamRequest
+	proxy := newTestProxy(t, nil, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"embeddings": [{"values": [0.5, -1]}]}`))
+	})
+	resp, err := proxy.EmbedContent(ctx, "text-embedding-004", genai.Text("Hi"), nil)
+	if err != nil {
+		t.Fatalf("EmbedContent() failed: %v", err)
+	}
+	if diff := cmp.Diff([]*genai.ContentEmbedding{{Values: []float32{0.5, -1}}}, resp.Embeddings); diff != "" {
+		t.Errorf("EmbedContent() mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := requests[0].Path, "/v1beta/models/text-embedding-004:batchEmbedContents"; got != want {
+		t.Errorf("EmbedContent() requested %q, want %q", got, want)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	ctx := context.Background()
+	opts := &ServerOptions{
+		AllowedModels: []string{"gemini-2.5-flash", "gemini-2.5-pro"},
+		Authorize: func(ctx context.Context, method, model string) error {
+			if model == "gemini-2.5-pro" {
+				return errors.New("reserved for the research team")
+			}
+			return nil
+		},
+	}
+	var requests []upstreamRequest
+	proxy := newTestProxy(t, opts, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error": {"code": 429, "message": "Resource exhausted.", "status": "RESOURCE_EXHAUSTED"}}`))
+	})
+	tests := []struct {
+		name     string
+		model    string
+		wantCode codes.Code
+	}{
+		{name: "MissingModel", wantCode: codes.InvalidArgument},
+		{name: "ModelNotAllowed", model: "gemini-2.0-flash", wantCode: codes.PermissionDenied},
+		{name: "Unauthorized", model: "gemini-2.5-pro", wantCode: codes.PermissionDenied},
+		{name: "APIError", model: "gemini-2.5-flash", wantCode: codes.ResourceExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			_, err := proxy.GenerateContent(ctx, tt.model, genai.Text("Hi"), nil)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("GenerateContent() error = %v, want code %v", err, tt.wantCode)
+			}
+			var streamErr error
+			for _, err := range proxy.GenerateContentStream(ctx, tt.model, genai.Text("Hi"), nil) {
+				streamErr = err
+			}
+			if got := status.Code(streamErr); got != tt.wantCode {
+				t.Errorf("GenerateContentStream() error = %v, want code %v", streamErr, tt.wantCode)
+			}
+			if tt.wantCode != codes.ResourceExhausted && len(requests) > 0 {
+				t.Errorf("the proxy sent %d requests to the model, want none", len(requests))
+			}
+		})
+	}
+}
+
+func TestServerRefusesHTTPOptions(t *testing.T) {
+	ctx := context.Background()
+	var requests []upstreamRequest
+	proxy := newTestProxy(t, nil, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"text": "Hello!"}]}}]}`))
+	})
+	httpOptions := &genai.HTTPOptions{BaseURL: "https://attacker.example.com"}
+	_, err := proxy.GenerateContent(ctx, "gemini-2.5-flash", genai.Text("Hi"), &genai.GenerateContentConfig{HTTPOptions: httpOptions})
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("GenerateContent() error = %v, want code %v", err, codes.InvalidArgument)
+	}
+	var streamErr error
+	for _, err := range proxy.GenerateContentStream(ctx, "gemini-2.5-flash", genai.Text("Hi"), &genai.GenerateContentConfig{HTTPOptions: httpOptions}) {
+		streamErr = err
+	}
+	if got := status.Code(streamErr); got != codes.InvalidArgument {
+		t.Errorf("GenerateContentStream() error = %v, want code %v", streamErr, codes.InvalidArgument)
+	}
+	_, err = proxy.EmbedContent(ctx, "text-embedding-004", genai.Text("Hi"), &genai.EmbedContentConfig{HTTPOptions: httpOptions})
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("EmbedContent() error = %v, want code %v", err, codes.InvalidArgument)
+	}
+	if len(requests) > 0 {
+		t.Errorf("the proxy sent %d requests to the model, want none", len(requests))
+	}
+}
+
+func TestHTTPStatusCode(t *testing.T) {
+	err := statusError(genai.APIError{Code: http.StatusServiceUnavailable, Message: "overloaded"})
+	if got := status.Code(err); got != codes.Unavailable {
+		t.Errorf("statusError() code = %v, want %v", got, codes.Unavailable)
+	}
+	if got := status.Code(statusError(context.Canceled)); got != codes.Canceled {
+		t.Errorf("statusError(context.Canceled) code = %v, want %v", got, codes.Canceled)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// The service descriptor below follows the code generated by protoc-gen-go-grpc
+// for proxy.proto. The messages are well-known types, so no message code needs to
+// be generated.
+
+const (
+	// ServiceName is the full name of the ModelProxy service of proxy.proto.
+	ServiceName = "genai.proxy.v1.ModelProxy"
+
+	generateContentMethod       = "/" + ServiceName + "/GenerateContent"
+	generateContentStreamMethod = "/" + ServiceName + "/GenerateContentStream"
+	embedContentMethod          = "/" + ServiceName + "/EmbedContent"
+)
+
+// ModelProxyServer is the server API of the ModelProxy service.
+type ModelProxyServer interface {
+	GenerateContent(context.Context, *structpb.Struct) (*structpb.Struct, error)
+	GenerateContentStream(*structpb.Struct, ModelProxy_GenerateContentStreamServer) error
+	EmbedContent(context.Context, *structpb.Struct) (*structpb.Struct, error)
+}
+
+// ModelProxy_GenerateContentStreamServer is the server stream of the
+// GenerateContentStream method.
+type ModelProxy_GenerateContentStreamServer = grpc.ServerStreamingServer[structpb.Struct]
+
+// RegisterModelProxyServer registers the ModelProxy service on a gRPC server:
+//
+//	s := grpc.NewServer()
+//	grpcproxy.RegisterModelProxyServer(s, grpcproxy.NewServer(client, nil))
+func RegisterModelProxyServer(s grpc.ServiceRegistrar, srv ModelProxyServer) {
+	s.RegisterService(&ModelProxy_ServiceDesc, srv)
+}
+
+// ModelProxy_ServiceDesc is the [grpc.ServiceDesc] of the ModelProxy service.
+var ModelProxy_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*ModelProxyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "GenerateContent", Handler: generateContentHandler},
+		{MethodName: "EmbedContent", Handler: embedContentHandler},
+	},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "GenerateContentStream", Handler: generateContentStreamHandler, ServerStreams: true},
+	},
+	Metadata: "grpcproxy/proxy.proto",
+}
+
+func generateContentHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(structpb.Struct)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelProxyServer).GenerateContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: generateContentMethod}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(ModelProxyServer).GenerateContent(ctx, req.(*structpb.Struct))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func embedContentHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(structpb.Struct)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModelProxyServer).EmbedContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: embedContentMethod}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(ModelProxyServer).EmbedContent(ctx, req.(*structpb.Struct))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func generateContentStreamHandler(srv any, stream grpc.ServerStream) error {
+	in := new(structpb.Struct)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(ModelProxyServer).GenerateContentStream(in, &grpc.GenericServerStream[structpb.Struct, structpb.Struct]{ServerStream: stream})
+}
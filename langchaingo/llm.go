@@ -0,0 +1,416 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package langchaingo implements the langchaingo [llms.Model] interface with
+// the Models service of a [genai.Client], so that the SDK can be used by the
+// chains and agents of langchaingo:
+//
+//	client, err := genai.NewClient(ctx, nil)
+//	if err != nil {
+//		return err
+//	}
+//	llm := langchaingo.New(client, "gemini-2.5-flash")
+//	answer, err := llms.GenerateFromSinglePrompt(ctx, llm, "Why is the sky blue?")
+//
+// It is a separate module, so that the SDK does not depend on langchaingo.
+package langchaingo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+	"google.golang.org/genai"
+)
+
+// LLM is a langchaingo [llms.Model] generating content with Gemini models.
+type LLM struct {
+	models *genai.Models
+	model  string
+}
+
+var _ llms.Model = (*LLM)(nil)
+
+// New returns an LLM generating content with the client, using model unless
+// another one is set by [llms.WithModel].
+func New(client *genai.Client, model string) *LLM {
+	return &LLM{models: client.Models, model: model}
+}
+
+// Call generates the completion of a single prompt.
+func (l *LLM) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, l, prompt, options...)
+}
+
+// GenerateContent generates a response to the messages, with a choice per
+// candidate. If [llms.WithStreamingFunc] is set, the response is streamed and
+// the text of the first candidate is passed to the function as it is generated.
+//
+// The tool calls of a choice are also returned as its function call, for the
+// agents using the legacy functions API.
+func (l *LLM) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	opts := llms.CallOptions{Model: l.model}
+	for _, o := range options {
+		o(&opts)
+	}
+	contents, config, err := convertRequest(messages, &opts)
+	if err != nil {
+		return nil, err
+	}
+	var resp *genai.GenerateContentResponse
+	if opts.StreamingFunc == nil {
+		resp, err = l.models.GenerateContent(ctx, opts.Model, contents, config)
+	} else {
+		resp, err = genai.CollectStream(streamText(ctx, l.models.GenerateContentStream(ctx, opts.Model, contents, config), opts.StreamingFunc))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Candidates) == 0 {
+		if resp.PromptFeedback != nil && resp.PromptFeedback.BlockReason != "" {
+			return nil, fmt.Errorf("prompt blocked: %s", resp.PromptFeedback.BlockReason)
+		}
+		return nil, fmt.Errorf("response has no candidates")
+	}
+	return convertResponse(resp), nil
+}
+
+// streamText passes the text of the first candidate of every chunk of the
+// stream to fn, and ends the stream with the error returned by fn, if any.
+func streamText(ctx context.Context, stream iter.Seq2[*genai.GenerateContentResponse, error], fn func(context.Context, []byte) error) iter.Seq2[*genai.GenerateContentResponse, error] {
+	return func(yield func(*genai.GenerateContentResponse, error) bool) {
+		for chunk, err := range stream {
+			if err == nil && len(chunk.Candidates) > 0 {
+				if text := candidateText(chunk.Candidates[0]); text != "" {
+					err = fn(ctx, []byte(text))
+				}
+			}
+			if !yield(chunk, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// convertRequest converts langchaingo messages and call options to the contents
+// and config of a content generation request.
+func convertRequest(messages []llms.MessageContent, opts *llms.CallOptions) ([]*genai.Content, *genai.GenerateContentConfig, error) {
+	config := &genai.GenerateContentConfig{
+		StopSequences: opts.StopWords,
+	}
+	if opts.Temperature != 0 {
+		config.Temperature = genai.Ptr(float32(opts.Temperature))
+	}
+	if opts.TopP != 0 {
+		config.TopP = genai.Ptr(float32(opts.TopP))
+	}
+	if opts.TopK != 0 {
+		config.TopK = genai.Ptr(float32(opts.TopK))
+	}
+	if opts.MaxTokens != 0 {
+		config.MaxOutputTokens = int32(opts.MaxTokens)
+	}
+	if opts.Seed != 0 {
+		config.Seed = genai.Ptr(int32(opts.Seed))
+	}
+	if opts.PresencePenalty != 0 {
+		config.PresencePenalty = genai.Ptr(float32(opts.PresencePenalty))
+	}
+	if opts.FrequencyPenalty != 0 {
+		config.FrequencyPenalty = genai.Ptr(float32(opts.FrequencyPenalty))
+	}
+	switch {
+	case opts.CandidateCount != 0:
+		config.CandidateCount = int32(opts.CandidateCount)
+	case opts.N != 0:
+		config.CandidateCount = int32(opts.N)
+	}
+	if opts.JSONMode {
+		config.ResponseMIMEType = "application/json"
+	}
+
+	system, contents, err := convertMessages(messages)
+	if err != nil {
+		return nil, nil, err
+	}
+	config.SystemInstruction = system
+
+	var declarations []*genai.FunctionDeclaration
+	for _, f := range opts.Functions {
+		fd, err := convertFunction(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		declarations = append(declarations, fd)
+	}
+	for _, t := range opts.Tools {
+		if t.Type != "function" || t.Function == nil {
+			return nil, nil, fmt.Errorf("unsupported tool type %q", t.Type)
+		}
+		fd, err := convertFunction(*t.Function)
+		if err != nil {
+			return nil, nil, err
+		}
+		declarations = append(declarations, fd)
+	}
+	if len(declarations) > 0 {
+		config.Tools = []*genai.Tool{{FunctionDeclarations: declarations}}
+	}
+	if opts.ToolChoice != nil {
+		fc, err := convertToolChoice(opts.ToolChoice)
+		if err != nil {
+			return nil, nil, err
+		}
+		config.ToolConfig = &genai.ToolConfig{FunctionCallingConfig: fc}
+	}
+	return contents, config, nil
+}
+
+// convertMessages converts langchaingo messages to the system instruction and
+// the contents of a conversation. Consecutive tool responses are grouped in a
+// single content, as the function responses of the preceding model turn.
+func convertMessages(messages []llms.MessageContent) (*genai.Content, []*genai.Content, error) {
+	var (
+		system   *genai.Content
+		contents []*genai.Content
+		// toolNames maps the IDs of the tool calls to the names of their functions,
+		// for the tool responses which omit them.
+		toolNames = map[string]string{}
+	)
+	for i, m := range messages {
+		parts := make([]*genai.Part, 0, len(m.Parts))
+		for _, p := range m.Parts {
+			part, err := convertPart(p, toolNames)
+			if err != nil {
+				return nil, nil, fmt.Errorf("message %d: %w", i, err)
+			}
+			parts = append(parts, part)
+		}
+		switch m.Role {
+		case llms.ChatMessageTypeSystem:
+			if system == nil {
+				system = &genai.Content{}
+			}
+			system.Parts = append(system.Parts, parts...)
+		case llms.ChatMessageTypeHuman, llms.ChatMessageTypeGeneric:
+			contents = append(contents, genai.NewContentFromParts(parts, genai.RoleUser))
+		case llms.ChatMessageTypeAI:
+			contents = append(contents, genai.NewContentFromParts(parts, genai.RoleModel))
+		case llms.ChatMessageTypeTool, llms.ChatMessageTypeFunction:
+			if n := len(contents); n > 0 && isFunctionResponses(contents[n-1]) {
+				contents[n-1].Parts = append(contents[n-1].Parts, parts...)
+			} else {
+				contents = append(contents, genai.NewContentFromParts(parts, genai.RoleUser))
+			}
+		default:
+			return nil, nil, fmt.Errorf("message %d: unsupported role %q", i, m.Role)
+		}
+	}
+	return system, contents, nil
+}
+
+// convertPart converts a langchaingo content part, recording the names of the
+// called functions in toolNames.
+func convertPart(p llms.ContentPart, toolNames map[string]string) (*genai.Part, error) {
+	switch p := p.(type) {
+	case llms.TextContent:
+		return genai.NewPartFromText(p.Text), nil
+	case llms.BinaryContent:
+		return genai.NewPartFromBytes(p.Data, p.MIMEType), nil
+	case llms.ImageURLContent:
+		return genai.NewPartFromURI(p.URL, imageMIMEType(p.URL)), nil
+	case llms.ToolCall:
+		if p.FunctionCall == nil {
+			return nil, fmt.Errorf("tool call %q has no function call", p.ID)
+		}
+		args := map[string]any{}
+		if p.FunctionCall.Arguments != "" {
+			if err := json.Unmarshal([]byte(p.FunctionCall.Arguments), &args); err != nil {
+				return nil, fmt.Errorf("arguments of tool call %q are not a JSON object: %w", p.ID, err)
+			}
+		}
+		toolNames[p.ID] = p.FunctionCall.Name
+		return &genai.Part{FunctionCall: &genai.FunctionCall{ID: p.ID, Name: p.FunctionCall.Name, Args: args}}, nil
+	case llms.ToolCallResponse:
+		name := p.Name
+		if name == "" {
+			name = toolNames[p.ToolCallID]
+		}
+		if name == "" {
+			return nil, fmt.Errorf("tool response %q answers an unknown tool call", p.ToolCallID)
+		}
+		return &genai.Part{FunctionResponse: &genai.FunctionResponse{
+			ID:       p.ToolCallID,
+			Name:     name,
+			Response: convertToolOutput(p.Content),
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported content part %T", p)
+	}
+}
+
+// imageMIMEType guesses the MIME type of an image from the extension of its URL.
+func imageMIMEType(url string) string {
+	url, _, _ = strings.Cut(url, "?")
+	switch {
+	case strings.HasSuffix(url, ".png"):
+		return "image/png"
+	case strings.HasSuffix(url, ".webp"):
+		return "image/webp"
+	case strings.HasSuffix(url, ".heic"):
+		return "image/heic"
+	case strings.HasSuffix(url, ".heif"):
+		return "image/heif"
+	default:
+		return "image/jpeg"
+	}
+}
+
+// convertToolOutput converts the content of a tool response to a function
+// response: a JSON object is sent as is, any other content is sent as the
+// "output" field.
+func convertToolOutput(content string) map[string]any {
+	var response map[string]any
+	if err := json.Unmarshal([]byte(content), &response); err == nil && response != nil {
+		return response
+	}
+	return map[string]any{"output": content}
+}
+
+// isFunctionResponses reports whether a content only holds function responses.
+func isFunctionResponses(c *genai.Content) bool {
+	if c.Role != genai.RoleUser || len(c.Parts) == 0 {
+		return false
+	}
+	for _, p := range c.Parts {
+		if p.FunctionResponse == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// convertFunction converts a langchaingo function definition, whose parameters
+// are a JSON Schema, to a function declaration.
+func convertFunction(f llms.FunctionDefinition) (*genai.FunctionDeclaration, error) {
+	fd := &genai.FunctionDeclaration{Name: f.Name, Description: f.Description}
+	if f.Parameters == nil {
+		return fd, nil
+	}
+	b, err := json.Marshal(f.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("parameters of function %q: %w", f.Name, err)
+	}
+	var jsonSchema map[string]any
+	if err := json.Unmarshal(b, &jsonSchema); err != nil {
+		return nil, fmt.Errorf("parameters of function %q are not a JSON object: %w", f.Name, err)
+	}
+	if len(jsonSchema) == 0 {
+		return fd, nil
+	}
+	fd.Parameters, err = genai.NewSchemaFromJSONSchema(jsonSchema)
+	if err != nil {
+		return nil, fmt.Errorf("parameters of function %q: %w", f.Name, err)
+	}
+	return fd, nil
+}
+
+// convertToolChoice converts a langchaingo tool choice, a mode string or an
+// [llms.ToolChoice] forcing a function, to a function calling config.
+func convertToolChoice(choice any) (*genai.FunctionCallingConfig, error) {
+	switch c := choice.(type) {
+	case string:
+		switch c {
+		case "none":
+			return &genai.FunctionCallingConfig{Mode: genai.FunctionCallingConfigModeNone}, nil
+		case "auto":
+			return &genai.FunctionCallingConfig{Mode: genai.FunctionCallingConfigModeAuto}, nil
+		case "required", "any":
+			return &genai.FunctionCallingConfig{Mode: genai.FunctionCallingConfigModeAny}, nil
+		}
+	case llms.ToolChoice:
+		if c.Function != nil && c.Function.Name != "" {
+			return &genai.FunctionCallingConfig{
+				Mode:                 genai.FunctionCallingConfigModeAny,
+				AllowedFunctionNames: []string{c.Function.Name},
+			}, nil
+		}
+	case *llms.ToolChoice:
+		if c != nil {
+			return convertToolChoice(*c)
+		}
+	}
+	return nil, fmt.Errorf("unsupported tool choice %v", choice)
+}
+
+// convertResponse converts a response to langchaingo choices. The generation
+// info of every choice reports the token usage of the request.
+func convertResponse(resp *genai.GenerateContentResponse) *llms.ContentResponse {
+	out := &llms.ContentResponse{}
+	for _, cand := range resp.Candidates {
+		choice := &llms.ContentChoice{
+			Content:        candidateText(cand),
+			StopReason:     string(cand.FinishReason),
+			GenerationInfo: map[string]any{},
+		}
+		if cand.Content != nil {
+			for _, p := range cand.Content.Parts {
+				if p.FunctionCall == nil {
+					continue
+				}
+				args := p.FunctionCall.Args
+				if args == nil {
+					args = map[string]any{}
+				}
+				b, _ := json.Marshal(args)
+				choice.ToolCalls = append(choice.ToolCalls, llms.ToolCall{
+					ID:   p.FunctionCall.ID,
+					Type: "function",
+					FunctionCall: &llms.FunctionCall{
+						Name:      p.FunctionCall.Name,
+						Arguments: string(b),
+					},
+				})
+			}
+		}
+		if len(choice.ToolCalls) > 0 {
+			choice.FuncCall = choice.ToolCalls[0].FunctionCall
+		}
+		if u := resp.UsageMetadata; u != nil {
+			choice.GenerationInfo["input_tokens"] = int(u.PromptTokenCount)
+			choice.GenerationInfo["output_tokens"] = int(u.CandidatesTokenCount)
+			choice.GenerationInfo["thoughts_tokens"] = int(u.ThoughtsTokenCount)
+			choice.GenerationInfo["total_tokens"] = int(u.TotalTokenCount)
+		}
+		out.Choices = append(out.Choices, choice)
+	}
+	return out
+}
+
+// candidateText returns the text of a candidate, without its thoughts.
+func candidateText(c *genai.Candidate) string {
+	if c.Content == nil {
+		return ""
+	}
+	var text strings.Builder
+	for _, p := range c.Content.Parts {
+		if !p.Thought {
+			text.WriteString(p.Text)
+		}
+	}
+	return text.String()
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package langchaingo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tmc/langchaingo/llms"
+	"google.golang.org/genai"
+)
+
+// newTestLLM returns an LLM whose requests are served by the response and
+// recorded in requests. Streamed responses are sent as server-sent events, one
+// per line of the response.
+func newTestLLM(t *testing.T, response string, requests *[]map[string]any) *LLM {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Error decoding request body: %v", err)
+		}
+		body["path"] = r.URL.Path
+		*requests = append(*requests, body)
+		if r.URL.Query().Get("alt") == "sse" {
+			for _, line := range strings.Split(response, "\n") {
+				fmt.Fprintf(w, "data: %s\n\n", line)
+			}
+			return
+		}
+		fmt.Fprintln(w, response)
+	}))
+	t.Cleanup(ts.Close)
+	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+		APIKey:      "test-api-key",
+		Backend:     genai.BackendGeminiAPI,
+		HTTPClient:  ts.Client(),
+		HTTPOptions: genai.HTTPOptions{BaseURL: ts.URL},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	return New(client, "gemini-2.5-flash")
+}
+
+func TestGenerateContentToolCalls(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	llm := newTestLLM(t, `{
+		"candidates": [{
+			"content": {"role": "model", "parts": [{"functionCall": {"id": "call_2", "name": "get_weather", "args": {"city": "Paris"}}}]},
+			"finishReason": "STOP"
+		}],
+		"usageMetadata": {"promptTokenCount": 10, "candidatesTokenCount": 5, "totalTokenCount": 15}
+	}`, &requests)
+
+	messages := []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, "You are terse."),
+		llms.TextParts(llms.ChatMessageTypeHuman, "What time is it?"),
+		{Role: llms.ChatMessageTypeAI, Parts: []llms.ContentPart{
+			llms.ToolCall{ID: "call_1", Type: "function", FunctionCall: &llms.FunctionCall{Name: "get_time", Arguments: `{"tz":"CET"}`}},
+		}},
+		{Role: llms.ChatMessageTypeTool, Parts: []llms.ContentPart{
+			llms.ToolCallResponse{ToolCallID: "call_1", Content: "12:00"},
+		}},
+		llms.TextParts(llms.ChatMessageTypeHuman, "And the weather in Paris?"),
+	}
+	tools := []llms.Tool{{Type: "function", Function: &llms.FunctionDefinition{
+		Name:        "get_weather",
+		Description: "Returns the weather.",
+		Parameters:  map[string]any{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "string"}}},
+	}}}
+	resp, err := llm.GenerateContent(ctx, messages, llms.WithTools(tools), llms.WithToolChoice("required"), llms.WithTemperature(0.5))
+	if err != nil {
+		t.Fatalf("GenerateContent() failed: %v", err)
+	}
+
+	toolCall := llms.ToolCall{ID: "call_2", Type: "function", FunctionCall: &llms.FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}}
+	want := &llms.ContentResponse{Choices: []*llms.ContentChoice{{
+		StopReason:     "STOP",
+		GenerationInfo: map[string]any{"input_tokens": 10, "output_tokens": 5, "thoughts_tokens": 0, "total_tokens": 15},
+		FuncCall:       toolCall.FunctionCall,
+		ToolCalls:      []llms.ToolCall{toolCall},
+	}}}
+	if diff := cmp.Diff(want, resp); diff != "" {
+		t.Errorf("GenerateContent() mismatch (-want +got):\n%s", diff)
+	}
+
+	var wantRequest map[string]any
+	if err := json.Unmarshal([]byte(`{
+		"path": "/v1beta/models/gemini-2.5-flash:generateContent",
+		"systemInstruction": {"role": "user", "parts": [{"text": "You are terse."}]},
+		"contents": [
+			{"role": "user", "parts": [{"text": "What time is it?"}]},
+			{"role": "model", "parts": [{"functionCall": {"id": "call_1", "name": "get_time", "args": {"tz": "CET"}}}]},
+			{"role": "user", "parts": [{"functionResponse": {"id": "call_1", "name": "get_time", "response": {"output": "12:00"}}}]},
+			{"role": "user", "parts": [{"text": "And the weather in Paris?"}]}
+		],
+		"generationConfig": {"temperature": 0.5},
+		"tools": [{"functionDeclarations": [{
+			"name": "get_weather",
+			"description": "Returns the weather.",
+			"parameters": {"type": "OBJECT", "properties": {"city": {"type": "STRING"}}}
+		}]}],
+		"toolConfig": {"functionCallingConfig": {"mode": "ANY"}}
+	}`), &wantRequest); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	if diff := cmp.Diff([]map[string]any{wantRequest}, requests); diff != "" {
+		t.Errorf("request mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateContentStreaming(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	llm := newTestLLM(t, strings.Join([]string{
+		`{"candidates": [{"content": {"role": "model", "parts": [{"text": "Because of", "thought": true}]}}]}`,
+		`{"candidates": [{"content": {"role": "model", "parts": [{"text": "Rayleigh"}]}}]}`,
+		`{"candidates": [{"content": {"role": "model", "parts": [{"text": " scattering."}]}, "finishReason": "STOP"}]}`,
+	}, "\n"), &requests)
+
+	var chunks []string
+	answer, err := llm.Call(ctx, "Why is the sky blue?", llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+		chunks = append(chunks, string(chunk))
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("Call() failed: %v", err)
+	}
+	if answer != "Rayleigh scattering." {
+		t.Errorf("Call() = %q, want %q", answer, "Rayleigh scattering.")
+	}
+	if diff := cmp.Diff([]string{"Rayleigh", " scattering."}, chunks); diff != "" {
+		t.Errorf("streamed chunks mismatch (-want +got):\n%s", diff)
+	}
+	if got := requests[0]["path"]; got != "/v1beta/models/gemini-2.5-flash:streamGenerateContent" {
+		t.Errorf("request path = %v, want the streaming method", got)
+	}
+
+	stop := fmt.Errorf("stop")
+	_, err = llm.Call(ctx, "Why is the sky blue?", llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+		return stop
+	}))
+	if err != stop {
+		t.Errorf("Call() error = %v, want the error of the streaming function", err)
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 
 	"github.com/gorilla/websocket"
 )
@@ -35,21 +37,90 @@ import (
 //	session, _ := client.Live.Connect(ctx, model, &genai.LiveConnectConfig{}).
 type Live struct {
 	apiClient *apiClient
+	// Music provides access to the realtime music generation sessions.
+	Music *LiveMusic
 }
 
 // Preview. Session represents an active, real-time WebSocket connection to the
 // Generative AI API. It provides methods for sending client messages and
 // receiving server messages over the established connection.
+//
+// The send methods can be called concurrently with each other, e.g. to stream
+// audio and video from different goroutines, and with [Session.Receive], which
+// must only be called from one goroutine at a time.
 type Session struct {
 	conn      *websocket.Conn
 	apiClient *apiClient
+
+	// writeMu serializes the writes to conn, which supports a single concurrent
+	// writer.
+	writeMu sync.Mutex
+
+	// toolMu guards the state of the tool calls executed by ReceiveWithTools.
+	toolMu sync.Mutex
+	// toolCalls maps the IDs of the tool calls in progress to their cancellation.
+	toolCalls map[string]context.CancelFunc
+	// toolErr is the first error sending the response to a tool call.
+	toolErr error
+
+	// model and config are the parameters of Connect, to resume the session.
+	model  string
+	config *LiveConnectConfig
+	// resumptionHandle is the latest handle to resume the session with, and goAway
+	// the GoAway message received since the last reconnection, if any. They are
+	// only accessed by Receive.
+	resumptionHandle string
+	goAway           *LiveServerGoAway
+	// connMu guards the replacement of conn on reconnection, and closed, which is
+	// set by Close.
+	connMu sync.Mutex
+	closed bool
 }
 
 // Preview. Connect establishes a WebSocket connection to the specified
 // model with the given configuration. It sends the initial
 // setup message and returns a [Session] object representing the connection.
-func (r *Live) Connect(context context.Context, model string, config *LiveConnectConfig) (*Session, error) {
+//
+// The context bounds the establishment of the connection only. The HTTP options
+// of the config, if any, take precedence over the ones of the client.
+func (r *Live) Connect(ctx context.Context, model string, config *LiveConnectConfig) (*Session, error) {
+	var configHTTPOptions *HTTPOptions
+	if config != nil {
+		configHTTPOptions = config.HTTPOptions
+	}
+	conn, err := r.dial(ctx, configHTTPOptions, "BidiGenerateContent")
+	if err != nil {
+		return nil, err
+	}
+	s := &Session{
+		conn:      conn,
+		apiClient: r.apiClient,
+		model:     model,
+	}
+	if config != nil {
+		c := *config
+		s.config = &c
+	}
+	if err := s.setup(conn, model, config); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// dial opens a WebSocket connection to a bidirectional streaming method of the
+// Live API, e.g. "BidiGenerateContent". The HTTP options, if any, take precedence
+// over the ones of the client.
+func (r *Live) dial(ctx context.Context, configHTTPOptions *HTTPOptions, method string) (*websocket.Conn, error) {
 	httpOptions := r.apiClient.clientConfig.HTTPOptions
+	if configHTTPOptions != nil {
+		if configHTTPOptions.BaseURL != "" {
+			httpOptions.BaseURL = configHTTPOptions.BaseURL
+		}
+		if configHTTPOptions.APIVersion != "" {
+			httpOptions.APIVersion = configHTTPOptions.APIVersion
+		}
+	}
 	if httpOptions.APIVersion == "" {
 		return nil, fmt.Errorf("live module requires APIVersion to be set. You can set APIVersion to v1beta1 for BackendVertexAI or v1apha for BackendGeminiAPI")
 	}
@@ -59,15 +130,17 @@ func (r *Live) Connect(context context.Context, model string, config *LiveConnec
 	}
 	scheme := baseURL.Scheme
 	// Avoid overwrite schema if websocket scheme is already specified.
-	if scheme != "wss" && scheme != "ws" {
+	if scheme == "http" && r.apiClient.clientConfig.Backend == BackendCustom {
+		// A custom backend, e.g. a local emulator, may not serve TLS.
+		scheme = "ws"
+	} else if scheme != "wss" && scheme != "ws" {
 		scheme = "wss"
 	}
 
 	var u url.URL
-	// TODO(b/406076143): Support function level httpOptions.
-	var header http.Header = mergeHeaders(&httpOptions, nil)
+	var header http.Header = mergeHeaders(&httpOptions, configHTTPOptions)
 	if r.apiClient.clientConfig.Backend == BackendVertexAI {
-		token, err := r.apiClient.clientConfig.Credentials.Token(context)
+		token, err := r.apiClient.clientConfig.Credentials.Token(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get token: %w", err)
 		}
@@ -75,57 +148,78 @@ func (r *Live) Connect(context context.Context, model string, config *LiveConnec
 		u = url.URL{
 			Scheme: scheme,
 			Host:   baseURL.Host,
-			Path:   fmt.Sprintf("%s/ws/google.cloud.aiplatform.%s.LlmBidiService/BidiGenerateContent", baseURL.Path, httpOptions.APIVersion),
+			Path:   fmt.Sprintf("%s/ws/google.cloud.aiplatform.%s.LlmBidiService/%s", baseURL.Path, httpOptions.APIVersion, method),
 		}
 	} else {
+		query := url.Values{}
+		if r.apiClient.clientConfig.APIKey != "" {
+			query.Set("key", r.apiClient.clientConfig.APIKey)
+		}
+		if method == "BidiGenerateContent" && strings.HasPrefix(r.apiClient.clientConfig.APIKey, "auth_tokens/") {
+			// Ephemeral tokens, see [AuthTokens.Create], are only accepted by the
+			// constrained method.
+			method = "BidiGenerateContentConstrained"
+			query = url.Values{"access_token": {r.apiClient.clientConfig.APIKey}}
+		}
 		u = url.URL{
 			Scheme:   scheme,
 			Host:     baseURL.Host,
-			Path:     fmt.Sprintf("%s/ws/google.ai.generativelanguage.%s.GenerativeService.BidiGenerateContent", baseURL.Path, httpOptions.APIVersion),
-			RawQuery: fmt.Sprintf("key=%s", r.apiClient.clientConfig.APIKey),
+			Path:     fmt.Sprintf("%s/ws/google.ai.generativelanguage.%s.GenerativeService.%s", baseURL.Path, httpOptions.APIVersion, method),
+			RawQuery: query.Encode(),
 		}
 	}
 
-	conn, _, err := websocket.DefaultDialer.Dial(u.String(), header)
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, u.String(), header)
 	if err != nil {
 		return nil, fmt.Errorf("Connect to %s failed: %w", u.String(), err)
 	}
-	s := &Session{
-		conn:      conn,
-		apiClient: r.apiClient,
-	}
-	modelFullName, err := tModelFullName(r.apiClient, model)
+	return conn, nil
+}
+
+// setup sends the setup message of the session to a connection which is not
+// written to concurrently.
+func (s *Session) setup(conn *websocket.Conn, model string, config *LiveConnectConfig) error {
+	modelFullName, err := tModelFullName(s.apiClient, model)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if config != nil && config.ContextWindowCompression != nil {
+		compression, err := config.ContextWindowCompression.withDefaults()
+		if err != nil {
+			return err
+		}
+		c := *config
+		c.ContextWindowCompression = compression
+		config = &c
 	}
 	kwargs := map[string]any{"model": modelFullName, "config": config}
 	parameterMap := make(map[string]any)
 	err = deepMarshal(kwargs, &parameterMap)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	var toConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
-	if r.apiClient.clientConfig.Backend == BackendVertexAI {
+	if s.apiClient.clientConfig.Backend == BackendVertexAI {
 		toConverter = liveConnectParametersToVertex
 	} else {
 		toConverter = liveConnectParametersToMldev
 	}
-	body, err := toConverter(r.apiClient, parameterMap, nil)
+	body, err := toConverter(s.apiClient, parameterMap, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	delete(body, "config")
 
 	clientBytes, err := json.Marshal(body)
 	if err != nil {
-		return nil, fmt.Errorf("marshal LiveClientSetup failed: %w", err)
+		return fmt.Errorf("marshal LiveClientSetup failed: %w", err)
 	}
-	err = s.conn.WriteMessage(websocket.TextMessage, clientBytes)
+	err = conn.WriteMessage(websocket.TextMessage, clientBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to write LiveClientSetup: %w", err)
+		return fmt.Errorf("failed to write LiveClientSetup: %w", err)
 	}
-	return s, nil
+	return nil
 }
 
 // Preview. LiveClientContentInput is the input for [SendClientContent].
@@ -206,7 +300,7 @@ func (s *Session) SendRealtimeInput(input LiveRealtimeInput) error {
 	if err != nil {
 		return fmt.Errorf("marshal client message error: %w", err)
 	}
-	return s.conn.WriteMessage(websocket.TextMessage, []byte(data))
+	return s.writeMessage(data)
 }
 
 // Preview. LiveToolResponseInput is the input for [SendToolResponse].
@@ -250,7 +344,14 @@ func (s *Session) send(input *LiveClientMessage) error {
 	if err != nil {
 		return fmt.Errorf("marshal client message error: %w", err)
 	}
-	return s.conn.WriteMessage(websocket.TextMessage, []byte(data))
+	return s.writeMessage(data)
+}
+
+// writeMessage writes a text message to the connection.
+func (s *Session) writeMessage(data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.conn.WriteMessage(websocket.TextMessage, data)
 }
 
 // Preview. Receive reads a LiveServerMessage from the connection.
@@ -259,13 +360,44 @@ func (s *Session) send(input *LiveClientMessage) error {
 // The returned message represents a part of or a complete model turn.
 // If the received message is a [LiveServerToolCall], the user must call
 // [SendToolResponse] to provide the function execution result and continue the turn.
+//
+// If [LiveConnectConfig.Reconnect] is set, the session is resumed on a new
+// connection after a [LiveServerGoAway] message or a connection loss, see
+// [LiveReconnectConfig].
 func (s *Session) Receive() (*LiveServerMessage, error) {
-	messageType, msgBytes, err := s.conn.ReadMessage()
-	if err != nil {
-		return nil, err
+	for {
+		if s.goAway != nil {
+			goAway := s.goAway
+			s.goAway = nil
+			if s.resumptionHandle != "" {
+				if err := s.reconnect(goAway); err != nil {
+					return nil, err
+				}
+			}
+		}
+		messageType, msgBytes, err := s.conn.ReadMessage()
+		if err != nil {
+			if !s.canReconnect() {
+				return nil, err
+			}
+			if err := s.reconnect(nil); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		message, err := s.parseMessage(messageType, msgBytes)
+		if err != nil {
+			return nil, err
+		}
+		s.trackResumption(message)
+		return message, nil
 	}
+}
+
+// parseMessage parses a server message read from the connection.
+func (s *Session) parseMessage(messageType int, msgBytes []byte) (*LiveServerMessage, error) {
 	responseMap := make(map[string]any)
-	err = json.Unmarshal(msgBytes, &responseMap)
+	err := json.Unmarshal(msgBytes, &responseMap)
 	if err != nil {
 		return nil, fmt.Errorf("invalid message format. Error %w. messageType: %d, message: %s", err, messageType, msgBytes)
 	}
@@ -292,10 +424,17 @@ func (s *Session) Receive() (*LiveServerMessage, error) {
 	return message, err
 }
 
-// Preview. Close terminates the connection.
+// Preview. Close terminates the connection, and cancels the tool calls in progress.
 func (s *Session) Close() error {
-	if s != nil && s.conn != nil {
-		return s.conn.Close()
+	if s == nil {
+		return nil
 	}
-	return nil
+	s.cancelAllToolCalls()
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	s.closed = true
+	return s.conn.Close()
 }
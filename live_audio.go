@@ -0,0 +1,306 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultInputSampleRate    = 16000
+	defaultOutputSampleRate   = 24000
+	defaultAudioChunkDuration = 100 * time.Millisecond
+)
+
+// Preview. LiveAudioFormat describes the raw audio sent with
+// [Session.SendRealtimeAudio]: little-endian 16-bit PCM samples, with the channels
+// interleaved.
+type LiveAudioFormat struct {
+	// Optional. The number of samples per second. Defaults to 16000, the native
+	// input rate of the Live API.
+	SampleRate int
+	// Optional. The number of channels. Defaults to 1.
+	Channels int
+	// Optional. The duration of the audio of every realtime input message. Defaults
+	// to 100 milliseconds.
+	ChunkDuration time.Duration
+	// Optional. Whether the user activity is signaled by the client, i.e. whether
+	// the automatic activity detection of the session is disabled, see
+	// [AutomaticActivityDetection]. If set, the audio is preceded by an activity
+	// start and followed by an activity end. Otherwise, the audio is followed by an
+	// audio stream end.
+	ManualActivity bool
+}
+
+// MIMEType returns the MIME type of the audio, e.g. "audio/pcm;rate=16000".
+func (f LiveAudioFormat) MIMEType() string {
+	return fmt.Sprintf("audio/pcm;rate=%d", f.withDefaults().SampleRate)
+}
+
+// withDefaults returns the format with the defaults of its unset fields.
+func (f LiveAudioFormat) withDefaults() LiveAudioFormat {
+	if f.SampleRate <= 0 {
+		f.SampleRate = defaultInputSampleRate
+	}
+	if f.Channels <= 0 {
+		f.Channels = 1
+	}
+	if f.ChunkDuration <= 0 {
+		f.ChunkDuration = defaultAudioChunkDuration
+	}
+	return f
+}
+
+// Preview. SendRealtimeAudio streams the raw PCM audio read from r to the session
+// until r returns io.EOF, e.g. from a microphone or from a file, in realtime input
+// messages of the chunk duration of the format.
+//
+// The chunks are sent at the cadence of the audio: the i-th chunk is sent when i
+// chunk durations have elapsed since the first one, or as soon as it is read from
+// a slower reader. The end of the audio is signaled as configured by the format,
+// also when the context is done or r fails:
+//
+//	mic := ... // io.Reader of 16 kHz mono PCM16 samples.
+//	go func() {
+//		err := session.SendRealtimeAudio(ctx, mic, genai.LiveAudioFormat{})
+//	}()
+func (s *Session) SendRealtimeAudio(ctx context.Context, r io.Reader, format LiveAudioFormat) error {
+	format = format.withDefaults()
+	frameSize := 2 * format.Channels
+	chunkFrames := max(1, int(int64(format.SampleRate)*int64(format.ChunkDuration)/int64(time.Second)))
+	buf := make([]byte, chunkFrames*frameSize)
+	mimeType := format.MIMEType()
+
+	if format.ManualActivity {
+		if err := s.SendRealtimeInput(LiveRealtimeInput{ActivityStart: &ActivityStart{}}); err != nil {
+			return err
+		}
+	}
+	err := s.sendAudioChunks(ctx, r, buf, mimeType, format.ChunkDuration)
+	end := LiveRealtimeInput{AudioStreamEnd: true}
+	if format.ManualActivity {
+		end = LiveRealtimeInput{ActivityEnd: &ActivityEnd{}}
+	}
+	if endErr := s.SendRealtimeInput(end); err == nil {
+		err = endErr
+	}
+	return err
+}
+
+// sendAudioChunks sends the audio read from r in chunks of the size of buf, one
+// chunk duration apart.
+func (s *Session) sendAudioChunks(ctx context.Context, r io.Reader, buf []byte, mimeType string, chunkDuration time.Duration) error {
+	start := time.Now()
+	for i := 0; ; i++ {
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			if d := time.Until(start.Add(time.Duration(i) * chunkDuration)); d > 0 {
+				timer := time.NewTimer(d)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return ctx.Err()
+				case <-timer.C:
+				}
+			}
+			data := make([]byte, n)
+			copy(data, buf[:n])
+			if err := s.SendRealtimeInput(LiveRealtimeInput{Audio: &Blob{Data: data, MIMEType: mimeType}}); err != nil {
+				return err
+			}
+		}
+		switch {
+		case readErr == io.EOF || readErr == io.ErrUnexpectedEOF:
+			return nil
+		case readErr != nil:
+			return fmt.Errorf("error reading audio: %w", readErr)
+		case ctx.Err() != nil:
+			return ctx.Err()
+		}
+	}
+}
+
+// Preview. LiveAudioFrame is a chunk of the audio generated by the model of a Live
+// session: little-endian 16-bit mono PCM samples.
+type LiveAudioFrame struct {
+	// The samples.
+	Data []byte
+	// The number of samples per second, 24000 unless specified otherwise by the
+	// MIME type of the audio.
+	SampleRate int
+}
+
+// Preview. LiveAudioOutput queues the audio generated by the model of a Live
+// session, to be played at the pace of an audio device. The server messages are
+// fed with [LiveAudioOutput.Handle] from the receive loop of the session, and the
+// audio is consumed either as an [io.Reader] or frame by frame with
+// [LiveAudioOutput.ReadFrame], from another goroutine:
+//
+//	out := genai.NewLiveAudioOutput()
+//	go play(out) // e.g. io.Copy(speaker, out)
+//	for {
+//		message, err := session.Receive()
+//		if err != nil {
+//			out.Close()
+//			return err
+//		}
+//		out.Handle(message)
+//	}
+//
+// When the model is interrupted, e.g. because the user started speaking (barge-in),
+// the queued audio is stale and dropped, so that the playback stops immediately.
+type LiveAudioOutput struct {
+	// Optional. Called after the queued audio is dropped on an interruption, e.g. to
+	// flush the buffer of the audio device. It must not block.
+	OnInterrupt func()
+
+	mu         sync.Mutex
+	frames     []*LiveAudioFrame
+	sampleRate int
+	closed     bool
+	// changed is closed and replaced whenever frames or closed change.
+	changed chan struct{}
+}
+
+// Preview. NewLiveAudioOutput returns an empty audio output.
+func NewLiveAudioOutput() *LiveAudioOutput {
+	return &LiveAudioOutput{sampleRate: defaultOutputSampleRate, changed: make(chan struct{})}
+}
+
+// Handle queues the PCM audio of the model turn of a server message, and drops the
+// queued audio if the message signals an interruption. Other messages are ignored.
+func (o *LiveAudioOutput) Handle(message *LiveServerMessage) {
+	if message == nil || message.ServerContent == nil {
+		return
+	}
+	content := message.ServerContent
+	o.mu.Lock()
+	if content.Interrupted {
+		o.frames = nil
+	}
+	if content.ModelTurn != nil {
+		for _, part := range content.ModelTurn.Parts {
+			if part == nil || part.InlineData == nil || len(part.InlineData.Data) == 0 {
+				continue
+			}
+			rate, ok := pcmSampleRate(part.InlineData.MIMEType)
+			if !ok {
+				continue
+			}
+			o.sampleRate = rate
+			o.frames = append(o.frames, &LiveAudioFrame{Data: part.InlineData.Data, SampleRate: rate})
+		}
+	}
+	o.notifyLocked()
+	o.mu.Unlock()
+	if content.Interrupted && o.OnInterrupt != nil {
+		o.OnInterrupt()
+	}
+}
+
+// SampleRate returns the sample rate of the last queued audio, 24000 if none.
+func (o *LiveAudioOutput) SampleRate() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.sampleRate
+}
+
+// Read reads the queued audio, blocking until some is queued. It returns io.EOF
+// once the output is closed and all the audio read.
+func (o *LiveAudioOutput) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	frame, err := o.next(context.Background(), len(p))
+	if err != nil {
+		return 0, err
+	}
+	return copy(p, frame.Data), nil
+}
+
+// ReadFrame returns the next queued frame, or the rest of the frame partially read
+// with [LiveAudioOutput.Read], blocking until a frame is queued or the context is
+// done. It returns io.EOF once the output is closed and all the audio read.
+func (o *LiveAudioOutput) ReadFrame(ctx context.Context) (*LiveAudioFrame, error) {
+	return o.next(ctx, 0)
+}
+
+// Close closes the output: the audio still queued can be read, then the reads
+// return io.EOF.
+func (o *LiveAudioOutput) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.closed = true
+	o.notifyLocked()
+	return nil
+}
+
+// next dequeues at most limit bytes of the first frame, or the whole frame if limit
+// is not positive.
+func (o *LiveAudioOutput) next(ctx context.Context, limit int) (*LiveAudioFrame, error) {
+	for {
+		o.mu.Lock()
+		if len(o.frames) > 0 {
+			frame := o.frames[0]
+			if limit > 0 && limit < len(frame.Data) {
+				head := &LiveAudioFrame{Data: frame.Data[:limit], SampleRate: frame.SampleRate}
+				o.frames[0] = &LiveAudioFrame{Data: frame.Data[limit:], SampleRate: frame.SampleRate}
+				o.mu.Unlock()
+				return head, nil
+			}
+			o.frames = o.frames[1:]
+			o.mu.Unlock()
+			return frame, nil
+		}
+		if o.closed {
+			o.mu.Unlock()
+			return nil, io.EOF
+		}
+		changed := o.changed
+		o.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// notifyLocked wakes up the pending reads. o.mu must be held.
+func (o *LiveAudioOutput) notifyLocked() {
+	close(o.changed)
+	o.changed = make(chan struct{})
+}
+
+// pcmSampleRate returns the sample rate of a PCM audio MIME type, e.g. 24000 for
+// "audio/pcm;rate=24000", and whether the MIME type is PCM audio.
+func pcmSampleRate(mimeType string) (int, bool) {
+	mediaType, params, err := mime.ParseMediaType(mimeType)
+	if err != nil || !strings.EqualFold(mediaType, "audio/pcm") {
+		return 0, false
+	}
+	if rate, err := strconv.Atoi(params["rate"]); err == nil && rate > 0 {
+		return rate, true
+	}
+	return defaultOutputSampleRate, true
+}
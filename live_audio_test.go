@@ -0,0 +1,168 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+)
+
+func TestSendRealtimeAudio(t *testing.T) {
+	ctx := context.Background()
+	audio := make([]byte, 800)
+	for i := range audio {
+		audio[i] = byte(i)
+	}
+	audioMessage := func(data []byte) map[string]any {
+		return map[string]any{"realtimeInput": map[string]any{"audio": map[string]any{
+			"data":     base64.StdEncoding.EncodeToString(data),
+			"mimeType": "audio/pcm;rate=16000",
+		}}}
+	}
+
+	for _, tt := range []struct {
+		name   string
+		format LiveAudioFormat
+		first  map[string]any
+		last   map[string]any
+	}{
+		{
+			name:   "AutomaticActivity",
+			format: LiveAudioFormat{ChunkDuration: 10 * time.Millisecond},
+			last:   map[string]any{"realtimeInput": map[string]any{"audioStreamEnd": true}},
+		},
+		{
+			name:   "ManualActivity",
+			format: LiveAudioFormat{ChunkDuration: 10 * time.Millisecond, ManualActivity: true},
+			first:  map[string]any{"realtimeInput": map[string]any{"activityStart": map[string]any{}}},
+			last:   map[string]any{"realtimeInput": map[string]any{"activityEnd": map[string]any{}}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan []map[string]any)
+			client := newTestLiveClient(t, func(r *http.Request, conn *websocket.Conn) {
+				done <- readLiveMessages(conn)
+			})
+			session, err := client.Live.Connect(ctx, "test-model", nil)
+			if err != nil {
+				t.Fatalf("Connect() failed: %v", err)
+			}
+			start := time.Now()
+			if err := session.SendRealtimeAudio(ctx, bytes.NewReader(audio), tt.format); err != nil {
+				t.Fatalf("SendRealtimeAudio() failed: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+				t.Errorf("SendRealtimeAudio() took %v, want at least 20ms for 3 chunks of 10ms", elapsed)
+			}
+			session.Close()
+
+			// 10ms of 16 kHz mono PCM16 audio is 320 bytes.
+			var want []map[string]any
+			if tt.first != nil {
+				want = append(want, tt.first)
+			}
+			want = append(want, audioMessage(audio[:320]), audioMessage(audio[320:640]), audioMessage(audio[640:]), tt.last)
+			got := <-done
+			if diff := cmp.Diff(want, got[1:]); diff != "" {
+				t.Errorf("messages mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSendRealtimeAudioCancelled(t *testing.T) {
+	done := make(chan []map[string]any)
+	client := newTestLiveClient(t, func(r *http.Request, conn *websocket.Conn) {
+		done <- readLiveMessages(conn)
+	})
+	session, err := client.Live.Connect(context.Background(), "test-model", nil)
+	if err != nil {
+		t.Fatalf("Connect() failed: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = session.SendRealtimeAudio(ctx, bytes.NewReader(make([]byte, 32000)), LiveAudioFormat{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SendRealtimeAudio() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	session.Close()
+	messages := <-done
+	if diff := cmp.Diff(map[string]any{"realtimeInput": map[string]any{"audioStreamEnd": true}}, messages[len(messages)-1]); diff != "" {
+		t.Errorf("last message mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLiveAudioOutput(t *testing.T) {
+	ctx := context.Background()
+	audioMessage := func(mimeType string, data ...byte) *LiveServerMessage {
+		return &LiveServerMessage{ServerContent: &LiveServerContent{ModelTurn: &Content{Parts: []*Part{
+			{Text: "ignored"},
+			{InlineData: &Blob{MIMEType: mimeType, Data: data}},
+		}}}}
+	}
+	interrupted := 0
+	out := NewLiveAudioOutput()
+	out.OnInterrupt = func() { interrupted++ }
+
+	out.Handle(audioMessage("audio/pcm;rate=24000", 1, 2, 3, 4))
+	out.Handle(audioMessage("image/png", 9))
+	buf := make([]byte, 3)
+	if n, err := out.Read(buf); err != nil || n != 3 || !bytes.Equal(buf, []byte{1, 2, 3}) {
+		t.Errorf("Read() = %d, %v, %v, want 3, nil, [1 2 3]", n, err, buf)
+	}
+	frame, err := out.ReadFrame(ctx)
+	if err != nil {
+		t.Fatalf("ReadFrame() failed: %v", err)
+	}
+	if diff := cmp.Diff(&LiveAudioFrame{Data: []byte{4}, SampleRate: 24000}, frame); diff != "" {
+		t.Errorf("ReadFrame() mismatch (-want +got):\n%s", diff)
+	}
+
+	out.Handle(audioMessage("audio/pcm;rate=16000", 5, 6))
+	if got := out.SampleRate(); got != 16000 {
+		t.Errorf("SampleRate() = %d, want 16000", got)
+	}
+	out.Handle(&LiveServerMessage{ServerContent: &LiveServerContent{Interrupted: true}})
+	if interrupted != 1 {
+		t.Errorf("OnInterrupt called %d times, want 1", interrupted)
+	}
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := out.ReadFrame(timeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ReadFrame() error = %v, want %v after the interruption", err, context.DeadlineExceeded)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		out.Handle(audioMessage("audio/pcm", 7, 8))
+		out.Close()
+	}()
+	all, err := io.ReadAll(out)
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if !bytes.Equal(all, []byte{7, 8}) {
+		t.Errorf("ReadAll() = %v, want [7 8]", all)
+	}
+}
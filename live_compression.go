@@ -0,0 +1,58 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import "fmt"
+
+// Preview. NewSlidingWindowCompression returns a context window compression
+// config which, once the context of a Live session reaches triggerTokens tokens,
+// drops its oldest turns until targetTokens tokens are left. A zero triggerTokens
+// or targetTokens uses the default of the server, e.g. half the trigger tokens
+// for the target:
+//
+//	session, err := client.Live.Connect(ctx, model, &genai.LiveConnectConfig{
+//		ContextWindowCompression: genai.NewSlidingWindowCompression(25600, 12800),
+//	})
+func NewSlidingWindowCompression(triggerTokens, targetTokens int64) *ContextWindowCompressionConfig {
+	c := &ContextWindowCompressionConfig{SlidingWindow: &SlidingWindow{}}
+	if triggerTokens != 0 {
+		c.TriggerTokens = &triggerTokens
+	}
+	if targetTokens != 0 {
+		c.SlidingWindow.TargetTokens = &targetTokens
+	}
+	return c
+}
+
+// withDefaults validates the token counts of the config, and returns it with the
+// sliding window mechanism if it has no mechanism, as the server does not compress
+// the context otherwise.
+func (c *ContextWindowCompressionConfig) withDefaults() (*ContextWindowCompressionConfig, error) {
+	if c == nil {
+		return nil, nil
+	}
+	if c.TriggerTokens != nil && *c.TriggerTokens <= 0 {
+		return nil, fmt.Errorf("context window compression TriggerTokens must be positive, got %d", *c.TriggerTokens)
+	}
+	if c.SlidingWindow == nil {
+		d := *c
+		d.SlidingWindow = &SlidingWindow{}
+		return &d, nil
+	}
+	if target := c.SlidingWindow.TargetTokens; target != nil && *target <= 0 {
+		return nil, fmt.Errorf("sliding window TargetTokens must be positive, got %d", *target)
+	}
+	return c, nil
+}
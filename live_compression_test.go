@@ -0,0 +1,90 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+)
+
+func TestLiveContextWindowCompression(t *testing.T) {
+	tests := []struct {
+		name        string
+		compression *ContextWindowCompressionConfig
+		want        map[string]any
+		wantErr     bool
+	}{
+		{
+			name:        "sliding window",
+			compression: NewSlidingWindowCompression(25600, 12800),
+			want:        map[string]any{"triggerTokens": "25600", "slidingWindow": map[string]any{"targetTokens": "12800"}},
+		},
+		{
+			name:        "server defaults",
+			compression: NewSlidingWindowCompression(0, 0),
+			want:        map[string]any{"slidingWindow": map[string]any{}},
+		},
+		{
+			name:        "default mechanism",
+			compression: &ContextWindowCompressionConfig{TriggerTokens: Ptr[int64](25600)},
+			want:        map[string]any{"triggerTokens": "25600", "slidingWindow": map[string]any{}},
+		},
+		{
+			name:        "invalid trigger tokens",
+			compression: NewSlidingWindowCompression(-1, 0),
+			wantErr:     true,
+		},
+		{
+			name:        "invalid target tokens",
+			compression: NewSlidingWindowCompression(25600, -1),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setups := make(chan map[string]any, 1)
+			client := newTestLiveClient(t, func(r *http.Request, conn *websocket.Conn) {
+				if messages := readLiveMessages(conn); len(messages) > 0 {
+					setups <- messages[0]
+				}
+			})
+			config := &LiveConnectConfig{ContextWindowCompression: tt.compression}
+			session, err := client.Live.Connect(context.Background(), "gemini-2.0-flash-live-001", config)
+			if tt.wantErr {
+				if err == nil {
+					session.Close()
+					t.Fatalf("Connect() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Connect() failed: %v", err)
+			}
+			session.Close()
+			setup := <-setups
+			got := setup["setup"].(map[string]any)["contextWindowCompression"]
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("contextWindowCompression mismatch (-want +got):\n%s", diff)
+			}
+			if config.ContextWindowCompression != tt.compression {
+				t.Errorf("Connect() modified the config")
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// The musical scale of the generated music.
+type Scale string
+
+const (
+	// Default value. This value is unused.
+	ScaleUnspecified Scale = "SCALE_UNSPECIFIED"
+	// C major or A minor.
+	ScaleCMajorAMinor Scale = "C_MAJOR_A_MINOR"
+	// Db major or Bb minor.
+	ScaleDFlatMajorBFlatMinor Scale = "D_FLAT_MAJOR_B_FLAT_MINOR"
+	// D major or B minor.
+	ScaleDMajorBMinor Scale = "D_MAJOR_B_MINOR"
+	// Eb major or C minor.
+	ScaleEFlatMajorCMinor Scale = "E_FLAT_MAJOR_C_MINOR"
+	// E major or Db minor.
+	ScaleEMajorDFlatMinor Scale = "E_MAJOR_D_FLAT_MINOR"
+	// F major or D minor.
+	ScaleFMajorDMinor Scale = "F_MAJOR_D_MINOR"
+	// Gb major or Eb minor.
+	ScaleGFlatMajorEFlatMinor Scale = "G_FLAT_MAJOR_E_FLAT_MINOR"
+	// G major or E minor.
+	ScaleGMajorEMinor Scale = "G_MAJOR_E_MINOR"
+	// Ab major or F minor.
+	ScaleAFlatMajorFMinor Scale = "A_FLAT_MAJOR_F_MINOR"
+	// A major or Gb minor.
+	ScaleAMajorGFlatMinor Scale = "A_MAJOR_G_FLAT_MINOR"
+	// Bb major or G minor.
+	ScaleBFlatMajorGMinor Scale = "B_FLAT_MAJOR_G_MINOR"
+	// B major or Ab minor.
+	ScaleBMajorAFlatMinor Scale = "B_MAJOR_A_FLAT_MINOR"
+)
+
+// The aspect of the music that the model focuses on.
+type MusicGenerationMode string
+
+const (
+	// Default value. The model chooses the mode.
+	MusicGenerationModeUnspecified MusicGenerationMode = "MUSIC_GENERATION_MODE_UNSPECIFIED"
+	// The model focuses on the quality of the music.
+	MusicGenerationModeQuality MusicGenerationMode = "QUALITY"
+	// The model focuses on the diversity of the music.
+	MusicGenerationModeDiversity MusicGenerationMode = "DIVERSITY"
+)
+
+// The playback control signal of a realtime music generation session.
+type LiveMusicPlaybackControl string
+
+const (
+	// This value is unused.
+	LiveMusicPlaybackControlUnspecified LiveMusicPlaybackControl = "PLAYBACK_CONTROL_UNSPECIFIED"
+	// Start generating the music.
+	LiveMusicPlaybackControlPlay LiveMusicPlaybackControl = "PLAY"
+	// Hold the music generation. Use PLAY to resume from the current position.
+	LiveMusicPlaybackControlPause LiveMusicPlaybackControl = "PAUSE"
+	// Stop the music generation and reset the context (prompts retained). Use PLAY
+	// to restart the music generation.
+	LiveMusicPlaybackControlStop LiveMusicPlaybackControl = "STOP"
+	// Reset the context of the music generation without stopping it. Retains the
+	// current prompts and config.
+	LiveMusicPlaybackControlResetContext LiveMusicPlaybackControl = "RESET_CONTEXT"
+)
+
+// A text prompt steering the music generation, with its weight relative to the
+// other prompts.
+type WeightedPrompt struct {
+	// Required. The text of the prompt, e.g. "minimal techno".
+	Text string `json:"text,omitempty"`
+	// Required. The weight of the prompt. The weights are normalized across the
+	// prompts, and must not be zero.
+	Weight float32 `json:"weight,omitempty"`
+}
+
+// The configuration of the music generation.
+type LiveMusicGenerationConfig struct {
+	// Optional. Controls the variance in the audio generation. Higher values produce
+	// higher variance. Range is [0.0, 3.0].
+	Temperature *float32 `json:"temperature,omitempty"`
+	// Optional. Controls how the model selects tokens for the output. Samples the
+	// topK tokens with the highest probabilities. Range is [1, 1000].
+	TopK *int32 `json:"topK,omitempty"`
+	// Optional. Seeds the audio generation. If not set, the request uses a randomly
+	// generated seed.
+	Seed *int32 `json:"seed,omitempty"`
+	// Optional. Controls how closely the model follows the prompts. Higher guidance
+	// follows the prompts more closely, but makes the transitions more abrupt.
+	// Range is [0.0, 6.0].
+	Guidance *float32 `json:"guidance,omitempty"`
+	// Optional. The beats per minute. Range is [60, 200]. Takes effect once the
+	// context is reset, see [LiveMusicSession.ResetContext].
+	BPM *int32 `json:"bpm,omitempty"`
+	// Optional. The density of the notes. Range is [0.0, 1.0].
+	Density *float32 `json:"density,omitempty"`
+	// Optional. The brightness of the music. Range is [0.0, 1.0].
+	Brightness *float32 `json:"brightness,omitempty"`
+	// Optional. The scale of the music. Takes effect once the context is reset,
+	// see [LiveMusicSession.ResetContext].
+	Scale Scale `json:"scale,omitempty"`
+	// Optional. Whether the audio output has no bass.
+	MuteBass bool `json:"muteBass,omitempty"`
+	// Optional. Whether the audio output has no drums.
+	MuteDrums bool `json:"muteDrums,omitempty"`
+	// Optional. Whether the audio output has only bass and drums.
+	OnlyBassAndDrums bool `json:"onlyBassAndDrums,omitempty"`
+	// Optional. The mode of the music generation.
+	MusicGenerationMode MusicGenerationMode `json:"musicGenerationMode,omitempty"`
+}
+
+// The weighted prompts sent to a realtime music generation session.
+type LiveMusicClientContent struct {
+	// The weighted prompts steering the music generation.
+	WeightedPrompts []*WeightedPrompt `json:"weightedPrompts,omitempty"`
+}
+
+// The prompts and config from which an audio chunk was generated.
+type LiveMusicSourceMetadata struct {
+	// The weighted prompts of the audio chunk.
+	ClientContent *LiveMusicClientContent `json:"clientContent,omitempty"`
+	// The music generation config of the audio chunk.
+	MusicGenerationConfig *LiveMusicGenerationConfig `json:"musicGenerationConfig,omitempty"`
+}
+
+// A chunk of the audio generated by a realtime music generation session.
+type AudioChunk struct {
+	// Raw bytes of the audio, e.g. 48 kHz stereo 16-bit PCM.
+	Data []byte `json:"data,omitempty"`
+	// The MIME type of the audio, e.g. "audio/l16;rate=48000;channels=2".
+	MIMEType string `json:"mimeType,omitempty"`
+	// The prompts and config from which the audio was generated.
+	SourceMetadata *LiveMusicSourceMetadata `json:"sourceMetadata,omitempty"`
+}
+
+// Sent in response to the setup message of a realtime music generation session.
+type LiveMusicServerSetupComplete struct {
+}
+
+// The audio generated by a realtime music generation session.
+type LiveMusicServerContent struct {
+	// The chunks of the generated audio.
+	AudioChunks []*AudioChunk `json:"audioChunks,omitempty"`
+}
+
+// A prompt filtered out by the safety filters of the model.
+type LiveMusicFilteredPrompt struct {
+	// The text of the filtered prompt.
+	Text string `json:"text,omitempty"`
+	// The reason why the prompt was filtered.
+	FilteredReason string `json:"filteredReason,omitempty"`
+}
+
+// A message received from a realtime music generation session.
+type LiveMusicServerMessage struct {
+	// Sent in response to the setup message of the session.
+	SetupComplete *LiveMusicServerSetupComplete `json:"setupComplete,omitempty"`
+	// The audio generated by the model.
+	ServerContent *LiveMusicServerContent `json:"serverContent,omitempty"`
+	// A prompt filtered out by the safety filters of the model.
+	FilteredPrompt *LiveMusicFilteredPrompt `json:"filteredPrompt,omitempty"`
+}
+
+// Preview. LiveMusicConnectConfig is the optional configuration of
+// [LiveMusic.Connect].
+type LiveMusicConnectConfig struct {
+	// Optional. Used to override HTTP request options. The API version defaults to
+	// v1alpha, the only version serving realtime music generation.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+}
+
+// Preview. LiveMusic serves as the entry point for establishing realtime music
+// generation sessions, e.g. with the "lyria-realtime-exp" model. Access it through
+// the Music field of [Live]:
+//
+//	session, err := client.Live.Music.Connect(ctx, "lyria-realtime-exp", nil)
+//	if err != nil {
+//		return err
+//	}
+//	defer session.Close()
+//	session.SetWeightedPrompts([]*genai.WeightedPrompt{{Text: "minimal techno", Weight: 1}})
+//	session.SetMusicGenerationConfig(&genai.LiveMusicGenerationConfig{BPM: genai.Ptr[int32](120)})
+//	session.Play()
+//	for {
+//		message, err := session.Receive()
+//		if err != nil {
+//			return err
+//		}
+//		if message.ServerContent != nil {
+//			for _, chunk := range message.ServerContent.AudioChunks {
+//				speaker.Write(chunk.Data)
+//			}
+//		}
+//	}
+type LiveMusic struct {
+	apiClient *apiClient
+}
+
+// Preview. LiveMusicSession is a realtime music generation session. The control
+// methods can be called concurrently with each other and with
+// [LiveMusicSession.Receive], which must only be called from one goroutine at a
+// time.
+type LiveMusicSession struct {
+	conn *websocket.Conn
+
+	// writeMu serializes the writes to conn, which supports a single concurrent
+	// writer.
+	writeMu sync.Mutex
+}
+
+// Preview. Connect establishes a realtime music generation session with the
+// model, and sends its setup message. It is only supported in the Gemini API.
+//
+// The context bounds the establishment of the connection only.
+func (r *LiveMusic) Connect(ctx context.Context, model string, config *LiveMusicConnectConfig) (*LiveMusicSession, error) {
+	if r.apiClient.clientConfig.Backend == BackendVertexAI {
+		return nil, fmt.Errorf("method Connect is only supported in the Gemini Developer client. You can choose to use Gemini Developer client by setting ClientConfig.Backend to BackendGeminiAPI.")
+	}
+	httpOptions := &HTTPOptions{APIVersion: "v1alpha"}
+	if config != nil && config.HTTPOptions != nil {
+		httpOptions = config.HTTPOptions
+		if httpOptions.APIVersion == "" {
+			c := *httpOptions
+			c.APIVersion = "v1alpha"
+			httpOptions = &c
+		}
+	}
+	modelName, err := tModel(r.apiClient, model)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := (&Live{apiClient: r.apiClient}).dial(ctx, httpOptions, "BidiGenerateMusic")
+	if err != nil {
+		return nil, err
+	}
+	s := &LiveMusicSession{conn: conn}
+	if err := s.send(map[string]any{"setup": map[string]any{"model": modelName}}); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to write the setup message: %w", err)
+	}
+	return s, nil
+}
+
+// Preview. SetWeightedPrompts replaces the prompts steering the music generation.
+// The music transitions smoothly to the new prompts.
+func (s *LiveMusicSession) SetWeightedPrompts(prompts []*WeightedPrompt) error {
+	if len(prompts) == 0 {
+		return fmt.Errorf("at least one weighted prompt is required")
+	}
+	return s.send(map[string]any{"clientContent": &LiveMusicClientContent{WeightedPrompts: prompts}})
+}
+
+// Preview. SetMusicGenerationConfig replaces the configuration of the music
+// generation. The unset fields are reset to their defaults. Some fields, e.g. the
+// BPM and the scale, only take effect once the context is reset, see
+// [LiveMusicSession.ResetContext].
+func (s *LiveMusicSession) SetMusicGenerationConfig(config *LiveMusicGenerationConfig) error {
+	if config == nil {
+		config = &LiveMusicGenerationConfig{}
+	}
+	return s.send(map[string]any{"musicGenerationConfig": config})
+}
+
+// Preview. SendPlaybackControl sends a playback control signal to the session.
+func (s *LiveMusicSession) SendPlaybackControl(control LiveMusicPlaybackControl) error {
+	return s.send(map[string]any{"playbackControl": control})
+}
+
+// Preview. Play starts or resumes the music generation.
+func (s *LiveMusicSession) Play() error {
+	return s.SendPlaybackControl(LiveMusicPlaybackControlPlay)
+}
+
+// Preview. Pause holds the music generation, to be resumed with
+// [LiveMusicSession.Play].
+func (s *LiveMusicSession) Pause() error {
+	return s.SendPlaybackControl(LiveMusicPlaybackControlPause)
+}
+
+// Preview. Stop stops the music generation and resets its context, retaining the
+// prompts and the config.
+func (s *LiveMusicSession) Stop() error {
+	return s.SendPlaybackControl(LiveMusicPlaybackControlStop)
+}
+
+// Preview. ResetContext resets the context of the music generation without
+// stopping it, e.g. to apply a new BPM or scale.
+func (s *LiveMusicSession) ResetContext() error {
+	return s.SendPlaybackControl(LiveMusicPlaybackControlResetContext)
+}
+
+// Preview. Receive reads the next message of the session, blocking until it is
+// received.
+func (s *LiveMusicSession) Receive() (*LiveMusicServerMessage, error) {
+	messageType, msgBytes, err := s.conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	var errorMessage struct {
+		Error any `json:"error"`
+	}
+	if err := json.Unmarshal(msgBytes, &errorMessage); err != nil {
+		return nil, fmt.Errorf("invalid message format. Error %w. messageType: %d, message: %s", err, messageType, msgBytes)
+	}
+	if errorMessage.Error != nil {
+		return nil, fmt.Errorf("received error in response: %v", string(msgBytes))
+	}
+	message := new(LiveMusicServerMessage)
+	if err := json.Unmarshal(msgBytes, message); err != nil {
+		return nil, fmt.Errorf("invalid message format. Error %w. messageType: %d, message: %s", err, messageType, msgBytes)
+	}
+	return message, nil
+}
+
+// Preview. Close terminates the connection.
+func (s *LiveMusicSession) Close() error {
+	if s != nil && s.conn != nil {
+		return s.conn.Close()
+	}
+	return nil
+}
+
+// send writes a client message to the connection.
+func (s *LiveMusicSession) send(message map[string]any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("marshal client message error: %w", err)
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.conn.WriteMessage(websocket.TextMessage, data)
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+)
+
+func TestLiveMusicSession(t *testing.T) {
+	ctx := context.Background()
+	paths := make(chan string, 1)
+	done := make(chan []map[string]any)
+	client := newTestLiveClient(t, func(r *http.Request, conn *websocket.Conn) {
+		paths <- r.URL.Path
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"setupComplete": {}}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"serverContent": {"audioChunks": [{"data": "AAEC", "mimeType": "audio/l16;rate=48000;channels=2", "sourceMetadata": {"clientContent": {"weightedPrompts": [{"text": "minimal techno", "weight": 1}]}}}]}}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"filteredPrompt": {"text": "forbidden", "filteredReason": "unsafe"}}`))
+		done <- readLiveMessages(conn)
+	})
+
+	session, err := client.Live.Music.Connect(ctx, "lyria-realtime-exp", nil)
+	if err != nil {
+		t.Fatalf("Connect() failed: %v", err)
+	}
+	if err := session.SetWeightedPrompts([]*WeightedPrompt{{Text: "minimal techno", Weight: 1}, {Text: "piano", Weight: 0.5}}); err != nil {
+		t.Fatalf("SetWeightedPrompts() failed: %v", err)
+	}
+	if err := session.SetMusicGenerationConfig(&LiveMusicGenerationConfig{BPM: Ptr[int32](120), Density: Ptr[float32](0.25), Scale: ScaleDMajorBMinor}); err != nil {
+		t.Fatalf("SetMusicGenerationConfig() failed: %v", err)
+	}
+	for _, control := range []func() error{session.Play, session.Pause, session.Stop, session.ResetContext} {
+		if err := control(); err != nil {
+			t.Fatalf("playback control failed: %v", err)
+		}
+	}
+	if err := session.SetWeightedPrompts(nil); err == nil {
+		t.Errorf("SetWeightedPrompts(nil) succeeded, want error")
+	}
+
+	wantMessages := []*LiveMusicServerMessage{
+		{SetupComplete: &LiveMusicServerSetupComplete{}},
+		{ServerContent: &LiveMusicServerContent{AudioChunks: []*AudioChunk{{
+			Data:           []byte{0, 1, 2},
+			MIMEType:       "audio/l16;rate=48000;channels=2",
+			SourceMetadata: &LiveMusicSourceMetadata{ClientContent: &LiveMusicClientContent{WeightedPrompts: []*WeightedPrompt{{Text: "minimal techno", Weight: 1}}}},
+		}}}},
+		{FilteredPrompt: &LiveMusicFilteredPrompt{Text: "forbidden", FilteredReason: "unsafe"}},
+	}
+	for i, want := range wantMessages {
+		got, err := session.Receive()
+		if err != nil {
+			t.Fatalf("Receive() failed: %v", err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Receive() message %d mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+	session.Close()
+
+	if got, want := <-paths, "/ws/google.ai.generativelanguage.v1alpha.GenerativeService.BidiGenerateMusic"; got != want {
+		t.Errorf("Connect() requested %q, want %q", got, want)
+	}
+	want := []map[string]any{
+		{"setup": map[string]any{"model": "models/lyria-realtime-exp"}},
+		{"clientContent": map[string]any{"weightedPrompts": []any{
+			map[string]any{"text": "minimal techno", "weight": 1.0},
+			map[string]any{"text": "piano", "weight": 0.5},
+		}}},
+		{"musicGenerationConfig": map[string]any{"bpm": 120.0, "density": 0.25, "scale": "D_MAJOR_B_MINOR"}},
+		{"playbackControl": "PLAY"},
+		{"playbackControl": "PAUSE"},
+		{"playbackControl": "STOP"},
+		{"playbackControl": "RESET_CONTEXT"},
+	}
+	if diff := cmp.Diff(want, <-done); diff != "" {
+		t.Errorf("messages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLiveMusicConnectVertex(t *testing.T) {
+	client := newTestLiveClient(t, func(r *http.Request, conn *websocket.Conn) {
+		t.Errorf("Connect() connected, want error")
+	})
+	client.Live.Music.apiClient.clientConfig.Backend = BackendVertexAI
+	if _, err := client.Live.Music.Connect(context.Background(), "lyria-realtime-exp", nil); err == nil {
+		t.Errorf("Connect() succeeded, want error")
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// reconnectTimeout bounds the establishment of the connection of a resumed session,
+// until its setup is complete.
+const reconnectTimeout = 30 * time.Second
+
+// Preview. LiveReconnectConfig configures the automatic reconnection of a Live
+// session, see [LiveConnectConfig.Reconnect].
+//
+// The server terminates the connections of Live sessions after a while, before
+// which it sends a [LiveServerGoAway] message, and connections can be lost. If
+// the session is resumable, [Session.Receive] then resumes it on a new
+// connection, with the latest handle of the [LiveServerSessionResumptionUpdate]
+// messages. [LiveConnectConfig.SessionResumption] must be set for the server to
+// send these messages:
+//
+//	session, err := client.Live.Connect(ctx, model, &genai.LiveConnectConfig{
+//		SessionResumption: &genai.SessionResumptionConfig{},
+//		Reconnect: &genai.LiveReconnectConfig{
+//			OnReconnecting: func(*genai.LiveServerGoAway) { mic.Pause() },
+//			OnReconnected: func(err error) {
+//				if err == nil {
+//					mic.Resume()
+//				}
+//			},
+//		},
+//	})
+//
+// The GoAway message is still returned by Receive, and the session is resumed
+// by the next call. The messages sent meanwhile wait for the new connection.
+type LiveReconnectConfig struct {
+	// Optional. Called by [Session.Receive] before reconnecting, with the GoAway
+	// message received from the server, or nil if the connection was lost, e.g.
+	// to pause the capture of the microphone during the gap. It must not block.
+	OnReconnecting func(goAway *LiveServerGoAway)
+	// Optional. Called by [Session.Receive] once the session is resumed, or failed
+	// to, with the error. It must not block.
+	OnReconnected func(err error)
+}
+
+// trackResumption records the resumption handle and the GoAway of a message, to
+// reconnect if configured.
+func (s *Session) trackResumption(message *LiveServerMessage) {
+	if s.config == nil || s.config.Reconnect == nil {
+		return
+	}
+	if u := message.SessionResumptionUpdate; u != nil && u.Resumable && u.NewHandle != "" {
+		s.resumptionHandle = u.NewHandle
+	}
+	if message.GoAway != nil {
+		s.goAway = message.GoAway
+	}
+}
+
+// canReconnect reports whether the session can be resumed after losing its
+// connection.
+func (s *Session) canReconnect() bool {
+	if s.config == nil || s.config.Reconnect == nil || s.resumptionHandle == "" {
+		return false
+	}
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+	return !s.closed
+}
+
+// reconnect resumes the session on a new connection, calling the callbacks of the
+// reconnect config.
+func (s *Session) reconnect(goAway *LiveServerGoAway) error {
+	r := s.config.Reconnect
+	if r.OnReconnecting != nil {
+		r.OnReconnecting(goAway)
+	}
+	err := s.resume()
+	if err != nil {
+		err = fmt.Errorf("failed to resume the session: %w", err)
+	}
+	if r.OnReconnected != nil {
+		r.OnReconnected(err)
+	}
+	return err
+}
+
+// resume connects with the latest resumption handle, waits for the setup of the
+// resumed session to complete, and replaces the connection of the session. The
+// writes wait for the new connection.
+func (s *Session) resume() error {
+	config := *s.config
+	resumption := SessionResumptionConfig{}
+	if config.SessionResumption != nil {
+		resumption = *config.SessionResumption
+	}
+	resumption.Handle = s.resumptionHandle
+	config.SessionResumption = &resumption
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), reconnectTimeout)
+	defer cancel()
+	conn, err := (&Live{apiClient: s.apiClient}).dial(ctx, config.HTTPOptions, "BidiGenerateContent")
+	if err != nil {
+		return err
+	}
+	conn.SetReadDeadline(time.Now().Add(reconnectTimeout))
+	err = s.setup(conn, s.model, &config)
+	if err == nil {
+		var messageType int
+		var msgBytes []byte
+		if messageType, msgBytes, err = conn.ReadMessage(); err == nil {
+			// The first message is the completion of the setup, already received
+			// on the first connection.
+			_, err = s.parseMessage(messageType, msgBytes)
+		}
+	}
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	s.connMu.Lock()
+	defer s.connMu.Unlock()
+	if s.closed {
+		conn.Close()
+		return errors.New("session closed")
+	}
+	s.conn.Close()
+	s.conn = conn
+	return nil
+}
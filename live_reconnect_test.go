@@ -0,0 +1,135 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+)
+
+func TestSessionReconnect(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	connections := 0
+	handles := make(chan any, 3)
+	client := newTestLiveClient(t, func(r *http.Request, conn *websocket.Conn) {
+		mu.Lock()
+		connections++
+		n := connections
+		mu.Unlock()
+
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Errorf("ReadMessage() failed: %v", err)
+			return
+		}
+		var setup map[string]any
+		json.Unmarshal(data, &setup)
+		handles <- setup["setup"].(map[string]any)["sessionResumption"].(map[string]any)["handle"]
+
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"setupComplete": {}}`))
+		conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"sessionResumptionUpdate": {"newHandle": "handle-%d", "resumable": true}}`, n)))
+		switch n {
+		case 1:
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"goAway": {"timeLeft": "10s"}}`))
+		case 2:
+			// The connection is lost.
+			return
+		default:
+			conn.WriteMessage(websocket.TextMessage, []byte(`{"serverContent": {"turnComplete": true}}`))
+		}
+		readLiveMessages(conn)
+	})
+
+	var events []string
+	session, err := client.Live.Connect(ctx, "test-model", &LiveConnectConfig{
+		SessionResumption: &SessionResumptionConfig{},
+		Reconnect: &LiveReconnectConfig{
+			OnReconnecting: func(goAway *LiveServerGoAway) {
+				events = append(events, fmt.Sprintf("reconnecting %v", goAway))
+			},
+			OnReconnected: func(err error) {
+				events = append(events, fmt.Sprintf("reconnected %v", err))
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Connect() failed: %v", err)
+	}
+	defer session.Close()
+
+	want := []*LiveServerMessage{
+		{SetupComplete: &LiveServerSetupComplete{}},
+		{SessionResumptionUpdate: &LiveServerSessionResumptionUpdate{NewHandle: "handle-1", Resumable: true}},
+		{GoAway: &LiveServerGoAway{TimeLeft: 10 * time.Second}},
+		{SessionResumptionUpdate: &LiveServerSessionResumptionUpdate{NewHandle: "handle-2", Resumable: true}},
+		{SessionResumptionUpdate: &LiveServerSessionResumptionUpdate{NewHandle: "handle-3", Resumable: true}},
+		{ServerContent: &LiveServerContent{TurnComplete: true}},
+	}
+	var got []*LiveServerMessage
+	for range want {
+		message, err := session.Receive()
+		if err != nil {
+			t.Fatalf("Receive() failed: %v", err)
+		}
+		got = append(got, message)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Receive() mismatch (-want +got):\n%s", diff)
+	}
+
+	wantEvents := []string{
+		"reconnecting &{10s}",
+		"reconnected <nil>",
+		"reconnecting <nil>",
+		"reconnected <nil>",
+	}
+	if diff := cmp.Diff(wantEvents, events); diff != "" {
+		t.Errorf("events mismatch (-want +got):\n%s", diff)
+	}
+	var gotHandles []any
+	for range 3 {
+		gotHandles = append(gotHandles, <-handles)
+	}
+	if diff := cmp.Diff([]any{nil, "handle-1", "handle-2"}, gotHandles); diff != "" {
+		t.Errorf("resumption handles mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSessionReconnectDisabled(t *testing.T) {
+	client := newTestLiveClient(t, func(r *http.Request, conn *websocket.Conn) {
+		conn.ReadMessage()
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"sessionResumptionUpdate": {"newHandle": "handle", "resumable": true}}`))
+	})
+	session, err := client.Live.Connect(context.Background(), "test-model", &LiveConnectConfig{SessionResumption: &SessionResumptionConfig{}})
+	if err != nil {
+		t.Fatalf("Connect() failed: %v", err)
+	}
+	defer session.Close()
+	if _, err := session.Receive(); err != nil {
+		t.Fatalf("Receive() failed: %v", err)
+	}
+	if _, err := session.Receive(); err == nil {
+		t.Errorf("Receive() succeeded after the connection was lost, want error")
+	}
+}
@@ -16,9 +16,12 @@ package genai
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 
 	"cloud.google.com/go/auth"
@@ -528,3 +531,127 @@ func setupTestWebsocketServer(t *testing.T, wantRequestBodySlice []string, fakeR
 
 	return ts
 }
+
+// newTestLiveClient returns a Gemini API client connecting Live sessions to a
+// websocket server serving every connection with handler.
+func newTestLiveClient(t *testing.T, handler func(r *http.Request, conn *websocket.Conn)) *Client {
+	t.Helper()
+	var upgrader = websocket.Upgrader{}
+	client := newTestClient(t, BackendGeminiAPI, func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		handler(r, conn)
+	})
+	// Live sessions connect with the websocket scheme of the base URL.
+	httpOptions := &client.Live.apiClient.clientConfig.HTTPOptions
+	httpOptions.BaseURL = strings.Replace(httpOptions.BaseURL, "http", "ws", 1)
+	return client
+}
+
+// readLiveMessages reads the client messages of a connection until it is closed.
+func readLiveMessages(conn *websocket.Conn) []map[string]any {
+	var messages []map[string]any
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return messages
+		}
+		var message map[string]any
+		json.Unmarshal(data, &message)
+		messages = append(messages, message)
+	}
+}
+
+func TestLiveConnectConfigHTTPOptions(t *testing.T) {
+	ctx := context.Background()
+	client := newTestLiveClient(t, func(r *http.Request, conn *websocket.Conn) {
+		if got := r.Header.Get("client-header"); got != "client-value" {
+			t.Errorf("client-header = %q, want %q", got, "client-value")
+		}
+		if got := r.Header.Get("config-header"); got != "config-value" {
+			t.Errorf("config-header = %q, want %q", got, "config-value")
+		}
+		if !strings.Contains(r.URL.Path, ".v1alpha.") {
+			t.Errorf("path = %q, want API version v1alpha", r.URL.Path)
+		}
+		conn.ReadMessage()
+	})
+	client.Live.apiClient.clientConfig.HTTPOptions.Headers = http.Header{"client-header": {"client-value"}}
+	session, err := client.Live.Connect(ctx, "test-model", &LiveConnectConfig{
+		HTTPOptions: &HTTPOptions{APIVersion: "v1alpha", Headers: http.Header{"config-header": {"config-value"}}},
+	})
+	if err != nil {
+		t.Fatalf("Connect() failed: %v", err)
+	}
+	session.Close()
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := client.Live.Connect(cancelled, "test-model", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Connect() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestLiveSessionConcurrentSend(t *testing.T) {
+	ctx := context.Background()
+	const senders, messagesPerSender = 8, 20
+	done := make(chan []map[string]any)
+	client := newTestLiveClient(t, func(r *http.Request, conn *websocket.Conn) {
+		done <- readLiveMessages(conn)
+	})
+	session, err := client.Live.Connect(ctx, "test-model", nil)
+	if err != nil {
+		t.Fatalf("Connect() failed: %v", err)
+	}
+	var wg sync.WaitGroup
+	for range senders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range messagesPerSender {
+				if err := session.SendRealtimeInput(LiveRealtimeInput{Text: "a"}); err != nil {
+					t.Errorf("SendRealtimeInput() failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	session.Close()
+	if got, want := len(<-done), 1+senders*messagesPerSender; got != want {
+		t.Errorf("server received %d messages, want %d", got, want)
+	}
+}
+
+func TestLiveDialCustomBackend(t *testing.T) {
+	var got string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.RequestURI()
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Upgrade failed: %v", err)
+			return
+		}
+		conn.Close()
+	}))
+	t.Cleanup(ts.Close)
+	client, err := NewClient(context.Background(), &ClientConfig{
+		Backend:     BackendCustom,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	conn, err := client.Live.dial(context.Background(), nil, "BidiGenerateContent")
+	if err != nil {
+		t.Fatalf("dial() failed: %v", err)
+	}
+	conn.Close()
+	if want := "/ws/google.ai.generativelanguage.v1beta.GenerativeService.BidiGenerateContent"; got != want {
+		t.Errorf("dial() requested %q, want %q", got, want)
+	}
+}
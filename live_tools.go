@@ -0,0 +1,138 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+)
+
+// Preview. ReceiveWithTools reads the next server message like [Session.Receive],
+// and automatically executes the function calls requested by the model, like
+// [Models.GenerateContentWithTools] does for unary requests.
+//
+// Whenever the server sends a [LiveServerToolCall] whose function calls all have a
+// handler registered in afc, the handlers are started, and the message is not
+// returned. The handlers run concurrently with the session, so that realtime input
+// and output keep flowing, and the result of every call is sent back to the model
+// with [Session.SendToolResponse] as soon as it is available. A handler error is
+// reported to the model under the "error" key of the [FunctionResponse]. The calls
+// cancelled by a [LiveServerToolCallCancellation], e.g. because the user
+// interrupted the model, have their context cancelled and get no response, and the
+// cancellation message is not returned either.
+//
+// The other messages are returned, including the tool calls of functions without a
+// handler, which must be answered with [Session.SendToolResponse]. The context is
+// the parent of the contexts of the handlers, so it must outlive the calls, e.g. be
+// the context of the session. An error sending the response to a call is returned
+// by the next call to ReceiveWithTools.
+func (s *Session) ReceiveWithTools(ctx context.Context, afc *AutomaticFunctionCallingConfig) (*LiveServerMessage, error) {
+	for {
+		s.toolMu.Lock()
+		err := s.toolErr
+		s.toolMu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+
+		message, err := s.Receive()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case message.ToolCall != nil && afc != nil && len(message.ToolCall.FunctionCalls) > 0 && hasHandlers(message.ToolCall.FunctionCalls, afc.Handlers):
+			s.startToolCalls(ctx, message.ToolCall.FunctionCalls, afc)
+		case message.ToolCallCancellation != nil && s.cancelToolCalls(message.ToolCallCancellation.IDs):
+		default:
+			return message, nil
+		}
+	}
+}
+
+// startToolCalls starts the handlers of the function calls, running at most
+// afc.MaxConcurrentCalls handlers at a time, and sends the response of every call
+// which is not cancelled.
+func (s *Session) startToolCalls(ctx context.Context, functionCalls []*FunctionCall, afc *AutomaticFunctionCallingConfig) {
+	maxConcurrent := afc.MaxConcurrentCalls
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultMaxConcurrentCalls
+	}
+	sem := make(chan struct{}, maxConcurrent)
+	for _, fc := range functionCalls {
+		callCtx, cancel := context.WithCancel(ctx)
+		if fc.ID != "" {
+			s.toolMu.Lock()
+			if s.toolCalls == nil {
+				s.toolCalls = make(map[string]context.CancelFunc)
+			}
+			s.toolCalls[fc.ID] = cancel
+			s.toolMu.Unlock()
+		}
+		go func() {
+			defer func() {
+				cancel()
+				if fc.ID != "" {
+					s.toolMu.Lock()
+					delete(s.toolCalls, fc.ID)
+					s.toolMu.Unlock()
+				}
+			}()
+			select {
+			case sem <- struct{}{}:
+			case <-callCtx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			part := callFunction(callCtx, fc, afc.Handlers[fc.Name])
+			if callCtx.Err() != nil {
+				return
+			}
+			if err := s.SendToolResponse(LiveToolResponseInput{FunctionResponses: []*FunctionResponse{part.FunctionResponse}}); err != nil {
+				s.toolMu.Lock()
+				if s.toolErr == nil {
+					s.toolErr = fmt.Errorf("failed to send the response to the call %s of %s: %w", fc.ID, fc.Name, err)
+				}
+				s.toolMu.Unlock()
+			}
+		}()
+	}
+}
+
+// cancelToolCalls cancels the tool calls in progress with the given IDs, and
+// reports whether any of them was in progress.
+func (s *Session) cancelToolCalls(ids []string) bool {
+	s.toolMu.Lock()
+	defer s.toolMu.Unlock()
+	cancelled := false
+	for _, id := range ids {
+		if cancel, ok := s.toolCalls[id]; ok {
+			cancel()
+			delete(s.toolCalls, id)
+			cancelled = true
+		}
+	}
+	return cancelled
+}
+
+// cancelAllToolCalls cancels all the tool calls in progress.
+func (s *Session) cancelAllToolCalls() {
+	s.toolMu.Lock()
+	defer s.toolMu.Unlock()
+	for id, cancel := range s.toolCalls {
+		cancel()
+		delete(s.toolCalls, id)
+	}
+}
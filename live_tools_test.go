@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+)
+
+func TestSessionReceiveWithTools(t *testing.T) {
+	ctx := context.Background()
+	slowStarted := make(chan struct{})
+	slowCancelled := make(chan struct{})
+	responses := make(chan []map[string]any)
+	client := newTestLiveClient(t, func(r *http.Request, conn *websocket.Conn) {
+		conn.ReadMessage() // setup
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"toolCall": {"functionCalls": [
+			{"id": "1", "name": "getWeather", "args": {"city": "Paris"}},
+			{"id": "2", "name": "slow"}
+		]}}`))
+		<-slowStarted
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"toolCallCancellation": {"ids": ["2"]}}`))
+		<-slowCancelled
+		_, response, err := conn.ReadMessage()
+		if err != nil {
+			t.Errorf("ReadMessage() failed: %v", err)
+			return
+		}
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"toolCall": {"functionCalls": [{"id": "3", "name": "unknown"}]}}`))
+		messages := readLiveMessages(conn)
+		if len(messages) != 0 {
+			t.Errorf("server received %v, want no more messages", messages)
+		}
+		responses <- []map[string]any{{"raw": string(response)}}
+	})
+	session, err := client.Live.Connect(ctx, "test-model", nil)
+	if err != nil {
+		t.Fatalf("Connect() failed: %v", err)
+	}
+	afc := &AutomaticFunctionCallingConfig{Handlers: map[string]FunctionHandler{
+		"getWeather": func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			return map[string]any{"weather": "sunny in " + args["city"].(string)}, nil
+		},
+		"slow": func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			close(slowStarted)
+			<-ctx.Done()
+			close(slowCancelled)
+			return nil, ctx.Err()
+		},
+	}}
+
+	message, err := session.ReceiveWithTools(ctx, afc)
+	if err != nil {
+		t.Fatalf("ReceiveWithTools() failed: %v", err)
+	}
+	want := &LiveServerMessage{ToolCall: &LiveServerToolCall{FunctionCalls: []*FunctionCall{{ID: "3", Name: "unknown"}}}}
+	if diff := cmp.Diff(want, message); diff != "" {
+		t.Errorf("ReceiveWithTools() mismatch (-want +got):\n%s", diff)
+	}
+	session.Close()
+
+	got := (<-responses)[0]["raw"]
+	wantResponse := `{"toolResponse":{"functionResponses":[{"id":"1","name":"getWeather","response":{"weather":"sunny in Paris"}}]}}`
+	if got != wantResponse {
+		t.Errorf("tool response = %s, want %s", got, wantResponse)
+	}
+}
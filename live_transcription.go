@@ -0,0 +1,119 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"strings"
+	"sync"
+)
+
+// Preview. LiveTranscriptSource is the speaker of the audio of a [LiveTranscript].
+type LiveTranscriptSource string
+
+const (
+	// The audio sent by the user, transcribed if
+	// [LiveConnectConfig.InputAudioTranscription] is set.
+	LiveTranscriptSourceInput LiveTranscriptSource = "input"
+	// The audio generated by the model, transcribed if
+	// [LiveConnectConfig.OutputAudioTranscription] is set.
+	LiveTranscriptSourceOutput LiveTranscriptSource = "output"
+)
+
+// Preview. LiveTranscript is an incremental transcription of the audio of a Live
+// session, e.g. to be displayed as a caption.
+type LiveTranscript struct {
+	// The speaker of the transcribed audio.
+	Source LiveTranscriptSource
+	// The text transcribed since the previous transcript of the source.
+	Text string
+	// The text transcribed since the beginning of the turn, the text of this
+	// transcript included.
+	TurnText string
+	// Whether the transcription of the turn is finished.
+	Finished bool
+}
+
+// Preview. LiveCaptions accumulates the input and output audio transcriptions of
+// the server messages of a Live session turn by turn. It is fed with
+// [LiveCaptions.Handle] from the receive loop of the session:
+//
+//	session, _ := client.Live.Connect(ctx, model, &genai.LiveConnectConfig{
+//		InputAudioTranscription:  &genai.AudioTranscriptionConfig{},
+//		OutputAudioTranscription: &genai.AudioTranscriptionConfig{},
+//	})
+//	var captions genai.LiveCaptions
+//	for {
+//		message, err := session.Receive()
+//		if err != nil {
+//			return err
+//		}
+//		for _, t := range captions.Handle(message) {
+//			fmt.Printf("%s: %s\n", t.Source, t.TurnText)
+//		}
+//	}
+//
+// The zero value is ready to use. It is safe for concurrent use.
+type LiveCaptions struct {
+	mu     sync.Mutex
+	input  strings.Builder
+	output strings.Builder
+}
+
+// Handle returns the transcripts of a server message, in the order input then
+// output. A finished transcript starts a new turn of its source, a message
+// completing the turn starts new turns of both sources, and a message signaling an
+// interruption starts a new turn of the output only, as the transcription of the
+// interrupting input may already have started.
+func (c *LiveCaptions) Handle(message *LiveServerMessage) []*LiveTranscript {
+	if message == nil || message.ServerContent == nil {
+		return nil
+	}
+	content := message.ServerContent
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var transcripts []*LiveTranscript
+	if t := content.InputTranscription; t != nil {
+		c.input.WriteString(t.Text)
+		transcripts = append(transcripts, &LiveTranscript{Source: LiveTranscriptSourceInput, Text: t.Text, TurnText: c.input.String(), Finished: t.Finished})
+		if t.Finished {
+			c.input.Reset()
+		}
+	}
+	if t := content.OutputTranscription; t != nil {
+		c.output.WriteString(t.Text)
+		transcripts = append(transcripts, &LiveTranscript{Source: LiveTranscriptSourceOutput, Text: t.Text, TurnText: c.output.String(), Finished: t.Finished})
+		if t.Finished {
+			c.output.Reset()
+		}
+	}
+	if content.TurnComplete {
+		c.input.Reset()
+	}
+	if content.TurnComplete || content.Interrupted {
+		c.output.Reset()
+	}
+	return transcripts
+}
+
+// TurnText returns the text transcribed from the source since the beginning of the
+// current turn.
+func (c *LiveCaptions) TurnText(source LiveTranscriptSource) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if source == LiveTranscriptSourceInput {
+		return c.input.String()
+	}
+	return c.output.String()
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+)
+
+func TestLiveCaptions(t *testing.T) {
+	ctx := context.Background()
+	serverMessages := []string{
+		`{"setupComplete": {}}`,
+		`{"serverContent": {"inputTranscription": {"text": "Hello, "}}}`,
+		`{"serverContent": {"inputTranscription": {"text": "how are you?", "finished": true}}}`,
+		`{"serverContent": {"outputTranscription": {"text": "I am "}, "modelTurn": {"parts": [{"inlineData": {"mimeType": "audio/pcm", "data": "AAA="}}]}}}`,
+		`{"serverContent": {"interrupted": true}}`,
+		`{"serverContent": {"inputTranscription": {"text": "Stop."}}}`,
+		`{"serverContent": {"outputTranscription": {"text": "Sure."}}}`,
+		`{"serverContent": {"turnComplete": true}}`,
+	}
+	client := newTestLiveClient(t, func(r *http.Request, conn *websocket.Conn) {
+		_, setup, err := conn.ReadMessage()
+		if err != nil {
+			t.Errorf("ReadMessage() failed: %v", err)
+			return
+		}
+		if want := `{"setup":{"inputAudioTranscription":{},"model":"models/test-model","outputAudioTranscription":{}}}`; string(setup) != want {
+			t.Errorf("setup = %s, want %s", setup, want)
+		}
+		for _, m := range serverMessages {
+			conn.WriteMessage(websocket.TextMessage, []byte(m))
+		}
+		conn.ReadMessage()
+	})
+	session, err := client.Live.Connect(ctx, "test-model", &LiveConnectConfig{
+		InputAudioTranscription:  &AudioTranscriptionConfig{},
+		OutputAudioTranscription: &AudioTranscriptionConfig{},
+	})
+	if err != nil {
+		t.Fatalf("Connect() failed: %v", err)
+	}
+	defer session.Close()
+
+	var captions LiveCaptions
+	var got []*LiveTranscript
+	for range serverMessages {
+		message, err := session.Receive()
+		if err != nil {
+			t.Fatalf("Receive() failed: %v", err)
+		}
+		got = append(got, captions.Handle(message)...)
+	}
+	want := []*LiveTranscript{
+		{Source: LiveTranscriptSourceInput, Text: "Hello, ", TurnText: "Hello, "},
+		{Source: LiveTranscriptSourceInput, Text: "how are you?", TurnText: "Hello, how are you?", Finished: true},
+		{Source: LiveTranscriptSourceOutput, Text: "I am ", TurnText: "I am "},
+		{Source: LiveTranscriptSourceInput, Text: "Stop.", TurnText: "Stop."},
+		{Source: LiveTranscriptSourceOutput, Text: "Sure.", TurnText: "Sure."},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Handle() mismatch (-want +got):\n%s", diff)
+	}
+	if text := captions.TurnText(LiveTranscriptSourceInput); text != "" {
+		t.Errorf("TurnText() = %q after the turn completed, want empty", text)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"time"
+)
+
+const (
+	defaultVideoFrameRate    = 1
+	defaultVideoMaxDimension = 768
+	defaultVideoJPEGQuality  = 75
+)
+
+// Preview. LiveVideoFormat describes how the video frames sent with
+// [Session.SendVideoFrame] and [Session.SendRealtimeVideo] are encoded: as JPEG
+// stills, downscaled to fit the maximum dimension.
+type LiveVideoFormat struct {
+	// Optional. The maximum number of frames sent per second by
+	// [Session.SendRealtimeVideo]. Defaults to 1, the rate at which the Live API
+	// samples video.
+	FrameRate float64
+	// Optional. The maximum width and height of the frames, in pixels. Larger frames
+	// are downscaled, keeping their aspect ratio. Defaults to 768.
+	MaxDimension int
+	// Optional. The JPEG quality, from 1 to 100. Defaults to 75.
+	Quality int
+}
+
+// withDefaults returns the format with the defaults of its unset fields.
+func (f LiveVideoFormat) withDefaults() LiveVideoFormat {
+	if f.FrameRate <= 0 {
+		f.FrameRate = defaultVideoFrameRate
+	}
+	if f.MaxDimension <= 0 {
+		f.MaxDimension = defaultVideoMaxDimension
+	}
+	if f.Quality <= 0 {
+		f.Quality = defaultVideoJPEGQuality
+	}
+	return f
+}
+
+// Preview. EncodeVideoFrame downscales the image to fit the maximum dimension of
+// the format, if larger, and encodes it as a JPEG blob to be sent as a realtime
+// video input.
+func EncodeVideoFrame(img image.Image, format LiveVideoFormat) (*Blob, error) {
+	format = format.withDefaults()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, DownscaleImage(img, format.MaxDimension), &jpeg.Options{Quality: format.Quality}); err != nil {
+		return nil, fmt.Errorf("error encoding video frame: %w", err)
+	}
+	return &Blob{Data: buf.Bytes(), MIMEType: "image/jpeg"}, nil
+}
+
+// Preview. DownscaleImage returns the image scaled down, keeping its aspect
+// ratio, so that neither its width nor its height exceeds maxDimension. Every
+// pixel of the returned image is the average of the pixels of the image it
+// covers. The image is returned as is if it already fits.
+func DownscaleImage(img image.Image, maxDimension int) image.Image {
+	b := img.Bounds()
+	srcW, srcH := b.Dx(), b.Dy()
+	if maxDimension <= 0 || (srcW <= maxDimension && srcH <= maxDimension) {
+		return img
+	}
+	dstW, dstH := maxDimension, max(1, srcH*maxDimension/srcW)
+	if srcH > srcW {
+		dstW, dstH = max(1, srcW*maxDimension/srcH), maxDimension
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0, y1 := b.Min.Y+y*srcH/dstH, b.Min.Y+max((y+1)*srcH/dstH, y*srcH/dstH+1)
+		for x := 0; x < dstW; x++ {
+			x0, x1 := b.Min.X+x*srcW/dstW, b.Min.X+max((x+1)*srcW/dstW, x*srcW/dstW+1)
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, bl, a, n = r+uint64(pr), g+uint64(pg), bl+uint64(pb), a+uint64(pa), n+1
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = uint8(r / n >> 8)
+			dst.Pix[i+1] = uint8(g / n >> 8)
+			dst.Pix[i+2] = uint8(bl / n >> 8)
+			dst.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+	return dst
+}
+
+// Preview. SendVideoFrame encodes the image as configured by the format, see
+// [EncodeVideoFrame], and sends it to the session as a realtime video input.
+func (s *Session) SendVideoFrame(img image.Image, format LiveVideoFormat) error {
+	blob, err := EncodeVideoFrame(img, format)
+	if err != nil {
+		return err
+	}
+	return s.SendRealtimeInput(LiveRealtimeInput{Video: blob})
+}
+
+// Preview. SendRealtimeVideo streams the frames received from the channel to the
+// session, e.g. from a camera, until the channel is closed or the context is done,
+// alongside the audio sent with [Session.SendRealtimeAudio].
+//
+// The frames are sent at most at the frame rate of the format: as the model
+// samples the video at a low rate, only the latest frame received is sent when
+// the next frame is due, and the others are dropped. A frame pending when the
+// channel is closed is still sent. The frames are encoded as configured by the
+// format, see [EncodeVideoFrame]:
+//
+//	frames := make(chan image.Image)
+//	go capture(frames) // e.g. from a webcam, at 30 frames per second.
+//	go func() {
+//		err := session.SendRealtimeVideo(ctx, frames, genai.LiveVideoFormat{})
+//	}()
+func (s *Session) SendRealtimeVideo(ctx context.Context, frames <-chan image.Image, format LiveVideoFormat) error {
+	format = format.withDefaults()
+	interval := time.Duration(float64(time.Second) / format.FrameRate)
+	var latest image.Image
+	var next time.Time
+	var timer *time.Timer
+	var due <-chan time.Time
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case img, ok := <-frames:
+			if !ok {
+				if due == nil {
+					return nil
+				}
+				// The latest frame is still sent when it is due.
+				frames = nil
+				continue
+			}
+			latest = img
+			if due != nil {
+				// The frame is sent when it is due.
+				continue
+			}
+			if d := time.Until(next); d > 0 {
+				timer = time.NewTimer(d)
+				due = timer.C
+				continue
+			}
+		case <-due:
+			due = nil
+		}
+		if latest == nil {
+			continue
+		}
+		next = time.Now().Add(interval)
+		if err := s.SendVideoFrame(latest, format); err != nil {
+			return err
+		}
+		latest = nil
+		if frames == nil {
+			return nil
+		}
+	}
+}
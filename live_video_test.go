@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+)
+
+// newUniformImage returns an image of the given size and color.
+func newUniformImage(width, height int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestDownscaleImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		src.SetRGBA(0, y, color.RGBA{R: 200, A: 255})
+		src.SetRGBA(1, y, color.RGBA{R: 100, A: 255})
+		src.SetRGBA(2, y, color.RGBA{G: 40, A: 255})
+		src.SetRGBA(3, y, color.RGBA{G: 60, B: 10, A: 255})
+	}
+	got := DownscaleImage(src, 2)
+	if diff := cmp.Diff(image.Rect(0, 0, 2, 1), got.Bounds()); diff != "" {
+		t.Fatalf("DownscaleImage() bounds mismatch (-want +got):\n%s", diff)
+	}
+	want := []color.RGBA{{R: 150, A: 255}, {G: 50, B: 5, A: 255}}
+	for x, c := range want {
+		if diff := cmp.Diff(c, color.RGBAModel.Convert(got.At(x, 0))); diff != "" {
+			t.Errorf("DownscaleImage() pixel %d mismatch (-want +got):\n%s", x, diff)
+		}
+	}
+
+	if got := DownscaleImage(newUniformImage(10, 40, color.RGBA{A: 255}), 8).Bounds(); got != image.Rect(0, 0, 2, 8) {
+		t.Errorf("DownscaleImage() of a portrait image has bounds %v, want 2x8", got)
+	}
+	small := newUniformImage(4, 2, color.RGBA{A: 255})
+	if got := DownscaleImage(small, 8); got != image.Image(small) {
+		t.Errorf("DownscaleImage() of a small image returned a new image")
+	}
+}
+
+func TestEncodeVideoFrame(t *testing.T) {
+	blob, err := EncodeVideoFrame(newUniformImage(1600, 800, color.RGBA{B: 255, A: 255}), LiveVideoFormat{})
+	if err != nil {
+		t.Fatalf("EncodeVideoFrame() failed: %v", err)
+	}
+	if blob.MIMEType != "image/jpeg" {
+		t.Errorf("EncodeVideoFrame() MIME type = %q, want image/jpeg", blob.MIMEType)
+	}
+	img, err := jpeg.Decode(bytes.NewReader(blob.Data))
+	if err != nil {
+		t.Fatalf("jpeg.Decode() failed: %v", err)
+	}
+	if got := img.Bounds(); got != image.Rect(0, 0, 768, 384) {
+		t.Errorf("EncodeVideoFrame() has bounds %v, want 768x384", got)
+	}
+}
+
+func TestSendRealtimeVideo(t *testing.T) {
+	ctx := context.Background()
+	done := make(chan []map[string]any)
+	client := newTestLiveClient(t, func(r *http.Request, conn *websocket.Conn) {
+		done <- readLiveMessages(conn)
+	})
+	session, err := client.Live.Connect(ctx, "test-model", nil)
+	if err != nil {
+		t.Fatalf("Connect() failed: %v", err)
+	}
+
+	format := LiveVideoFormat{FrameRate: 20}
+	first := newUniformImage(8, 8, color.RGBA{R: 255, A: 255})
+	dropped := newUniformImage(8, 8, color.RGBA{G: 255, A: 255})
+	last := newUniformImage(8, 8, color.RGBA{B: 255, A: 255})
+	frames := make(chan image.Image)
+	go func() {
+		frames <- first
+		frames <- dropped
+		frames <- last
+		close(frames)
+	}()
+	start := time.Now()
+	if err := session.SendRealtimeVideo(ctx, frames, format); err != nil {
+		t.Fatalf("SendRealtimeVideo() failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("SendRealtimeVideo() took %v, want at least 50ms for 2 frames at 20 frames per second", elapsed)
+	}
+	session.Close()
+
+	videoMessage := func(img image.Image) map[string]any {
+		blob, err := EncodeVideoFrame(img, format)
+		if err != nil {
+			t.Fatalf("EncodeVideoFrame() failed: %v", err)
+		}
+		return map[string]any{"realtimeInput": map[string]any{"video": map[string]any{
+			"data":     base64.StdEncoding.EncodeToString(blob.Data),
+			"mimeType": "image/jpeg",
+		}}}
+	}
+	want := []map[string]any{videoMessage(first), videoMessage(last)}
+	got := <-done
+	if diff := cmp.Diff(want, got[1:]); diff != "" {
+		t.Errorf("messages mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Model Context Protocol (MCP) tool integration.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MCPTool describes a tool advertised by an MCP server in its tools/list result.
+type MCPTool struct {
+	// Required. The name of the tool.
+	Name string `json:"name,omitempty"`
+	// Optional. A human-readable description of the tool.
+	Description string `json:"description,omitempty"`
+	// Optional. The JSON Schema of the tool arguments.
+	InputSchema map[string]any `json:"inputSchema,omitempty"`
+}
+
+// MCPContent is a single content item of an MCP tool result.
+type MCPContent struct {
+	// The type of the content, e.g. "text", "image" or "audio".
+	Type string `json:"type,omitempty"`
+	// The text of a "text" content.
+	Text string `json:"text,omitempty"`
+	// The base64-encoded data of an "image" or "audio" content.
+	Data string `json:"data,omitempty"`
+	// The MIME type of an "image" or "audio" content.
+	MIMEType string `json:"mimeType,omitempty"`
+}
+
+// MCPCallToolResult is the result of an MCP tools/call request.
+type MCPCallToolResult struct {
+	// The unstructured result of the tool call.
+	Content []*MCPContent `json:"content,omitempty"`
+	// Optional. The structured result of the tool call.
+	StructuredContent map[string]any `json:"structuredContent,omitempty"`
+	// Whether the tool call ended in an error.
+	IsError bool `json:"isError,omitempty"`
+}
+
+// MCPClient is the subset of an MCP client session used to expose the tools of an
+// MCP server to the model. Wrap the session of the MCP client library of your choice
+// to implement it.
+type MCPClient interface {
+	// ListTools returns the tools advertised by the server.
+	ListTools(ctx context.Context) ([]*MCPTool, error)
+	// CallTool calls the named tool with the given arguments.
+	CallTool(ctx context.Context, name string, args map[string]any) (*MCPCallToolResult, error)
+}
+
+// NewToolFromMCP lists the tools of an MCP server and returns a [Tool] declaring them
+// to the model, along with the handlers that route the model's function calls back
+// to the server. The handlers can be passed to [Models.GenerateContentWithTools]:
+//
+//	tool, handlers, err := genai.NewToolFromMCP(ctx, session)
+//	config := &genai.GenerateContentConfig{Tools: []*genai.Tool{tool}}
+//	resp, err := client.Models.GenerateContentWithTools(ctx, model, contents, config,
+//		&genai.AutomaticFunctionCallingConfig{Handlers: handlers})
+func NewToolFromMCP(ctx context.Context, client MCPClient) (*Tool, map[string]FunctionHandler, error) {
+	if client == nil {
+		return nil, nil, errors.New("NewToolFromMCP: client must not be nil")
+	}
+	mcpTools, err := client.ListTools(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("NewToolFromMCP: error listing tools: %w", err)
+	}
+	tool := &Tool{}
+	handlers := make(map[string]FunctionHandler, len(mcpTools))
+	for _, mcpTool := range mcpTools {
+		decl := &FunctionDeclaration{Name: mcpTool.Name, Description: mcpTool.Description}
+		if len(mcpTool.InputSchema) > 0 {
+			decl.Parameters, err = schemaFromJSONSchema(mcpTool.InputSchema)
+			if err != nil {
+				return nil, nil, fmt.Errorf("NewToolFromMCP: tool %s: %w", mcpTool.Name, err)
+			}
+		}
+		tool.FunctionDeclarations = append(tool.FunctionDeclarations, decl)
+		handlers[mcpTool.Name] = newMCPHandler(client, mcpTool.Name)
+	}
+	return tool, handlers, nil
+}
+
+func newMCPHandler(client MCPClient, name string) FunctionHandler {
+	return func(ctx context.Context, args map[string]any) (map[string]any, error) {
+		result, err := client.CallTool(ctx, name, args)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			return map[string]any{"output": ""}, nil
+		}
+		var texts []string
+		for _, c := range result.Content {
+			if c != nil && c.Type == "text" {
+				texts = append(texts, c.Text)
+			}
+		}
+		if result.IsError {
+			return nil, fmt.Errorf("MCP tool %s failed: %s", name, strings.Join(texts, "\n"))
+		}
+		if result.StructuredContent != nil {
+			return result.StructuredContent, nil
+		}
+		return map[string]any{"output": strings.Join(texts, "\n")}, nil
+	}
+}
+
+// schemaFromJSONSchema converts a JSON Schema object to the OpenAPI subset
+// supported by [Schema]. Unsupported keywords are ignored.
+func schemaFromJSONSchema(jsonSchema map[string]any) (*Schema, error) {
+	s := &Schema{}
+	if v, ok := jsonSchema["description"].(string); ok {
+		s.Description = v
+	}
+	if v, ok := jsonSchema["title"].(string); ok {
+		s.Title = v
+	}
+	if v, ok := jsonSchema["format"].(string); ok {
+		s.Format = v
+	}
+	if v, ok := jsonSchema["pattern"].(string); ok {
+		s.Pattern = v
+	}
+	if v, ok := jsonSchema["default"]; ok {
+		s.Default = v
+	}
+	switch v := jsonSchema["type"].(type) {
+	case nil:
+	case string:
+		s.Type = Type(strings.ToUpper(v))
+	case []any:
+		// A list of types is only supported when it is a single type and "null".
+		for _, t := range v {
+			name, _ := t.(string)
+			switch {
+			case name == "null":
+				s.Nullable = Ptr(true)
+			case s.Type == "":
+				s.Type = Type(strings.ToUpper(name))
+			default:
+				return nil, fmt.Errorf("schemaFromJSONSchema: unsupported type list %v", v)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("schemaFromJSONSchema: unsupported type %v", v)
+	}
+	if v, ok := jsonSchema["enum"].([]any); ok {
+		for _, e := range v {
+			s.Enum = append(s.Enum, fmt.Sprint(e))
+		}
+	}
+	if v, ok := jsonSchema["required"].([]any); ok {
+		for _, r := range v {
+			if name, ok := r.(string); ok {
+				s.Required = append(s.Required, name)
+			}
+		}
+	}
+	if v, ok := jsonSchema["properties"].(map[string]any); ok {
+		s.Properties = make(map[string]*Schema, len(v))
+		for name, p := range v {
+			property, ok := p.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("schemaFromJSONSchema: property %s is not an object", name)
+			}
+			ps, err := schemaFromJSONSchema(property)
+			if err != nil {
+				return nil, err
+			}
+			s.Properties[name] = ps
+		}
+	}
+	if v, ok := jsonSchema["items"].(map[string]any); ok {
+		items, err := schemaFromJSONSchema(v)
+		if err != nil {
+			return nil, err
+		}
+		s.Items = items
+	}
+	if v, ok := jsonSchema["anyOf"].([]any); ok {
+		for _, a := range v {
+			sub, ok := a.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("schemaFromJSONSchema: anyOf item is not an object")
+			}
+			ss, err := schemaFromJSONSchema(sub)
+			if err != nil {
+				return nil, err
+			}
+			s.AnyOf = append(s.AnyOf, ss)
+		}
+	}
+	s.Minimum = jsonSchemaFloat(jsonSchema, "minimum")
+	s.Maximum = jsonSchemaFloat(jsonSchema, "maximum")
+	s.MinLength = jsonSchemaInt(jsonSchema, "minLength")
+	s.MaxLength = jsonSchemaInt(jsonSchema, "maxLength")
+	s.MinItems = jsonSchemaInt(jsonSchema, "minItems")
+	s.MaxItems = jsonSchemaInt(jsonSchema, "maxItems")
+	s.MinProperties = jsonSchemaInt(jsonSchema, "minProperties")
+	s.MaxProperties = jsonSchemaInt(jsonSchema, "maxProperties")
+	return s, nil
+}
+
+func jsonSchemaFloat(jsonSchema map[string]any, key string) *float64 {
+	if v, ok := jsonSchema[key].(float64); ok {
+		return &v
+	}
+	return nil
+}
+
+func jsonSchemaInt(jsonSchema map[string]any, key string) *int64 {
+	if v, ok := jsonSchema[key].(float64); ok {
+		return Ptr(int64(v))
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeMCPClient struct {
+	tools   []*MCPTool
+	results map[string]*MCPCallToolResult
+	calls   []map[string]any
+}
+
+func (c *fakeMCPClient) ListTools(ctx context.Context) ([]*MCPTool, error) {
+	return c.tools, nil
+}
+
+func (c *fakeMCPClient) CallTool(ctx context.Context, name string, args map[string]any) (*MCPCallToolResult, error) {
+	c.calls = append(c.calls, args)
+	return c.results[name], nil
+}
+
+func TestNewToolFromMCP(t *testing.T) {
+	ctx := context.Background()
+	var inputSchema map[string]any
+	if err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"city": {"type": "string", "description": "The city."},
+			"days": {"type": ["integer", "null"], "minimum": 1},
+			"unit": {"type": "string", "enum": ["celsius", "fahrenheit"]}
+		},
+		"required": ["city"]
+	}`), &inputSchema); err != nil {
+		t.Fatal(err)
+	}
+	client := &fakeMCPClient{
+		tools: []*MCPTool{
+			{Name: "forecast", Description: "Weather forecast.", InputSchema: inputSchema},
+			{Name: "fail"},
+		},
+		results: map[string]*MCPCallToolResult{
+			"forecast": {Content: []*MCPContent{{Type: "text", Text: "sunny"}}},
+			"fail":     {Content: []*MCPContent{{Type: "text", Text: "no data"}}, IsError: true},
+		},
+	}
+
+	tool, handlers, err := NewToolFromMCP(ctx, client)
+	if err != nil {
+		t.Fatalf("NewToolFromMCP() failed: %v", err)
+	}
+	want := &Tool{FunctionDeclarations: []*FunctionDeclaration{
+		{
+			Name:        "forecast",
+			Description: "Weather forecast.",
+			Parameters: &Schema{
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"city": {Type: TypeString, Description: "The city."},
+					"days": {Type: TypeInteger, Nullable: Ptr(true), Minimum: Ptr(1.0)},
+					"unit": {Type: TypeString, Enum: []string{"celsius", "fahrenheit"}},
+				},
+				Required: []string{"city"},
+			},
+		},
+		{Name: "fail"},
+	}}
+	if diff := cmp.Diff(want, tool); diff != "" {
+		t.Errorf("NewToolFromMCP() tool mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err := handlers["forecast"](ctx, map[string]any{"city": "Paris"})
+	if err != nil {
+		t.Fatalf("forecast handler failed: %v", err)
+	}
+	if diff := cmp.Diff(map[string]any{"output": "sunny"}, got); diff != "" {
+		t.Errorf("forecast handler mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]map[string]any{{"city": "Paris"}}, client.calls); diff != "" {
+		t.Errorf("CallTool args mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := handlers["fail"](ctx, nil); err == nil {
+		t.Errorf("fail handler succeeded, want error")
+	}
+}
+
+func TestMCPHandlerNilResult(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeMCPClient{results: map[string]*MCPCallToolResult{
+		"sparse": {Content: []*MCPContent{nil, {Type: "text", Text: "done"}}},
+	}}
+	tests := []struct {
+		name string
+		want map[string]any
+	}{
+		{name: "missing", want: map[string]any{"output": ""}},
+		{name: "sparse", want: map[string]any{"output": "done"}},
+	}
+	for _, tt := range tests {
+		got, err := newMCPHandler(client, tt.name)(ctx, nil)
+		if err != nil {
+			t.Fatalf("%s handler failed: %v", tt.name, err)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%s handler mismatch (-want +got):\n%s", tt.name, diff)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// sniffLen is the number of bytes needed to detect a MIME type from the content.
+const sniffLen = 512
+
+// mimeTypesByExtension lists the MIME types of the file extensions commonly sent to
+// the models, so that they are detected regardless of the MIME tables of the system.
+var mimeTypesByExtension = map[string]string{
+	".aac":  "audio/aac",
+	".aiff": "audio/aiff",
+	".avi":  "video/x-msvideo",
+	".c":    "text/x-c",
+	".cpp":  "text/x-c++",
+	".css":  "text/css",
+	".csv":  "text/csv",
+	".flac": "audio/flac",
+	".flv":  "video/x-flv",
+	".gif":  "image/gif",
+	".go":   "text/x-go",
+	".heic": "image/heic",
+	".heif": "image/heif",
+	".htm":  "text/html",
+	".html": "text/html",
+	".java": "text/x-java",
+	".jpeg": "image/jpeg",
+	".jpg":  "image/jpeg",
+	".js":   "text/javascript",
+	".json": "application/json",
+	".m4a":  "audio/mp4",
+	".md":   "text/markdown",
+	".mov":  "video/quicktime",
+	".mp3":  "audio/mpeg",
+	".mp4":  "video/mp4",
+	".mpeg": "video/mpeg",
+	".mpg":  "video/mpeg",
+	".ogg":  "audio/ogg",
+	".opus": "audio/opus",
+	".pdf":  "application/pdf",
+	".png":  "image/png",
+	".py":   "text/x-python",
+	".rtf":  "text/rtf",
+	".svg":  "image/svg+xml",
+	".ts":   "video/mp2t",
+	".txt":  "text/plain",
+	".wav":  "audio/wav",
+	".webm": "video/webm",
+	".webp": "image/webp",
+	".wmv":  "video/x-ms-wmv",
+	".xml":  "text/xml",
+	".3gp":  "video/3gpp",
+}
+
+// DetectMIMEType returns the MIME type of a file from the extension of its name,
+// path or URI, or else from its content, of which only the first 512 bytes are
+// used. Either can be empty. An empty string is returned if the MIME type cannot
+// be determined.
+//
+// The MIME type of the parts and uploaded files is detected this way when it is
+// not set explicitly, see [NewPartFromBytes], [NewPartFromURI] and [Files.Upload].
+func DetectMIMEType(name string, data []byte) string {
+	if mimeType := mimeTypeFromName(name); mimeType != "" {
+		return mimeType
+	}
+	return mimeTypeFromContent(data)
+}
+
+// mimeTypeFromName returns the MIME type of the extension of a file name, path or
+// URI, or an empty string if unknown.
+func mimeTypeFromName(name string) string {
+	if u, err := url.Parse(name); err == nil && u.Scheme != "" {
+		name = u.Path
+	}
+	ext := strings.ToLower(path.Ext(name))
+	if ext == "" {
+		return ""
+	}
+	if mimeType, ok := mimeTypesByExtension[ext]; ok {
+		return mimeType
+	}
+	return withoutMIMEParams(mime.TypeByExtension(ext))
+}
+
+// mimeTypeFromContent returns the MIME type sniffed from the content, or an empty
+// string if unknown.
+func mimeTypeFromContent(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	mimeType := withoutMIMEParams(http.DetectContentType(data[:min(len(data), sniffLen)]))
+	switch mimeType {
+	case "application/octet-stream":
+		return ""
+	case "audio/wave":
+		return "audio/wav"
+	case "application/ogg":
+		return "audio/ogg"
+	}
+	return mimeType
+}
+
+func withoutMIMEParams(mimeType string) string {
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return mimeType
+	}
+	return mediaType
+}
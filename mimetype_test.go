@@ -0,0 +1,126 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestDetectMIMEType(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		data     []byte
+		want     string
+	}{
+		{name: "Extension", fileName: "clip.MP4", want: "video/mp4"},
+		{name: "Path", fileName: "/tmp/talk.mp3", want: "audio/mpeg"},
+		{name: "URI", fileName: "gs://bucket/docs/report.pdf", want: "application/pdf"},
+		{name: "URIWithQuery", fileName: "https://example.com/image.webp?size=large", want: "image/webp"},
+		{name: "TransportStream", fileName: "broadcast.ts", want: "video/mp2t"},
+		{name: "ExtensionTakesPrecedence", fileName: "notes.md", data: pngHeader, want: "text/markdown"},
+		{name: "SniffedImage", fileName: "upload", data: pngHeader, want: "image/png"},
+		{name: "SniffedText", data: []byte("hello"), want: "text/plain"},
+		{name: "SniffedPDF", fileName: "file.unknownext", data: []byte("%PDF-1.7\n"), want: "application/pdf"},
+		{name: "Unknown", fileName: "file.unknownext", data: []byte{0, 1, 2, 3}, want: ""},
+		{name: "Empty", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectMIMEType(tt.fileName, tt.data); got != tt.want {
+				t.Errorf("DetectMIMEType(%q) = %q, want %q", tt.fileName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPartMIMETypeDetection(t *testing.T) {
+	if got := NewPartFromBytes(pngHeader, "").InlineData.MIMEType; got != "image/png" {
+		t.Errorf("NewPartFromBytes() MIMEType = %q, want %q", got, "image/png")
+	}
+	if got := NewPartFromBytes(pngHeader, "image/x-custom").InlineData.MIMEType; got != "image/x-custom" {
+		t.Errorf("NewPartFromBytes() MIMEType = %q, want the override", got)
+	}
+	if got := NewPartFromURI("gs://bucket/video.mov", "").FileData.MIMEType; got != "video/quicktime" {
+		t.Errorf("NewPartFromURI() MIMEType = %q, want %q", got, "video/quicktime")
+	}
+}
+
+func TestFilesUploadMIMETypeDetection(t *testing.T) {
+	ctx := context.Background()
+	mockServer := NewMockUploadServer(t)
+	ts := httptest.NewServer(mockServer)
+	defer ts.Close()
+	mockServer.baseURL = ts.URL
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		APIKey:      "test-api-key",
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	content := string(pngHeader) + strings.Repeat("\x00", 1000)
+	path := filepath.Join(t.TempDir(), "image.unknownext")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		upload func() (*File, error)
+		want   string
+	}{
+		{
+			name:   "Upload",
+			upload: func() (*File, error) { return client.Files.Upload(ctx, strings.NewReader(content), nil) },
+			want:   "image/png",
+		},
+		{
+			name:   "UploadFromPath",
+			upload: func() (*File, error) { return client.Files.UploadFromPath(ctx, path, nil) },
+			want:   "image/png",
+		},
+		{
+			name: "Override",
+			upload: func() (*File, error) {
+				return client.Files.Upload(ctx, strings.NewReader(content), &UploadFileConfig{MIMEType: "application/x-custom"})
+			},
+			want: "application/x-custom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := tt.upload()
+			if err != nil {
+				t.Fatalf("upload failed: %v", err)
+			}
+			if file.MIMEType != tt.want {
+				t.Errorf("MIMEType = %q, want %q", file.MIMEType, tt.want)
+			}
+			if got := *file.SizeBytes; got != int64(len(content)) {
+				t.Errorf("SizeBytes = %d, want %d", got, len(content))
+			}
+		})
+	}
+}
@@ -187,6 +187,10 @@ func schemaToMldev(ac *apiClient, fromObject map[string]any, parentObject map[st
 		setValueByPath(toObject, []string{"default"}, fromDefault)
 	}
 
+	if getValueByPath(fromObject, []string{"defs"}) != nil {
+		return nil, fmt.Errorf("defs parameter is not supported in Gemini API")
+	}
+
 	fromDescription := getValueByPath(fromObject, []string{"description"})
 	if fromDescription != nil {
 		setValueByPath(toObject, []string{"description"}, fromDescription)
@@ -272,6 +276,10 @@ func schemaToMldev(ac *apiClient, fromObject map[string]any, parentObject map[st
 		setValueByPath(toObject, []string{"propertyOrdering"}, fromPropertyOrdering)
 	}
 
+	if getValueByPath(fromObject, []string{"ref"}) != nil {
+		return nil, fmt.Errorf("ref parameter is not supported in Gemini API")
+	}
+
 	fromRequired := getValueByPath(fromObject, []string{"required"})
 	if fromRequired != nil {
 		setValueByPath(toObject, []string{"required"}, fromRequired)
@@ -823,6 +831,11 @@ func generateContentConfigToMldev(ac *apiClient, fromObject map[string]any, pare
 		setValueByPath(toObject, []string{"responseSchema"}, fromResponseSchema)
 	}
 
+	fromResponseJsonSchema := getValueByPath(fromObject, []string{"responseJsonSchema"})
+	if fromResponseJsonSchema != nil {
+		setValueByPath(toObject, []string{"responseJsonSchema"}, fromResponseJsonSchema)
+	}
+
 	if getValueByPath(fromObject, []string{"routingConfig"}) != nil {
 		return nil, fmt.Errorf("routingConfig parameter is not supported in Gemini API")
 	}
@@ -1035,6 +1048,205 @@ func embedContentParametersToMldev(ac *apiClient, fromObject map[string]any, par
 	return toObject, nil
 }
 
+func groundingPassageToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromId := getValueByPath(fromObject, []string{"id"})
+	if fromId != nil {
+		setValueByPath(toObject, []string{"id"}, fromId)
+	}
+
+	fromContent := getValueByPath(fromObject, []string{"content"})
+	if fromContent != nil {
+		fromContent, err = contentToMldev(ac, fromContent.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"content"}, fromContent)
+	}
+
+	return toObject, nil
+}
+
+func semanticRetrieverConfigToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromSource := getValueByPath(fromObject, []string{"source"})
+	if fromSource != nil {
+		setValueByPath(toObject, []string{"source"}, fromSource)
+	}
+
+	fromQuery := getValueByPath(fromObject, []string{"query"})
+	if fromQuery != nil {
+		fromQuery, err = contentToMldev(ac, fromQuery.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"query"}, fromQuery)
+	}
+
+	fromMetadataFilters := getValueByPath(fromObject, []string{"metadataFilters"})
+	if fromMetadataFilters != nil {
+		setValueByPath(toObject, []string{"metadataFilters"}, fromMetadataFilters)
+	}
+
+	fromMaxChunksCount := getValueByPath(fromObject, []string{"maxChunksCount"})
+	if fromMaxChunksCount != nil {
+		setValueByPath(toObject, []string{"maxChunksCount"}, fromMaxChunksCount)
+	}
+
+	fromMinimumRelevanceScore := getValueByPath(fromObject, []string{"minimumRelevanceScore"})
+	if fromMinimumRelevanceScore != nil {
+		setValueByPath(toObject, []string{"minimumRelevanceScore"}, fromMinimumRelevanceScore)
+	}
+
+	return toObject, nil
+}
+
+func generateAnswerConfigToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromAnswerStyle := getValueByPath(fromObject, []string{"answerStyle"})
+	if fromAnswerStyle != nil {
+		setValueByPath(parentObject, []string{"answerStyle"}, fromAnswerStyle)
+	}
+
+	fromInlinePassages := getValueByPath(fromObject, []string{"inlinePassages"})
+	if fromInlinePassages != nil {
+		fromInlinePassages, err = applyConverterToSlice(ac, fromInlinePassages.([]any), groundingPassageToMldev)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(parentObject, []string{"inlinePassages", "passages"}, fromInlinePassages)
+	}
+
+	fromSemanticRetriever := getValueByPath(fromObject, []string{"semanticRetriever"})
+	if fromSemanticRetriever != nil {
+		fromSemanticRetriever, err = semanticRetrieverConfigToMldev(ac, fromSemanticRetriever.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(parentObject, []string{"semanticRetriever"}, fromSemanticRetriever)
+	}
+
+	fromSafetySettings := getValueByPath(fromObject, []string{"safetySettings"})
+	if fromSafetySettings != nil {
+		fromSafetySettings, err = applyConverterToSlice(ac, fromSafetySettings.([]any), safetySettingToMldev)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(parentObject, []string{"safetySettings"}, fromSafetySettings)
+	}
+
+	fromTemperature := getValueByPath(fromObject, []string{"temperature"})
+	if fromTemperature != nil {
+		setValueByPath(parentObject, []string{"temperature"}, fromTemperature)
+	}
+
+	return toObject, nil
+}
+
+func generateAnswerParametersToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromModel := getValueByPath(fromObject, []string{"model"})
+	if fromModel != nil {
+		fromModel, err = tModel(ac, fromModel)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"_url", "model"}, fromModel)
+	}
+
+	fromContents := getValueByPath(fromObject, []string{"contents"})
+	if fromContents != nil {
+		fromContents, err = tContents(ac, fromContents)
+		if err != nil {
+			return nil, err
+		}
+
+		fromContents, err = applyConverterToSlice(ac, fromContents.([]any), contentToMldev)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"contents"}, fromContents)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		fromConfig, err = generateAnswerConfigToMldev(ac, fromConfig.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func rerankConfigToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromTopN := getValueByPath(fromObject, []string{"topN"})
+	if fromTopN != nil {
+		setValueByPath(parentObject, []string{"topN"}, fromTopN)
+	}
+
+	fromIgnoreRecordDetailsInResponse := getValueByPath(fromObject, []string{"ignoreRecordDetailsInResponse"})
+	if fromIgnoreRecordDetailsInResponse != nil {
+		setValueByPath(parentObject, []string{"ignoreRecordDetailsInResponse"}, fromIgnoreRecordDetailsInResponse)
+	}
+
+	fromRankingConfig := getValueByPath(fromObject, []string{"rankingConfig"})
+	if fromRankingConfig != nil {
+		setValueByPath(parentObject, []string{"_url", "rankingConfig"}, fromRankingConfig)
+	}
+
+	return toObject, nil
+}
+
+func rerankParametersToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	setValueByPath(toObject, []string{"_url", "project"}, ac.clientConfig.Project)
+	setValueByPath(toObject, []string{"_url", "rankingConfig"}, "default_ranking_config")
+
+	fromModel := getValueByPath(fromObject, []string{"model"})
+	if fromModel != nil {
+		setValueByPath(toObject, []string{"model"}, fromModel)
+	}
+
+	fromQuery := getValueByPath(fromObject, []string{"query"})
+	if fromQuery != nil {
+		setValueByPath(toObject, []string{"query"}, fromQuery)
+	}
+
+	fromRecords := getValueByPath(fromObject, []string{"records"})
+	if fromRecords != nil {
+		setValueByPath(toObject, []string{"records"}, fromRecords)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		fromConfig, err = rerankConfigToVertex(ac, fromConfig.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
 func generateImagesConfigToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
 	toObject = make(map[string]any)
 
@@ -1629,6 +1841,11 @@ func schemaToVertex(ac *apiClient, fromObject map[string]any, parentObject map[s
 		setValueByPath(toObject, []string{"default"}, fromDefault)
 	}
 
+	fromDefs := getValueByPath(fromObject, []string{"defs"})
+	if fromDefs != nil {
+		setValueByPath(toObject, []string{"defs"}, fromDefs)
+	}
+
 	fromDescription := getValueByPath(fromObject, []string{"description"})
 	if fromDescription != nil {
 		setValueByPath(toObject, []string{"description"}, fromDescription)
@@ -1714,6 +1931,11 @@ func schemaToVertex(ac *apiClient, fromObject map[string]any, parentObject map[s
 		setValueByPath(toObject, []string{"propertyOrdering"}, fromPropertyOrdering)
 	}
 
+	fromRef := getValueByPath(fromObject, []string{"ref"})
+	if fromRef != nil {
+		setValueByPath(toObject, []string{"ref"}, fromRef)
+	}
+
 	fromRequired := getValueByPath(fromObject, []string{"required"})
 	if fromRequired != nil {
 		setValueByPath(toObject, []string{"required"}, fromRequired)
@@ -2269,6 +2491,11 @@ func generateContentConfigToVertex(ac *apiClient, fromObject map[string]any, par
 		setValueByPath(toObject, []string{"responseSchema"}, fromResponseSchema)
 	}
 
+	fromResponseJsonSchema := getValueByPath(fromObject, []string{"responseJsonSchema"})
+	if fromResponseJsonSchema != nil {
+		setValueByPath(toObject, []string{"responseJsonSchema"}, fromResponseJsonSchema)
+	}
+
 	fromRoutingConfig := getValueByPath(fromObject, []string{"routingConfig"})
 	if fromRoutingConfig != nil {
 		setValueByPath(toObject, []string{"routingConfig"}, fromRoutingConfig)
@@ -3564,6 +3791,11 @@ func candidateFromMldev(ac *apiClient, fromObject map[string]any, parentObject m
 		setValueByPath(toObject, []string{"safetyRatings"}, fromSafetyRatings)
 	}
 
+	fromGroundingAttributions := getValueByPath(fromObject, []string{"groundingAttributions"})
+	if fromGroundingAttributions != nil {
+		setValueByPath(toObject, []string{"groundingAttributions"}, fromGroundingAttributions)
+	}
+
 	return toObject, nil
 }
 
@@ -3621,6 +3853,32 @@ func embedContentMetadataFromMldev(ac *apiClient, fromObject map[string]any, par
 	return toObject, nil
 }
 
+func generateAnswerResponseFromMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromAnswer := getValueByPath(fromObject, []string{"answer"})
+	if fromAnswer != nil {
+		fromAnswer, err = candidateFromMldev(ac, fromAnswer.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"answer"}, fromAnswer)
+	}
+
+	fromAnswerableProbability := getValueByPath(fromObject, []string{"answerableProbability"})
+	if fromAnswerableProbability != nil {
+		setValueByPath(toObject, []string{"answerableProbability"}, fromAnswerableProbability)
+	}
+
+	fromInputFeedback := getValueByPath(fromObject, []string{"inputFeedback"})
+	if fromInputFeedback != nil {
+		setValueByPath(toObject, []string{"inputFeedback"}, fromInputFeedback)
+	}
+
+	return toObject, nil
+}
+
 func embedContentResponseFromMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
 	toObject = make(map[string]any)
 
@@ -4330,6 +4588,17 @@ func embedContentMetadataFromVertex(ac *apiClient, fromObject map[string]any, pa
 	return toObject, nil
 }
 
+func rerankResponseFromVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromRecords := getValueByPath(fromObject, []string{"records"})
+	if fromRecords != nil {
+		setValueByPath(toObject, []string{"records"}, fromRecords)
+	}
+
+	return toObject, nil
+}
+
 func embedContentResponseFromVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
 	toObject = make(map[string]any)
 
@@ -4989,6 +5258,165 @@ func (m Models) EmbedContent(ctx context.Context, model string, contents []*Cont
 	return response, nil
 }
 
+// GenerateAnswer generates a grounded answer to the question in the last of the
+// contents, from the passages of the config, and attributes the parts of the answer
+// to the passages, see [Candidate.GroundingAttributions]. The passages are either
+// inline, or retrieved from a [Corpus] by similarity with a query. The model is
+// usually "aqa", the Attributed Question Answering model.
+//
+// Check [GenerateAnswerResponse.AnswerableProbability] to tell grounded answers
+// from guesses.
+func (m Models) GenerateAnswer(ctx context.Context, model string, contents []*Content, config *GenerateAnswerConfig) (*GenerateAnswerResponse, error) {
+	if config == nil || (config.InlinePassages == nil) == (config.SemanticRetriever == nil) {
+		return nil, fmt.Errorf("exactly one of config.InlinePassages and config.SemanticRetriever must be set")
+	}
+	parameterMap := make(map[string]any)
+
+	kwargs := map[string]any{"model": model, "contents": contents, "config": config}
+	deepMarshal(kwargs, &parameterMap)
+
+	var httpOptions *HTTPOptions
+	if config == nil {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, nil)
+	} else {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, config.HTTPOptions)
+		config.HTTPOptions = nil
+	}
+	var response = new(GenerateAnswerResponse)
+	var responseMap map[string]any
+	var fromConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	var toConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+
+		return nil, fmt.Errorf("method GenerateAnswer is only supported in the Gemini Developer client. You can choose to use Gemini Developer client by setting ClientConfig.Backend to BackendGeminiAPI.")
+
+	} else {
+		toConverter = generateAnswerParametersToMldev
+		fromConverter = generateAnswerResponseFromMldev
+	}
+
+	body, err := toConverter(m.apiClient, parameterMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	var path string
+	var urlParams map[string]any
+	if _, ok := body["_url"]; ok {
+		urlParams = body["_url"].(map[string]any)
+		delete(body, "_url")
+	}
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		path, err = formatMap("None", urlParams)
+	} else {
+		path, err = formatMap("{model}:generateAnswer", urlParams)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid url params: %#v.\n%w", urlParams, err)
+	}
+	if _, ok := body["_query"]; ok {
+		query, err := createURLQuery(body["_query"].(map[string]any))
+		if err != nil {
+			return nil, err
+		}
+		path += "?" + query
+		delete(body, "_query")
+	}
+
+	if _, ok := body["config"]; ok {
+		delete(body, "config")
+	}
+	responseMap, err = sendRequest(ctx, m.apiClient, path, http.MethodPost, body, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	responseMap, err = fromConverter(m.apiClient, responseMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = mapToStruct(responseMap, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Rerank ranks the records by relevance to the query with a ranking model of
+// Vertex AI, e.g. "semantic-ranker-default@latest", for example to rerank the
+// documents retrieved for the query before grounding the answer of a model on the
+// most relevant ones. The requests are sent to the ranking API of Vertex AI
+// Search, which must be enabled in the project.
+func (m Models) Rerank(ctx context.Context, model string, query string, records []*RankingRecord, config *RerankConfig) (*RerankResponse, error) {
+	parameterMap := make(map[string]any)
+
+	kwargs := map[string]any{"model": model, "query": query, "records": records, "config": config}
+	deepMarshal(kwargs, &parameterMap)
+
+	var httpOptions *HTTPOptions
+	if config == nil {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, nil)
+	} else {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, config.HTTPOptions)
+		config.HTTPOptions = nil
+	}
+	var response = new(RerankResponse)
+	var responseMap map[string]any
+	var fromConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	var toConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		toConverter = rerankParametersToVertex
+		fromConverter = rerankResponseFromVertex
+		httpOptions = rankingHTTPOptions(m.apiClient.clientConfig.Location, httpOptions)
+	} else {
+
+		return nil, fmt.Errorf("method Rerank is only supported in the Vertex AI client. You can choose to use Vertex AI by setting ClientConfig.Backend to BackendVertexAI.")
+
+	}
+
+	body, err := toConverter(m.apiClient, parameterMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	var path string
+	var urlParams map[string]any
+	if _, ok := body["_url"]; ok {
+		urlParams = body["_url"].(map[string]any)
+		delete(body, "_url")
+	}
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		path, err = formatMap("projects/{project}/locations/global/rankingConfigs/{rankingConfig}:rank", urlParams)
+	} else {
+		path, err = formatMap("None", urlParams)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid url params: %#v.\n%w", urlParams, err)
+	}
+	if _, ok := body["_query"]; ok {
+		query, err := createURLQuery(body["_query"].(map[string]any))
+		if err != nil {
+			return nil, err
+		}
+		path += "?" + query
+		delete(body, "_query")
+	}
+
+	if _, ok := body["config"]; ok {
+		delete(body, "config")
+	}
+	responseMap, err = sendRequest(ctx, m.apiClient, path, http.MethodPost, body, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	responseMap, err = fromConverter(m.apiClient, responseMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = mapToStruct(responseMap, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (m Models) generateImages(ctx context.Context, model string, prompt string, config *GenerateImagesConfig) (*GenerateImagesResponse, error) {
 	parameterMap := make(map[string]any)
 
@@ -5699,19 +6127,57 @@ func (m Models) GenerateVideos(ctx context.Context, model string, prompt string,
 }
 
 // GenerateContent generates content based on the provided model, contents, and configuration.
+//
+// If the prompt is blocked, a [SafetyBlockedError] is returned.
 func (m Models) GenerateContent(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig) (*GenerateContentResponse, error) {
 	if config != nil {
 		config.setDefaults()
 	}
-	return m.generateContent(ctx, model, contents, config)
+	contents, err := m.offloadInlineData(ctx, contents)
+	if err != nil {
+		return nil, err
+	}
+	response, err := m.generateContent(ctx, model, contents, config.withDefaultLabels(m.apiClient))
+	if err != nil {
+		return nil, err
+	}
+	m.apiClient.recordCacheUsage(config, response.UsageMetadata)
+	if err := promptBlockedError(response); err != nil {
+		return nil, err
+	}
+	return response, nil
 }
 
 // GenerateContentStream generates a stream of content based on the provided model, contents, and configuration.
+//
+// If the prompt is blocked, a [SafetyBlockedError] is yielded.
 func (m Models) GenerateContentStream(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig) iter.Seq2[*GenerateContentResponse, error] {
 	if config != nil {
 		config.setDefaults()
 	}
-	return m.generateContentStream(ctx, model, contents, config)
+	return func(yield func(*GenerateContentResponse, error) bool) {
+		contents, err := m.offloadInlineData(ctx, contents)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		var usage StreamUsage
+		defer func() { m.apiClient.recordCacheUsage(config, usage.Usage()) }()
+		stream := m.generateContentStream(ctx, model, contents, config.withDefaultLabels(m.apiClient))
+		for response, err := range stream {
+			if response != nil {
+				usage.Add(response.UsageMetadata)
+			}
+			if err == nil {
+				if blockedErr := promptBlockedError(response); blockedErr != nil {
+					response, err = nil, blockedErr
+				}
+			}
+			if !yield(response, err) {
+				return
+			}
+		}
+	}
 }
 
 // List retrieves a paginated list of models resources.
@@ -5767,7 +6233,7 @@ func (m Models) All(ctx context.Context) iter.Seq2[*Model, error] {
 	if err != nil {
 		return yieldErrorAndEndIterator[Model](err)
 	}
-	return p.all(ctx)
+	return p.All(ctx)
 }
 
 // GenerateImages generates images based on the provided model, prompt, and configuration.
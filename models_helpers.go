@@ -31,6 +31,28 @@ func (c *GenerateContentConfig) setDefaults() {
 	}
 }
 
+// withDefaultLabels returns the config with the default labels of the client
+// merged into its labels. The given config is not modified.
+func (c *GenerateContentConfig) withDefaultLabels(ac *apiClient) *GenerateContentConfig {
+	if ac.clientConfig.Backend != BackendVertexAI || len(ac.clientConfig.Labels) == 0 {
+		return c
+	}
+	merged := &GenerateContentConfig{}
+	if c != nil {
+		*merged = *c
+	}
+	merged.Labels = make(map[string]string, len(ac.clientConfig.Labels)+len(merged.Labels))
+	for k, v := range ac.clientConfig.Labels {
+		merged.Labels[k] = v
+	}
+	if c != nil {
+		for k, v := range c.Labels {
+			merged.Labels[k] = v
+		}
+	}
+	return merged
+}
+
 func (c *Content) setDefaults() {
 	if c == nil {
 		return
@@ -39,3 +61,50 @@ func (c *Content) setDefaults() {
 		c.Role = RoleUser
 	}
 }
+
+// NewToolConfigFromFunctionCallingMode builds a [ToolConfig] that controls whether
+// the model may, must or must not call the declared functions. allowedFunctionNames
+// restricts the functions the model can call and is only used with
+// [FunctionCallingConfigModeAny].
+//
+//	// Force the model to call get_weather.
+//	config := &genai.GenerateContentConfig{
+//		Tools:      tools,
+//		ToolConfig: genai.NewToolConfigFromFunctionCallingMode(genai.FunctionCallingConfigModeAny, "get_weather"),
+//	}
+func NewToolConfigFromFunctionCallingMode(mode FunctionCallingConfigMode, allowedFunctionNames ...string) *ToolConfig {
+	return &ToolConfig{
+		FunctionCallingConfig: &FunctionCallingConfig{
+			Mode:                 mode,
+			AllowedFunctionNames: allowedFunctionNames,
+		},
+	}
+}
+
+// NewAutoRoutingConfig builds a [GenerationConfigRoutingConfig] that lets the Vertex
+// AI model router pick the model serving the request, according to the given
+// quality and cost preference.
+//
+//	config := &genai.GenerateContentConfig{
+//		RoutingConfig: genai.NewAutoRoutingConfig(genai.FeatureSelectionPreferencePrioritizeCost),
+//	}
+//
+// [FeatureSelectionPreferenceUnspecified] omits the preference, leaving the
+// choice to the router. Routing is only supported by the Vertex AI backend.
+func NewAutoRoutingConfig(preference FeatureSelectionPreference) *GenerationConfigRoutingConfig {
+	mode := &GenerationConfigRoutingConfigAutoRoutingMode{}
+	if preference != FeatureSelectionPreferenceUnspecified {
+		mode.ModelRoutingPreference = string(preference)
+	}
+	return &GenerationConfigRoutingConfig{AutoMode: mode}
+}
+
+// NewManualRoutingConfig builds a [GenerationConfigRoutingConfig] that pins the
+// requests sent to the Vertex AI model router to the given model.
+//
+// Routing is only supported by the Vertex AI backend.
+func NewManualRoutingConfig(modelName string) *GenerationConfigRoutingConfig {
+	return &GenerationConfigRoutingConfig{
+		ManualMode: &GenerationConfigRoutingConfigManualRoutingMode{ModelName: modelName},
+	}
+}
@@ -15,7 +15,10 @@
 package genai
 
 import (
+	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -49,4 +52,392 @@ func TestContentHelpers(t *testing.T) {
 			t.Errorf("GenerateContentConfig.setDefaults mismatch (-want +got):\n%s", diff)
 		}
 	})
+
+	t.Run("NewToolConfigFromFunctionCallingMode", func(t *testing.T) {
+		expected := &ToolConfig{
+			FunctionCallingConfig: &FunctionCallingConfig{
+				Mode:                 FunctionCallingConfigModeAny,
+				AllowedFunctionNames: []string{"get_weather"},
+			},
+		}
+		got := NewToolConfigFromFunctionCallingMode(FunctionCallingConfigModeAny, "get_weather")
+		if diff := cmp.Diff(got, expected); diff != "" {
+			t.Errorf("NewToolConfigFromFunctionCallingMode mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("NewAutoRoutingConfig", func(t *testing.T) {
+		expected := &GenerationConfigRoutingConfig{
+			AutoMode: &GenerationConfigRoutingConfigAutoRoutingMode{ModelRoutingPreference: "PRIORITIZE_COST"},
+		}
+		got := NewAutoRoutingConfig(FeatureSelectionPreferencePrioritizeCost)
+		if diff := cmp.Diff(got, expected); diff != "" {
+			t.Errorf("NewAutoRoutingConfig mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("NewAutoRoutingConfigUnspecified", func(t *testing.T) {
+		expected := &GenerationConfigRoutingConfig{AutoMode: &GenerationConfigRoutingConfigAutoRoutingMode{}}
+		got := NewAutoRoutingConfig(FeatureSelectionPreferenceUnspecified)
+		if diff := cmp.Diff(got, expected); diff != "" {
+			t.Errorf("NewAutoRoutingConfig mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("NewManualRoutingConfig", func(t *testing.T) {
+		expected := &GenerationConfigRoutingConfig{
+			ManualMode: &GenerationConfigRoutingConfigManualRoutingMode{ModelName: "gemini-2.0-flash"},
+		}
+		got := NewManualRoutingConfig("gemini-2.0-flash")
+		if diff := cmp.Diff(got, expected); diff != "" {
+			t.Errorf("NewManualRoutingConfig mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestGenerateContentToolConfigRequest(t *testing.T) {
+	config := &GenerateContentConfig{
+		ToolConfig: NewToolConfigFromFunctionCallingMode(FunctionCallingConfigModeAny, "get_weather"),
+	}
+	want := map[string]any{
+		"functionCallingConfig": map[string]any{
+			"mode":                 "ANY",
+			"allowedFunctionNames": []any{"get_weather"},
+		},
+	}
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			var requests []map[string]any
+			models := newTestClient(t, backend.Backend, replyInOrder(t, []string{finalTextResponseJSON}, &requests)).Models
+			if _, err := models.GenerateContent(context.Background(), "gemini-2.0-flash", Text("Weather?"), config); err != nil {
+				t.Fatalf("GenerateContent() failed: %v", err)
+			}
+			if diff := cmp.Diff(want, requests[0]["toolConfig"]); diff != "" {
+				t.Errorf("toolConfig mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateContentResponseJSONSchemaRequest(t *testing.T) {
+	config := &GenerateContentConfig{
+		ResponseMIMEType:   "application/json",
+		ResponseJSONSchema: []byte(`{"type": "object", "properties": {"name": {"type": "string"}}, "required": ["name"]}`),
+	}
+	want := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{"name": map[string]any{"type": "string"}},
+		"required":   []any{"name"},
+	}
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			var requests []map[string]any
+			models := newTestClient(t, backend.Backend, replyInOrder(t, []string{finalTextResponseJSON}, &requests)).Models
+			if _, err := models.GenerateContent(context.Background(), "gemini-2.0-flash", Text("Who are you?"), config); err != nil {
+				t.Fatalf("GenerateContent() failed: %v", err)
+			}
+			generationConfig := requests[0]["generationConfig"].(map[string]any)
+			if diff := cmp.Diff(want, generationConfig["responseJsonSchema"]); diff != "" {
+				t.Errorf("responseJsonSchema mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateContentDefaultLabelsRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend Backend
+		config  *GenerateContentConfig
+		want    any
+	}{
+		{
+			name:    "VertexNilConfig",
+			backend: BackendVertexAI,
+			want:    map[string]any{"team": "search", "feature": "default"},
+		},
+		{
+			name:    "VertexConfigLabelsTakePrecedence",
+			backend: BackendVertexAI,
+			config:  &GenerateContentConfig{Labels: map[string]string{"feature": "summaries"}},
+			want:    map[string]any{"team": "search", "feature": "summaries"},
+		},
+		{
+			name:    "GeminiIgnoresDefaultLabels",
+			backend: BackendGeminiAPI,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []map[string]any
+			models := newTestClient(t, tt.backend, replyInOrder(t, []string{finalTextResponseJSON}, &requests)).Models
+			models.apiClient.clientConfig.Labels = map[string]string{"team": "search", "feature": "default"}
+			if _, err := models.GenerateContent(context.Background(), "gemini-2.0-flash", Text("Who are you?"), tt.config); err != nil {
+				t.Fatalf("GenerateContent() failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, requests[0]["labels"]); diff != "" {
+				t.Errorf("labels mismatch (-want +got):\n%s", diff)
+			}
+			if tt.config != nil && len(tt.config.Labels) != 1 {
+				t.Errorf("GenerateContent() modified the config labels: %v", tt.config.Labels)
+			}
+		})
+	}
+}
+
+func TestGenerateContentRoutingConfigRequest(t *testing.T) {
+	config := &GenerateContentConfig{RoutingConfig: NewAutoRoutingConfig(FeatureSelectionPreferenceBalanced)}
+
+	t.Run("VertexAI", func(t *testing.T) {
+		var requests []map[string]any
+		models := newTestClient(t, BackendVertexAI, replyInOrder(t, []string{finalTextResponseJSON}, &requests)).Models
+		if _, err := models.GenerateContent(context.Background(), "gemini-2.0-flash", Text("Who are you?"), config); err != nil {
+			t.Fatalf("GenerateContent() failed: %v", err)
+		}
+		want := map[string]any{"autoMode": map[string]any{"modelRoutingPreference": "BALANCED"}}
+		generationConfig := requests[0]["generationConfig"].(map[string]any)
+		if diff := cmp.Diff(want, generationConfig["routingConfig"]); diff != "" {
+			t.Errorf("routingConfig mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("GeminiAPI", func(t *testing.T) {
+		var requests []map[string]any
+		models := newTestClient(t, BackendGeminiAPI, replyInOrder(t, []string{finalTextResponseJSON}, &requests)).Models
+		if _, err := models.GenerateContent(context.Background(), "gemini-2.0-flash", Text("Who are you?"), config); err == nil {
+			t.Errorf("GenerateContent() succeeded, want error for unsupported routingConfig")
+		}
+	})
+}
+
+func TestGenerateContentMediaResolutionRequest(t *testing.T) {
+	config := &GenerateContentConfig{MediaResolution: MediaResolutionLow}
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			var requests []map[string]any
+			models := newTestClient(t, backend.Backend, replyInOrder(t, []string{finalTextResponseJSON}, &requests)).Models
+			if _, err := models.GenerateContent(context.Background(), "gemini-2.0-flash", Text("Describe the image."), config); err != nil {
+				t.Fatalf("GenerateContent() failed: %v", err)
+			}
+			generationConfig := requests[0]["generationConfig"].(map[string]any)
+			if got, want := generationConfig["mediaResolution"], "MEDIA_RESOLUTION_LOW"; got != want {
+				t.Errorf("mediaResolution = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGenerateContentVideoMetadataRequest(t *testing.T) {
+	video := NewPartFromURI("gs://bucket/long-video.mp4", "video/mp4")
+	video.VideoMetadata = &VideoMetadata{
+		FPS:         Ptr(2.0),
+		StartOffset: 90 * time.Second,
+		EndOffset:   100*time.Second + 500*time.Millisecond,
+	}
+	contents := []*Content{NewContentFromParts([]*Part{video, NewPartFromText("What happens here?")}, RoleUser)}
+	want := map[string]any{"fps": 2.0, "startOffset": "90s", "endOffset": "100.5s"}
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			var requests []map[string]any
+			models := newTestClient(t, backend.Backend, replyInOrder(t, []string{finalTextResponseJSON}, &requests)).Models
+			if _, err := models.GenerateContent(context.Background(), "gemini-2.0-flash", contents, nil); err != nil {
+				t.Fatalf("GenerateContent() failed: %v", err)
+			}
+			part := requests[0]["contents"].([]any)[0].(map[string]any)["parts"].([]any)[0].(map[string]any)
+			if diff := cmp.Diff(want, part["videoMetadata"]); diff != "" {
+				t.Errorf("videoMetadata mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEmbedContentTaskTypeRequest(t *testing.T) {
+	config := &EmbedContentConfig{
+		TaskType:             TaskTypeRetrievalDocument,
+		Title:                "Names",
+		OutputDimensionality: Ptr[int32](256),
+	}
+	for _, tt := range []struct {
+		backend Backend
+		want    map[string]any
+	}{
+		{BackendGeminiAPI, map[string]any{"requests": []any{map[string]any{
+			"model":                "models/text-embedding-004",
+			"content":              map[string]any{"parts": []any{map[string]any{"text": "What is your name?"}}, "role": "user"},
+			"taskType":             "RETRIEVAL_DOCUMENT",
+			"title":                "Names",
+			"outputDimensionality": 256.0,
+		}}}},
+		{BackendVertexAI, map[string]any{
+			"instances":  []any{map[string]any{"content": "What is your name?", "task_type": "RETRIEVAL_DOCUMENT", "title": "Names"}},
+			"parameters": map[string]any{"outputDimensionality": 256.0},
+		}},
+	} {
+		t.Run(tt.backend.String(), func(t *testing.T) {
+			var requests []map[string]any
+			models := newTestClient(t, tt.backend, replyInOrder(t, []string{`{}`}, &requests)).Models
+			if _, err := models.EmbedContent(context.Background(), "text-embedding-004", Text("What is your name?"), config); err != nil {
+				t.Fatalf("EmbedContent() failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, requests[0]); diff != "" {
+				t.Errorf("request mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestModelsGenerateAnswer(t *testing.T) {
+	ctx := context.Background()
+	t.Run("InlinePassages", func(t *testing.T) {
+		var requests []map[string]any
+		models := newTestClient(t, BackendGeminiAPI, replyInOrder(t, []string{`{
+			"answer": {
+				"content": {"parts": [{"text": "Press the red button."}], "role": "model"},
+				"finishReason": "STOP",
+				"groundingAttributions": [{"sourceId": {"groundingPassage": {"passageId": "manual", "partIndex": 0}}, "content": {"parts": [{"text": "To print, press the red button."}]}}]
+			},
+			"answerableProbability": 0.9
+		}`}, &requests)).Models
+		config := &GenerateAnswerConfig{
+			AnswerStyle:    AnswerStyleExtractive,
+			InlinePassages: []*GroundingPassage{{ID: "manual", Content: NewContentFromText("To print, press the red button.", RoleUser)}},
+			Temperature:    Ptr[float32](0),
+		}
+		response, err := models.GenerateAnswer(ctx, "aqa", Text("How do I print?"), config)
+		if err != nil {
+			t.Fatalf("GenerateAnswer() failed: %v", err)
+		}
+		want := &GenerateAnswerResponse{
+			Answer: &Candidate{
+				Content:      &Content{Parts: []*Part{{Text: "Press the red button."}}, Role: RoleModel},
+				FinishReason: FinishReasonStop,
+				GroundingAttributions: []*GroundingAttribution{{
+					SourceID: &AttributionSourceID{GroundingPassage: &GroundingPassageID{PassageID: "manual"}},
+					Content:  &Content{Parts: []*Part{{Text: "To print, press the red button."}}},
+				}},
+			},
+			AnswerableProbability: Ptr[float32](0.9),
+		}
+		if diff := cmp.Diff(want, response); diff != "" {
+			t.Errorf("GenerateAnswer() mismatch (-want +got):\n%s", diff)
+		}
+		wantRequest := map[string]any{
+			"contents":       []any{map[string]any{"parts": []any{map[string]any{"text": "How do I print?"}}, "role": "user"}},
+			"answerStyle":    "EXTRACTIVE",
+			"inlinePassages": map[string]any{"passages": []any{map[string]any{"id": "manual", "content": map[string]any{"parts": []any{map[string]any{"text": "To print, press the red button."}}, "role": "user"}}}},
+			"temperature":    0.0,
+		}
+		if diff := cmp.Diff(wantRequest, requests[0]); diff != "" {
+			t.Errorf("request mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("SemanticRetriever", func(t *testing.T) {
+		var requests []map[string]any
+		models := newTestClient(t, BackendGeminiAPI, replyInOrder(t, []string{`{"answer": {"content": {"parts": [{"text": "Press the red button."}]}}, "answerableProbability": 0.5}`}, &requests)).Models
+		config := &GenerateAnswerConfig{SemanticRetriever: &SemanticRetrieverConfig{
+			Source:         "corpora/c1",
+			Query:          NewContentFromText("printing", RoleUser),
+			MaxChunksCount: 3,
+		}}
+		if _, err := models.GenerateAnswer(ctx, "aqa", Text("How do I print?"), config); err != nil {
+			t.Fatalf("GenerateAnswer() failed: %v", err)
+		}
+		want := map[string]any{
+			"source":         "corpora/c1",
+			"query":          map[string]any{"parts": []any{map[string]any{"text": "printing"}}, "role": "user"},
+			"maxChunksCount": 3.0,
+		}
+		if diff := cmp.Diff(want, requests[0]["semanticRetriever"]); diff != "" {
+			t.Errorf("semanticRetriever mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		var requests []map[string]any
+		models := newTestClient(t, BackendGeminiAPI, replyInOrder(t, []string{`{}`}, &requests)).Models
+		for _, config := range []*GenerateAnswerConfig{
+			nil,
+			{},
+			{InlinePassages: []*GroundingPassage{{ID: "a"}}, SemanticRetriever: &SemanticRetrieverConfig{Source: "corpora/c1"}},
+		} {
+			if _, err := models.GenerateAnswer(ctx, "aqa", Text("How do I print?"), config); err == nil {
+				t.Errorf("GenerateAnswer(%+v) succeeded, want error", config)
+			}
+		}
+		models.apiClient.clientConfig.Backend = BackendVertexAI
+		if _, err := models.GenerateAnswer(ctx, "aqa", Text("How do I print?"), &GenerateAnswerConfig{InlinePassages: []*GroundingPassage{{ID: "a"}}}); err == nil {
+			t.Errorf("GenerateAnswer() succeeded on Vertex AI, want error")
+		}
+		if len(requests) != 0 {
+			t.Errorf("sent %d requests, want 0", len(requests))
+		}
+	})
+}
+
+func TestModelsRerank(t *testing.T) {
+	ctx := context.Background()
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendVertexAI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"records": [{"id": "2", "content": "Press the red button to print.", "score": 0.92}, {"id": "1", "content": "The printer is blue.", "score": 0.11}]}`))
+	})
+	records := []*RankingRecord{
+		{ID: "1", Content: "The printer is blue."},
+		{ID: "2", Title: "Manual", Content: "Press the red button to print."},
+	}
+	response, err := client.Models.Rerank(ctx, "semantic-ranker-default@latest", "How do I print?", records, &RerankConfig{TopN: 2})
+	if err != nil {
+		t.Fatalf("Rerank() failed: %v", err)
+	}
+	want := &RerankResponse{Records: []*RankingRecord{
+		{ID: "2", Content: "Press the red button to print.", Score: 0.92},
+		{ID: "1", Content: "The printer is blue.", Score: 0.11},
+	}}
+	if diff := cmp.Diff(want, response); diff != "" {
+		t.Errorf("Rerank() mismatch (-want +got):\n%s", diff)
+	}
+	wantRequests := []batchesRequest{{
+		Method: "POST",
+		Path:   "/v1beta1/projects/project/locations/global/rankingConfigs/default_ranking_config:rank",
+		Body: map[string]any{
+			"model": "semantic-ranker-default@latest",
+			"query": "How do I print?",
+			"records": []any{
+				map[string]any{"id": "1", "content": "The printer is blue."},
+				map[string]any{"id": "2", "title": "Manual", "content": "Press the red button to print."},
+			},
+			"topN": 2.0,
+		},
+	}}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+
+	t.Run("RankingConfig", func(t *testing.T) {
+		requests = nil
+		if _, err := client.Models.Rerank(ctx, "semantic-ranker-default@latest", "How do I print?", records, &RerankConfig{RankingConfig: "custom"}); err != nil {
+			t.Fatalf("Rerank() failed: %v", err)
+		}
+		if got, want := requests[0].Path, "/v1beta1/projects/project/locations/global/rankingConfigs/custom:rank"; got != want {
+			t.Errorf("Rerank() requested %q, want %q", got, want)
+		}
+	})
+
+	t.Run("GeminiAPI", func(t *testing.T) {
+		client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {})
+		if _, err := client.Models.Rerank(ctx, "semantic-ranker-default@latest", "How do I print?", records, nil); err == nil {
+			t.Errorf("Rerank() succeeded, want error")
+		}
+	})
+
+	t.Run("BaseURL", func(t *testing.T) {
+		got := rankingHTTPOptions("us-central1", &HTTPOptions{BaseURL: "https://us-central1-aiplatform.googleapis.com/", APIVersion: "v1beta1"})
+		if want := (&HTTPOptions{BaseURL: "https://discoveryengine.googleapis.com/", APIVersion: "v1"}); !cmp.Equal(want, got) {
+			t.Errorf("rankingHTTPOptions() = %+v, want %+v", got, want)
+		}
+		custom := &HTTPOptions{BaseURL: "https://proxy.example.com/", APIVersion: "v1"}
+		if got := rankingHTTPOptions("us-central1", custom); got != custom {
+			t.Errorf("rankingHTTPOptions() = %+v, want the custom options", got)
+		}
+	})
 }
@@ -0,0 +1,201 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// defaultMaxInlineBytes is the maximum size of the inline data of a request,
+// base64 encoded, accepted by the API.
+const defaultMaxInlineBytes = 20 * 1024 * 1024
+
+// InlineDataOffload is a client policy uploading the inline data of the
+// GenerateContent requests exceeding the request size limit, and referencing the
+// uploaded data with [FileData] parts instead. Callers can then send inline data
+// of any size with a single code path.
+//
+// The references to the uploaded data are cached by the client, by SHA-256 hash
+// of the data, so that the data sent again, e.g. in the history of a chat or at
+// every step of automatic function calling, is uploaded once.
+type InlineDataOffload struct {
+	// Optional. The maximum total size in bytes of the inline data of a request,
+	// once base64 encoded. The largest blobs are uploaded until the inline data of
+	// the request fits. Defaults to 20 MB.
+	MaxInlineBytes int
+	// Optional. Uploads the data of a blob and returns the reference sent instead.
+	// Defaults to uploading the data with the Files API and waiting until the file
+	// is active on BackendGeminiAPI, and to [Files.UploadBlobToGCS] on
+	// BackendVertexAI, which has no Files API. Required on BackendVertexAI if
+	// [ClientConfig.GCSStagingURI] is not set.
+	Upload func(ctx context.Context, blob *Blob) (*FileData, error)
+}
+
+// offloadExpiryMargin is the minimum remaining lifetime of a cached upload for it
+// to be referenced by a request.
+const offloadExpiryMargin = time.Hour
+
+// offloadKey identifies the data of a blob.
+type offloadKey struct {
+	sum      [sha256.Size]byte
+	mimeType string
+}
+
+// offloadEntry is the reference to the uploaded data of a blob.
+type offloadEntry struct {
+	fileData *FileData
+	// The expiration time of the uploaded data, if any.
+	expires time.Time
+}
+
+// offloadCache caches the references to the data uploaded by the InlineDataOffload
+// policy of a client.
+type offloadCache struct {
+	mu      sync.Mutex
+	entries map[offloadKey]offloadEntry
+}
+
+func (c *offloadCache) get(key offloadKey) (*FileData, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || !entry.expires.IsZero() && time.Until(entry.expires) < offloadExpiryMargin {
+		return nil, false
+	}
+	fileData := *entry.fileData
+	return &fileData, true
+}
+
+func (c *offloadCache) put(key offloadKey, entry offloadEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[offloadKey]offloadEntry)
+	}
+	c.entries[key] = entry
+}
+
+// offloadInlineData returns the contents with the largest inline data uploaded
+// according to the InlineDataOffload policy of the client, if any. The contents
+// are not modified.
+func (m Models) offloadInlineData(ctx context.Context, contents []*Content) ([]*Content, error) {
+	policy := m.apiClient.clientConfig.InlineDataOffload
+	if policy == nil {
+		return contents, nil
+	}
+	limit := policy.MaxInlineBytes
+	if limit <= 0 {
+		limit = defaultMaxInlineBytes
+	}
+
+	type blobRef struct {
+		content, part, size int
+	}
+	var refs []blobRef
+	total := 0
+	for i, content := range contents {
+		if content == nil {
+			continue
+		}
+		for j, part := range content.Parts {
+			if part != nil && part.InlineData != nil {
+				size := base64.StdEncoding.EncodedLen(len(part.InlineData.Data))
+				refs = append(refs, blobRef{content: i, part: j, size: size})
+				total += size
+			}
+		}
+	}
+	if total <= limit {
+		return contents, nil
+	}
+
+	var upload func(ctx context.Context, blob *Blob) (offloadEntry, error)
+	switch {
+	case policy.Upload != nil:
+		upload = func(ctx context.Context, blob *Blob) (offloadEntry, error) {
+			fileData, err := policy.Upload(ctx, blob)
+			return offloadEntry{fileData: fileData}, err
+		}
+	case m.apiClient.clientConfig.Backend == BackendVertexAI:
+		if m.apiClient.clientConfig.GCSStagingURI == "" {
+			return nil, fmt.Errorf("inline data of %d bytes exceeds the limit of %d bytes, and InlineDataOffload.Upload or ClientConfig.GCSStagingURI is required for BackendVertexAI", total, limit)
+		}
+		upload = func(ctx context.Context, blob *Blob) (offloadEntry, error) {
+			fileData, err := Files{apiClient: m.apiClient}.UploadBlobToGCS(ctx, blob)
+			return offloadEntry{fileData: fileData}, err
+		}
+	default:
+		upload = m.uploadBlob
+	}
+	slices.SortStableFunc(refs, func(a, b blobRef) int { return cmp.Compare(b.size, a.size) })
+	result := slices.Clone(contents)
+	copied := map[int]bool{}
+	for _, ref := range refs {
+		if total <= limit {
+			break
+		}
+		if !copied[ref.content] {
+			content := *result[ref.content]
+			content.Parts = slices.Clone(content.Parts)
+			result[ref.content] = &content
+			copied[ref.content] = true
+		}
+		part := result[ref.content].Parts[ref.part]
+		key := offloadKey{sum: sha256.Sum256(part.InlineData.Data), mimeType: part.InlineData.MIMEType}
+		fileData, ok := m.apiClient.offloads.get(key)
+		if !ok {
+			entry, err := upload(ctx, part.InlineData)
+			if err != nil {
+				return nil, fmt.Errorf("error uploading inline data: %w", err)
+			}
+			m.apiClient.offloads.put(key, entry)
+			fileData = entry.fileData
+		}
+		offloaded := *part
+		offloaded.InlineData = nil
+		offloaded.FileData = fileData
+		result[ref.content].Parts[ref.part] = &offloaded
+		total -= ref.size
+	}
+	return result, nil
+}
+
+// uploadBlob uploads the data of a blob with the Files API and waits until the
+// file is active.
+func (m Models) uploadBlob(ctx context.Context, blob *Blob) (offloadEntry, error) {
+	files := Files{apiClient: m.apiClient}
+	file, err := files.Upload(ctx, bytes.NewReader(blob.Data), &UploadFileConfig{MIMEType: blob.MIMEType, DisplayName: blob.DisplayName})
+	if err != nil {
+		return offloadEntry{}, err
+	}
+	if file.State == FileStateProcessing {
+		if file, err = files.WaitUntilActive(ctx, file.Name, 0); err != nil {
+			return offloadEntry{}, err
+		}
+	}
+	mimeType := file.MIMEType
+	if mimeType == "" {
+		mimeType = blob.MIMEType
+	}
+	return offloadEntry{fileData: &FileData{FileURI: file.URI, MIMEType: mimeType}, expires: file.ExpirationTime}, nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// requestParts returns the keys of the data of every part of the first content of
+// a request, e.g. "text", "inlineData" or "fileData".
+func requestParts(request map[string]any) []string {
+	var kinds []string
+	for _, part := range request["contents"].([]any)[0].(map[string]any)["parts"].([]any) {
+		for key := range part.(map[string]any) {
+			kinds = append(kinds, key)
+		}
+	}
+	return kinds
+}
+
+func TestInlineDataOffload(t *testing.T) {
+	ctx := context.Background()
+	large := NewPartFromBytes([]byte(strings.Repeat("v", 3000)), "video/mp4")
+	medium := NewPartFromBytes([]byte(strings.Repeat("a", 2000)), "audio/mpeg")
+	small := NewPartFromBytes([]byte(strings.Repeat("i", 100)), "image/png")
+	contents := []*Content{NewContentFromParts([]*Part{NewPartFromText("Describe these."), small, large, medium}, RoleUser)}
+	var uploaded []string
+	upload := func(ctx context.Context, blob *Blob) (*FileData, error) {
+		uploaded = append(uploaded, blob.MIMEType)
+		return &FileData{FileURI: "gs://bucket/" + blob.MIMEType, MIMEType: blob.MIMEType}, nil
+	}
+
+	tests := []struct {
+		name         string
+		backend      Backend
+		policy       *InlineDataOffload
+		wantParts    []string
+		wantUploaded []string
+		wantErr      bool
+	}{
+		{
+			name:      "NoPolicy",
+			backend:   BackendGeminiAPI,
+			wantParts: []string{"text", "inlineData", "inlineData", "inlineData"},
+		},
+		{
+			name:         "LargestBlobs",
+			backend:      BackendVertexAI,
+			policy:       &InlineDataOffload{MaxInlineBytes: 3000, Upload: upload},
+			wantParts:    []string{"text", "inlineData", "fileData", "inlineData"},
+			wantUploaded: []string{"video/mp4"},
+		},
+		{
+			name:         "SeveralBlobs",
+			backend:      BackendGeminiAPI,
+			policy:       &InlineDataOffload{MaxInlineBytes: 1000, Upload: upload},
+			wantParts:    []string{"text", "inlineData", "fileData", "fileData"},
+			wantUploaded: []string{"video/mp4", "audio/mpeg"},
+		},
+		{
+			name:      "UnderLimit",
+			backend:   BackendGeminiAPI,
+			policy:    &InlineDataOffload{Upload: upload},
+			wantParts: []string{"text", "inlineData", "inlineData", "inlineData"},
+		},
+		{
+			name:    "VertexRequiresUpload",
+			backend: BackendVertexAI,
+			policy:  &InlineDataOffload{MaxInlineBytes: 1000},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uploaded = nil
+			var requests []map[string]any
+			models := newTestClient(t, tt.backend, replyInOrder(t, []string{finalTextResponseJSON}, &requests)).Models
+			models.apiClient.clientConfig.InlineDataOffload = tt.policy
+			_, err := models.GenerateContent(ctx, "gemini-2.0-flash", contents, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GenerateContent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.wantParts, requestParts(requests[0])); diff != "" {
+				t.Errorf("request parts mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantUploaded, uploaded); diff != "" {
+				t.Errorf("uploaded blobs mismatch (-want +got):\n%s", diff)
+			}
+			if contents[0].Parts[2].InlineData == nil {
+				t.Errorf("GenerateContent() modified the contents")
+			}
+		})
+	}
+}
+
+func TestInlineDataOffloadFilesAPI(t *testing.T) {
+	ctx := context.Background()
+	var generateRequest map[string]any
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/upload/v1beta/files":
+			w.Header().Set("X-Goog-Upload-Url", ts.URL+"/upload-session")
+			io.WriteString(w, `{}`)
+		case r.URL.Path == "/upload-session":
+			io.Copy(io.Discard, r.Body)
+			w.Header().Set("X-Goog-Upload-Status", "final")
+			io.WriteString(w, `{"file": {"name": "files/video", "uri": "https://example.com/files/video", "mimeType": "video/mp4", "state": "PROCESSING"}}`)
+		case r.URL.Path == "/v1beta/files/video":
+			io.WriteString(w, `{"name": "files/video", "uri": "https://example.com/files/video", "mimeType": "video/mp4", "state": "ACTIVE"}`)
+		case strings.HasSuffix(r.URL.Path, ":generateContent"):
+			json.NewDecoder(r.Body).Decode(&generateRequest)
+			io.WriteString(w, finalTextResponseJSON)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:           BackendGeminiAPI,
+		APIKey:            "test-api-key",
+		HTTPOptions:       HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:        ts.Client(),
+		InlineDataOffload: &InlineDataOffload{MaxInlineBytes: 10},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	video := NewPartFromBytes([]byte(strings.Repeat("v", 100)), "video/mp4")
+	if _, err := client.Models.GenerateContent(ctx, "gemini-2.0-flash", []*Content{NewContentFromParts([]*Part{video}, RoleUser)}, nil); err != nil {
+		t.Fatalf("GenerateContent() failed: %v", err)
+	}
+	part := generateRequest["contents"].([]any)[0].(map[string]any)["parts"].([]any)[0]
+	want := map[string]any{"fileData": map[string]any{"fileUri": "https://example.com/files/video", "mimeType": "video/mp4"}}
+	if diff := cmp.Diff(want, part); diff != "" {
+		t.Errorf("part mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInlineDataOffloadVertexGCS(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	var objects, generated int
+	var generateRequest map[string]any
+	client := newTestClient(t, BackendVertexAI, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/upload/storage/v1/b/staging/o"):
+			objects++
+			io.Copy(io.Discard, r.Body)
+			json.NewEncoder(w).Encode(map[string]string{"bucket": "staging", "name": r.URL.Query().Get("name"), "contentType": r.Header.Get("Content-Type")})
+		case strings.HasSuffix(r.URL.Path, ":generateContent"):
+			generated++
+			generateRequest = nil
+			json.NewDecoder(r.Body).Decode(&generateRequest)
+			io.WriteString(w, finalTextResponseJSON)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	defer func(u string) { gcsBaseURL = u }(gcsBaseURL)
+	gcsBaseURL = client.Models.apiClient.clientConfig.HTTPOptions.BaseURL + "/"
+	client.Models.apiClient.clientConfig.GCSStagingURI = "gs://staging"
+	client.Models.apiClient.clientConfig.InlineDataOffload = &InlineDataOffload{MaxInlineBytes: 10}
+
+	data := []byte(strings.Repeat("v", 100))
+	contents := []*Content{NewContentFromParts([]*Part{NewPartFromBytes(data, "video/mp4")}, RoleUser)}
+	for range 2 {
+		if _, err := client.Models.GenerateContent(ctx, "gemini-2.0-flash", contents, nil); err != nil {
+			t.Fatalf("GenerateContent() failed: %v", err)
+		}
+	}
+	sum := sha256.Sum256(data)
+	part := generateRequest["contents"].([]any)[0].(map[string]any)["parts"].([]any)[0]
+	want := map[string]any{"fileData": map[string]any{"fileUri": "gs://staging/" + hex.EncodeToString(sum[:]), "mimeType": "video/mp4"}}
+	if diff := cmp.Diff(want, part); diff != "" {
+		t.Errorf("part mismatch (-want +got):\n%s", diff)
+	}
+	if objects != 1 || generated != 2 {
+		t.Errorf("uploaded %d objects for %d requests, want 1 object for 2 requests", objects, generated)
+	}
+}
+
+func TestOffloadCacheExpiry(t *testing.T) {
+	var cache offloadCache
+	fresh := offloadKey{mimeType: "video/mp4"}
+	expiring := offloadKey{mimeType: "audio/mpeg"}
+	cache.put(fresh, offloadEntry{fileData: &FileData{FileURI: "files/fresh"}, expires: time.Now().Add(24 * time.Hour)})
+	cache.put(expiring, offloadEntry{fileData: &FileData{FileURI: "files/expiring"}, expires: time.Now().Add(time.Minute)})
+	if fileData, ok := cache.get(fresh); !ok || fileData.FileURI != "files/fresh" {
+		t.Errorf("get() of a fresh upload = %v, %v, want files/fresh", fileData, ok)
+	}
+	if _, ok := cache.get(expiring); ok {
+		t.Errorf("get() of an upload expiring soon succeeded, want it uploaded again")
+	}
+}
@@ -0,0 +1,561 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// convertRequest converts a chat completion request to the contents and config
+// of a content generation request.
+func convertRequest(req *ChatCompletionRequest) ([]*genai.Content, *genai.GenerateContentConfig, error) {
+	if req == nil {
+		return nil, nil, fmt.Errorf("request is nil")
+	}
+	system, contents, err := MessagesToContents(req.Messages)
+	if err != nil {
+		return nil, nil, err
+	}
+	config := &genai.GenerateContentConfig{
+		SystemInstruction: system,
+		Temperature:       req.Temperature,
+		TopP:              req.TopP,
+		StopSequences:     req.Stop,
+		PresencePenalty:   req.PresencePenalty,
+		FrequencyPenalty:  req.FrequencyPenalty,
+	}
+	if req.N > 0 {
+		config.CandidateCount = int32(req.N)
+	}
+	switch {
+	case req.MaxCompletionTokens > 0:
+		config.MaxOutputTokens = int32(req.MaxCompletionTokens)
+	case req.MaxTokens > 0:
+		config.MaxOutputTokens = int32(req.MaxTokens)
+	}
+	if req.Seed != nil {
+		config.Seed = genai.Ptr(int32(*req.Seed))
+	}
+	if f := req.ResponseFormat; f != nil {
+		switch f.Type {
+		case "", ResponseFormatTypeText:
+		case ResponseFormatTypeJSONObject:
+			config.ResponseMIMEType = "application/json"
+		case ResponseFormatTypeJSONSchema:
+			if f.JSONSchema == nil || len(f.JSONSchema.Schema) == 0 {
+				return nil, nil, fmt.Errorf("response format %q requires a JSON schema", f.Type)
+			}
+			config.ResponseMIMEType = "application/json"
+			config.ResponseJSONSchema = f.JSONSchema.Schema
+		default:
+			return nil, nil, fmt.Errorf("unsupported response format %q", f.Type)
+		}
+	}
+	if len(req.Tools) > 0 {
+		tool := &genai.Tool{}
+		for _, t := range req.Tools {
+			fd, err := convertTool(t)
+			if err != nil {
+				return nil, nil, err
+			}
+			tool.FunctionDeclarations = append(tool.FunctionDeclarations, fd)
+		}
+		config.Tools = []*genai.Tool{tool}
+	}
+	if req.ToolChoice != nil {
+		fc, err := convertToolChoice(req.ToolChoice)
+		if err != nil {
+			return nil, nil, err
+		}
+		config.ToolConfig = &genai.ToolConfig{FunctionCallingConfig: fc}
+	}
+	return contents, config, nil
+}
+
+// MessagesToContents converts chat messages to the system instruction and the
+// contents of a conversation, e.g. to migrate a stored transcript:
+//
+//   - System and developer messages are joined in the system instruction.
+//   - User messages are user contents, whose base64 data URL images are inline
+//     data and other image URLs are file data.
+//   - Assistant messages are model contents, whose tool calls are function calls.
+//   - Tool messages are function responses, grouped in a single user content
+//     when they are consecutive. A content which is a JSON object is the
+//     response, any other content is its "output" field.
+//
+// [ContentsToMessages] is its inverse.
+func MessagesToContents(messages []ChatCompletionMessage) (*genai.Content, []*genai.Content, error) {
+	var (
+		system   *genai.Content
+		contents []*genai.Content
+		// toolNames maps the IDs of the tool calls to the names of their functions,
+		// which function responses require.
+		toolNames = map[string]string{}
+	)
+	for i, m := range messages {
+		switch m.Role {
+		case ChatMessageRoleSystem, ChatMessageRoleDeveloper:
+			parts, err := convertMessageContent(m)
+			if err != nil {
+				return nil, nil, fmt.Errorf("message %d: %w", i, err)
+			}
+			if system == nil {
+				system = &genai.Content{}
+			}
+			system.Parts = append(system.Parts, parts...)
+		case ChatMessageRoleUser:
+			parts, err := convertMessageContent(m)
+			if err != nil {
+				return nil, nil, fmt.Errorf("message %d: %w", i, err)
+			}
+			contents = append(contents, genai.NewContentFromParts(parts, genai.RoleUser))
+		case ChatMessageRoleAssistant:
+			parts, err := convertMessageContent(m)
+			if err != nil {
+				return nil, nil, fmt.Errorf("message %d: %w", i, err)
+			}
+			for _, tc := range m.ToolCalls {
+				args := map[string]any{}
+				if tc.Function.Arguments != "" {
+					if err := json.Unmarshal([]byte(tc.Function.Arguments), &args); err != nil {
+						return nil, nil, fmt.Errorf("message %d: arguments of tool call %q are not a JSON object: %w", i, tc.ID, err)
+					}
+				}
+				toolNames[tc.ID] = tc.Function.Name
+				parts = append(parts, &genai.Part{FunctionCall: &genai.FunctionCall{
+					ID:   tc.ID,
+					Name: tc.Function.Name,
+					Args: args,
+				}})
+			}
+			contents = append(contents, genai.NewContentFromParts(parts, genai.RoleModel))
+		case ChatMessageRoleTool:
+			name, ok := toolNames[m.ToolCallID]
+			if !ok {
+				return nil, nil, fmt.Errorf("message %d: tool message answers unknown tool call %q", i, m.ToolCallID)
+			}
+			part := &genai.Part{FunctionResponse: &genai.FunctionResponse{
+				ID:       m.ToolCallID,
+				Name:     name,
+				Response: convertToolOutput(messageText(m)),
+			}}
+			if n := len(contents); n > 0 && isFunctionResponses(contents[n-1]) {
+				contents[n-1].Parts = append(contents[n-1].Parts, part)
+			} else {
+				contents = append(contents, genai.NewContentFromParts([]*genai.Part{part}, genai.RoleUser))
+			}
+		default:
+			return nil, nil, fmt.Errorf("message %d: unsupported role %q", i, m.Role)
+		}
+	}
+	return system, contents, nil
+}
+
+// ContentsToMessages converts the system instruction and the contents of a
+// conversation to chat messages, e.g. to export a transcript. It is the inverse
+// of [MessagesToContents]:
+//
+//   - The system instruction is a system message.
+//   - Model contents are assistant messages, whose function calls are tool
+//     calls. Thoughts are skipped.
+//   - User contents are user messages, whose inline images are base64 data URLs
+//     and file data images are image URLs. Their function responses are tool
+//     messages, preceding the user message of the other parts if any. A
+//     response with a single "output" field is its content, any other response
+//     is a JSON object.
+//
+// Function calls without ID get a random ID, which the function responses
+// without ID refer to in order.
+func ContentsToMessages(system *genai.Content, contents []*genai.Content) ([]ChatCompletionMessage, error) {
+	var messages []ChatCompletionMessage
+	if system != nil {
+		m, err := convertParts(ChatMessageRoleSystem, system.Parts)
+		if err != nil {
+			return nil, fmt.Errorf("system instruction: %w", err)
+		}
+		messages = append(messages, m)
+	}
+	// pendingCalls maps the names of the functions to the IDs generated for their
+	// calls without ID, until their responses.
+	pendingCalls := map[string][]string{}
+	for i, c := range contents {
+		if c == nil {
+			continue
+		}
+		switch c.Role {
+		case genai.RoleModel:
+			var parts []*genai.Part
+			var toolCalls []ToolCall
+			for _, p := range c.Parts {
+				if p.FunctionCall == nil {
+					parts = append(parts, p)
+					continue
+				}
+				tc := convertFunctionCall(p.FunctionCall)
+				if tc.ID == "" {
+					tc.ID = newToolCallID()
+					pendingCalls[tc.Function.Name] = append(pendingCalls[tc.Function.Name], tc.ID)
+				}
+				toolCalls = append(toolCalls, tc)
+			}
+			m, err := convertParts(ChatMessageRoleAssistant, parts)
+			if err != nil {
+				return nil, fmt.Errorf("content %d: %w", i, err)
+			}
+			m.ToolCalls = toolCalls
+			messages = append(messages, m)
+		case genai.RoleUser, "":
+			var parts []*genai.Part
+			var toolMessages []ChatCompletionMessage
+			for _, p := range c.Parts {
+				fr := p.FunctionResponse
+				if fr == nil {
+					parts = append(parts, p)
+					continue
+				}
+				id := fr.ID
+				if pending := pendingCalls[fr.Name]; id == "" && len(pending) > 0 {
+					id, pendingCalls[fr.Name] = pending[0], pending[1:]
+				}
+				if id == "" {
+					return nil, fmt.Errorf("content %d: function response %q answers no function call", i, fr.Name)
+				}
+				content, err := convertFunctionResponse(fr)
+				if err != nil {
+					return nil, fmt.Errorf("content %d: %w", i, err)
+				}
+				toolMessages = append(toolMessages, ChatCompletionMessage{Role: ChatMessageRoleTool, ToolCallID: id, Content: content})
+			}
+			messages = append(messages, toolMessages...)
+			if len(parts) > 0 {
+				m, err := convertParts(ChatMessageRoleUser, parts)
+				if err != nil {
+					return nil, fmt.Errorf("content %d: %w", i, err)
+				}
+				messages = append(messages, m)
+			}
+		default:
+			return nil, fmt.Errorf("content %d: unsupported role %q", i, c.Role)
+		}
+	}
+	return messages, nil
+}
+
+// convertParts converts parts to a message of the role, whose content is a
+// string if the parts are text and a list of parts otherwise.
+func convertParts(role string, parts []*genai.Part) (ChatCompletionMessage, error) {
+	m := ChatCompletionMessage{Role: role}
+	var texts []string
+	onlyText := true
+	for _, p := range parts {
+		switch {
+		case p.Thought:
+		case p.InlineData != nil:
+			if !strings.HasPrefix(p.InlineData.MIMEType, "image/") {
+				return m, fmt.Errorf("unsupported inline data of type %q", p.InlineData.MIMEType)
+			}
+			onlyText = false
+			m.MultiContent = append(m.MultiContent, ChatMessagePart{
+				Type:     ChatMessagePartTypeImageURL,
+				ImageURL: &ChatMessageImageURL{URL: "data:" + p.InlineData.MIMEType + ";base64," + base64.StdEncoding.EncodeToString(p.InlineData.Data)},
+			})
+		case p.FileData != nil:
+			if !strings.HasPrefix(p.FileData.MIMEType, "image/") {
+				return m, fmt.Errorf("unsupported file data of type %q", p.FileData.MIMEType)
+			}
+			onlyText = false
+			m.MultiContent = append(m.MultiContent, ChatMessagePart{
+				Type:     ChatMessagePartTypeImageURL,
+				ImageURL: &ChatMessageImageURL{URL: p.FileData.FileURI},
+			})
+		case p.FunctionCall != nil, p.FunctionResponse != nil:
+			return m, fmt.Errorf("unexpected function part in a %s message", role)
+		case p.ExecutableCode != nil, p.CodeExecutionResult != nil:
+			return m, fmt.Errorf("unsupported code execution part")
+		default:
+			texts = append(texts, p.Text)
+			m.MultiContent = append(m.MultiContent, ChatMessagePart{Type: ChatMessagePartTypeText, Text: p.Text})
+		}
+	}
+	if onlyText {
+		m.MultiContent = nil
+		m.Content = strings.Join(texts, "")
+	}
+	return m, nil
+}
+
+// convertFunctionResponse converts a function response to the content of a tool
+// message: the "output" field of a response holding only it, or the JSON object
+// of any other response.
+func convertFunctionResponse(fr *genai.FunctionResponse) (string, error) {
+	if output, ok := fr.Response["output"].(string); ok && len(fr.Response) == 1 {
+		return output, nil
+	}
+	if fr.Response == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(fr.Response)
+	if err != nil {
+		return "", fmt.Errorf("function response %q: %w", fr.Name, err)
+	}
+	return string(b), nil
+}
+
+// convertMessageContent converts the content of a message to parts.
+func convertMessageContent(m ChatCompletionMessage) ([]*genai.Part, error) {
+	if len(m.MultiContent) == 0 {
+		if m.Content == "" {
+			return nil, nil
+		}
+		return []*genai.Part{genai.NewPartFromText(m.Content)}, nil
+	}
+	var parts []*genai.Part
+	for _, p := range m.MultiContent {
+		switch p.Type {
+		case ChatMessagePartTypeText:
+			parts = append(parts, genai.NewPartFromText(p.Text))
+		case ChatMessagePartTypeImageURL:
+			if p.ImageURL == nil {
+				return nil, fmt.Errorf("image part has no URL")
+			}
+			part, err := convertImageURL(p.ImageURL.URL)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, part)
+		default:
+			return nil, fmt.Errorf("unsupported content part type %q", p.Type)
+		}
+	}
+	return parts, nil
+}
+
+// convertImageURL converts the URL of an image to a part, inline for a base64
+// data URL and as file data otherwise.
+func convertImageURL(rawURL string) (*genai.Part, error) {
+	if data, ok := strings.CutPrefix(rawURL, "data:"); ok {
+		header, encoded, ok := strings.Cut(data, ",")
+		mimeType, isBase64 := strings.CutSuffix(header, ";base64")
+		if !ok || !isBase64 {
+			return nil, fmt.Errorf("image data URL is not base64 encoded")
+		}
+		b, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("image data URL: %w", err)
+		}
+		return genai.NewPartFromBytes(b, mimeType), nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("image URL: %w", err)
+	}
+	mimeType := mime.TypeByExtension(path.Ext(u.Path))
+	if mimeType == "" {
+		mimeType = "image/jpeg"
+	}
+	return genai.NewPartFromURI(rawURL, mimeType), nil
+}
+
+// messageText returns the text content of a message.
+func messageText(m ChatCompletionMessage) string {
+	if len(m.MultiContent) == 0 {
+		return m.Content
+	}
+	var texts []string
+	for _, p := range m.MultiContent {
+		if p.Type == ChatMessagePartTypeText {
+			texts = append(texts, p.Text)
+		}
+	}
+	return strings.Join(texts, "")
+}
+
+// convertToolOutput converts the content of a tool message to a function
+// response: a JSON object is sent as is, any other content is sent as the
+// "output" field.
+func convertToolOutput(content string) map[string]any {
+	var response map[string]any
+	if err := json.Unmarshal([]byte(content), &response); err == nil && response != nil {
+		return response
+	}
+	return map[string]any{"output": content}
+}
+
+// isFunctionResponses reports whether a content only holds function responses.
+func isFunctionResponses(c *genai.Content) bool {
+	if c.Role != genai.RoleUser || len(c.Parts) == 0 {
+		return false
+	}
+	for _, p := range c.Parts {
+		if p.FunctionResponse == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// convertTool converts a tool to a function declaration.
+func convertTool(t Tool) (*genai.FunctionDeclaration, error) {
+	if t.Type != ToolTypeFunction || t.Function == nil {
+		return nil, fmt.Errorf("unsupported tool type %q", t.Type)
+	}
+	fd := &genai.FunctionDeclaration{
+		Name:        t.Function.Name,
+		Description: t.Function.Description,
+	}
+	if t.Function.Parameters == nil {
+		return fd, nil
+	}
+	b, err := json.Marshal(t.Function.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("parameters of function %q: %w", fd.Name, err)
+	}
+	var jsonSchema map[string]any
+	if err := json.Unmarshal(b, &jsonSchema); err != nil {
+		return nil, fmt.Errorf("parameters of function %q are not a JSON object: %w", fd.Name, err)
+	}
+	if len(jsonSchema) == 0 {
+		return fd, nil
+	}
+	fd.Parameters, err = genai.NewSchemaFromJSONSchema(jsonSchema)
+	if err != nil {
+		return nil, fmt.Errorf("parameters of function %q: %w", fd.Name, err)
+	}
+	return fd, nil
+}
+
+// convertToolChoice converts a tool choice, a mode string or a [ToolChoice]
+// forcing a function, to a function calling config.
+func convertToolChoice(choice any) (*genai.FunctionCallingConfig, error) {
+	b, err := json.Marshal(choice)
+	if err != nil {
+		return nil, fmt.Errorf("tool choice: %w", err)
+	}
+	var mode string
+	if err := json.Unmarshal(b, &mode); err == nil {
+		switch mode {
+		case ToolChoiceNone:
+			return &genai.FunctionCallingConfig{Mode: genai.FunctionCallingConfigModeNone}, nil
+		case ToolChoiceAuto:
+			return &genai.FunctionCallingConfig{Mode: genai.FunctionCallingConfigModeAuto}, nil
+		case ToolChoiceRequired:
+			return &genai.FunctionCallingConfig{Mode: genai.FunctionCallingConfigModeAny}, nil
+		default:
+			return nil, fmt.Errorf("unsupported tool choice %q", mode)
+		}
+	}
+	var tc ToolChoice
+	if err := json.Unmarshal(b, &tc); err != nil || tc.Type != ToolTypeFunction || tc.Function.Name == "" {
+		return nil, fmt.Errorf("unsupported tool choice %s", b)
+	}
+	return &genai.FunctionCallingConfig{
+		Mode:                 genai.FunctionCallingConfigModeAny,
+		AllowedFunctionNames: []string{tc.Function.Name},
+	}, nil
+}
+
+// convertCandidateContent converts the content of a candidate to the text and
+// tool calls of a message. Thoughts are skipped. If next is not nil, the tool
+// calls are indexed from it, as in a streamed message, and it is advanced.
+func convertCandidateContent(content *genai.Content, next *int) (string, []ToolCall) {
+	if content == nil {
+		return "", nil
+	}
+	var (
+		text      strings.Builder
+		toolCalls []ToolCall
+	)
+	for _, p := range content.Parts {
+		switch {
+		case p.Thought:
+		case p.FunctionCall != nil:
+			tc := convertFunctionCall(p.FunctionCall)
+			if tc.ID == "" {
+				tc.ID = newToolCallID()
+			}
+			if next != nil {
+				tc.Index = genai.Ptr(*next)
+				*next++
+			}
+			toolCalls = append(toolCalls, tc)
+		default:
+			text.WriteString(p.Text)
+		}
+	}
+	return text.String(), toolCalls
+}
+
+// convertFunctionCall converts a function call to a tool call, whose arguments
+// are a JSON object.
+func convertFunctionCall(fc *genai.FunctionCall) ToolCall {
+	args := fc.Args
+	if args == nil {
+		args = map[string]any{}
+	}
+	b, _ := json.Marshal(args)
+	return ToolCall{
+		ID:       fc.ID,
+		Type:     ToolTypeFunction,
+		Function: FunctionCall{Name: fc.Name, Arguments: string(b)},
+	}
+}
+
+// newToolCallID returns a random ID for a tool call the model did not identify.
+func newToolCallID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return "call_" + hex.EncodeToString(b)
+}
+
+// convertFinishReason converts the finish reason of a candidate.
+func convertFinishReason(reason genai.FinishReason, hasToolCalls bool) string {
+	switch reason {
+	case genai.FinishReasonMaxTokens:
+		return FinishReasonLength
+	case genai.FinishReasonSafety, genai.FinishReasonRecitation, genai.FinishReasonBlocklist,
+		genai.FinishReasonProhibitedContent, genai.FinishReasonSPII, genai.FinishReasonImageSafety:
+		return FinishReasonContentFilter
+	}
+	if hasToolCalls {
+		return FinishReasonToolCalls
+	}
+	return FinishReasonStop
+}
+
+// convertUsage converts the usage metadata of a response.
+func convertUsage(u *genai.GenerateContentResponseUsageMetadata) *Usage {
+	if u == nil {
+		return nil
+	}
+	usage := &Usage{
+		PromptTokens:     int(u.PromptTokenCount),
+		CompletionTokens: int(u.CandidatesTokenCount + u.ThoughtsTokenCount),
+		TotalTokens:      int(u.TotalTokenCount),
+	}
+	if u.CachedContentTokenCount > 0 {
+		usage.PromptTokensDetails = &PromptTokensDetails{CachedTokens: int(u.CachedContentTokenCount)}
+	}
+	return usage
+}
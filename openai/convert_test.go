@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+)
+
+const transcriptJSON = `[
+	{"role": "system", "content": "You are terse."},
+	{"role": "user", "content": [
+		{"type": "text", "text": "What is this?"},
+		{"type": "image_url", "image_url": {"url": "data:image/png;base64,aGVsbG8="}}
+	]},
+	{"role": "assistant", "content": "A greeting. Checking the time.", "tool_calls": [
+		{"id": "call_1", "type": "function", "function": {"name": "get_time", "arguments": "{\"tz\":\"CET\"}"}},
+		{"id": "call_2", "type": "function", "function": {"name": "get_date", "arguments": "{}"}}
+	]},
+	{"role": "tool", "tool_call_id": "call_1", "content": "12:00"},
+	{"role": "tool", "tool_call_id": "call_2", "content": "{\"date\":\"2025-06-01\"}"},
+	{"role": "assistant", "content": "It is noon."}
+]`
+
+func TestMessagesContentsRoundTrip(t *testing.T) {
+	var messages []ChatCompletionMessage
+	if err := json.Unmarshal([]byte(transcriptJSON), &messages); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	system, contents, err := MessagesToContents(messages)
+	if err != nil {
+		t.Fatalf("MessagesToContents() failed: %v", err)
+	}
+	wantSystem := &genai.Content{Parts: []*genai.Part{{Text: "You are terse."}}}
+	wantContents := []*genai.Content{
+		{Role: genai.RoleUser, Parts: []*genai.Part{
+			{Text: "What is this?"},
+			{InlineData: &genai.Blob{Data: []byte("hello"), MIMEType: "image/png"}},
+		}},
+		{Role: genai.RoleModel, Parts: []*genai.Part{
+			{Text: "A greeting. Checking the time."},
+			{FunctionCall: &genai.FunctionCall{ID: "call_1", Name: "get_time", Args: map[string]any{"tz": "CET"}}},
+			{FunctionCall: &genai.FunctionCall{ID: "call_2", Name: "get_date", Args: map[string]any{}}},
+		}},
+		{Role: genai.RoleUser, Parts: []*genai.Part{
+			{FunctionResponse: &genai.FunctionResponse{ID: "call_1", Name: "get_time", Response: map[string]any{"output": "12:00"}}},
+			{FunctionResponse: &genai.FunctionResponse{ID: "call_2", Name: "get_date", Response: map[string]any{"date": "2025-06-01"}}},
+		}},
+		{Role: genai.RoleModel, Parts: []*genai.Part{{Text: "It is noon."}}},
+	}
+	if diff := cmp.Diff(wantSystem, system); diff != "" {
+		t.Errorf("MessagesToContents() system mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantContents, contents); diff != "" {
+		t.Errorf("MessagesToContents() contents mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err := ContentsToMessages(system, contents)
+	if err != nil {
+		t.Fatalf("ContentsToMessages() failed: %v", err)
+	}
+	if diff := cmp.Diff(messages, got); diff != "" {
+		t.Errorf("ContentsToMessages() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestContentsToMessagesWithoutIDs(t *testing.T) {
+	contents := []*genai.Content{
+		genai.NewContentFromText("Weather in Paris and Rome?", genai.RoleUser),
+		{Role: genai.RoleModel, Parts: []*genai.Part{
+			{Text: "Let me think.", Thought: true},
+			genai.NewPartFromFunctionCall("get_weather", map[string]any{"city": "Paris"}),
+			genai.NewPartFromFunctionCall("get_weather", map[string]any{"city": "Rome"}),
+		}},
+		{Role: genai.RoleUser, Parts: []*genai.Part{
+			genai.NewPartFromFunctionResponse("get_weather", map[string]any{"output": "sunny"}),
+			genai.NewPartFromFunctionResponse("get_weather", map[string]any{"output": "rainy"}),
+			genai.NewPartFromURI("gs://bucket/map.png", "image/png"),
+		}},
+	}
+	messages, err := ContentsToMessages(nil, contents)
+	if err != nil {
+		t.Fatalf("ContentsToMessages() failed: %v", err)
+	}
+	if len(messages) != 5 {
+		t.Fatalf("ContentsToMessages() returned %d messages, want 5: %+v", len(messages), messages)
+	}
+	calls := messages[1].ToolCalls
+	if len(calls) != 2 || messages[1].Content != "" || !strings.HasPrefix(calls[0].ID, "call_") || calls[0].ID == calls[1].ID {
+		t.Fatalf("ContentsToMessages() assistant message = %+v, want two tool calls with distinct IDs", messages[1])
+	}
+	want := []ChatCompletionMessage{
+		{Role: ChatMessageRoleTool, ToolCallID: calls[0].ID, Content: "sunny"},
+		{Role: ChatMessageRoleTool, ToolCallID: calls[1].ID, Content: "rainy"},
+		{Role: ChatMessageRoleUser, MultiContent: []ChatMessagePart{
+			{Type: ChatMessagePartTypeImageURL, ImageURL: &ChatMessageImageURL{URL: "gs://bucket/map.png"}},
+		}},
+	}
+	if diff := cmp.Diff(want, messages[2:]); diff != "" {
+		t.Errorf("ContentsToMessages() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := ContentsToMessages(nil, []*genai.Content{{Role: genai.RoleUser, Parts: []*genai.Part{
+		genai.NewPartFromFunctionResponse("get_weather", map[string]any{"output": "sunny"}),
+	}}}); err == nil {
+		t.Errorf("ContentsToMessages() succeeded for a response without call, want error")
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package openai exposes a [genai.Client] through the request and response
+// shapes of the OpenAI chat completions API, so that code written against
+// OpenAI-style interfaces can call Gemini models with minimal changes:
+//
+//	client, err := genai.NewClient(ctx, nil)
+//	if err != nil {
+//		return err
+//	}
+//	chat := openai.NewClient(client)
+//	resp, err := chat.CreateChatCompletion(ctx, &openai.ChatCompletionRequest{
+//		Model: "gemini-2.5-flash",
+//		Messages: []openai.ChatCompletionMessage{
+//			{Role: openai.ChatMessageRoleUser, Content: "Why is the sky blue?"},
+//		},
+//	})
+//
+// Requests are converted to [genai.Models.GenerateContent] calls: system and
+// developer messages become the system instruction, assistant messages become
+// model turns and tool messages become function responses. The same conversion
+// is exposed by [MessagesToContents], and reversed by [ContentsToMessages], to
+// migrate stored transcripts and prompts.
+package openai
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+
+	"google.golang.org/genai"
+)
+
+// Client creates chat completions with a [genai.Client].
+type Client struct {
+	client *genai.Client
+}
+
+// NewClient returns a Client creating chat completions with client.
+func NewClient(client *genai.Client) *Client {
+	return &Client{client: client}
+}
+
+// CreateChatCompletion generates a chat completion.
+func (c *Client) CreateChatCompletion(ctx context.Context, req *ChatCompletionRequest) (*ChatCompletionResponse, error) {
+	contents, config, err := convertRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Models.GenerateContent(ctx, req.Model, contents, config)
+	if err != nil {
+		return nil, err
+	}
+	out := &ChatCompletionResponse{
+		ID:      completionID(resp),
+		Object:  "chat.completion",
+		Created: createdTime(resp),
+		Model:   modelVersion(resp, req.Model),
+		Usage:   convertUsage(resp.UsageMetadata),
+	}
+	if len(resp.Candidates) == 0 && resp.PromptFeedback != nil && resp.PromptFeedback.BlockReason != "" {
+		out.Choices = []ChatCompletionChoice{{
+			Message:      ChatCompletionMessage{Role: ChatMessageRoleAssistant},
+			FinishReason: FinishReasonContentFilter,
+		}}
+		return out, nil
+	}
+	for i, cand := range resp.Candidates {
+		text, toolCalls := convertCandidateContent(cand.Content, nil)
+		out.Choices = append(out.Choices, ChatCompletionChoice{
+			Index: i,
+			Message: ChatCompletionMessage{
+				Role:      ChatMessageRoleAssistant,
+				Content:   text,
+				ToolCalls: toolCalls,
+			},
+			FinishReason: convertFinishReason(cand.FinishReason, len(toolCalls) > 0),
+		})
+	}
+	return out, nil
+}
+
+// CreateChatCompletionStream generates a chat completion as a stream of chunks.
+// The first chunk of every choice sets the assistant role of its delta and the
+// last one sets its finish reason. If [StreamOptions.IncludeUsage] is set, a
+// last chunk without choices reports the usage of the request.
+func (c *Client) CreateChatCompletionStream(ctx context.Context, req *ChatCompletionRequest) iter.Seq2[*ChatCompletionStreamResponse, error] {
+	return func(yield func(*ChatCompletionStreamResponse, error) bool) {
+		contents, config, err := convertRequest(req)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		var (
+			id       string
+			created  int64
+			model    = req.Model
+			usage    genai.StreamUsage
+			started  = map[int]bool{}
+			hasTools = map[int]bool{}
+			// toolCallIndexes counts the tool calls of every choice, to index them
+			// across chunks.
+			toolCallIndexes = map[int]int{}
+		)
+		for resp, err := range c.client.Models.GenerateContentStream(ctx, req.Model, contents, config) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if id == "" {
+				id = completionID(resp)
+				created = createdTime(resp)
+				model = modelVersion(resp, req.Model)
+			}
+			usage.Add(resp.UsageMetadata)
+			chunk := &ChatCompletionStreamResponse{
+				ID:      id,
+				Object:  "chat.completion.chunk",
+				Created: created,
+				Model:   model,
+			}
+			if len(resp.Candidates) == 0 && resp.PromptFeedback != nil && resp.PromptFeedback.BlockReason != "" {
+				chunk.Choices = []ChatCompletionStreamChoice{{
+					Delta:        ChatCompletionDelta{Role: ChatMessageRoleAssistant},
+					FinishReason: FinishReasonContentFilter,
+				}}
+			}
+			for _, cand := range resp.Candidates {
+				i := int(cand.Index)
+				next := toolCallIndexes[i]
+				text, toolCalls := convertCandidateContent(cand.Content, &next)
+				toolCallIndexes[i] = next
+				if len(toolCalls) > 0 {
+					hasTools[i] = true
+				}
+				choice := ChatCompletionStreamChoice{
+					Index: i,
+					Delta: ChatCompletionDelta{Content: text, ToolCalls: toolCalls},
+				}
+				if !started[i] {
+					started[i] = true
+					choice.Delta.Role = ChatMessageRoleAssistant
+				}
+				if cand.FinishReason != "" && cand.FinishReason != genai.FinishReasonUnspecified {
+					choice.FinishReason = convertFinishReason(cand.FinishReason, hasTools[i])
+				}
+				chunk.Choices = append(chunk.Choices, choice)
+			}
+			if !yield(chunk, nil) {
+				return
+			}
+		}
+		if req.StreamOptions != nil && req.StreamOptions.IncludeUsage {
+			yield(&ChatCompletionStreamResponse{
+				ID:      id,
+				Object:  "chat.completion.chunk",
+				Created: created,
+				Model:   model,
+				Choices: []ChatCompletionStreamChoice{},
+				Usage:   convertUsage(usage.Usage()),
+			}, nil)
+		}
+	}
+}
+
+// completionID returns the ID of the completion of a response.
+func completionID(resp *genai.GenerateContentResponse) string {
+	if resp.ResponseID != "" {
+		return "chatcmpl-" + resp.ResponseID
+	}
+	return fmt.Sprintf("chatcmpl-%d", time.Now().UnixNano())
+}
+
+// createdTime returns the creation time of a response, in seconds since the
+// Unix epoch.
+func createdTime(resp *genai.GenerateContentResponse) int64 {
+	if !resp.CreateTime.IsZero() {
+		return resp.CreateTime.Unix()
+	}
+	return time.Now().Unix()
+}
+
+// modelVersion returns the model which generated a response.
+func modelVersion(resp *genai.GenerateContentResponse, model string) string {
+	if resp.ModelVersion != "" {
+		return resp.ModelVersion
+	}
+	return model
+}
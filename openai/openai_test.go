@@ -0,0 +1,284 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/genai"
+)
+
+// newTestClient returns a Client whose requests are served by the responses, in
+// order, and recorded in requests. Streamed responses are sent as server-sent
+// events, one per line of the response.
+func newTestClient(t *testing.T, responses []string, requests *[]map[string]any) *Client {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Error decoding request body: %v", err)
+		}
+		body["path"] = r.URL.Path
+		*requests = append(*requests, body)
+		resp := responses[min(len(*requests), len(responses))-1]
+		if r.URL.Query().Get("alt") == "sse" {
+			for _, line := range strings.Split(resp, "\n") {
+				fmt.Fprintf(w, "data: %s\n\n", line)
+			}
+			return
+		}
+		fmt.Fprintln(w, resp)
+	}))
+	t.Cleanup(ts.Close)
+	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+		APIKey:      "test-api-key",
+		Backend:     genai.BackendGeminiAPI,
+		HTTPClient:  ts.Client(),
+		HTTPOptions: genai.HTTPOptions{BaseURL: ts.URL},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() failed: %v", err)
+	}
+	return NewClient(client)
+}
+
+func TestCreateChatCompletion(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	client := newTestClient(t, []string{`{
+		"modelVersion": "gemini-2.5-flash-001",
+		"candidates": [{
+			"content": {"role": "model", "parts": [
+				{"text": "Let me check.", "thought": true},
+				{"text": "Checking the weather."},
+				{"functionCall": {"id": "call_2", "name": "get_weather", "args": {"city": "Paris"}}}
+			]},
+			"finishReason": "STOP"
+		}],
+		"usageMetadata": {"promptTokenCount": 10, "candidatesTokenCount": 5, "thoughtsTokenCount": 3, "totalTokenCount": 18}
+	}`}, &requests)
+
+	var req ChatCompletionRequest
+	if err := json.Unmarshal([]byte(`{
+		"model": "gemini-2.5-flash",
+		"messages": [
+			{"role": "system", "content": "You are terse."},
+			{"role": "user", "content": [
+				{"type": "text", "text": "What is in this image?"},
+				{"type": "image_url", "image_url": {"url": "data:image/png;base64,aGVsbG8="}},
+				{"type": "image_url", "image_url": {"url": "gs://bucket/cat.jpg"}}
+			]},
+			{"role": "assistant", "content": null, "tool_calls": [
+				{"id": "call_1", "type": "function", "function": {"name": "get_time", "arguments": "{\"tz\":\"CET\"}"}}
+			]},
+			{"role": "tool", "tool_call_id": "call_1", "content": "12:00"},
+			{"role": "user", "content": "And the weather?"}
+		],
+		"temperature": 0.5,
+		"max_tokens": 100,
+		"max_completion_tokens": 200,
+		"seed": 7,
+		"stop": ["END"],
+		"response_format": {"type": "json_object"},
+		"tools": [{"type": "function", "function": {
+			"name": "get_weather",
+			"description": "Returns the weather.",
+			"parameters": {"type": "object", "properties": {"city": {"type": "string"}}, "required": ["city"]}
+		}}],
+		"tool_choice": {"type": "function", "function": {"name": "get_weather"}}
+	}`), &req); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	resp, err := client.CreateChatCompletion(ctx, &req)
+	if err != nil {
+		t.Fatalf("CreateChatCompletion() failed: %v", err)
+	}
+
+	if !strings.HasPrefix(resp.ID, "chatcmpl-") || resp.Created == 0 {
+		t.Errorf("CreateChatCompletion() ID, created = %q, %d, want a chatcmpl- ID and a creation time", resp.ID, resp.Created)
+	}
+	want := &ChatCompletionResponse{
+		Object: "chat.completion",
+		Model:  "gemini-2.5-flash-001",
+		Choices: []ChatCompletionChoice{{
+			Message: ChatCompletionMessage{
+				Role:    ChatMessageRoleAssistant,
+				Content: "Checking the weather.",
+				ToolCalls: []ToolCall{{
+					ID:       "call_2",
+					Type:     ToolTypeFunction,
+					Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`},
+				}},
+			},
+			FinishReason: FinishReasonToolCalls,
+		}},
+		Usage: &Usage{PromptTokens: 10, CompletionTokens: 8, TotalTokens: 18},
+	}
+	if diff := cmp.Diff(want, resp, cmpopts.IgnoreFields(ChatCompletionResponse{}, "ID", "Created")); diff != "" {
+		t.Errorf("CreateChatCompletion() mismatch (-want +got):\n%s", diff)
+	}
+
+	var wantRequest map[string]any
+	if err := json.Unmarshal([]byte(`{
+		"path": "/v1beta/models/gemini-2.5-flash:generateContent",
+		"systemInstruction": {"role": "user", "parts": [{"text": "You are terse."}]},
+		"contents": [
+			{"role": "user", "parts": [
+				{"text": "What is in this image?"},
+				{"inlineData": {"data": "aGVsbG8=", "mimeType": "image/png"}},
+				{"fileData": {"fileUri": "gs://bucket/cat.jpg", "mimeType": "image/jpeg"}}
+			]},
+			{"role": "model", "parts": [{"functionCall": {"id": "call_1", "name": "get_time", "args": {"tz": "CET"}}}]},
+			{"role": "user", "parts": [{"functionResponse": {"id": "call_1", "name": "get_time", "response": {"output": "12:00"}}}]},
+			{"role": "user", "parts": [{"text": "And the weather?"}]}
+		],
+		"generationConfig": {
+			"temperature": 0.5,
+			"maxOutputTokens": 200,
+			"seed": 7,
+			"stopSequences": ["END"],
+			"responseMimeType": "application/json"
+		},
+		"tools": [{"functionDeclarations": [{
+			"name": "get_weather",
+			"description": "Returns the weather.",
+			"parameters": {"type": "OBJECT", "properties": {"city": {"type": "STRING"}}, "required": ["city"]}
+		}]}],
+		"toolConfig": {"functionCallingConfig": {"mode": "ANY", "allowedFunctionNames": ["get_weather"]}}
+	}`), &wantRequest); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	if diff := cmp.Diff([]map[string]any{wantRequest}, requests); diff != "" {
+		t.Errorf("request mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCreateChatCompletionStream(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	client := newTestClient(t, []string{strings.Join([]string{
+		`{"candidates": [{"content": {"role": "model", "parts": [{"text": "Hello"}]}}], "usageMetadata": {"promptTokenCount": 4, "cachedContentTokenCount": 3, "candidatesTokenCount": 1}}`,
+		`{"candidates": [{"content": {"role": "model", "parts": [{"text": " world"}, {"functionCall": {"id": "c1", "name": "f"}}]}}]}`,
+		`{"candidates": [{"content": {"role": "model", "parts": [{"functionCall": {"id": "c2", "name": "g", "args": {"x": 1}}}]}, "finishReason": "STOP"}], "usageMetadata": {"promptTokenCount": 4, "candidatesTokenCount": 6, "totalTokenCount": 10}}`,
+	}, "\n")}, &requests)
+
+	req := &ChatCompletionRequest{
+		Model:         "gemini-2.5-flash",
+		Messages:      []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hi"}},
+		StreamOptions: &StreamOptions{IncludeUsage: true},
+	}
+	var (
+		got   []ChatCompletionStreamChoice
+		usage *Usage
+		ids   = map[string]bool{}
+	)
+	for chunk, err := range client.CreateChatCompletionStream(ctx, req) {
+		if err != nil {
+			t.Fatalf("CreateChatCompletionStream() failed: %v", err)
+		}
+		if chunk.Object != "chat.completion.chunk" {
+			t.Errorf("chunk object = %q, want %q", chunk.Object, "chat.completion.chunk")
+		}
+		ids[chunk.ID] = true
+		got = append(got, chunk.Choices...)
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+	}
+	want := []ChatCompletionStreamChoice{
+		{Delta: ChatCompletionDelta{Role: ChatMessageRoleAssistant, Content: "Hello"}},
+		{Delta: ChatCompletionDelta{Content: " world", ToolCalls: []ToolCall{
+			{Index: genai.Ptr(0), ID: "c1", Type: ToolTypeFunction, Function: FunctionCall{Name: "f", Arguments: "{}"}},
+		}}},
+		{
+			Delta: ChatCompletionDelta{ToolCalls: []ToolCall{
+				{Index: genai.Ptr(1), ID: "c2", Type: ToolTypeFunction, Function: FunctionCall{Name: "g", Arguments: `{"x":1}`}},
+			}},
+			FinishReason: FinishReasonToolCalls,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CreateChatCompletionStream() mismatch (-want +got):\n%s", diff)
+	}
+	if len(ids) != 1 {
+		t.Errorf("chunk IDs = %v, want a single ID", ids)
+	}
+	if diff := cmp.Diff(&Usage{PromptTokens: 4, CompletionTokens: 6, TotalTokens: 10, PromptTokensDetails: &PromptTokensDetails{CachedTokens: 3}}, usage); diff != "" {
+		t.Errorf("usage mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertRequestErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		req  *ChatCompletionRequest
+	}{
+		{"UnknownRole", &ChatCompletionRequest{Messages: []ChatCompletionMessage{{Role: "narrator"}}}},
+		{"UnknownToolCall", &ChatCompletionRequest{Messages: []ChatCompletionMessage{{Role: ChatMessageRoleTool, ToolCallID: "call_1"}}}},
+		{"InvalidArguments", &ChatCompletionRequest{Messages: []ChatCompletionMessage{{
+			Role:      ChatMessageRoleAssistant,
+			ToolCalls: []ToolCall{{ID: "call_1", Type: ToolTypeFunction, Function: FunctionCall{Name: "f", Arguments: "[1]"}}},
+		}}}},
+		{"UnknownToolChoice", &ChatCompletionRequest{ToolChoice: "sometimes"}},
+		{"SchemaWithoutSchema", &ChatCompletionRequest{ResponseFormat: &ResponseFormat{Type: ResponseFormatTypeJSONSchema}}},
+		{"NonBase64DataURL", &ChatCompletionRequest{Messages: []ChatCompletionMessage{{
+			Role:         ChatMessageRoleUser,
+			MultiContent: []ChatMessagePart{{Type: ChatMessagePartTypeImageURL, ImageURL: &ChatMessageImageURL{URL: "data:image/png,abc"}}},
+		}}}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := convertRequest(tt.req); err == nil {
+				t.Errorf("convertRequest() succeeded, want error")
+			}
+		})
+	}
+}
+
+func TestChatCompletionMessageJSON(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		msg  ChatCompletionMessage
+		want string
+	}{
+		{"Text", ChatCompletionMessage{Role: ChatMessageRoleUser, Content: "Hi"}, `{"role":"user","content":"Hi"}`},
+		{"Parts", ChatCompletionMessage{Role: ChatMessageRoleUser, MultiContent: []ChatMessagePart{{Type: "text", Text: "Hi"}}}, `{"role":"user","content":[{"type":"text","text":"Hi"}]}`},
+		{"ToolCalls", ChatCompletionMessage{Role: ChatMessageRoleAssistant, ToolCalls: []ToolCall{{ID: "c", Type: "function", Function: FunctionCall{Name: "f", Arguments: "{}"}}}}, `{"role":"assistant","content":null,"tool_calls":[{"id":"c","type":"function","function":{"name":"f","arguments":"{}"}}]}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.msg)
+			if err != nil {
+				t.Fatalf("json.Marshal() failed: %v", err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("json.Marshal() = %s, want %s", b, tt.want)
+			}
+			var got ChatCompletionMessage
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatalf("json.Unmarshal() failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.msg, got); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
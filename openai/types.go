@@ -0,0 +1,365 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// The roles of a [ChatCompletionMessage].
+const (
+	ChatMessageRoleSystem    = "system"
+	ChatMessageRoleDeveloper = "developer"
+	ChatMessageRoleUser      = "user"
+	ChatMessageRoleAssistant = "assistant"
+	ChatMessageRoleTool      = "tool"
+)
+
+// The types of a [ChatMessagePart].
+const (
+	ChatMessagePartTypeText     = "text"
+	ChatMessagePartTypeImageURL = "image_url"
+)
+
+// The reasons a model stopped generating a [ChatCompletionChoice].
+const (
+	FinishReasonStop          = "stop"
+	FinishReasonLength        = "length"
+	FinishReasonToolCalls     = "tool_calls"
+	FinishReasonContentFilter = "content_filter"
+)
+
+// The types of a [ResponseFormat].
+const (
+	ResponseFormatTypeText       = "text"
+	ResponseFormatTypeJSONObject = "json_object"
+	ResponseFormatTypeJSONSchema = "json_schema"
+)
+
+// The modes of a string tool choice.
+const (
+	ToolChoiceNone     = "none"
+	ToolChoiceAuto     = "auto"
+	ToolChoiceRequired = "required"
+)
+
+// ToolTypeFunction is the type of a function [Tool] and [ToolCall].
+const ToolTypeFunction = "function"
+
+// ChatCompletionRequest is a request to create a chat completion.
+type ChatCompletionRequest struct {
+	// The Gemini model generating the completion, e.g. "gemini-2.5-flash".
+	Model string `json:"model"`
+	// The messages of the conversation so far.
+	Messages []ChatCompletionMessage `json:"messages"`
+	// Optional. The sampling temperature.
+	Temperature *float32 `json:"temperature,omitempty"`
+	// Optional. The nucleus sampling probability mass.
+	TopP *float32 `json:"top_p,omitempty"`
+	// Optional. The number of choices to generate.
+	N int `json:"n,omitempty"`
+	// Optional. Sequences stopping the generation.
+	Stop []string `json:"stop,omitempty"`
+	// Optional. The maximum number of generated tokens. Deprecated by OpenAI in
+	// favor of MaxCompletionTokens, which takes precedence.
+	MaxTokens int `json:"max_tokens,omitempty"`
+	// Optional. The maximum number of generated tokens.
+	MaxCompletionTokens int `json:"max_completion_tokens,omitempty"`
+	// Optional. Penalizes tokens already present in the text.
+	PresencePenalty *float32 `json:"presence_penalty,omitempty"`
+	// Optional. Penalizes tokens by their frequency in the text.
+	FrequencyPenalty *float32 `json:"frequency_penalty,omitempty"`
+	// Optional. The seed of the sampling.
+	Seed *int `json:"seed,omitempty"`
+	// Optional. The format of the generated message.
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+	// Optional. The tools the model may call.
+	Tools []Tool `json:"tools,omitempty"`
+	// Optional. Controls the calls to tools: "none", "auto", "required" or a
+	// [ToolChoice] forcing a function.
+	ToolChoice any `json:"tool_choice,omitempty"`
+	// Whether the completion is streamed. It is ignored by the adapter, which
+	// streams from [Client.CreateChatCompletionStream].
+	Stream bool `json:"stream,omitempty"`
+	// Optional. Options of a streamed completion.
+	StreamOptions *StreamOptions `json:"stream_options,omitempty"`
+	// Optional. The end user of the request. It is ignored by the adapter.
+	User string `json:"user,omitempty"`
+}
+
+// StreamOptions are the options of a streamed chat completion.
+type StreamOptions struct {
+	// Whether a last chunk without choices reports the usage of the request.
+	IncludeUsage bool `json:"include_usage,omitempty"`
+}
+
+// ChatCompletionMessage is a message of a chat conversation.
+//
+// The content is either the string Content or the parts MultiContent, which
+// are encoded as the "content" JSON field.
+type ChatCompletionMessage struct {
+	// The role of the author of the message.
+	Role string `json:"role"`
+	// The text content of the message.
+	Content string `json:"-"`
+	// The content parts of the message, exclusive of Content.
+	MultiContent []ChatMessagePart `json:"-"`
+	// Optional. The name of the author of the message.
+	Name string `json:"name,omitempty"`
+	// The tools called by an assistant message.
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// The ID of the call answered by a tool message.
+	ToolCallID string `json:"tool_call_id,omitempty"`
+}
+
+type chatCompletionMessageJSON struct {
+	Role       string          `json:"role"`
+	Content    json.RawMessage `json:"content"`
+	Name       string          `json:"name,omitempty"`
+	ToolCalls  []ToolCall      `json:"tool_calls,omitempty"`
+	ToolCallID string          `json:"tool_call_id,omitempty"`
+}
+
+// MarshalJSON encodes the content of the message as a string, an array of
+// parts or null for an assistant message only calling tools.
+func (m ChatCompletionMessage) MarshalJSON() ([]byte, error) {
+	if m.Content != "" && len(m.MultiContent) > 0 {
+		return nil, fmt.Errorf("message has both Content and MultiContent")
+	}
+	aux := chatCompletionMessageJSON{
+		Role:       m.Role,
+		Name:       m.Name,
+		ToolCalls:  m.ToolCalls,
+		ToolCallID: m.ToolCallID,
+	}
+	var err error
+	switch {
+	case len(m.MultiContent) > 0:
+		aux.Content, err = json.Marshal(m.MultiContent)
+	case m.Content == "" && len(m.ToolCalls) > 0:
+		aux.Content = json.RawMessage("null")
+	default:
+		aux.Content, err = json.Marshal(m.Content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(aux)
+}
+
+// UnmarshalJSON decodes a message whose content is a string, an array of parts
+// or null.
+func (m *ChatCompletionMessage) UnmarshalJSON(data []byte) error {
+	var aux chatCompletionMessageJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*m = ChatCompletionMessage{
+		Role:       aux.Role,
+		Name:       aux.Name,
+		ToolCalls:  aux.ToolCalls,
+		ToolCallID: aux.ToolCallID,
+	}
+	content := bytes.TrimSpace(aux.Content)
+	switch {
+	case len(content) == 0 || bytes.Equal(content, []byte("null")):
+		return nil
+	case content[0] == '[':
+		return json.Unmarshal(content, &m.MultiContent)
+	default:
+		return json.Unmarshal(content, &m.Content)
+	}
+}
+
+// ChatMessagePart is a part of the content of a [ChatCompletionMessage].
+type ChatMessagePart struct {
+	// The type of the part: "text" or "image_url".
+	Type string `json:"type"`
+	// The text of a text part.
+	Text string `json:"text,omitempty"`
+	// The image of an image part.
+	ImageURL *ChatMessageImageURL `json:"image_url,omitempty"`
+}
+
+// ChatMessageImageURL is the image of a [ChatMessagePart].
+type ChatMessageImageURL struct {
+	// The URL of the image. A base64 "data:" URL is sent inline, any other URL
+	// is sent as a file URI, e.g. a Cloud Storage URI on Vertex AI or a Files API
+	// URI on the Gemini Developer API.
+	URL string `json:"url"`
+	// Optional. The detail level of the image. It is ignored by the adapter.
+	Detail string `json:"detail,omitempty"`
+}
+
+// Tool is a tool the model may call.
+type Tool struct {
+	// The type of the tool, which must be "function".
+	Type string `json:"type"`
+	// The function declaration.
+	Function *FunctionDefinition `json:"function,omitempty"`
+}
+
+// FunctionDefinition declares a function the model may call.
+type FunctionDefinition struct {
+	// The name of the function.
+	Name string `json:"name"`
+	// Optional. The description of the function.
+	Description string `json:"description,omitempty"`
+	// Optional. The JSON Schema of the arguments of the function, as a value
+	// encoding to a JSON object, e.g. a map or a [json.RawMessage].
+	Parameters any `json:"parameters,omitempty"`
+	// Optional. Whether the arguments must follow the schema exactly. It is
+	// ignored by the adapter.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// ToolChoice forces the model to call a function.
+type ToolChoice struct {
+	// The type of the tool, which must be "function".
+	Type string `json:"type"`
+	// The function to call.
+	Function ToolFunction `json:"function"`
+}
+
+// ToolFunction names the function of a [ToolChoice].
+type ToolFunction struct {
+	// The name of the function.
+	Name string `json:"name"`
+}
+
+// ToolCall is a call to a tool generated by the model.
+type ToolCall struct {
+	// The position of the call in a streamed message.
+	Index *int `json:"index,omitempty"`
+	// The ID of the call, which the tool message answering it refers to.
+	ID string `json:"id,omitempty"`
+	// The type of the tool, which is "function".
+	Type string `json:"type"`
+	// The function called.
+	Function FunctionCall `json:"function"`
+}
+
+// FunctionCall is a function called by a [ToolCall].
+type FunctionCall struct {
+	// The name of the function.
+	Name string `json:"name,omitempty"`
+	// The arguments of the call, as a JSON object.
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// ResponseFormat is the format of the generated message.
+type ResponseFormat struct {
+	// The type of the format: "text", "json_object" or "json_schema".
+	Type string `json:"type"`
+	// The JSON Schema of a "json_schema" format.
+	JSONSchema *JSONSchemaFormat `json:"json_schema,omitempty"`
+}
+
+// JSONSchemaFormat is the JSON Schema a generated message follows.
+type JSONSchemaFormat struct {
+	// The name of the format.
+	Name string `json:"name"`
+	// Optional. The description of the format.
+	Description string `json:"description,omitempty"`
+	// The JSON Schema.
+	Schema json.RawMessage `json:"schema,omitempty"`
+	// Optional. Whether the message must follow the schema exactly. It is
+	// ignored by the adapter.
+	Strict bool `json:"strict,omitempty"`
+}
+
+// ChatCompletionResponse is a generated chat completion.
+type ChatCompletionResponse struct {
+	// The ID of the completion.
+	ID string `json:"id"`
+	// The type of the object, which is "chat.completion".
+	Object string `json:"object"`
+	// The creation time of the completion, in seconds since the Unix epoch.
+	Created int64 `json:"created"`
+	// The model which generated the completion.
+	Model string `json:"model"`
+	// The generated choices.
+	Choices []ChatCompletionChoice `json:"choices"`
+	// The token usage of the request.
+	Usage *Usage `json:"usage,omitempty"`
+}
+
+// ChatCompletionChoice is a choice of a [ChatCompletionResponse].
+type ChatCompletionChoice struct {
+	// The index of the choice.
+	Index int `json:"index"`
+	// The generated message.
+	Message ChatCompletionMessage `json:"message"`
+	// The reason the model stopped generating the message.
+	FinishReason string `json:"finish_reason"`
+}
+
+// ChatCompletionStreamResponse is a chunk of a streamed chat completion.
+type ChatCompletionStreamResponse struct {
+	// The ID of the completion, shared by all its chunks.
+	ID string `json:"id"`
+	// The type of the object, which is "chat.completion.chunk".
+	Object string `json:"object"`
+	// The creation time of the completion, in seconds since the Unix epoch.
+	Created int64 `json:"created"`
+	// The model which generated the completion.
+	Model string `json:"model"`
+	// The choices generated in the chunk.
+	Choices []ChatCompletionStreamChoice `json:"choices"`
+	// The token usage of the request, only reported by the last chunk when
+	// [StreamOptions.IncludeUsage] is set.
+	Usage *Usage `json:"usage,omitempty"`
+}
+
+// ChatCompletionStreamChoice is a choice of a [ChatCompletionStreamResponse].
+type ChatCompletionStreamChoice struct {
+	// The index of the choice.
+	Index int `json:"index"`
+	// The message generated in the chunk.
+	Delta ChatCompletionDelta `json:"delta"`
+	// The reason the model stopped generating the message, in the last chunk of
+	// the choice.
+	FinishReason string `json:"finish_reason,omitempty"`
+}
+
+// ChatCompletionDelta is the part of a message generated in a chunk.
+type ChatCompletionDelta struct {
+	// The role of the author of the message, in the first chunk of the choice.
+	Role string `json:"role,omitempty"`
+	// The generated text.
+	Content string `json:"content,omitempty"`
+	// The generated tool calls.
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+}
+
+// Usage is the token usage of a chat completion request.
+type Usage struct {
+	// The number of tokens of the prompt.
+	PromptTokens int `json:"prompt_tokens"`
+	// The number of generated tokens, including thoughts.
+	CompletionTokens int `json:"completion_tokens"`
+	// The total number of tokens.
+	TotalTokens int `json:"total_tokens"`
+	// The breakdown of the tokens of the prompt, if some were cached.
+	PromptTokensDetails *PromptTokensDetails `json:"prompt_tokens_details,omitempty"`
+}
+
+// PromptTokensDetails is the breakdown of the tokens of a prompt.
+type PromptTokensDetails struct {
+	// The number of tokens of the prompt read from the context cache.
+	CachedTokens int `json:"cached_tokens"`
+}
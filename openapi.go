@@ -0,0 +1,208 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// JSONSchema converts the schema to a JSON Schema object, the inverse of
+// [NewSchemaFromJSONSchema]. Types are lowercased, nullable schemas list "null" as
+// an alternative type and the recursive types of [Schema.Defs] are rendered under
+// "$defs". The result can be marshaled with encoding/json and used as the
+// parameters of a tool for another provider.
+func (s *Schema) JSONSchema() map[string]any {
+	if s == nil {
+		return nil
+	}
+	jsonSchema := jsonSchemaFromSchema(s, "#/$defs/")
+	if len(s.Defs) > 0 {
+		defs := make(map[string]any, len(s.Defs))
+		for name, def := range s.Defs {
+			defs[name] = jsonSchemaFromSchema(def, "#/$defs/")
+		}
+		jsonSchema["$defs"] = defs
+	}
+	return jsonSchema
+}
+
+// FunctionDeclarationsToOpenAPI renders function declarations to an OpenAPI 3.1
+// document, to share tool definitions with agents of other providers or to review
+// them with OpenAPI tooling. Every function becomes a POST operation on the path
+// "/{name}", whose request body is described by [FunctionDeclaration.Parameters]
+// and whose response is described by [FunctionDeclaration.Response]. The recursive
+// types of the schemas are rendered as components. The result can be marshaled
+// with encoding/json:
+//
+//	doc, err := genai.FunctionDeclarationsToOpenAPI("Weather tools", "1.0.0", config.Tools[0].FunctionDeclarations...)
+//	if err != nil {
+//		return err
+//	}
+//	data, err := json.MarshalIndent(doc, "", "  ")
+func FunctionDeclarationsToOpenAPI(title, version string, declarations ...*FunctionDeclaration) (map[string]any, error) {
+	const refPrefix = "#/components/schemas/"
+	paths := map[string]any{}
+	components := map[string]any{}
+	for _, decl := range declarations {
+		if decl == nil {
+			continue
+		}
+		if decl.Name == "" {
+			return nil, fmt.Errorf("FunctionDeclarationsToOpenAPI: function declaration without a name")
+		}
+		path := "/" + decl.Name
+		if _, ok := paths[path]; ok {
+			return nil, fmt.Errorf("FunctionDeclarationsToOpenAPI: duplicate function %s", decl.Name)
+		}
+		operation := map[string]any{"operationId": decl.Name}
+		if decl.Description != "" {
+			operation["description"] = decl.Description
+		}
+		if decl.Parameters != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": jsonSchemaFromSchema(decl.Parameters, refPrefix)},
+				},
+			}
+		}
+		response := map[string]any{"description": "The result of the function."}
+		if decl.Response != nil {
+			response["content"] = map[string]any{
+				"application/json": map[string]any{"schema": jsonSchemaFromSchema(decl.Response, refPrefix)},
+			}
+		}
+		operation["responses"] = map[string]any{"200": response}
+		paths[path] = map[string]any{"post": operation}
+
+		for _, schema := range []*Schema{decl.Parameters, decl.Response} {
+			if schema == nil {
+				continue
+			}
+			for name, def := range schema.Defs {
+				component := jsonSchemaFromSchema(def, refPrefix)
+				if existing, ok := components[name]; ok && !reflect.DeepEqual(existing, component) {
+					return nil, fmt.Errorf("FunctionDeclarationsToOpenAPI: conflicting definitions of schema %s", name)
+				}
+				components[name] = component
+			}
+		}
+	}
+	doc := map[string]any{
+		"openapi": "3.1.0",
+		"info":    map[string]any{"title": title, "version": version},
+		"paths":   paths,
+	}
+	if len(components) > 0 {
+		doc["components"] = map[string]any{"schemas": components}
+	}
+	return doc, nil
+}
+
+// jsonSchemaFromSchema converts s to a JSON Schema object, pointing the references
+// to recursive types at refPrefix followed by the type name. The definitions of
+// s are not included.
+func jsonSchemaFromSchema(s *Schema, refPrefix string) map[string]any {
+	jsonSchema := map[string]any{}
+	if s.Ref != "" {
+		ref := s.Ref
+		for _, prefix := range []string{"#/defs/", "#/$defs/"} {
+			if strings.HasPrefix(s.Ref, prefix) {
+				ref = refPrefix + strings.TrimPrefix(s.Ref, prefix)
+			}
+		}
+		jsonSchema["$ref"] = ref
+		return jsonSchema
+	}
+	if s.Type != "" && s.Type != TypeUnspecified {
+		typ := strings.ToLower(string(s.Type))
+		if s.Nullable != nil && *s.Nullable {
+			jsonSchema["type"] = []any{typ, "null"}
+		} else {
+			jsonSchema["type"] = typ
+		}
+	}
+	if s.Title != "" {
+		jsonSchema["title"] = s.Title
+	}
+	if s.Description != "" {
+		jsonSchema["description"] = s.Description
+	}
+	// The "enum" format is an OpenAPI convention of the Gemini API, implied by
+	// the enum keyword in JSON Schema.
+	if s.Format != "" && s.Format != "enum" {
+		jsonSchema["format"] = s.Format
+	}
+	if s.Pattern != "" {
+		jsonSchema["pattern"] = s.Pattern
+	}
+	if s.Default != nil {
+		jsonSchema["default"] = s.Default
+	}
+	if s.Example != nil {
+		jsonSchema["examples"] = []any{s.Example}
+	}
+	if len(s.Enum) > 0 {
+		enum := make([]any, len(s.Enum))
+		for i, e := range s.Enum {
+			enum[i] = e
+		}
+		jsonSchema["enum"] = enum
+	}
+	if len(s.Properties) > 0 {
+		properties := make(map[string]any, len(s.Properties))
+		for name, property := range s.Properties {
+			properties[name] = jsonSchemaFromSchema(property, refPrefix)
+		}
+		jsonSchema["properties"] = properties
+	}
+	if len(s.Required) > 0 {
+		required := make([]any, len(s.Required))
+		for i, name := range s.Required {
+			required[i] = name
+		}
+		jsonSchema["required"] = required
+	}
+	if s.Items != nil {
+		jsonSchema["items"] = jsonSchemaFromSchema(s.Items, refPrefix)
+	}
+	if len(s.AnyOf) > 0 {
+		anyOf := make([]any, len(s.AnyOf))
+		for i, alternative := range s.AnyOf {
+			anyOf[i] = jsonSchemaFromSchema(alternative, refPrefix)
+		}
+		jsonSchema["anyOf"] = anyOf
+	}
+	for key, v := range map[string]*float64{"minimum": s.Minimum, "maximum": s.Maximum} {
+		if v != nil {
+			jsonSchema[key] = *v
+		}
+	}
+	for key, v := range map[string]*int64{
+		"minLength":     s.MinLength,
+		"maxLength":     s.MaxLength,
+		"minItems":      s.MinItems,
+		"maxItems":      s.MaxItems,
+		"minProperties": s.MinProperties,
+		"maxProperties": s.MaxProperties,
+	} {
+		if v != nil {
+			jsonSchema[key] = float64(*v)
+		}
+	}
+	return jsonSchema
+}
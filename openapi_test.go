@@ -0,0 +1,159 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSchemaJSONSchema(t *testing.T) {
+	schema := &Schema{
+		Type:        TypeObject,
+		Description: "A weather request.",
+		Properties: map[string]*Schema{
+			"city":  {Type: TypeString, MinLength: Ptr(int64(1))},
+			"unit":  {Type: TypeString, Format: "enum", Enum: []string{"celsius", "fahrenheit"}, Nullable: Ptr(true)},
+			"days":  {Type: TypeInteger, Format: "int32", Minimum: Ptr(1.0), Maximum: Ptr(7.0)},
+			"areas": {Type: TypeArray, Items: &Schema{Ref: "#/defs/Area"}},
+		},
+		Required: []string{"city"},
+		Defs: map[string]*Schema{
+			"Area": {Type: TypeObject, Properties: map[string]*Schema{"children": {Type: TypeArray, Items: &Schema{Ref: "#/defs/Area"}}}},
+		},
+	}
+	want := map[string]any{
+		"type":        "object",
+		"description": "A weather request.",
+		"properties": map[string]any{
+			"city":  map[string]any{"type": "string", "minLength": 1.0},
+			"unit":  map[string]any{"type": []any{"string", "null"}, "enum": []any{"celsius", "fahrenheit"}},
+			"days":  map[string]any{"type": "integer", "format": "int32", "minimum": 1.0, "maximum": 7.0},
+			"areas": map[string]any{"type": "array", "items": map[string]any{"$ref": "#/$defs/Area"}},
+		},
+		"required": []any{"city"},
+		"$defs": map[string]any{
+			"Area": map[string]any{"type": "object", "properties": map[string]any{
+				"children": map[string]any{"type": "array", "items": map[string]any{"$ref": "#/$defs/Area"}},
+			}},
+		},
+	}
+	got := schema.JSONSchema()
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("JSONSchema() mismatch (-want +got):\n%s", diff)
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		schema := &Schema{
+			Type: TypeObject,
+			Properties: map[string]*Schema{
+				"query": {Type: TypeString, Description: "The search query.", MaxLength: Ptr(int64(100))},
+				"tags":  {Type: TypeArray, Items: &Schema{Type: TypeString}, MaxItems: Ptr(int64(5))},
+			},
+			Required: []string{"query"},
+		}
+		data, err := json.Marshal(schema.JSONSchema())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var jsonSchema map[string]any
+		if err := json.Unmarshal(data, &jsonSchema); err != nil {
+			t.Fatal(err)
+		}
+		got, err := NewSchemaFromJSONSchema(jsonSchema)
+		if err != nil {
+			t.Fatalf("NewSchemaFromJSONSchema() failed: %v", err)
+		}
+		if diff := cmp.Diff(schema, got); diff != "" {
+			t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestFunctionDeclarationsToOpenAPI(t *testing.T) {
+	area := &Schema{Type: TypeObject, Properties: map[string]*Schema{"name": {Type: TypeString}}}
+	declarations := []*FunctionDeclaration{
+		{
+			Name:        "get_weather",
+			Description: "Returns the current weather.",
+			Parameters: &Schema{
+				Type:       TypeObject,
+				Properties: map[string]*Schema{"area": {Ref: "#/defs/Area"}},
+				Defs:       map[string]*Schema{"Area": area},
+			},
+			Response: &Schema{Type: TypeObject, Properties: map[string]*Schema{"temperature": {Type: TypeNumber}}},
+		},
+		{Name: "stop"},
+	}
+	got, err := FunctionDeclarationsToOpenAPI("Weather tools", "1.0.0", declarations...)
+	if err != nil {
+		t.Fatalf("FunctionDeclarationsToOpenAPI() failed: %v", err)
+	}
+	want := map[string]any{
+		"openapi": "3.1.0",
+		"info":    map[string]any{"title": "Weather tools", "version": "1.0.0"},
+		"paths": map[string]any{
+			"/get_weather": map[string]any{"post": map[string]any{
+				"operationId": "get_weather",
+				"description": "Returns the current weather.",
+				"requestBody": map[string]any{
+					"required": true,
+					"content": map[string]any{"application/json": map[string]any{"schema": map[string]any{
+						"type":       "object",
+						"properties": map[string]any{"area": map[string]any{"$ref": "#/components/schemas/Area"}},
+					}}},
+				},
+				"responses": map[string]any{"200": map[string]any{
+					"description": "The result of the function.",
+					"content": map[string]any{"application/json": map[string]any{"schema": map[string]any{
+						"type":       "object",
+						"properties": map[string]any{"temperature": map[string]any{"type": "number"}},
+					}}},
+				}},
+			}},
+			"/stop": map[string]any{"post": map[string]any{
+				"operationId": "stop",
+				"responses":   map[string]any{"200": map[string]any{"description": "The result of the function."}},
+			}},
+		},
+		"components": map[string]any{"schemas": map[string]any{
+			"Area": map[string]any{"type": "object", "properties": map[string]any{"name": map[string]any{"type": "string"}}},
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FunctionDeclarationsToOpenAPI() mismatch (-want +got):\n%s", diff)
+	}
+
+	errorTests := []struct {
+		name         string
+		declarations []*FunctionDeclaration
+	}{
+		{name: "MissingName", declarations: []*FunctionDeclaration{{Description: "No name."}}},
+		{name: "Duplicate", declarations: []*FunctionDeclaration{{Name: "stop"}, {Name: "stop"}}},
+		{name: "ConflictingDefs", declarations: []*FunctionDeclaration{
+			declarations[0],
+			{Name: "list_areas", Parameters: &Schema{Ref: "#/defs/Area", Defs: map[string]*Schema{"Area": {Type: TypeString}}}},
+		}},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FunctionDeclarationsToOpenAPI("Tools", "1", tt.declarations...); err == nil {
+				t.Errorf("FunctionDeclarationsToOpenAPI() succeeded, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	defaultOperationPollInterval   = 10 * time.Second
+	defaultOperationPollMultiplier = 1.5
+	maxOperationPollInterval       = time.Minute
+)
+
+// OperationError is returned when a long-running operation failed or was
+// cancelled.
+type OperationError struct {
+	// The name of the operation.
+	Name string
+	// The status code, e.g. 1 if the operation was cancelled, see
+	// https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto.
+	Code int
+	// A developer-facing error message.
+	Message string
+}
+
+// Error returns a string representation of the OperationError.
+func (e OperationError) Error() string {
+	return fmt.Sprintf("operation %s failed with code %d: %s", e.Name, e.Code, e.Message)
+}
+
+// newOperationError returns the error of an operation, or nil if it has none.
+func newOperationError(name string, status map[string]any) error {
+	if status == nil {
+		return nil
+	}
+	err := OperationError{Name: name}
+	if code, ok := status["code"].(float64); ok {
+		err.Code = int(code)
+	}
+	err.Message, _ = status["message"].(string)
+	return err
+}
+
+// OperationResponse decodes the response of a done operation into a value of type
+// T, e.g. a [TuningJob]. An [OperationError] is returned if the operation failed.
+//
+//	op, err := client.Operations.Wait(ctx, op, nil)
+//	if err != nil {
+//		return err
+//	}
+//	job, err := genai.OperationResponse[genai.TuningJob](op)
+func OperationResponse[T any](op *Operation) (*T, error) {
+	if !op.Done {
+		return nil, fmt.Errorf("operation %s is not done", op.Name)
+	}
+	if err := newOperationError(op.Name, op.Error); err != nil {
+		return nil, err
+	}
+	v := new(T)
+	if err := mapToStruct(op.Response, v); err != nil {
+		return nil, fmt.Errorf("failed to decode the response of operation %s: %w", op.Name, err)
+	}
+	return v, nil
+}
+
+// OperationMetadata decodes the metadata of an operation into a value of type T,
+// e.g. to read the progress of the operation.
+func OperationMetadata[T any](op *Operation) (*T, error) {
+	v := new(T)
+	if err := mapToStruct(op.Metadata, v); err != nil {
+		return nil, fmt.Errorf("failed to decode the metadata of operation %s: %w", op.Name, err)
+	}
+	return v, nil
+}
+
+// WaitOperationConfig configures [Operations.Wait] and
+// [Operations.WaitVideosOperation].
+type WaitOperationConfig struct {
+	// Optional. The initial interval between the polls of the operation. Defaults
+	// to 10 seconds.
+	PollInterval time.Duration
+	// Optional. The factor by which the interval grows at every poll, at least 1.
+	// Defaults to 1.5.
+	PollMultiplier float64
+	// Optional. The maximum interval between the polls of the operation. Defaults
+	// to 1 minute, or to the initial interval if larger.
+	MaxPollInterval time.Duration
+	// Optional. The maximum duration of the wait, in addition to the deadline of
+	// the context, if any. Unlimited if zero.
+	Timeout time.Duration
+	// Optional. Called with the name and the metadata of the operation after every
+	// poll, including the last one, e.g. to report the progress of the operation.
+	// See [OperationMetadata] to decode the metadata of an [Operation].
+	Progress func(name string, metadata map[string]any)
+}
+
+// Wait polls a long-running operation until it is done and returns the done
+// operation. An [OperationError] is returned along with the operation if it
+// failed. The interval between the polls grows from [WaitOperationConfig.PollInterval]
+// up to [WaitOperationConfig.MaxPollInterval], and the wait is bounded by the
+// context and [WaitOperationConfig.Timeout].
+func (m Operations) Wait(ctx context.Context, op *Operation, config *WaitOperationConfig) (*Operation, error) {
+	return waitOperation(ctx, op, config,
+		func(op *Operation) operationState {
+			return operationState{name: op.Name, done: op.Done, err: newOperationError(op.Name, op.Error), metadata: op.Metadata}
+		},
+		func(ctx context.Context, op *Operation) (*Operation, error) { return m.Get(ctx, op.Name, nil) })
+}
+
+// WaitVideosOperation polls a video generation operation until it is done, see
+// [Operations.Wait].
+func (m Operations) WaitVideosOperation(ctx context.Context, op *GenerateVideosOperation, config *WaitOperationConfig) (*GenerateVideosOperation, error) {
+	return waitOperation(ctx, op, config,
+		func(op *GenerateVideosOperation) operationState {
+			return operationState{name: op.Name, done: op.Done, err: newOperationError(op.Name, op.Error), metadata: op.Metadata}
+		},
+		func(ctx context.Context, op *GenerateVideosOperation) (*GenerateVideosOperation, error) {
+			return m.GetVideosOperation(ctx, op, nil)
+		})
+}
+
+// Result returns the videos generated by a done operation. An [OperationError] is
+// returned if the operation failed, and an error if all the videos were filtered.
+//
+// If files is not nil, the videos only available by URI on the Gemini Developer
+// API are downloaded, and their VideoBytes field populated. Otherwise, the URIs
+// are returned as is, and can be downloaded later with [Files.Download]:
+//
+//	op, err := client.Operations.WaitVideosOperation(ctx, op, nil)
+//	if err != nil {
+//		return err
+//	}
+//	videos, err := op.Result(ctx, client.Files)
+func (op *GenerateVideosOperation) Result(ctx context.Context, files *Files) ([]*GeneratedVideo, error) {
+	if !op.Done {
+		return nil, fmt.Errorf("operation %s is not done", op.Name)
+	}
+	if err := newOperationError(op.Name, op.Error); err != nil {
+		return nil, err
+	}
+	r := op.Response
+	if r == nil || len(r.GeneratedVideos) == 0 {
+		if r != nil && r.RAIMediaFilteredCount > 0 {
+			return nil, fmt.Errorf("operation %s returned no videos, %d were filtered: %s", op.Name, r.RAIMediaFilteredCount, strings.Join(r.RAIMediaFilteredReasons, "; "))
+		}
+		return nil, fmt.Errorf("operation %s returned no videos", op.Name)
+	}
+	if files == nil || files.apiClient.clientConfig.Backend == BackendVertexAI {
+		return r.GeneratedVideos, nil
+	}
+	for _, v := range r.GeneratedVideos {
+		if v.Video == nil || v.Video.URI == "" || len(v.Video.VideoBytes) > 0 {
+			continue
+		}
+		if _, err := files.Download(ctx, v, nil); err != nil {
+			return nil, fmt.Errorf("failed to download video %s: %w", v.Video.URI, err)
+		}
+	}
+	return r.GeneratedVideos, nil
+}
+
+// operationState is the state of an operation of any type, with the error of the
+// operation once it is done, if any.
+type operationState struct {
+	name     string
+	done     bool
+	err      error
+	metadata map[string]any
+}
+
+// waitOperation polls an operation with get until it is done, as configured, and
+// returns it with its error, if any.
+func waitOperation[T any](ctx context.Context, op T, config *WaitOperationConfig, state func(T) operationState, get func(context.Context, T) (T, error)) (T, error) {
+	var zero T
+	if config == nil {
+		config = &WaitOperationConfig{}
+	}
+	pollInterval := config.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultOperationPollInterval
+	}
+	multiplier := config.PollMultiplier
+	if multiplier <= 0 {
+		multiplier = defaultOperationPollMultiplier
+	}
+	multiplier = max(multiplier, 1)
+	maxInterval := config.MaxPollInterval
+	if maxInterval <= 0 {
+		maxInterval = maxOperationPollInterval
+	}
+	maxInterval = max(maxInterval, pollInterval)
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	for {
+		s := state(op)
+		if s.name == "" {
+			return zero, fmt.Errorf("operation name is empty")
+		}
+		if s.done {
+			return op, s.err
+		}
+
+		timer := time.NewTimer(pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, fmt.Errorf("Wait: operation %s is not done: %w", s.name, ctx.Err())
+		case <-timer.C:
+		}
+		pollInterval = min(time.Duration(float64(pollInterval)*multiplier), maxInterval)
+
+		var err error
+		if op, err = get(ctx, op); err != nil {
+			return zero, err
+		}
+		if config.Progress != nil {
+			s := state(op)
+			config.Progress(s.name, s.metadata)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTestOperations returns a client whose requests are answered by handler, and
+// records the method and path of the requests.
+func newTestOperations(t *testing.T, backend Backend, requests *[]string, handler http.HandlerFunc) *Client {
+	t.Helper()
+	var mu sync.Mutex
+	return newTestClient(t, backend, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		handler(w, r)
+	})
+}
+
+func TestOperationsGet(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		backend  Backend
+		name     string
+		wantPath string
+	}{
+		{BackendGeminiAPI, "tunedModels/abc/operations/1", "GET /v1beta/tunedModels/abc/operations/1"},
+		{BackendVertexAI, "projects/project/locations/us-central1/operations/1", "GET /v1beta1/projects/project/locations/us-central1/operations/1"},
+	} {
+		t.Run(tt.backend.String(), func(t *testing.T) {
+			var requests []string
+			client := newTestOperations(t, tt.backend, &requests, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"name": "` + tt.name + `", "metadata": {"completedPercent": 50}, "done": true, "response": {"name": "tunedModels/abc"}}`))
+			})
+			op, err := client.Operations.Get(ctx, tt.name, nil)
+			if err != nil {
+				t.Fatalf("Get() failed: %v", err)
+			}
+			want := &Operation{
+				Name:     tt.name,
+				Metadata: map[string]any{"completedPercent": 50.0},
+				Done:     true,
+				Response: map[string]any{"name": "tunedModels/abc"},
+			}
+			if diff := cmp.Diff(want, op); diff != "" {
+				t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]string{tt.wantPath}, requests); diff != "" {
+				t.Errorf("requests mismatch (-want +got):\n%s", diff)
+			}
+
+			type metadata struct {
+				CompletedPercent float64 `json:"completedPercent"`
+			}
+			gotMetadata, err := OperationMetadata[metadata](op)
+			if err != nil {
+				t.Fatalf("OperationMetadata() failed: %v", err)
+			}
+			if gotMetadata.CompletedPercent != 50 {
+				t.Errorf("OperationMetadata() = %+v, want 50 completed percent", gotMetadata)
+			}
+			job, err := OperationResponse[TuningJob](op)
+			if err != nil {
+				t.Fatalf("OperationResponse() failed: %v", err)
+			}
+			if job.Name != "tunedModels/abc" {
+				t.Errorf("OperationResponse() = %+v, want job tunedModels/abc", job)
+			}
+		})
+	}
+}
+
+func TestOperationsCancelDelete(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		backend Backend
+		name    string
+		want    []string
+	}{
+		{BackendGeminiAPI, "batches/abc/operations/1", []string{"POST /v1beta/batches/abc/operations/1:cancel", "DELETE /v1beta/batches/abc/operations/1"}},
+		{BackendVertexAI, "projects/project/locations/us-central1/operations/1", []string{"POST /v1beta1/projects/project/locations/us-central1/operations/1:cancel", "DELETE /v1beta1/projects/project/locations/us-central1/operations/1"}},
+	} {
+		t.Run(tt.backend.String(), func(t *testing.T) {
+			var requests []string
+			client := newTestOperations(t, tt.backend, &requests, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{}`))
+			})
+			if err := client.Operations.Cancel(ctx, tt.name, nil); err != nil {
+				t.Fatalf("Cancel() failed: %v", err)
+			}
+			if err := client.Operations.Delete(ctx, tt.name, nil); err != nil {
+				t.Fatalf("Delete() failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, requests); diff != "" {
+				t.Errorf("requests mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		var requests []string
+		client := newTestOperations(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"code": 404, "message": "not found", "status": "NOT_FOUND"}}`))
+		})
+		if err := client.Operations.Cancel(ctx, "operations/1", nil); err == nil {
+			t.Errorf("Cancel() succeeded, want error")
+		}
+	})
+}
+
+func TestOperationsWait(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name    string
+		last    string
+		wantErr error
+	}{
+		{
+			name: "Succeeded",
+			last: `{"name": "operations/1", "done": true, "response": {"name": "tunedModels/abc"}}`,
+		},
+		{
+			name:    "Cancelled",
+			last:    `{"name": "operations/1", "done": true, "error": {"code": 1, "message": "cancelled"}}`,
+			wantErr: OperationError{Name: "operations/1", Code: 1, Message: "cancelled"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			polls := 0
+			client := newTestOperations(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+				polls++
+				if polls < 2 {
+					w.Write([]byte(`{"name": "operations/1"}`))
+					return
+				}
+				w.Write([]byte(tt.last))
+			})
+			op, err := client.Operations.Wait(ctx, &Operation{Name: "operations/1"}, &WaitOperationConfig{PollInterval: time.Millisecond})
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Wait() failed: %v", err)
+			}
+			var opErr OperationError
+			if tt.wantErr != nil && (!errors.As(err, &opErr) || opErr != tt.wantErr) {
+				t.Fatalf("Wait() error = %v, want %v", err, tt.wantErr)
+			}
+			if !op.Done {
+				t.Errorf("Wait() = %+v, want a done operation", op)
+			}
+			if _, err := OperationResponse[TuningJob](op); !errors.Is(err, tt.wantErr) {
+				t.Errorf("OperationResponse() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(requests) != 2 {
+				t.Errorf("Wait() sent %d requests, want 2", len(requests))
+			}
+		})
+	}
+
+	t.Run("Deadline", func(t *testing.T) {
+		var requests []string
+		client := newTestOperations(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"name": "operations/1"}`))
+		})
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		if _, err := client.Operations.Wait(ctx, &Operation{Name: "operations/1"}, &WaitOperationConfig{PollInterval: time.Millisecond}); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		var requests []string
+		client := newTestOperations(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"name": "operations/1"}`))
+		})
+		// The polls are sent after 5ms and 55ms, the next one would be after 555ms.
+		config := &WaitOperationConfig{PollInterval: 5 * time.Millisecond, PollMultiplier: 10, MaxPollInterval: time.Hour, Timeout: 300 * time.Millisecond}
+		if _, err := client.Operations.Wait(ctx, &Operation{Name: "operations/1"}, config); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+		if len(requests) != 2 {
+			t.Errorf("Wait() sent %d requests, want 2", len(requests))
+		}
+	})
+
+	t.Run("Progress", func(t *testing.T) {
+		var requests []string
+		client := newTestOperations(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+			if len(requests) < 2 {
+				w.Write([]byte(`{"name": "operations/1", "metadata": {"completedPercent": 50}}`))
+				return
+			}
+			w.Write([]byte(`{"name": "operations/1", "metadata": {"completedPercent": 100}, "done": true}`))
+		})
+		var progress []any
+		config := &WaitOperationConfig{
+			PollInterval:    time.Millisecond,
+			MaxPollInterval: 2 * time.Millisecond,
+			Progress: func(name string, metadata map[string]any) {
+				if name != "operations/1" {
+					t.Errorf("Progress() called with operation %s, want operations/1", name)
+				}
+				progress = append(progress, metadata["completedPercent"])
+			},
+		}
+		if _, err := client.Operations.Wait(ctx, &Operation{Name: "operations/1"}, config); err != nil {
+			t.Fatalf("Wait() failed: %v", err)
+		}
+		if diff := cmp.Diff([]any{50.0, 100.0}, progress); diff != "" {
+			t.Errorf("progress mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("NotDone", func(t *testing.T) {
+		if _, err := OperationResponse[TuningJob](&Operation{Name: "operations/1"}); err == nil {
+			t.Errorf("OperationResponse() succeeded for an operation in progress, want error")
+		}
+	})
+}
+
+func TestOperationsWaitVideosOperation(t *testing.T) {
+	var requests []string
+	client := newTestOperations(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name": "models/veo/operations/1", "done": true, "response": {"generateVideoResponse": {"generatedSamples": [{"video": {"uri": "https://example.com/video.mp4"}}]}}}`))
+	})
+	op, err := client.Operations.WaitVideosOperation(context.Background(), &GenerateVideosOperation{Name: "models/veo/operations/1"}, &WaitOperationConfig{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WaitVideosOperation() failed: %v", err)
+	}
+	want := &GenerateVideosOperation{
+		Name:     "models/veo/operations/1",
+		Done:     true,
+		Response: &GenerateVideosResponse{GeneratedVideos: []*GeneratedVideo{{Video: &Video{URI: "https://example.com/video.mp4"}}}},
+	}
+	if diff := cmp.Diff(want, op); diff != "" {
+		t.Errorf("WaitVideosOperation() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"GET /v1beta/models/veo/operations/1"}, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateVideosOperationResult(t *testing.T) {
+	ctx := context.Background()
+	t.Run("Download", func(t *testing.T) {
+		var requests []string
+		client := newTestOperations(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("video"))
+		})
+		op := &GenerateVideosOperation{
+			Name: "models/veo/operations/1",
+			Done: true,
+			Response: &GenerateVideosResponse{GeneratedVideos: []*GeneratedVideo{
+				{Video: &Video{URI: "https://generativelanguage.googleapis.com/v1beta/files/abc:download?alt=media"}},
+				{Video: &Video{VideoBytes: []byte("inline")}},
+			}},
+		}
+		videos, err := op.Result(ctx, client.Files)
+		if err != nil {
+			t.Fatalf("Result() failed: %v", err)
+		}
+		want := []*GeneratedVideo{
+			{Video: &Video{URI: "https://generativelanguage.googleapis.com/v1beta/files/abc:download?alt=media", VideoBytes: []byte("video")}},
+			{Video: &Video{VideoBytes: []byte("inline")}},
+		}
+		if diff := cmp.Diff(want, videos); diff != "" {
+			t.Errorf("Result() mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{"GET /v1beta/files/abc:download"}, requests); diff != "" {
+			t.Errorf("requests mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("URIs", func(t *testing.T) {
+		op := &GenerateVideosOperation{
+			Name:     "models/veo/operations/1",
+			Done:     true,
+			Response: &GenerateVideosResponse{GeneratedVideos: []*GeneratedVideo{{Video: &Video{URI: "gs://bucket/video.mp4"}}}},
+		}
+		videos, err := op.Result(ctx, nil)
+		if err != nil {
+			t.Fatalf("Result() failed: %v", err)
+		}
+		if diff := cmp.Diff(op.Response.GeneratedVideos, videos); diff != "" {
+			t.Errorf("Result() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	for _, tt := range []struct {
+		name string
+		op   *GenerateVideosOperation
+	}{
+		{"NotDone", &GenerateVideosOperation{Name: "models/veo/operations/1"}},
+		{"Failed", &GenerateVideosOperation{Name: "models/veo/operations/1", Done: true, Error: map[string]any{"code": 3.0, "message": "invalid prompt"}}},
+		{"Filtered", &GenerateVideosOperation{Name: "models/veo/operations/1", Done: true, Response: &GenerateVideosResponse{RAIMediaFilteredCount: 1, RAIMediaFilteredReasons: []string{"unsafe"}}}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.op.Result(ctx, nil); err == nil {
+				t.Errorf("Result() succeeded, want error")
+			}
+		})
+	}
+}
@@ -49,13 +49,18 @@ func newPage[T any](ctx context.Context, name string, config map[string]any, lis
 	return p, nil
 }
 
-// all returns an iterator that yields all items across all pages of results.
+// All returns an iterator that yields the items of this page and of all the
+// following pages of results, e.g. to iterate over the files listed with a given
+// page size:
+//
+//	page, err := client.Files.List(ctx, &genai.ListFilesConfig{PageSize: 100})
+//	for file, err := range page.All(ctx) {
+//		...
+//	}
 //
 // The iterator retrieves each page sequentially and yields each item within
-// the page.  If an error occurs during retrieval, the iterator will stop
-// and the error will be returned as the second value in the next call to Next().
-// A genai.PageDone error indicates that all pages have been processed.
-func (p Page[T]) all(ctx context.Context) iter.Seq2[*T, error] {
+// the page. If an error occurs during retrieval, the iterator yields it and stops.
+func (p Page[T]) All(ctx context.Context) iter.Seq2[*T, error] {
 	return func(yield func(*T, error) bool) {
 		for {
 			for _, item := range p.Items {
@@ -89,6 +94,9 @@ func (p Page[T]) Next(ctx context.Context) (Page[T], error) {
 	for k, v := range p.config {
 		c[k] = v
 	}
+	// The config may hold the token of the first page under its JSON name, which
+	// would take precedence over the new token when the config is unmarshalled.
+	delete(c, "pageToken")
 	c["PageToken"] = p.NextPageToken
 
 	return newPage[T](ctx, p.Name, c, p.listFunc)
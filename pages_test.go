@@ -101,7 +101,7 @@ func TestPageAll(t *testing.T) {
 	}
 
 	allItems := []string{}
-	for item, err := range page.all(ctx) {
+	for item, err := range page.All(ctx) {
 		if err != nil {
 			if errors.Is(err, ErrPageDone) {
 				break // Expected PageDone at the end of iteration.
@@ -129,7 +129,7 @@ func TestPageAll(t *testing.T) {
 		t.Fatalf("newPage failed: %v", err)
 	}
 
-	for _, err := range page.all(ctx) {
+	for _, err := range page.All(ctx) {
 		if err != nil {
 			if err.Error() == "list func error" {
 				return // Expected error.
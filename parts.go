@@ -0,0 +1,103 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxInlinePartBytes is the maximum size of the data of an inline part, such that
+// it fits in a request once base64 encoded.
+var maxInlinePartBytes = int64(base64.StdEncoding.DecodedLen(defaultMaxInlineBytes))
+
+// NewPartFromPath builds an inline data Part from the content of a local file. If
+// the mime type is empty, it is detected from the extension of the path or else
+// from the content, see [DetectMIMEType].
+//
+// An error is returned if the file does not fit in a request, i.e. exceeds 15 MB.
+// Upload larger files with [Files.UploadFromPath] or [Files.UploadToGCS], or send
+// them with an [InlineDataOffload] policy.
+func NewPartFromPath(path, mimeType string) (*Part, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	data, err := readInlineData(f, path)
+	if err != nil {
+		return nil, err
+	}
+	if mimeType == "" {
+		if mimeType = DetectMIMEType(path, data); mimeType == "" {
+			return nil, fmt.Errorf("could not determine the MIME type of %s", path)
+		}
+	}
+	return &Part{InlineData: &Blob{Data: data, MIMEType: mimeType}}, nil
+}
+
+// NewPartFromReader builds an inline data Part from the content read from r until
+// EOF. If the mime type is empty, it is detected from the content, see
+// [DetectMIMEType].
+//
+// An error is returned if the content does not fit in a request, i.e. exceeds
+// 15 MB, see [NewPartFromPath].
+func NewPartFromReader(r io.Reader, mimeType string) (*Part, error) {
+	data, err := readInlineData(r, "content")
+	if err != nil {
+		return nil, err
+	}
+	if mimeType == "" {
+		if mimeType = mimeTypeFromContent(data); mimeType == "" {
+			return nil, fmt.Errorf("could not determine the MIME type of the content")
+		}
+	}
+	return &Part{InlineData: &Blob{Data: data, MIMEType: mimeType}}, nil
+}
+
+// NewPartFromGCS builds a Part referencing a Cloud Storage object by its gs:// URI,
+// e.g. "gs://bucket/video.mp4". If the mime type is empty, it is detected from the
+// extension of the URI, see [DetectMIMEType].
+func NewPartFromGCS(uri, mimeType string) (*Part, error) {
+	_, object, err := parseGCSURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	if object == "" || strings.HasSuffix(uri, "/") {
+		return nil, fmt.Errorf("%q has no object name", uri)
+	}
+	if mimeType == "" {
+		if mimeType = mimeTypeFromName(uri); mimeType == "" {
+			return nil, fmt.Errorf("could not determine the MIME type of %s", uri)
+		}
+	}
+	return &Part{FileData: &FileData{FileURI: uri, MIMEType: mimeType}}, nil
+}
+
+// readInlineData reads r until EOF, failing if it exceeds the size of an inline
+// part.
+func readInlineData(r io.Reader, name string) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxInlinePartBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxInlinePartBytes {
+		return nil, fmt.Errorf("%s exceeds the maximum size of inline data of %d bytes", name, maxInlinePartBytes)
+	}
+	return data, nil
+}
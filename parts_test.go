@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPartConstructors(t *testing.T) {
+	dir := t.TempDir()
+	notes := filepath.Join(dir, "notes.md")
+	if err := os.WriteFile(notes, []byte("# Notes"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	image := filepath.Join(dir, "image")
+	if err := os.WriteFile(image, pngHeader, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		build   func() (*Part, error)
+		want    *Part
+		wantErr bool
+	}{
+		{
+			name:  "PathExtension",
+			build: func() (*Part, error) { return NewPartFromPath(notes, "") },
+			want:  &Part{InlineData: &Blob{Data: []byte("# Notes"), MIMEType: "text/markdown"}},
+		},
+		{
+			name:  "PathContent",
+			build: func() (*Part, error) { return NewPartFromPath(image, "") },
+			want:  &Part{InlineData: &Blob{Data: pngHeader, MIMEType: "image/png"}},
+		},
+		{
+			name:    "PathMissing",
+			build:   func() (*Part, error) { return NewPartFromPath(filepath.Join(dir, "missing.txt"), "") },
+			wantErr: true,
+		},
+		{
+			name:  "Reader",
+			build: func() (*Part, error) { return NewPartFromReader(bytes.NewReader(pngHeader), "") },
+			want:  &Part{InlineData: &Blob{Data: pngHeader, MIMEType: "image/png"}},
+		},
+		{
+			name:  "ReaderExplicitMIMEType",
+			build: func() (*Part, error) { return NewPartFromReader(bytes.NewReader([]byte("a,b")), "text/csv") },
+			want:  &Part{InlineData: &Blob{Data: []byte("a,b"), MIMEType: "text/csv"}},
+		},
+		{
+			name: "ReaderTooLarge",
+			build: func() (*Part, error) {
+				return NewPartFromReader(bytes.NewReader(make([]byte, maxInlinePartBytes+1)), "application/octet-stream")
+			},
+			wantErr: true,
+		},
+		{
+			name:  "GCS",
+			build: func() (*Part, error) { return NewPartFromGCS("gs://bucket/videos/a.mp4", "") },
+			want:  &Part{FileData: &FileData{FileURI: "gs://bucket/videos/a.mp4", MIMEType: "video/mp4"}},
+		},
+		{
+			name:    "GCSNoObject",
+			build:   func() (*Part, error) { return NewPartFromGCS("gs://bucket/", "video/mp4") },
+			wantErr: true,
+		},
+		{
+			name:    "GCSNotGCS",
+			build:   func() (*Part, error) { return NewPartFromGCS("https://example.com/a.mp4", "") },
+			wantErr: true,
+		},
+		{
+			name:    "GCSUnknownMIMEType",
+			build:   func() (*Part, error) { return NewPartFromGCS("gs://bucket/data", "") },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.build()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("got %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Persona is a preset of an assistant: the model, system instruction, tools and
+// config of its chat sessions. Personas can be defined in a central place and
+// shared through a [PersonaRegistry].
+type Persona struct {
+	// Required. The name of the persona.
+	Name string
+	// Optional. The version of the persona, e.g. "v2" or "2025-06-01".
+	Version string
+	// Required. The model of the chat sessions.
+	Model string
+	// Optional. The system instruction of the chat sessions. It replaces the system
+	// instruction of Config.
+	SystemInstruction *Content
+	// Optional. The tools of the chat sessions, added to the tools of Config.
+	Tools []*Tool
+	// Optional. The config of the chat sessions.
+	Config *GenerateContentConfig
+}
+
+// generateContentConfig returns the config of the chat sessions of the persona.
+func (p *Persona) generateContentConfig() *GenerateContentConfig {
+	config := &GenerateContentConfig{}
+	if p.Config != nil {
+		*config = *p.Config
+	}
+	if p.SystemInstruction != nil {
+		config.SystemInstruction = p.SystemInstruction
+	}
+	if len(p.Tools) > 0 {
+		config.Tools = append(slices.Clone(config.Tools), p.Tools...)
+	}
+	return config
+}
+
+// PersonaRegistry holds the versions of a set of personas. It is safe for
+// concurrent use.
+type PersonaRegistry struct {
+	mu sync.RWMutex
+	// The versions of every persona, in registration order.
+	personas map[string][]*Persona
+}
+
+// NewPersonaRegistry returns an empty registry.
+func NewPersonaRegistry() *PersonaRegistry {
+	return &PersonaRegistry{personas: map[string][]*Persona{}}
+}
+
+// Register adds a version of a persona to the registry. The latest registered
+// version of a persona is its default version.
+func (r *PersonaRegistry) Register(persona *Persona) error {
+	if persona == nil {
+		return fmt.Errorf("persona is nil")
+	}
+	if persona.Name == "" {
+		return fmt.Errorf("persona name is required")
+	}
+	if persona.Model == "" {
+		return fmt.Errorf("persona %q has no model", persona.Name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range r.personas[persona.Name] {
+		if p.Version == persona.Version {
+			return fmt.Errorf("persona %q version %q is already registered", persona.Name, persona.Version)
+		}
+	}
+	r.personas[persona.Name] = append(r.personas[persona.Name], persona)
+	return nil
+}
+
+// Get returns the given version of a persona, or its latest registered version if
+// version is empty.
+func (r *PersonaRegistry) Get(name, version string) (*Persona, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	versions := r.personas[name]
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("persona %q is not registered", name)
+	}
+	if version == "" {
+		return versions[len(versions)-1], nil
+	}
+	for _, p := range versions {
+		if p.Version == version {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("persona %q has no version %q", name, version)
+}
+
+// CreateFromPersona initializes a new chat session with the model, system
+// instruction, tools and config of the persona.
+//
+//	registry := genai.NewPersonaRegistry()
+//	registry.Register(&genai.Persona{
+//		Name:              "support",
+//		Version:           "v1",
+//		Model:             "gemini-2.0-flash",
+//		SystemInstruction: genai.NewContentFromText("You are a friendly support agent.", genai.RoleUser),
+//	})
+//	persona, _ := registry.Get("support", "")
+//	chat, _ := client.Chats.CreateFromPersona(ctx, persona, nil)
+func (c *Chats) CreateFromPersona(ctx context.Context, persona *Persona, history []*Content) (*Chat, error) {
+	if persona == nil {
+		return nil, fmt.Errorf("persona is nil")
+	}
+	if persona.Model == "" {
+		return nil, fmt.Errorf("persona %q has no model", persona.Name)
+	}
+	return c.Create(ctx, persona.Model, persona.generateContentConfig(), history)
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPersonaRegistry(t *testing.T) {
+	registry := NewPersonaRegistry()
+	v1 := &Persona{Name: "support", Version: "v1", Model: "gemini-2.0-flash"}
+	v2 := &Persona{Name: "support", Version: "v2", Model: "gemini-2.5-flash"}
+	for _, p := range []*Persona{v1, v2} {
+		if err := registry.Register(p); err != nil {
+			t.Fatalf("Register() failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		persona string
+		version string
+		want    *Persona
+		wantErr bool
+	}{
+		{name: "Latest", persona: "support", want: v2},
+		{name: "Version", persona: "support", version: "v1", want: v1},
+		{name: "UnknownVersion", persona: "support", version: "v3", wantErr: true},
+		{name: "UnknownPersona", persona: "sales", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := registry.Get(tt.persona, tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Get() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	for _, p := range []*Persona{nil, {Model: "gemini-2.0-flash"}, {Name: "sales"}, {Name: "support", Version: "v1", Model: "gemini-2.0-flash"}} {
+		if err := registry.Register(p); err == nil {
+			t.Errorf("Register(%+v) succeeded, want error", p)
+		}
+	}
+}
+
+func TestChatsCreateFromPersona(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	chats := newTestChats(t, []string{finalTextResponseJSON}, &requests)
+	config := &GenerateContentConfig{
+		Temperature:       Ptr[float32](0.2),
+		SystemInstruction: NewContentFromText("Be brief.", RoleUser),
+		Tools:             []*Tool{{GoogleSearch: &GoogleSearch{}}},
+	}
+	persona := &Persona{
+		Name:              "support",
+		Model:             "gemini-2.0-flash",
+		SystemInstruction: NewContentFromText("You are a support agent.", RoleUser),
+		Tools:             []*Tool{{FunctionDeclarations: []*FunctionDeclaration{{Name: "get_order"}}}},
+		Config:            config,
+	}
+	chat, err := chats.CreateFromPersona(ctx, persona, nil)
+	if err != nil {
+		t.Fatalf("CreateFromPersona() failed: %v", err)
+	}
+	if _, err := chat.SendMessage(ctx, Part{Text: "Where is my order?"}); err != nil {
+		t.Fatalf("SendMessage() failed: %v", err)
+	}
+
+	want := map[string]any{
+		"systemInstruction": map[string]any{"role": "user", "parts": []any{map[string]any{"text": "You are a support agent."}}},
+		"tools": []any{
+			map[string]any{"googleSearch": map[string]any{}},
+			map[string]any{"functionDeclarations": []any{map[string]any{"name": "get_order"}}},
+		},
+		"temperature": 0.2,
+	}
+	got := map[string]any{
+		"systemInstruction": requests[0]["systemInstruction"],
+		"tools":             requests[0]["tools"],
+		"temperature":       requests[0]["generationConfig"].(map[string]any)["temperature"],
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("request mismatch (-want +got):\n%s", diff)
+	}
+	if len(config.Tools) != 1 || config.SystemInstruction.Parts[0].Text != "Be brief." {
+		t.Errorf("CreateFromPersona() modified the config of the persona: %+v", config)
+	}
+	if _, err := chats.CreateFromPersona(ctx, &Persona{Name: "support"}, nil); err == nil {
+		t.Errorf("CreateFromPersona() without a model succeeded, want error")
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Predict sends instances to the predict method of a Vertex AI endpoint, e.g. a
+// custom model deployed to the endpoint, and returns its predictions. The
+// endpoint is its ID, e.g. "1234567890", or its resource name. The schemas of
+// the instances, of the [PredictConfig.Parameters] and of the predictions are
+// defined by the deployed model. See [PredictEndpoint] for typed instances and
+// predictions.
+func (m Models) Predict(ctx context.Context, endpoint string, instances []any, config *PredictConfig) (*PredictResponse, error) {
+	if m.apiClient.clientConfig.Backend != BackendVertexAI {
+		return nil, fmt.Errorf("method Predict is only supported in the Vertex AI client. You can choose to use Vertex AI by setting ClientConfig.Backend to BackendVertexAI.")
+	}
+	if instances == nil {
+		instances = []any{}
+	}
+	body := map[string]any{"instances": instances}
+	var configHTTPOptions *HTTPOptions
+	if config != nil {
+		configHTTPOptions = config.HTTPOptions
+		if config.Parameters != nil {
+			body["parameters"] = config.Parameters
+		}
+	}
+	httpOptions := mergeHTTPOptions(m.apiClient.clientConfig, configHTTPOptions)
+	resp, err := sendRawRequest(ctx, m.apiClient, endpointResource(endpoint)+":predict", body, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if !httpStatusOk(resp) {
+		return nil, newAPIError(resp)
+	}
+	response := new(PredictResponse)
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return nil, fmt.Errorf("Predict: error decoding response: %w", err)
+	}
+	return response, nil
+}
+
+// PredictEndpoint calls [Models.Predict] with instances of type I, and decodes
+// the predictions to type P:
+//
+//	type Instance struct {
+//		Features []float64 `json:"features"`
+//	}
+//	type Prediction struct {
+//		Label string  `json:"label"`
+//		Score float64 `json:"score"`
+//	}
+//	predictions, _, err := genai.PredictEndpoint[Instance, Prediction](ctx, client.Models, "1234567890", instances, nil)
+func PredictEndpoint[I, P any](ctx context.Context, models *Models, endpoint string, instances []I, config *PredictConfig) ([]P, *PredictResponse, error) {
+	values := make([]any, len(instances))
+	for i, instance := range instances {
+		values[i] = instance
+	}
+	resp, err := models.Predict(ctx, endpoint, values, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	predictions := make([]P, len(resp.Predictions))
+	for i, p := range resp.Predictions {
+		if err := json.Unmarshal(p, &predictions[i]); err != nil {
+			return nil, resp, fmt.Errorf("PredictEndpoint: error decoding prediction %d: %w", i, err)
+		}
+	}
+	return predictions, resp, nil
+}
+
+// RawPredictEndpoint sends a request of type Req to the rawPredict method of a
+// Vertex AI endpoint, e.g. a custom container serving its own request format,
+// and decodes the JSON response to type Resp. The endpoint is its ID or its
+// resource name. See [Models.RawPredict] for responses which are not JSON.
+func RawPredictEndpoint[Req, Resp any](ctx context.Context, models *Models, endpoint string, request Req, config *RawPredictConfig) (*Resp, error) {
+	resp, err := models.RawPredict(ctx, endpointResource(endpoint), request, config)
+	if err != nil {
+		return nil, err
+	}
+	response := new(Resp)
+	if err := json.Unmarshal(resp.Body, response); err != nil {
+		return nil, fmt.Errorf("RawPredictEndpoint: error decoding response: %w", err)
+	}
+	return response, nil
+}
+
+// endpointResource returns the resource name of an endpoint given its ID or its
+// resource name.
+func endpointResource(endpoint string) string {
+	if strings.HasPrefix(endpoint, "projects/") || strings.HasPrefix(endpoint, "endpoints/") {
+		return endpoint
+	}
+	return "endpoints/" + endpoint
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPredictEndpoint(t *testing.T) {
+	ctx := context.Background()
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendVertexAI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"predictions": [{"label": "cat", "score": 0.9}, {"label": "dog", "score": 0.6}],
+			"deployedModelId": "42",
+			"model": "projects/project/locations/us-central1/models/7",
+			"modelVersionId": "1"
+		}`))
+	})
+	type instance struct {
+		Features []float64 `json:"features"`
+	}
+	type prediction struct {
+		Label string  `json:"label"`
+		Score float64 `json:"score"`
+	}
+	predictions, resp, err := PredictEndpoint[instance, prediction](ctx, client.Models, "1234567890",
+		[]instance{{Features: []float64{1, 2}}, {Features: []float64{3}}},
+		&PredictConfig{Parameters: map[string]any{"threshold": 0.5}})
+	if err != nil {
+		t.Fatalf("PredictEndpoint() failed: %v", err)
+	}
+	if diff := cmp.Diff([]prediction{{"cat", 0.9}, {"dog", 0.6}}, predictions); diff != "" {
+		t.Errorf("PredictEndpoint() mismatch (-want +got):\n%s", diff)
+	}
+	if resp.DeployedModelID != "42" || resp.ModelVersionID != "1" {
+		t.Errorf("PredictEndpoint() response = %+v, want deployed model 42 version 1", resp)
+	}
+	wantRequests := []batchesRequest{{
+		Method: "POST",
+		Path:   "/v1beta1/projects/project/locations/us-central1/endpoints/1234567890:predict",
+		Body: map[string]any{
+			"instances":  []any{map[string]any{"features": []any{1.0, 2.0}}, map[string]any{"features": []any{3.0}}},
+			"parameters": map[string]any{"threshold": 0.5},
+		},
+	}}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+
+	t.Run("GeminiAPI", func(t *testing.T) {
+		client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {})
+		if _, err := client.Models.Predict(ctx, "1234567890", nil, nil); err == nil {
+			t.Errorf("Predict() succeeded, want error")
+		}
+	})
+}
+
+func TestRawPredictEndpoint(t *testing.T) {
+	ctx := context.Background()
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendVertexAI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"text": "Bonjour"}`))
+	})
+	type request struct {
+		Prompt string `json:"prompt"`
+	}
+	type response struct {
+		Text string `json:"text"`
+	}
+	resp, err := RawPredictEndpoint[request, response](ctx, client.Models, "projects/project/locations/europe-west4/endpoints/99", request{Prompt: "Hello"}, nil)
+	if err != nil {
+		t.Fatalf("RawPredictEndpoint() failed: %v", err)
+	}
+	if diff := cmp.Diff(&response{Text: "Bonjour"}, resp); diff != "" {
+		t.Errorf("RawPredictEndpoint() mismatch (-want +got):\n%s", diff)
+	}
+	wantRequests := []batchesRequest{{
+		Method: "POST",
+		Path:   "/v1beta1/projects/project/locations/europe-west4/endpoints/99:rawPredict",
+		Body:   map[string]any{"prompt": "Hello"},
+	}}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+}
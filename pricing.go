@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"maps"
+	"sync"
+)
+
+// defaultModelPrices are the list prices of the paid tier of the Gemini API, in
+// USD per million tokens, for prompts of up to 200k tokens and text, image and
+// video input. Last updated in July 2025, see https://ai.google.dev/pricing.
+var defaultModelPrices = map[string]ModelPrice{
+	"gemini-2.5-pro":        {InputTokens: 1.25, CachedInputTokens: 0.31, OutputTokens: 10},
+	"gemini-2.5-flash":      {InputTokens: 0.30, CachedInputTokens: 0.075, OutputTokens: 2.50},
+	"gemini-2.5-flash-lite": {InputTokens: 0.10, CachedInputTokens: 0.025, OutputTokens: 0.40},
+	"gemini-2.0-flash":      {InputTokens: 0.10, CachedInputTokens: 0.025, OutputTokens: 0.40},
+	"gemini-2.0-flash-lite": {InputTokens: 0.075, OutputTokens: 0.30},
+	"gemini-1.5-pro":        {InputTokens: 1.25, CachedInputTokens: 0.3125, OutputTokens: 5},
+	"gemini-1.5-flash":      {InputTokens: 0.075, CachedInputTokens: 0.01875, OutputTokens: 0.30},
+	"gemini-1.5-flash-8b":   {InputTokens: 0.0375, CachedInputTokens: 0.01, OutputTokens: 0.15},
+	"gemini-embedding-001":  {InputTokens: 0.15},
+}
+
+// defaultPriceRegistry is the registry of the default prices, used when no
+// registry is given.
+var defaultPriceRegistry = NewPriceRegistry(nil)
+
+// DefaultModelPrices returns a copy of the price table maintained with the SDK:
+// the list prices of the paid tier of the Gemini API, in USD per million tokens,
+// by model ID. The prices are the ones of prompts of up to 200k tokens, and of
+// text, image and video input. Check https://ai.google.dev/pricing for the
+// current prices, and override the prices that differ with a [PriceRegistry].
+func DefaultModelPrices() map[string]ModelPrice {
+	return maps.Clone(defaultModelPrices)
+}
+
+// PriceRegistry holds the prices of the models used to estimate costs, see
+// [Batches.Summary] and [Chat.Cost]. It layers overrides, e.g. negotiated prices,
+// over a table of base prices. It is safe for concurrent use.
+//
+//	prices := genai.NewPriceRegistry(nil)
+//	prices.Override("gemini-2.5-pro", genai.ModelPrice{InputTokens: 1, OutputTokens: 8})
+//	cost, ok := prices.Cost(resp.ModelVersion, resp.UsageMetadata)
+//
+// Models are looked up by ID, without their resource name prefix. The price of a
+// model version is the price of the longest ID prefixing it, e.g.
+// "gemini-2.0-flash-001" has the price of "gemini-2.0-flash". The longest ID is
+// looked up among the overrides and the base prices together, and an override
+// takes precedence over a base price of the same ID: overriding "gemini-2.5-flash"
+// does not change the price of "gemini-2.5-flash-lite".
+type PriceRegistry struct {
+	mu        sync.RWMutex
+	base      map[string]ModelPrice
+	overrides map[string]ModelPrice
+}
+
+// NewPriceRegistry returns a registry with the given base prices, or the prices of
+// [DefaultModelPrices] if base is nil.
+func NewPriceRegistry(base map[string]ModelPrice) *PriceRegistry {
+	if base == nil {
+		base = defaultModelPrices
+	}
+	return &PriceRegistry{base: maps.Clone(base), overrides: map[string]ModelPrice{}}
+}
+
+// Override sets the price of a model ID, taking precedence over its base price.
+func (r *PriceRegistry) Override(model string, price ModelPrice) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.overrides == nil {
+		r.overrides = map[string]ModelPrice{}
+	}
+	r.overrides[modelID(model)] = price
+}
+
+// RemoveOverride removes the override of a model ID, restoring its base price, if
+// any.
+func (r *PriceRegistry) RemoveOverride(model string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.overrides, modelID(model))
+}
+
+// Price returns the price of a model, and whether the model has a price.
+func (r *PriceRegistry) Price(model string) (ModelPrice, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	model = modelID(model)
+	override, overrideLen := longestPrefixPrice(r.overrides, model)
+	base, baseLen := longestPrefixPrice(r.base, model)
+	switch {
+	case overrideLen >= 0 && overrideLen >= baseLen:
+		return override, true
+	case baseLen >= 0:
+		return base, true
+	default:
+		return ModelPrice{}, false
+	}
+}
+
+// Cost returns the estimated cost of the usage metadata of a response of a model,
+// and whether the model has a price.
+func (r *PriceRegistry) Cost(model string, usage *GenerateContentResponseUsageMetadata) (float64, bool) {
+	price, ok := r.Price(model)
+	if !ok {
+		return 0, false
+	}
+	if usage == nil {
+		return 0, true
+	}
+	return price.tokensCost(
+		int64(usage.PromptTokenCount)+int64(usage.ToolUsePromptTokenCount),
+		int64(usage.CachedContentTokenCount),
+		int64(usage.CandidatesTokenCount)+int64(usage.ThoughtsTokenCount),
+	), true
+}
+
+// tokensCost returns the cost of input tokens, cached ones included, and output
+// tokens.
+func (p ModelPrice) tokensCost(input, cached, output int64) float64 {
+	cachedPrice := p.CachedInputTokens
+	if cachedPrice == 0 {
+		cachedPrice = p.InputTokens
+	}
+	return (float64(input-cached)*p.InputTokens + float64(cached)*cachedPrice + float64(output)*p.OutputTokens) / 1e6
+}
+
+// scaled returns the price multiplied by factor.
+func (p ModelPrice) scaled(factor float64) ModelPrice {
+	return ModelPrice{
+		InputTokens:       p.InputTokens * factor,
+		CachedInputTokens: p.CachedInputTokens * factor,
+		OutputTokens:      p.OutputTokens * factor,
+	}
+}
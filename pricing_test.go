@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPriceRegistryPrice(t *testing.T) {
+	prices := NewPriceRegistry(nil)
+	prices.Override("models/gemini-2.5-flash", ModelPrice{InputTokens: 1, OutputTokens: 4})
+	prices.Override("gemini-2.5", ModelPrice{InputTokens: 2, OutputTokens: 8})
+	tests := []struct {
+		name   string
+		model  string
+		want   ModelPrice
+		wantOK bool
+	}{
+		{name: "Default", model: "gemini-2.0-flash", want: defaultModelPrices["gemini-2.0-flash"], wantOK: true},
+		{name: "Version", model: "gemini-2.0-flash-001", want: defaultModelPrices["gemini-2.0-flash"], wantOK: true},
+		{name: "LongestPrefix", model: "gemini-2.0-flash-lite-001", want: defaultModelPrices["gemini-2.0-flash-lite"], wantOK: true},
+		{name: "ResourceName", model: "publishers/google/models/gemini-1.5-pro-002", want: defaultModelPrices["gemini-1.5-pro"], wantOK: true},
+		{name: "OverrideTakesPrecedence", model: "gemini-2.5-flash-001", want: ModelPrice{InputTokens: 1, OutputTokens: 4}, wantOK: true},
+		{name: "LongerBasePrice", model: "gemini-2.5-flash-lite", want: defaultModelPrices["gemini-2.5-flash-lite"], wantOK: true},
+		{name: "ShorterOverride", model: "gemini-2.5-ultra", want: ModelPrice{InputTokens: 2, OutputTokens: 8}, wantOK: true},
+		{name: "Unknown", model: "gemma-3-27b-it", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := prices.Price(tt.model)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Price(%q) = %+v, %v, want %+v, %v", tt.model, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	prices.RemoveOverride("gemini-2.5-flash")
+	if got, _ := prices.Price("gemini-2.5-flash-001"); got != defaultModelPrices["gemini-2.5-flash"] {
+		t.Errorf("Price() after RemoveOverride() = %+v, want %+v", got, defaultModelPrices["gemini-2.5-flash"])
+	}
+}
+
+func TestPriceRegistryBase(t *testing.T) {
+	base := map[string]ModelPrice{"gemma": {InputTokens: 0.1}}
+	prices := NewPriceRegistry(base)
+	base["gemma"] = ModelPrice{InputTokens: 5}
+	if got, ok := prices.Price("gemma-3-27b-it"); !ok || got.InputTokens != 0.1 {
+		t.Errorf("Price() = %+v, %v, want the price at creation", got, ok)
+	}
+	if _, ok := prices.Price("gemini-2.0-flash"); ok {
+		t.Errorf("Price() of a model of the default prices succeeded, want only the base prices")
+	}
+
+	var zero PriceRegistry
+	zero.Override("gemma", ModelPrice{InputTokens: 2})
+	if got, ok := zero.Price("gemma-3"); !ok || got.InputTokens != 2 {
+		t.Errorf("zero value Price() = %+v, %v, want the override", got, ok)
+	}
+
+	defaults := DefaultModelPrices()
+	delete(defaults, "gemini-2.0-flash")
+	if _, ok := NewPriceRegistry(nil).Price("gemini-2.0-flash"); !ok {
+		t.Errorf("modifying DefaultModelPrices() changed the default prices")
+	}
+}
+
+func TestPriceRegistryCost(t *testing.T) {
+	prices := NewPriceRegistry(map[string]ModelPrice{
+		"cached":   {InputTokens: 2, CachedInputTokens: 0.5, OutputTokens: 8},
+		"uncached": {InputTokens: 2, OutputTokens: 8},
+	})
+	usage := &GenerateContentResponseUsageMetadata{
+		PromptTokenCount:        900_000,
+		ToolUsePromptTokenCount: 100_000,
+		CachedContentTokenCount: 400_000,
+		CandidatesTokenCount:    200_000,
+		ThoughtsTokenCount:      300_000,
+	}
+	tests := []struct {
+		name     string
+		model    string
+		usage    *GenerateContentResponseUsageMetadata
+		wantCost float64
+		wantOK   bool
+	}{
+		{name: "CachedPrice", model: "cached", usage: usage, wantCost: 0.6*2 + 0.4*0.5 + 0.5*8, wantOK: true},
+		{name: "InputPriceForCachedTokens", model: "uncached", usage: usage, wantCost: 1*2 + 0.5*8, wantOK: true},
+		{name: "NilUsage", model: "cached", wantCost: 0, wantOK: true},
+		{name: "Unknown", model: "gemini-2.0-flash", usage: usage, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := prices.Cost(tt.model, tt.usage)
+			if math.Abs(got-tt.wantCost) > 1e-9 || ok != tt.wantOK {
+				t.Errorf("Cost() = %v, %v, want %v, %v", got, ok, tt.wantCost, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"log"
+	"net/http"
+)
+
+// RawPredict sends a request body in the native format of a model to its
+// rawPredict method, and returns the response body as is. It calls the partner
+// models of Vertex AI which don't use the GenerateContent schema, e.g. the
+// Anthropic Claude and Mistral models. The model is a publisher model, e.g.
+// "anthropic/claude-sonnet-4", or the resource name of a model or endpoint. The
+// body is encoded to JSON, unless it is already a [json.RawMessage] or []byte.
+//
+//	resp, err := client.Models.RawPredict(ctx, "anthropic/claude-sonnet-4", map[string]any{
+//		"anthropic_version": "vertex-2023-10-16",
+//		"max_tokens":        256,
+//		"messages":          []map[string]any{{"role": "user", "content": "Hello"}},
+//	}, nil)
+//	if err != nil {
+//		return err
+//	}
+//	var message AnthropicMessage
+//	err = json.Unmarshal(resp.Body, &message)
+func (m Models) RawPredict(ctx context.Context, model string, body any, config *RawPredictConfig) (*RawPredictResponse, error) {
+	resp, err := m.sendRawPredict(ctx, "RawPredict", model, "rawPredict", body, config)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if !httpStatusOk(resp) {
+		return nil, newAPIError(resp)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("RawPredict: error reading response body: %w", err)
+	}
+	return &RawPredictResponse{
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        b,
+		HTTPHeaders: resp.Header,
+	}, nil
+}
+
+// StreamRawPredict sends a request body in the native format of a model to its
+// streamRawPredict method, and returns the server-sent events of the response,
+// e.g. the message events of an Anthropic Claude model. The "[DONE]" event
+// ending an OpenAI-style stream is not returned. See [Models.RawPredict] for
+// the supported models and bodies.
+func (m Models) StreamRawPredict(ctx context.Context, model string, body any, config *RawPredictConfig) iter.Seq2[*RawPredictResponse, error] {
+	return func(yield func(*RawPredictResponse, error) bool) {
+		resp, err := m.sendRawPredict(ctx, "StreamRawPredict", model, "streamRawPredict", body, config)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer resp.Body.Close()
+		if !httpStatusOk(resp) {
+			yield(nil, newAPIError(resp))
+			return
+		}
+		for event, err := range serverSentEvents(resp.Body) {
+			if err == nil && string(event.Body) == "[DONE]" {
+				return
+			}
+			if !yield(event, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendRawPredict sends a raw body to a method of a Vertex AI model and returns
+// the HTTP response, whose body must be closed.
+func (m Models) sendRawPredict(ctx context.Context, name, model, method string, body any, config *RawPredictConfig) (*http.Response, error) {
+	if m.apiClient.clientConfig.Backend != BackendVertexAI {
+		return nil, fmt.Errorf("method %s is only supported in the Vertex AI client. You can choose to use Vertex AI by setting ClientConfig.Backend to BackendVertexAI.", name)
+	}
+	var configHTTPOptions *HTTPOptions
+	if config != nil {
+		configHTTPOptions = config.HTTPOptions
+	}
+	httpOptions := mergeHTTPOptions(m.apiClient.clientConfig, configHTTPOptions)
+	resource, err := tModel(m.apiClient, model)
+	if err != nil {
+		return nil, err
+	}
+	return sendRawRequest(ctx, m.apiClient, resource+":"+method, body, httpOptions)
+}
+
+// sendRawRequest sends a POST request whose body is encoded to JSON, unless it
+// is already a [json.RawMessage] or []byte, and returns the HTTP response, whose
+// body must be closed.
+func sendRawRequest(ctx context.Context, ac *apiClient, path string, body any, httpOptions *HTTPOptions) (*http.Response, error) {
+	var b []byte
+	switch body := body.(type) {
+	case json.RawMessage:
+		b = body
+	case []byte:
+		b = body
+	default:
+		var err error
+		if b, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("sendRawRequest: error encoding body: %w", err)
+		}
+	}
+	u, err := ac.createAPIURL(path, http.MethodPost, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	doMergeHeaders(httpOptions.Headers, &req.Header)
+	doMergeHeaders(sdkHeader(ctx, ac), &req.Header)
+	return doRequest(ac, req)
+}
+
+// serverSentEvents returns the events of a server-sent events stream. The data
+// lines of an event are joined with newlines, and comments and the other fields
+// are ignored.
+func serverSentEvents(r io.Reader) iter.Seq2[*RawPredictResponse, error] {
+	return func(yield func(*RawPredictResponse, error) bool) {
+		s := bufio.NewScanner(r)
+		// See deserializeStreamResponse for the buffer sizes.
+		s.Buffer(make([]byte, 1024), 268435456)
+		s.Split(scan)
+		for s.Scan() {
+			event := &RawPredictResponse{}
+			var data [][]byte
+			for _, line := range bytes.Split(s.Bytes(), []byte("\n")) {
+				field, value, _ := bytes.Cut(dropCR(line), []byte(":"))
+				value = bytes.TrimPrefix(value, []byte(" "))
+				switch string(field) {
+				case "event":
+					event.Event = string(value)
+				case "data":
+					data = append(data, value)
+				}
+			}
+			if event.Event == "" && data == nil {
+				continue
+			}
+			event.Body = bytes.Join(data, []byte("\n"))
+			if !yield(event, nil) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			if err == bufio.ErrTooLong {
+				log.Printf("The response is too large to process in streaming mode. Please use a non-streaming method.")
+			}
+			yield(nil, fmt.Errorf("serverSentEvents: error reading stream: %w", err))
+		}
+	}
+}
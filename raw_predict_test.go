@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModelsRawPredict(t *testing.T) {
+	ctx := context.Background()
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendVertexAI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"type": "message", "content": [{"type": "text", "text": "Hello!"}]}`))
+	})
+	body := map[string]any{
+		"anthropic_version": "vertex-2023-10-16",
+		"max_tokens":        256,
+		"messages":          []map[string]any{{"role": "user", "content": "Hi"}},
+	}
+	resp, err := client.Models.RawPredict(ctx, "anthropic/claude-sonnet-4", body, nil)
+	if err != nil {
+		t.Fatalf("RawPredict() failed: %v", err)
+	}
+	if got, want := string(resp.Body), `{"type": "message", "content": [{"type": "text", "text": "Hello!"}]}`; got != want {
+		t.Errorf("RawPredict() body = %s, want %s", got, want)
+	}
+	if resp.ContentType != "application/json" {
+		t.Errorf("RawPredict() content type = %q, want %q", resp.ContentType, "application/json")
+	}
+	wantRequests := []batchesRequest{{
+		Method: "POST",
+		Path:   "/v1beta1/projects/project/locations/us-central1/publishers/anthropic/models/claude-sonnet-4:rawPredict",
+		Body: map[string]any{
+			"anthropic_version": "vertex-2023-10-16",
+			"max_tokens":        256.0,
+			"messages":          []any{map[string]any{"role": "user", "content": "Hi"}},
+		},
+	}}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+
+	t.Run("RawBody", func(t *testing.T) {
+		requests = nil
+		if _, err := client.Models.RawPredict(ctx, "projects/project/locations/us-east5/publishers/mistralai/models/mistral-large", json.RawMessage(`{"messages": []}`), nil); err != nil {
+			t.Fatalf("RawPredict() failed: %v", err)
+		}
+		if diff := cmp.Diff(map[string]any{"messages": []any{}}, requests[0].Body); diff != "" {
+			t.Errorf("request body mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("GeminiAPI", func(t *testing.T) {
+		client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {})
+		if _, err := client.Models.RawPredict(ctx, "anthropic/claude-sonnet-4", body, nil); err == nil {
+			t.Errorf("RawPredict() succeeded, want error")
+		}
+	})
+
+	t.Run("APIError", func(t *testing.T) {
+		client := newTestBatches(t, BackendVertexAI, &requests, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"code": 400, "message": "max_tokens: Field required", "status": "INVALID_ARGUMENT"}}`))
+		})
+		_, err := client.Models.RawPredict(ctx, "anthropic/claude-sonnet-4", map[string]any{}, nil)
+		var apiErr APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != 400 {
+			t.Errorf("RawPredict() error = %v, want an APIError with code 400", err)
+		}
+	})
+}
+
+func TestModelsStreamRawPredict(t *testing.T) {
+	ctx := context.Background()
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendVertexAI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Join([]string{
+			"event: message_start\ndata: {\"type\": \"message_start\"}\n\n",
+			": ping\n\n",
+			"event: content_block_delta\ndata: {\"type\": \"content_block_delta\",\ndata: \"delta\": {\"text\": \"Hi\"}}\n\n",
+			"data: [DONE]\n\n",
+			"data: {\"ignored\": true}\n\n",
+		}, "")))
+	})
+	var got []*RawPredictResponse
+	for event, err := range client.Models.StreamRawPredict(ctx, "anthropic/claude-sonnet-4", map[string]any{"stream": true}, nil) {
+		if err != nil {
+			t.Fatalf("StreamRawPredict() failed: %v", err)
+		}
+		got = append(got, event)
+	}
+	want := []*RawPredictResponse{
+		{Event: "message_start", Body: []byte(`{"type": "message_start"}`)},
+		{Event: "content_block_delta", Body: []byte("{\"type\": \"content_block_delta\",\n\"delta\": {\"text\": \"Hi\"}}")},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("StreamRawPredict() mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := requests[0].Path, "/v1beta1/projects/project/locations/us-central1/publishers/anthropic/models/claude-sonnet-4:streamRawPredict"; got != want {
+		t.Errorf("StreamRawPredict() requested %q, want %q", got, want)
+	}
+}
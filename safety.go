@@ -0,0 +1,120 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"fmt"
+	"sort"
+)
+
+// NewSafetySettings builds the [SafetySetting] list for the given per-category
+// thresholds. The settings are sorted by category.
+//
+//	config := &genai.GenerateContentConfig{
+//		SafetySettings: genai.NewSafetySettings(map[genai.HarmCategory]genai.HarmBlockThreshold{
+//			genai.HarmCategoryHateSpeech: genai.HarmBlockThresholdBlockLowAndAbove,
+//			genai.HarmCategoryHarassment: genai.HarmBlockThresholdBlockOnlyHigh,
+//		}),
+//	}
+func NewSafetySettings(thresholds map[HarmCategory]HarmBlockThreshold) []*SafetySetting {
+	settings := make([]*SafetySetting, 0, len(thresholds))
+	for category, threshold := range thresholds {
+		settings = append(settings, &SafetySetting{Category: category, Threshold: threshold})
+	}
+	sort.Slice(settings, func(i, j int) bool { return settings[i].Category < settings[j].Category })
+	return settings
+}
+
+// NewSafetySettingsWithThreshold builds the [SafetySetting] list that applies the
+// same threshold to all the given categories. If no category is given, the
+// threshold is applied to hate speech, dangerous content, harassment and sexually
+// explicit content.
+func NewSafetySettingsWithThreshold(threshold HarmBlockThreshold, categories ...HarmCategory) []*SafetySetting {
+	if len(categories) == 0 {
+		categories = []HarmCategory{
+			HarmCategoryHateSpeech,
+			HarmCategoryDangerousContent,
+			HarmCategoryHarassment,
+			HarmCategorySexuallyExplicit,
+		}
+	}
+	thresholds := make(map[HarmCategory]HarmBlockThreshold, len(categories))
+	for _, category := range categories {
+		thresholds[category] = threshold
+	}
+	return NewSafetySettings(thresholds)
+}
+
+// BlockedReason returns the reason the prompt was blocked, or the empty string if
+// it was not blocked.
+func (r *GenerateContentResponse) BlockedReason() BlockedReason {
+	if r == nil || r.PromptFeedback == nil {
+		return ""
+	}
+	return r.PromptFeedback.BlockReason
+}
+
+// SafetyViolations returns the safety ratings that caused the candidate to be
+// filtered: the ratings flagged as blocked and, if the candidate finished because
+// of safety reasons, the ratings with a medium or high harm probability.
+func (c *Candidate) SafetyViolations() []*SafetyRating {
+	if c == nil {
+		return nil
+	}
+	var violations []*SafetyRating
+	for _, rating := range c.SafetyRatings {
+		if rating.Blocked || (c.FinishReason == FinishReasonSafety &&
+			(rating.Probability == HarmProbabilityMedium || rating.Probability == HarmProbabilityHigh)) {
+			violations = append(violations, rating)
+		}
+	}
+	return violations
+}
+
+// SafetyBlockedError is returned by [Models.GenerateContent] and
+// [Models.GenerateContentStream] when the prompt is blocked and no candidate is
+// generated.
+type SafetyBlockedError struct {
+	// The reason the prompt was blocked.
+	BlockReason BlockedReason
+	// A readable block reason message.
+	BlockReasonMessage string
+	// The safety ratings of the prompt.
+	SafetyRatings []*SafetyRating
+	// The response returned by the server.
+	Response *GenerateContentResponse
+}
+
+// Error returns a string representation of the SafetyBlockedError.
+func (e SafetyBlockedError) Error() string {
+	if e.BlockReasonMessage != "" {
+		return fmt.Sprintf("prompt blocked, reason: %s, message: %s", e.BlockReason, e.BlockReasonMessage)
+	}
+	return fmt.Sprintf("prompt blocked, reason: %s", e.BlockReason)
+}
+
+// promptBlockedError returns a [SafetyBlockedError] if the prompt of the response
+// was blocked, nil otherwise.
+func promptBlockedError(r *GenerateContentResponse) error {
+	if r == nil || len(r.Candidates) > 0 || r.BlockedReason() == "" {
+		return nil
+	}
+	return SafetyBlockedError{
+		BlockReason:        r.PromptFeedback.BlockReason,
+		BlockReasonMessage: r.PromptFeedback.BlockReasonMessage,
+		SafetyRatings:      r.PromptFeedback.SafetyRatings,
+		Response:           r,
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const promptBlockedResponseJSON = `{
+	"promptFeedback": {
+		"blockReason": "SAFETY",
+		"safetyRatings": [{"category": "HARM_CATEGORY_HARASSMENT", "probability": "HIGH", "blocked": true}]
+	}
+}`
+
+func TestNewSafetySettings(t *testing.T) {
+	got := NewSafetySettings(map[HarmCategory]HarmBlockThreshold{
+		HarmCategorySexuallyExplicit: HarmBlockThresholdBlockNone,
+		HarmCategoryHarassment:       HarmBlockThresholdBlockOnlyHigh,
+	})
+	want := []*SafetySetting{
+		{Category: HarmCategoryHarassment, Threshold: HarmBlockThresholdBlockOnlyHigh},
+		{Category: HarmCategorySexuallyExplicit, Threshold: HarmBlockThresholdBlockNone},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewSafetySettings() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := NewSafetySettingsWithThreshold(HarmBlockThresholdOff); len(got) != 4 {
+		t.Errorf("NewSafetySettingsWithThreshold() returned %d settings, want 4", len(got))
+	}
+}
+
+func TestSafetyViolations(t *testing.T) {
+	harassment := &SafetyRating{Category: HarmCategoryHarassment, Probability: HarmProbabilityHigh}
+	hate := &SafetyRating{Category: HarmCategoryHateSpeech, Probability: HarmProbabilityNegligible}
+	dangerous := &SafetyRating{Category: HarmCategoryDangerousContent, Probability: HarmProbabilityLow, Blocked: true}
+
+	tests := []struct {
+		name      string
+		candidate *Candidate
+		want      []*SafetyRating
+	}{
+		{
+			name:      "NilCandidate",
+			candidate: nil,
+			want:      nil,
+		},
+		{
+			name:      "Stop",
+			candidate: &Candidate{FinishReason: FinishReasonStop, SafetyRatings: []*SafetyRating{harassment, hate}},
+			want:      nil,
+		},
+		{
+			name:      "Safety",
+			candidate: &Candidate{FinishReason: FinishReasonSafety, SafetyRatings: []*SafetyRating{harassment, hate, dangerous}},
+			want:      []*SafetyRating{harassment, dangerous},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.candidate.SafetyViolations()); diff != "" {
+				t.Errorf("SafetyViolations() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateContentPromptBlocked(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	models := newTestClient(t, BackendGeminiAPI, replyInOrder(t, []string{promptBlockedResponseJSON}, &requests)).Models
+
+	_, err := models.GenerateContent(ctx, "gemini-2.0-flash", Text("Say something mean."), nil)
+	var blockedErr SafetyBlockedError
+	if !errors.As(err, &blockedErr) {
+		t.Fatalf("GenerateContent() error = %v, want SafetyBlockedError", err)
+	}
+	if blockedErr.BlockReason != BlockedReasonSafety {
+		t.Errorf("BlockReason = %q, want %q", blockedErr.BlockReason, BlockedReasonSafety)
+	}
+	if blockedErr.Response.BlockedReason() != BlockedReasonSafety {
+		t.Errorf("Response.BlockedReason() = %q, want %q", blockedErr.Response.BlockedReason(), BlockedReasonSafety)
+	}
+
+	for _, err := range models.GenerateContentStream(ctx, "gemini-2.0-flash", Text("Say something mean."), nil) {
+		if !errors.As(err, &blockedErr) {
+			t.Errorf("GenerateContentStream() error = %v, want SafetyBlockedError", err)
+		}
+		break
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaEnumer is implemented by types whose JSON encoding is one of a fixed set of
+// strings, such as integer constants declared with iota that marshal to their names.
+// The schema generated for such a type is a string enum of the returned values.
+//
+//	type Color int
+//
+//	const (
+//		Red Color = iota
+//		Green
+//	)
+//
+//	func (Color) SchemaEnum() []string { return []string{"RED", "GREEN"} }
+type SchemaEnumer interface {
+	SchemaEnum() []string
+}
+
+// SchemaAnyOfer is implemented by types whose JSON encoding is one of several
+// shapes. SchemaAnyOf returns a value of each alternative type, and the schema
+// generated for the type is the anyOf of the alternatives' schemas. The method is
+// called on the zero value of the type.
+type SchemaAnyOfer interface {
+	SchemaAnyOf() []any
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	schemaEnumerType  = reflect.TypeOf((*SchemaEnumer)(nil)).Elem()
+	schemaAnyOferType = reflect.TypeOf((*SchemaAnyOfer)(nil)).Elem()
+)
+
+// NewSchemaFromType builds a [Schema] describing the JSON encoding of values of
+// type T. It can be used as a response schema for structured output, e.g.:
+//
+//	schema, err := genai.NewSchemaFromType[Recipe]()
+//	config := &genai.GenerateContentConfig{ResponseMIMEType: "application/json", ResponseSchema: schema}
+//
+// The schema of a struct lists its exported fields in declaration order. The
+// property names follow the `json` struct tag, fields tagged with `omitempty` are
+// optional, the `description` struct tag is used as the property description and
+// the `enum` struct tag restricts a string field to a comma-separated set of values.
+// Types implementing [SchemaEnumer] or [SchemaAnyOfer] produce enums and anyOf
+// schemas respectively. Recursive types are described with `ref` and `defs`, which
+// are only supported by Vertex AI.
+func NewSchemaFromType[T any]() (*Schema, error) {
+	return schemaFromType(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// NewSchemaFromJSONSchema converts a decoded JSON Schema object, such as the
+// parameters of a tool written for another provider, to the OpenAPI subset
+// supported by [Schema]. Unsupported keywords are ignored.
+func NewSchemaFromJSONSchema(jsonSchema map[string]any) (*Schema, error) {
+	return schemaFromJSONSchema(jsonSchema)
+}
+
+// schemaFromType builds a [Schema] describing the JSON encoding of values of type t.
+func schemaFromType(t reflect.Type) (*Schema, error) {
+	b := &schemaBuilder{
+		visiting:  map[reflect.Type]bool{},
+		recursive: map[reflect.Type]bool{},
+		defs:      map[string]*Schema{},
+	}
+	schema, err := b.build(t)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.defs) > 0 {
+		schema.Defs = b.defs
+	}
+	return schema, nil
+}
+
+type schemaBuilder struct {
+	// visiting holds the struct types being built, to detect recursion.
+	visiting map[reflect.Type]bool
+	// recursive holds the struct types that reference themselves.
+	recursive map[reflect.Type]bool
+	// defs holds the schemas of the recursive types, keyed by type name.
+	defs map[string]*Schema
+}
+
+func (b *schemaBuilder) build(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if v, ok := implementation(t, schemaEnumerType); ok {
+		return &Schema{Type: TypeString, Format: "enum", Enum: v.(SchemaEnumer).SchemaEnum()}, nil
+	}
+	if v, ok := implementation(t, schemaAnyOferType); ok {
+		schema := &Schema{}
+		for i, alternative := range v.(SchemaAnyOfer).SchemaAnyOf() {
+			if alternative == nil {
+				return nil, fmt.Errorf("schemaFromType: alternative %d of %s is nil", i, t)
+			}
+			s, err := b.build(reflect.TypeOf(alternative))
+			if err != nil {
+				return nil, err
+			}
+			schema.AnyOf = append(schema.AnyOf, s)
+		}
+		return schema, nil
+	}
+	if t == timeType {
+		return &Schema{Type: TypeString, Format: "date-time"}, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: TypeString}, nil
+	case reflect.Bool:
+		return &Schema{Type: TypeBoolean}, nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64, reflect.Uint32:
+		return &Schema{Type: TypeInteger, Format: "int64"}, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: TypeInteger, Format: "int32"}, nil
+	case reflect.Float32:
+		return &Schema{Type: TypeNumber, Format: "float"}, nil
+	case reflect.Float64:
+		return &Schema{Type: TypeNumber, Format: "double"}, nil
+	case reflect.Slice, reflect.Array:
+		// encoding/json encodes byte slices as base64 strings, and byte arrays as
+		// arrays of numbers.
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: TypeString, Format: "byte"}, nil
+		}
+		items, err := b.build(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: TypeArray, Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("schemaFromType: map key type must be string, got %s", t)
+		}
+		return &Schema{Type: TypeObject}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Struct:
+		return b.buildStruct(t)
+	default:
+		return nil, fmt.Errorf("schemaFromType: unsupported type %s", t)
+	}
+}
+
+func (b *schemaBuilder) buildStruct(t reflect.Type) (*Schema, error) {
+	ref := &Schema{Ref: "#/defs/" + schemaDefName(t)}
+	if b.visiting[t] {
+		b.recursive[t] = true
+		return ref, nil
+	}
+	b.visiting[t] = true
+	defer delete(b.visiting, t)
+
+	schema := &Schema{Type: TypeObject, Properties: map[string]*Schema{}}
+	if err := b.addStructProperties(schema, t); err != nil {
+		return nil, err
+	}
+	if b.recursive[t] {
+		b.defs[schemaDefName(t)] = schema
+		return ref, nil
+	}
+	return schema, nil
+}
+
+// addStructProperties adds the exported fields of struct type t to schema, following
+// the naming rules of encoding/json. Fields of embedded structs are promoted.
+func (b *schemaBuilder) addStructProperties(schema *Schema, t reflect.Type) error {
+	for _, f := range dominantFields(structFields(t, 0, map[reflect.Type]bool{t: true}, nil)) {
+		property, err := b.build(f.field.Type)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", f.field.Name, err)
+		}
+		property.Description = f.field.Tag.Get("description")
+		if enum := f.field.Tag.Get("enum"); enum != "" {
+			property.Enum = strings.Split(enum, ",")
+			if property.Type == TypeString {
+				property.Format = "enum"
+			}
+		}
+		schema.Properties[f.name] = property
+		schema.PropertyOrdering = append(schema.PropertyOrdering, f.name)
+		if !f.omitempty {
+			schema.Required = append(schema.Required, f.name)
+		}
+	}
+	return nil
+}
+
+// structField is a field of a struct, possibly promoted from an embedded struct.
+type structField struct {
+	name      string
+	field     reflect.StructField
+	depth     int
+	tagged    bool
+	omitempty bool
+}
+
+// structFields returns the fields of struct type t, embedded at depth, in the
+// order of encoding/json. Embedded struct types in expanding, i.e. already being
+// expanded, are skipped.
+func structFields(t reflect.Type, depth int, expanding map[reflect.Type]bool, fields []structField) []structField {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if !expanding[ft] {
+					expanding[ft] = true
+					fields = structFields(ft, depth+1, expanding, fields)
+					delete(expanding, ft)
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		tagged := name != ""
+		if !tagged {
+			name = field.Name
+		}
+		fields = append(fields, structField{
+			name:      name,
+			field:     field,
+			depth:     depth,
+			tagged:    tagged,
+			omitempty: strings.Contains(","+opts+",", ",omitempty,"),
+		})
+	}
+	return fields
+}
+
+// dominantFields returns the fields that encoding/json encodes among fields
+// sharing names: the shallowest field of a name, or the tagged one if several are
+// the shallowest. Names with no dominant field are dropped.
+func dominantFields(fields []structField) []structField {
+	var dominant []structField
+	for i, f := range fields {
+		ok := true
+		for j, g := range fields {
+			if i == j || g.name != f.name {
+				continue
+			}
+			if g.depth < f.depth || g.depth == f.depth && (g.tagged || !f.tagged) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			dominant = append(dominant, f)
+		}
+	}
+	return dominant
+}
+
+// implementation returns a value of type t or *t that implements iface, if any.
+func implementation(t, iface reflect.Type) (any, bool) {
+	if t.Kind() == reflect.Interface {
+		return nil, false
+	}
+	if t.Implements(iface) {
+		return reflect.Zero(t).Interface(), true
+	}
+	if reflect.PointerTo(t).Implements(iface) {
+		return reflect.New(t).Interface(), true
+	}
+	return nil, false
+}
+
+func schemaDefName(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return strings.NewReplacer(" ", "", "*", "", "[", "_", "]", "_").Replace(t.String())
+}
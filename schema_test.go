@@ -0,0 +1,213 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testColor int
+
+func (testColor) SchemaEnum() []string { return []string{"RED", "GREEN", "BLUE"} }
+
+type testCircle struct {
+	Radius float64 `json:"radius"`
+}
+
+type testSquare struct {
+	Side float64 `json:"side"`
+}
+
+type testShape struct{}
+
+func (testShape) SchemaAnyOf() []any { return []any{testCircle{}, testSquare{}} }
+
+type testNode struct {
+	Value    string      `json:"value"`
+	Children []*testNode `json:"children,omitempty"`
+}
+
+type testDrawing struct {
+	Color   testColor `json:"color"`
+	Shape   testShape `json:"shape"`
+	Size    string    `json:"size" enum:"small,large"`
+	Created time.Time `json:"created,omitempty"`
+}
+
+type testTree struct {
+	Root *testNode `json:"root"`
+}
+
+type testSelfEmbedding struct {
+	*testSelfEmbedding
+	Name string `json:"name"`
+}
+
+type testNilAlternative struct{}
+
+func (testNilAlternative) SchemaAnyOf() []any { return []any{testCircle{}, nil} }
+
+type testBytes struct {
+	Data   []byte  `json:"data"`
+	Digest [4]byte `json:"digest"`
+}
+
+type testBase struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type testDerived struct {
+	testBase
+	Name  string `json:"name,omitempty"`
+	Extra int    `json:"extra"`
+}
+
+func TestNewSchemaFromType(t *testing.T) {
+	t.Run("EnumsAndAnyOf", func(t *testing.T) {
+		got, err := NewSchemaFromType[testDrawing]()
+		if err != nil {
+			t.Fatalf("NewSchemaFromType() failed: %v", err)
+		}
+		want := &Schema{
+			Type: TypeObject,
+			Properties: map[string]*Schema{
+				"color": {Type: TypeString, Format: "enum", Enum: []string{"RED", "GREEN", "BLUE"}},
+				"shape": {AnyOf: []*Schema{
+					{Type: TypeObject, Properties: map[string]*Schema{"radius": {Type: TypeNumber, Format: "double"}}, PropertyOrdering: []string{"radius"}, Required: []string{"radius"}},
+					{Type: TypeObject, Properties: map[string]*Schema{"side": {Type: TypeNumber, Format: "double"}}, PropertyOrdering: []string{"side"}, Required: []string{"side"}},
+				}},
+				"size":    {Type: TypeString, Format: "enum", Enum: []string{"small", "large"}},
+				"created": {Type: TypeString, Format: "date-time"},
+			},
+			PropertyOrdering: []string{"color", "shape", "size", "created"},
+			Required:         []string{"color", "shape", "size"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("NewSchemaFromType() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Recursive", func(t *testing.T) {
+		got, err := NewSchemaFromType[testTree]()
+		if err != nil {
+			t.Fatalf("NewSchemaFromType() failed: %v", err)
+		}
+		want := &Schema{
+			Type:             TypeObject,
+			Properties:       map[string]*Schema{"root": {Ref: "#/defs/testNode"}},
+			PropertyOrdering: []string{"root"},
+			Required:         []string{"root"},
+			Defs: map[string]*Schema{
+				"testNode": {
+					Type: TypeObject,
+					Properties: map[string]*Schema{
+						"value":    {Type: TypeString},
+						"children": {Type: TypeArray, Items: &Schema{Ref: "#/defs/testNode"}},
+					},
+					PropertyOrdering: []string{"value", "children"},
+					Required:         []string{"value"},
+				},
+			},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("NewSchemaFromType() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("SelfEmbedding", func(t *testing.T) {
+		got, err := NewSchemaFromType[testSelfEmbedding]()
+		if err != nil {
+			t.Fatalf("NewSchemaFromType() failed: %v", err)
+		}
+		want := &Schema{
+			Type:             TypeObject,
+			Properties:       map[string]*Schema{"name": {Type: TypeString}},
+			PropertyOrdering: []string{"name"},
+			Required:         []string{"name"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("NewSchemaFromType() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ShadowedField", func(t *testing.T) {
+		got, err := NewSchemaFromType[testDerived]()
+		if err != nil {
+			t.Fatalf("NewSchemaFromType() failed: %v", err)
+		}
+		want := &Schema{
+			Type: TypeObject,
+			Properties: map[string]*Schema{
+				"id":    {Type: TypeString},
+				"name":  {Type: TypeString},
+				"extra": {Type: TypeInteger, Format: "int64"},
+			},
+			PropertyOrdering: []string{"id", "name", "extra"},
+			Required:         []string{"id", "extra"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("NewSchemaFromType() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Bytes", func(t *testing.T) {
+		got, err := NewSchemaFromType[testBytes]()
+		if err != nil {
+			t.Fatalf("NewSchemaFromType() failed: %v", err)
+		}
+		want := &Schema{
+			Type: TypeObject,
+			Properties: map[string]*Schema{
+				"data":   {Type: TypeString, Format: "byte"},
+				"digest": {Type: TypeArray, Items: &Schema{Type: TypeInteger, Format: "int32"}},
+			},
+			PropertyOrdering: []string{"data", "digest"},
+			Required:         []string{"data", "digest"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("NewSchemaFromType() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("NilAlternative", func(t *testing.T) {
+		if _, err := NewSchemaFromType[testNilAlternative](); err == nil {
+			t.Errorf("NewSchemaFromType() with a nil alternative succeeded, want error")
+		}
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		if _, err := NewSchemaFromType[chan int](); err == nil {
+			t.Errorf("NewSchemaFromType[chan int]() succeeded, want error")
+		}
+	})
+}
+
+func TestSchemaRefConverters(t *testing.T) {
+	schema := map[string]any{"ref": "#/defs/Node", "defs": map[string]any{"Node": map[string]any{"type": "OBJECT"}}}
+	if _, err := schemaToMldev(nil, schema, nil); err == nil {
+		t.Errorf("schemaToMldev() succeeded, want error")
+	}
+	got, err := schemaToVertex(nil, schema, nil)
+	if err != nil {
+		t.Fatalf("schemaToVertex() failed: %v", err)
+	}
+	if diff := cmp.Diff(schema, got); diff != "" {
+		t.Errorf("schemaToVertex() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"iter"
+)
+
+// CollectStream consumes a stream returned by [Models.GenerateContentStream] and
+// merges its chunks into a single response. Adjacent text parts of a candidate are
+// concatenated, the last reported finish reason and model version are kept, and
+// the usage metadata of the chunks is accumulated as by [StreamUsage].
+//
+// If the stream ends with an error, e.g. because its context was cancelled,
+// CollectStream returns the response accumulated so far along with the error. This
+// allows a "stop generating" action to keep the partial text and the partial usage
+// metadata:
+//
+//	ctx, cancel := context.WithCancel(ctx)
+//	// Call cancel() when the user stops the generation.
+//	resp, err := genai.CollectStream(client.Models.GenerateContentStream(ctx, model, contents, nil))
+//	if errors.Is(err, context.Canceled) {
+//		fmt.Println(resp.Text())
+//	}
+//
+// The returned response is nil only if no chunk was received.
+func CollectStream(stream iter.Seq2[*GenerateContentResponse, error]) (*GenerateContentResponse, error) {
+	var (
+		merged *GenerateContentResponse
+		usage  StreamUsage
+	)
+	for chunk, err := range stream {
+		if err != nil {
+			return merged, err
+		}
+		if chunk == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &GenerateContentResponse{}
+		}
+		mergeResponseChunk(merged, chunk, &usage)
+	}
+	return merged, nil
+}
+
+// mergeResponseChunk appends a stream chunk to the accumulated response, whose
+// usage metadata is accumulated by usage.
+func mergeResponseChunk(merged, chunk *GenerateContentResponse, usage *StreamUsage) {
+	if merged.CreateTime.IsZero() {
+		merged.CreateTime = chunk.CreateTime
+	}
+	if chunk.ResponseID != "" {
+		merged.ResponseID = chunk.ResponseID
+	}
+	if chunk.ModelVersion != "" {
+		merged.ModelVersion = chunk.ModelVersion
+	}
+	if merged.PromptFeedback == nil {
+		merged.PromptFeedback = chunk.PromptFeedback
+	}
+	if chunk.UsageMetadata != nil {
+		usage.Add(chunk.UsageMetadata)
+		merged.UsageMetadata = usage.Usage()
+	}
+	for i, c := range chunk.Candidates {
+		if c == nil {
+			continue
+		}
+		// The index is omitted by some backends, in which case the position of the
+		// candidate in the chunk identifies it.
+		index := c.Index
+		if index == 0 {
+			index = int32(i)
+		}
+		var target *Candidate
+		for _, m := range merged.Candidates {
+			if m.Index == index {
+				target = m
+				break
+			}
+		}
+		if target == nil {
+			target = &Candidate{Index: index}
+			merged.Candidates = append(merged.Candidates, target)
+		}
+		mergeCandidateChunk(target, c)
+	}
+}
+
+// mergeCandidateChunk appends a candidate of a stream chunk to the accumulated
+// candidate with the same index.
+func mergeCandidateChunk(merged, chunk *Candidate) {
+	if chunk.Content != nil {
+		if merged.Content == nil {
+			merged.Content = &Content{Role: chunk.Content.Role}
+		}
+		for _, part := range chunk.Content.Parts {
+			if part == nil {
+				continue
+			}
+			merged.Content.Parts = appendMergedPart(merged.Content.Parts, part)
+		}
+	}
+	if chunk.FinishReason != "" {
+		merged.FinishReason = chunk.FinishReason
+	}
+	if chunk.FinishMessage != "" {
+		merged.FinishMessage = chunk.FinishMessage
+	}
+	if chunk.CitationMetadata != nil {
+		if merged.CitationMetadata == nil {
+			merged.CitationMetadata = &CitationMetadata{}
+		}
+		merged.CitationMetadata.Citations = append(merged.CitationMetadata.Citations, chunk.CitationMetadata.Citations...)
+	}
+	if chunk.GroundingMetadata != nil {
+		merged.GroundingMetadata = chunk.GroundingMetadata
+	}
+	if chunk.URLContextMetadata != nil {
+		merged.URLContextMetadata = chunk.URLContextMetadata
+	}
+	if chunk.SafetyRatings != nil {
+		merged.SafetyRatings = chunk.SafetyRatings
+	}
+	if chunk.TokenCount != 0 {
+		merged.TokenCount = chunk.TokenCount
+	}
+}
+
+// appendMergedPart appends a part, concatenating it to the last part if both are
+// plain text parts of the same kind.
+func appendMergedPart(parts []*Part, part *Part) []*Part {
+	if n := len(parts); n > 0 && isPlainTextPart(parts[n-1]) && isPlainTextPart(part) && parts[n-1].Thought == part.Thought {
+		last := *parts[n-1]
+		last.Text += part.Text
+		if part.ThoughtSignature != nil {
+			last.ThoughtSignature = part.ThoughtSignature
+		}
+		parts[n-1] = &last
+		return parts
+	}
+	return append(parts, part)
+}
+
+func isPlainTextPart(p *Part) bool {
+	return p.Text != "" && p.InlineData == nil && p.FileData == nil && p.FunctionCall == nil &&
+		p.FunctionResponse == nil && p.ExecutableCode == nil && p.CodeExecutionResult == nil
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultMaxStreamRequestBytes = 1 << 20
+
+// StreamFormat is the wire format of the chunks written by a [StreamHandler].
+type StreamFormat string
+
+const (
+	// StreamFormatSSE writes every chunk as a server-sent event, as read by the
+	// EventSource API of browsers. The stream ends with a "[DONE]" event.
+	StreamFormatSSE StreamFormat = "sse"
+	// StreamFormatNDJSON writes every chunk as a line of JSON.
+	StreamFormatNDJSON StreamFormat = "ndjson"
+)
+
+// StreamHandler is an [http.Handler] streaming generated content to web clients.
+// It reads the contents of a POST request, calls [Models.GenerateContentStream]
+// and writes every [GenerateContentResponse] chunk to the client as soon as it is
+// received:
+//
+//	http.Handle("/api/generate", &genai.StreamHandler{
+//		Models: client.Models,
+//		Model:  "gemini-2.5-flash",
+//	})
+//
+// By default the body of the request is a JSON object holding the contents, e.g.
+// {"contents": [{"role": "user", "parts": [{"text": "Hello"}]}]}, and the model
+// and config can't be chosen by the client. Set Decode to read other requests, to
+// authorize them or to pick the config per request.
+//
+// The next chunk is only read from the model once the previous one is written, so
+// a slow client slows the stream down rather than buffering it. The stream is
+// cancelled when the client disconnects, or when a chunk can't be written within
+// WriteTimeout.
+//
+// Errors returned before the first chunk are written with the status code of the
+// [APIError], or 500 for other errors. Errors returned afterwards are written as
+// a last chunk {"error": {"code": ..., "message": ..., "status": ...}}, sent as an
+// "error" event in the SSE format.
+type StreamHandler struct {
+	// Required. The models generating the content.
+	Models *Models
+	// Optional. The model used if Decode doesn't return one.
+	Model string
+	// Optional. The config used if Decode doesn't return one.
+	Config *GenerateContentConfig
+	// Optional. Reads the request of the client, and returns the model, contents and
+	// config of the generation. An empty model or a nil config fall back to the
+	// ones of the handler. If the returned error is an [APIError], its code is the
+	// status code of the response, otherwise the status code is 400.
+	Decode func(r *http.Request) (model string, contents []*Content, config *GenerateContentConfig, err error)
+	// Optional. The wire format of the chunks. By default, NDJSON is written if the
+	// Accept header of the request includes "application/x-ndjson", and SSE otherwise.
+	Format StreamFormat
+	// Optional. The maximum size of the request body read by the default decoder.
+	// Defaults to 1 MiB.
+	MaxRequestBytes int64
+	// Optional. The maximum duration of the write of a chunk. Unlimited if zero.
+	WriteTimeout time.Duration
+}
+
+// ServeHTTP implements [http.Handler].
+func (h *StreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeStreamHandlerError(w, APIError{Code: http.StatusMethodNotAllowed, Message: "method not allowed"})
+		return
+	}
+	decode := h.Decode
+	if decode == nil {
+		decode = h.decodeRequest
+	}
+	model, contents, config, err := decode(r)
+	if err != nil {
+		var apiErr APIError
+		if !errors.As(err, &apiErr) {
+			apiErr = APIError{Code: http.StatusBadRequest, Message: err.Error(), Status: "INVALID_ARGUMENT"}
+		}
+		writeStreamHandlerError(w, apiErr)
+		return
+	}
+	if model == "" {
+		model = h.Model
+	}
+	if config == nil {
+		config = h.Config
+	}
+
+	format := h.Format
+	if format == "" {
+		format = StreamFormatSSE
+		if strings.Contains(r.Header.Get("Accept"), "application/x-ndjson") {
+			format = StreamFormatNDJSON
+		}
+	}
+	rc := http.NewResponseController(w)
+	started := false
+	for resp, err := range h.Models.GenerateContentStream(r.Context(), model, contents, config) {
+		if err != nil {
+			if r.Context().Err() != nil {
+				// The client is gone.
+				return
+			}
+			apiErr := streamHandlerAPIError(err)
+			if !started {
+				writeStreamHandlerError(w, apiErr)
+				return
+			}
+			writeStreamChunk(w, rc, format, "error", responseWithError{ErrorInfo: &apiErr}, h.WriteTimeout)
+			return
+		}
+		if !started {
+			started = true
+			if format == StreamFormatNDJSON {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+			} else {
+				w.Header().Set("Content-Type", "text/event-stream")
+				w.Header().Set("Cache-Control", "no-cache")
+				// Disables the buffering of reverse proxies such as nginx.
+				w.Header().Set("X-Accel-Buffering", "no")
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+		if err := writeStreamChunk(w, rc, format, "", resp, h.WriteTimeout); err != nil {
+			// Returning stops the stream and closes the connection to the model.
+			return
+		}
+	}
+	if !started {
+		writeStreamHandlerError(w, APIError{Code: http.StatusBadGateway, Message: "the model returned no content", Status: "UNAVAILABLE"})
+		return
+	}
+	if format == StreamFormatSSE {
+		writeStreamChunk(w, rc, format, "", nil, h.WriteTimeout)
+	}
+}
+
+// decodeRequest reads the contents of a JSON request body.
+func (h *StreamHandler) decodeRequest(r *http.Request) (string, []*Content, *GenerateContentConfig, error) {
+	limit := h.MaxRequestBytes
+	if limit <= 0 {
+		limit = defaultMaxStreamRequestBytes
+	}
+	var body struct {
+		Contents []*Content `json:"contents"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(nil, r.Body, limit)).Decode(&body); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return "", nil, nil, APIError{Code: http.StatusRequestEntityTooLarge, Message: "request body too large", Status: "INVALID_ARGUMENT"}
+		}
+		return "", nil, nil, fmt.Errorf("invalid request body: %w", err)
+	}
+	if len(body.Contents) == 0 {
+		return "", nil, nil, fmt.Errorf("invalid request body: contents are required")
+	}
+	return "", body.Contents, nil, nil
+}
+
+// writeStreamChunk writes a chunk to the client and flushes it. A nil chunk is
+// written as the "[DONE]" event ending an SSE stream.
+func writeStreamChunk(w http.ResponseWriter, rc *http.ResponseController, format StreamFormat, event string, chunk any, timeout time.Duration) error {
+	var buf bytes.Buffer
+	switch {
+	case format == StreamFormatNDJSON:
+		if err := json.NewEncoder(&buf).Encode(chunk); err != nil {
+			return err
+		}
+	case chunk == nil:
+		buf.WriteString("data: [DONE]\n\n")
+	default:
+		data, err := json.Marshal(chunk)
+		if err != nil {
+			return err
+		}
+		if event != "" {
+			fmt.Fprintf(&buf, "event: %s\n", event)
+		}
+		fmt.Fprintf(&buf, "data: %s\n\n", data)
+	}
+	if timeout > 0 {
+		if err := rc.SetWriteDeadline(time.Now().Add(timeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+	}
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// streamHandlerAPIError returns the error reported to the client for an error of
+// the stream. Only the details of API errors are reported.
+func streamHandlerAPIError(err error) APIError {
+	var apiErr APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return APIError{Code: http.StatusInternalServerError, Message: http.StatusText(http.StatusInternalServerError), Status: "INTERNAL"}
+}
+
+// writeStreamHandlerError writes an error response in the format of the errors
+// of the API.
+func writeStreamHandlerError(w http.ResponseWriter, apiErr APIError) {
+	if apiErr.Code == 0 {
+		apiErr.Code = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.Code)
+	json.NewEncoder(w).Encode(responseWithError{ErrorInfo: &apiErr})
+}
@@ -0,0 +1,215 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testStreamChunks = "data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"Hel\"}]}}]}\n\n" +
+	"data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"lo\"}]}, \"finishReason\": \"STOP\"}]}\n\n"
+
+func TestStreamHandler(t *testing.T) {
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testStreamChunks))
+	})
+	handler := &StreamHandler{Models: client.Models, Model: "gemini-2.5-flash", Config: &GenerateContentConfig{Temperature: Ptr[float32](0.5)}}
+
+	tests := []struct {
+		name            string
+		accept          string
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "SSE",
+			wantContentType: "text/event-stream",
+			wantBody: `data: {"candidates":[{"content":{"parts":[{"text":"Hel"}],"role":"model"}}]}` + "\n\n" +
+				`data: {"candidates":[{"content":{"parts":[{"text":"lo"}],"role":"model"},"finishReason":"STOP"}]}` + "\n\n" +
+				"data: [DONE]\n\n",
+		},
+		{
+			name:            "NDJSON",
+			accept:          "application/x-ndjson",
+			wantContentType: "application/x-ndjson",
+			wantBody: `{"candidates":[{"content":{"parts":[{"text":"Hel"}],"role":"model"}}]}` + "\n" +
+				`{"candidates":[{"content":{"parts":[{"text":"lo"}],"role":"model"},"finishReason":"STOP"}]}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = nil
+			r := httptest.NewRequest(http.MethodPost, "/generate", strings.NewReader(`{"contents": [{"role": "user", "parts": [{"text": "Hi"}]}]}`))
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != http.StatusOK {
+				t.Fatalf("ServeHTTP() status = %d, want 200, body %s", w.Code, w.Body)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("ServeHTTP() content type = %q, want %q", got, tt.wantContentType)
+			}
+			if !w.Flushed {
+				t.Errorf("ServeHTTP() didn't flush the chunks")
+			}
+			if diff := cmp.Diff(tt.wantBody, w.Body.String()); diff != "" {
+				t.Errorf("ServeHTTP() body mismatch (-want +got):\n%s", diff)
+			}
+			wantRequests := []batchesRequest{{
+				Method: "POST",
+				Path:   "/v1beta/models/gemini-2.5-flash:streamGenerateContent",
+				Query:  "alt=sse",
+				Body: map[string]any{
+					"contents":         []any{map[string]any{"role": "user", "parts": []any{map[string]any{"text": "Hi"}}}},
+					"generationConfig": map[string]any{"temperature": 0.5},
+				},
+			}}
+			if diff := cmp.Diff(wantRequests, requests); diff != "" {
+				t.Errorf("requests mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStreamHandlerDecode(t *testing.T) {
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testStreamChunks))
+	})
+	handler := &StreamHandler{
+		Models: client.Models,
+		Model:  "gemini-2.5-flash",
+		Format: StreamFormatNDJSON,
+		Decode: func(r *http.Request) (string, []*Content, *GenerateContentConfig, error) {
+			if r.Header.Get("Authorization") == "" {
+				return "", nil, nil, APIError{Code: http.StatusUnauthorized, Message: "missing credentials", Status: "UNAUTHENTICATED"}
+			}
+			return "gemini-2.5-pro", Text(r.URL.Query().Get("q")), nil, nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/generate?q=Hi", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("ServeHTTP() status = %d, want 401", w.Code)
+	}
+	if len(requests) != 0 {
+		t.Errorf("ServeHTTP() sent %d requests to the model, want none", len(requests))
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/generate?q=Hi", nil)
+	r.Header.Set("Authorization", "Bearer token")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Errorf("ServeHTTP() = status %d content type %q, want 200 application/x-ndjson", w.Code, w.Header().Get("Content-Type"))
+	}
+	if got, want := requests[0].Path, "/v1beta/models/gemini-2.5-pro:streamGenerateContent"; got != want {
+		t.Errorf("ServeHTTP() requested %q, want %q", got, want)
+	}
+}
+
+func TestStreamHandlerErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		body     string
+		upstream func(w http.ResponseWriter, r *http.Request)
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "MethodNotAllowed",
+			method:   http.MethodGet,
+			wantCode: http.StatusMethodNotAllowed,
+			wantBody: `{"error":{"code":405,"message":"method not allowed"}}` + "\n",
+		},
+		{
+			name:     "MissingContents",
+			body:     `{}`,
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"error":{"code":400,"message":"invalid request body: contents are required","status":"INVALID_ARGUMENT"}}` + "\n",
+		},
+		{
+			name: "APIError",
+			upstream: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"error": {"code": 429, "message": "Resource exhausted.", "status": "RESOURCE_EXHAUSTED"}}`))
+			},
+			wantCode: http.StatusTooManyRequests,
+			wantBody: `{"error":{"code":429,"message":"Resource exhausted.","status":"RESOURCE_EXHAUSTED"}}` + "\n",
+		},
+		{
+			name: "ErrorAfterFirstChunk",
+			upstream: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("data: {\"candidates\": [{\"content\": {\"parts\": [{\"text\": \"Hel\"}]}}]}\n\ndata: {invalid\n\n"))
+			},
+			wantCode: http.StatusOK,
+			wantBody: `data: {"candidates":[{"content":{"parts":[{"text":"Hel"}]}}]}` + "\n\n" +
+				"event: error\n" + `data: {"error":{"code":500,"message":"Internal Server Error","status":"INTERNAL"}}` + "\n\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []batchesRequest
+			upstream := tt.upstream
+			if upstream == nil {
+				upstream = func(w http.ResponseWriter, r *http.Request) {}
+			}
+			client := newTestBatches(t, BackendGeminiAPI, &requests, upstream)
+			handler := &StreamHandler{Models: client.Models, Model: "gemini-2.5-flash"}
+			method, body := tt.method, tt.body
+			if method == "" {
+				method = http.MethodPost
+			}
+			if body == "" {
+				body = `{"contents": [{"parts": [{"text": "Hi"}]}]}`
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(method, "/generate", strings.NewReader(body)))
+			if w.Code != tt.wantCode {
+				t.Errorf("ServeHTTP() status = %d, want %d", w.Code, tt.wantCode)
+			}
+			if diff := cmp.Diff(tt.wantBody, w.Body.String()); diff != "" {
+				t.Errorf("ServeHTTP() body mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStreamHandlerClientGone(t *testing.T) {
+	var requests []batchesRequest
+	client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testStreamChunks))
+	})
+	handler := &StreamHandler{Models: client.Models, Model: "gemini-2.5-flash"}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/generate", strings.NewReader(`{"contents": [{"parts": [{"text": "Hi"}]}]}`))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Body.Len() != 0 {
+		t.Errorf("ServeHTTP() wrote %q to a disconnected client, want nothing", w.Body)
+	}
+}
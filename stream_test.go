@@ -0,0 +1,112 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTestStreamModels returns a Models whose server streams the given chunks and
+// then, if hang is true, keeps the connection open until the request is cancelled.
+func newTestStreamModels(t *testing.T, chunks []string, hang bool) Models {
+	t.Helper()
+	client := newTestClient(t, BackendGeminiAPI, func(w http.ResponseWriter, r *http.Request) {
+		for _, chunk := range chunks {
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+		}
+		w.(http.Flusher).Flush()
+		if hang {
+			<-r.Context().Done()
+		}
+	})
+	return *client.Models
+}
+
+func TestCollectStream(t *testing.T) {
+	chunks := []string{
+		`{"candidates":[{"content":{"role":"model","parts":[{"text":"thinking","thought":true}]}}],"modelVersion":"gemini-test"}`,
+		`{"candidates":[{"content":{"role":"model","parts":[{"text":"Hello"}]}}],"usageMetadata":{"promptTokenCount":3,"candidatesTokenCount":1}}`,
+		`{"candidates":[{"content":{"role":"model","parts":[{"text":", world"}]}}],"usageMetadata":{"promptTokenCount":3,"candidatesTokenCount":2}}`,
+	}
+
+	t.Run("Complete", func(t *testing.T) {
+		final := `{"candidates":[{"content":{"role":"model","parts":[{"text":"!"}]},"finishReason":"STOP"}],"usageMetadata":{"promptTokenCount":3,"candidatesTokenCount":3,"totalTokenCount":6}}`
+		models := newTestStreamModels(t, append(chunks, final), false)
+		got, err := CollectStream(models.GenerateContentStream(context.Background(), "gemini-test", Text("Hi"), nil))
+		if err != nil {
+			t.Fatalf("CollectStream() failed: %v", err)
+		}
+		want := &GenerateContentResponse{
+			Candidates: []*Candidate{{
+				Content: &Content{Role: RoleModel, Parts: []*Part{
+					{Text: "thinking", Thought: true},
+					{Text: "Hello, world!"},
+				}},
+				FinishReason: FinishReasonStop,
+			}},
+			ModelVersion:  "gemini-test",
+			UsageMetadata: &GenerateContentResponseUsageMetadata{PromptTokenCount: 3, CandidatesTokenCount: 3, TotalTokenCount: 6},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("CollectStream() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		models := newTestStreamModels(t, chunks, true)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream := models.GenerateContentStream(ctx, "gemini-test", Text("Hi"), nil)
+		// Cancel the context once all the chunks have been received, as a "stop
+		// generating" button would.
+		stopAfter := func(n int, stream iter.Seq2[*GenerateContentResponse, error]) iter.Seq2[*GenerateContentResponse, error] {
+			return func(yield func(*GenerateContentResponse, error) bool) {
+				received := 0
+				for resp, err := range stream {
+					if err == nil {
+						received++
+						if received == n {
+							cancel()
+						}
+					}
+					if !yield(resp, err) {
+						return
+					}
+				}
+			}
+		}
+
+		got, err := CollectStream(stopAfter(len(chunks), stream))
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("CollectStream() error = %v, want %v", err, context.Canceled)
+		}
+		if got.Text() != "Hello, world" {
+			t.Errorf("Text() = %q, want %q", got.Text(), "Hello, world")
+		}
+		if got.UsageMetadata == nil || got.UsageMetadata.CandidatesTokenCount != 2 {
+			t.Errorf("UsageMetadata = %+v, want CandidatesTokenCount 2", got.UsageMetadata)
+		}
+		if got.Candidates[0].FinishReason != "" {
+			t.Errorf("FinishReason = %q, want empty", got.Candidates[0].FinishReason)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+// StreamUsage accumulates the usage metadata of the chunks of a stream into the
+// usage metadata of the whole response. Streams report usage metadata in several
+// chunks, which must be neither summed nor read from the first chunk:
+//
+//	var usage genai.StreamUsage
+//	for resp, err := range client.Models.GenerateContentStream(ctx, model, contents, nil) {
+//		if err != nil {
+//			return err
+//		}
+//		usage.Add(resp.UsageMetadata)
+//		fmt.Print(resp.Text())
+//	}
+//	fmt.Println(usage.Usage().TotalTokenCount)
+//
+// The prompt, tool use prompt and cached token counts are counted once: the last
+// reported value is kept, so that cached tokens reported at the start of the
+// stream only aren't lost. The candidates and thoughts token counts are running
+// totals in the chunks of the Gemini API and Vertex AI, and the last reported
+// value is kept as well. Set PerChunk for backends reporting the output token
+// counts of each chunk instead, to sum them. [CollectStream] accumulates the usage
+// metadata the same way, as running totals.
+//
+// The zero value is ready to use.
+type StreamUsage struct {
+	// Optional. Whether the output token counts of the chunks are per-chunk counts,
+	// to sum, instead of running totals.
+	PerChunk bool
+
+	// The last usage metadata reported by the stream, with the counts omitted by
+	// the later chunks kept from the earlier ones.
+	last *GenerateContentResponseUsageMetadata
+	// The sums of the output token counts, used if PerChunk is set.
+	candidatesSum        int32
+	thoughtsSum          int32
+	candidatesDetailsSum []*ModalityTokenCount
+}
+
+// Add accumulates the usage metadata of a chunk. A nil usage metadata is ignored.
+func (u *StreamUsage) Add(chunk *GenerateContentResponseUsageMetadata) {
+	if chunk == nil {
+		return
+	}
+	if u.last == nil {
+		u.last = &GenerateContentResponseUsageMetadata{}
+	}
+	last := u.last
+	if chunk.CandidatesTokenCount != 0 {
+		u.candidatesSum += chunk.CandidatesTokenCount
+		last.CandidatesTokenCount = chunk.CandidatesTokenCount
+	}
+	if chunk.ThoughtsTokenCount != 0 {
+		u.thoughtsSum += chunk.ThoughtsTokenCount
+		last.ThoughtsTokenCount = chunk.ThoughtsTokenCount
+	}
+	if chunk.CandidatesTokensDetails != nil {
+		u.candidatesDetailsSum = addModalityTokenCounts(u.candidatesDetailsSum, chunk.CandidatesTokensDetails)
+		last.CandidatesTokensDetails = chunk.CandidatesTokensDetails
+	}
+	if chunk.PromptTokenCount != 0 {
+		last.PromptTokenCount = chunk.PromptTokenCount
+	}
+	if chunk.PromptTokensDetails != nil {
+		last.PromptTokensDetails = chunk.PromptTokensDetails
+	}
+	if chunk.CachedContentTokenCount != 0 {
+		last.CachedContentTokenCount = chunk.CachedContentTokenCount
+	}
+	if chunk.CacheTokensDetails != nil {
+		last.CacheTokensDetails = chunk.CacheTokensDetails
+	}
+	if chunk.ToolUsePromptTokenCount != 0 {
+		last.ToolUsePromptTokenCount = chunk.ToolUsePromptTokenCount
+	}
+	if chunk.ToolUsePromptTokensDetails != nil {
+		last.ToolUsePromptTokensDetails = chunk.ToolUsePromptTokensDetails
+	}
+	if chunk.TotalTokenCount != 0 {
+		last.TotalTokenCount = chunk.TotalTokenCount
+	}
+	if chunk.TrafficType != "" {
+		last.TrafficType = chunk.TrafficType
+	}
+}
+
+// Usage returns the usage metadata of the stream so far, or nil if no chunk
+// reported usage metadata. The total token count is the last reported one, or the
+// sum of the other counts if PerChunk is set or if no total was reported.
+func (u *StreamUsage) Usage() *GenerateContentResponseUsageMetadata {
+	if u.last == nil {
+		return nil
+	}
+	usage := *u.last
+	usage.PromptTokensDetails = cloneModalityTokenCounts(u.last.PromptTokensDetails)
+	usage.CacheTokensDetails = cloneModalityTokenCounts(u.last.CacheTokensDetails)
+	usage.CandidatesTokensDetails = cloneModalityTokenCounts(u.last.CandidatesTokensDetails)
+	usage.ToolUsePromptTokensDetails = cloneModalityTokenCounts(u.last.ToolUsePromptTokensDetails)
+	if u.PerChunk {
+		usage.CandidatesTokenCount = u.candidatesSum
+		usage.ThoughtsTokenCount = u.thoughtsSum
+		usage.CandidatesTokensDetails = cloneModalityTokenCounts(u.candidatesDetailsSum)
+	}
+	if u.PerChunk || usage.TotalTokenCount == 0 {
+		usage.TotalTokenCount = usage.PromptTokenCount + usage.ToolUsePromptTokenCount + usage.CandidatesTokenCount + usage.ThoughtsTokenCount
+	}
+	return &usage
+}
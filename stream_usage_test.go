@@ -0,0 +1,152 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStreamUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		perChunk bool
+		chunks   []*GenerateContentResponseUsageMetadata
+		want     *GenerateContentResponseUsageMetadata
+	}{
+		{
+			name: "NoUsage",
+			want: nil,
+		},
+		{
+			name: "RunningTotals",
+			chunks: []*GenerateContentResponseUsageMetadata{
+				{PromptTokenCount: 10, CandidatesTokenCount: 4, ThoughtsTokenCount: 20, TotalTokenCount: 34},
+				nil,
+				{PromptTokenCount: 10, CandidatesTokenCount: 4, ThoughtsTokenCount: 20, TotalTokenCount: 34},
+				{PromptTokenCount: 10, CandidatesTokenCount: 9, ThoughtsTokenCount: 20, TotalTokenCount: 39},
+			},
+			want: &GenerateContentResponseUsageMetadata{PromptTokenCount: 10, CandidatesTokenCount: 9, ThoughtsTokenCount: 20, TotalTokenCount: 39},
+		},
+		{
+			name: "CachedTokensInFirstChunk",
+			chunks: []*GenerateContentResponseUsageMetadata{
+				{
+					PromptTokenCount:        1000,
+					CachedContentTokenCount: 800,
+					CacheTokensDetails:      []*ModalityTokenCount{{Modality: MediaModalityText, TokenCount: 800}},
+					CandidatesTokenCount:    2,
+				},
+				{PromptTokenCount: 1000, CandidatesTokenCount: 5, TotalTokenCount: 1005, TrafficType: TrafficTypeOnDemand},
+			},
+			want: &GenerateContentResponseUsageMetadata{
+				PromptTokenCount:        1000,
+				CachedContentTokenCount: 800,
+				CacheTokensDetails:      []*ModalityTokenCount{{Modality: MediaModalityText, TokenCount: 800}},
+				CandidatesTokenCount:    5,
+				TotalTokenCount:         1005,
+				TrafficType:             TrafficTypeOnDemand,
+			},
+		},
+		{
+			name:     "PerChunkCounts",
+			perChunk: true,
+			chunks: []*GenerateContentResponseUsageMetadata{
+				{PromptTokenCount: 10, CandidatesTokenCount: 3, CandidatesTokensDetails: []*ModalityTokenCount{{Modality: MediaModalityText, TokenCount: 3}}, TotalTokenCount: 13},
+				{PromptTokenCount: 10, CandidatesTokenCount: 5, CandidatesTokensDetails: []*ModalityTokenCount{{Modality: MediaModalityText, TokenCount: 5}}, TotalTokenCount: 15},
+				{PromptTokenCount: 10, CandidatesTokenCount: 2, CandidatesTokensDetails: []*ModalityTokenCount{{Modality: MediaModalityText, TokenCount: 2}}, TotalTokenCount: 12},
+			},
+			want: &GenerateContentResponseUsageMetadata{
+				PromptTokenCount:        10,
+				CandidatesTokenCount:    10,
+				CandidatesTokensDetails: []*ModalityTokenCount{{Modality: MediaModalityText, TokenCount: 10}},
+				TotalTokenCount:         20,
+			},
+		},
+		{
+			name:     "RisingPerChunkCounts",
+			perChunk: true,
+			chunks: []*GenerateContentResponseUsageMetadata{
+				{PromptTokenCount: 10, CandidatesTokenCount: 3},
+				{PromptTokenCount: 10, CandidatesTokenCount: 5},
+				{PromptTokenCount: 10, CandidatesTokenCount: 7},
+			},
+			want: &GenerateContentResponseUsageMetadata{PromptTokenCount: 10, CandidatesTokenCount: 15, TotalTokenCount: 25},
+		},
+		{
+			name:     "NonDecreasingPerChunkCounts",
+			perChunk: true,
+			chunks: []*GenerateContentResponseUsageMetadata{
+				{PromptTokenCount: 10, CandidatesTokenCount: 5, TotalTokenCount: 15},
+				{PromptTokenCount: 10, CandidatesTokenCount: 5, TotalTokenCount: 15},
+				{PromptTokenCount: 10, CandidatesTokenCount: 7, TotalTokenCount: 17},
+			},
+			want: &GenerateContentResponseUsageMetadata{PromptTokenCount: 10, CandidatesTokenCount: 17, TotalTokenCount: 27},
+		},
+		{
+			name: "MissingTotal",
+			chunks: []*GenerateContentResponseUsageMetadata{
+				{PromptTokenCount: 3, ToolUsePromptTokenCount: 2, CandidatesTokenCount: 1},
+				{PromptTokenCount: 3, ToolUsePromptTokenCount: 2, CandidatesTokenCount: 4},
+			},
+			want: &GenerateContentResponseUsageMetadata{PromptTokenCount: 3, ToolUsePromptTokenCount: 2, CandidatesTokenCount: 4, TotalTokenCount: 9},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usage := StreamUsage{PerChunk: tt.perChunk}
+			for _, chunk := range tt.chunks {
+				usage.Add(chunk)
+			}
+			got := usage.Usage()
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Usage() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStreamUsageDoesNotAlias(t *testing.T) {
+	var usage StreamUsage
+	chunk := &GenerateContentResponseUsageMetadata{
+		PromptTokenCount:    3,
+		PromptTokensDetails: []*ModalityTokenCount{{Modality: MediaModalityText, TokenCount: 3}},
+	}
+	usage.Add(chunk)
+	got := usage.Usage()
+	got.PromptTokenCount = 100
+	got.PromptTokensDetails[0].TokenCount = 100
+	if again := usage.Usage(); again.PromptTokenCount != 3 || again.PromptTokensDetails[0].TokenCount != 3 {
+		t.Errorf("Usage() = %+v after modifying a previous result, want the accumulated usage", again)
+	}
+}
+
+func TestCollectStreamUsage(t *testing.T) {
+	chunks := []string{
+		`{"candidates":[{"content":{"role":"model","parts":[{"text":"Hello"}]}}],"usageMetadata":{"promptTokenCount":3,"cachedContentTokenCount":2,"candidatesTokenCount":4}}`,
+		`{"candidates":[{"content":{"role":"model","parts":[{"text":", world"}]},"finishReason":"STOP"}],"usageMetadata":{"promptTokenCount":3,"candidatesTokenCount":5}}`,
+	}
+	models := newTestStreamModels(t, chunks, false)
+	got, err := CollectStream(models.GenerateContentStream(context.Background(), "gemini-test", Text("Hi"), nil))
+	if err != nil {
+		t.Fatalf("CollectStream() failed: %v", err)
+	}
+	want := &GenerateContentResponseUsageMetadata{PromptTokenCount: 3, CachedContentTokenCount: 2, CandidatesTokenCount: 5, TotalTokenCount: 8}
+	if diff := cmp.Diff(want, got.UsageMetadata); diff != "" {
+		t.Errorf("CollectStream() usage mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -12,8 +12,6 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Code generated by the Google Gen AI SDK generator DO NOT EDIT.
-
 package genai
 
 import (
@@ -124,11 +122,24 @@ func createAuthTokenConfigToVertex(ac *apiClient, fromObject map[string]any, par
 func createAuthTokenParametersToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
 	toObject = make(map[string]any)
 
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		fromConfig, err = createAuthTokenConfigToMldev(ac, fromConfig.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
 	return toObject, nil
 }
 
 func createAuthTokenParametersToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
 	toObject = make(map[string]any)
+	if getValueByPath(fromObject, []string{"config"}) != nil {
+		return nil, fmt.Errorf("config parameter is not supported in Vertex AI")
+	}
 
 	return toObject, nil
 }
@@ -136,6 +147,11 @@ func createAuthTokenParametersToVertex(ac *apiClient, fromObject map[string]any,
 func authTokenFromMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
 	toObject = make(map[string]any)
 
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		setValueByPath(toObject, []string{"name"}, fromName)
+	}
+
 	return toObject, nil
 }
 
@@ -0,0 +1,124 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/websocket"
+)
+
+func TestAuthTokensCreate(t *testing.T) {
+	ctx := context.Background()
+	expireTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	constraints := &LiveConnectConstraints{
+		Model:  "gemini-2.0-flash-live-001",
+		Config: &LiveConnectConfig{Temperature: Ptr[float32](0.5), SystemInstruction: NewContentFromText("Be brief.", RoleUser)},
+	}
+	wantSetup := map[string]any{
+		"model":             "models/gemini-2.0-flash-live-001",
+		"generationConfig":  map[string]any{"temperature": 0.5},
+		"systemInstruction": map[string]any{"parts": []any{map[string]any{"text": "Be brief."}}, "role": "user"},
+	}
+
+	tests := []struct {
+		name     string
+		config   *CreateAuthTokenConfig
+		wantBody map[string]any
+	}{
+		{
+			name: "nil config",
+		},
+		{
+			name:     "without constraints",
+			config:   &CreateAuthTokenConfig{ExpireTime: expireTime, Uses: 2, LockAdditionalFields: []string{"temperature"}},
+			wantBody: map[string]any{"expireTime": "2025-01-02T03:04:05Z", "uses": 2.0},
+		},
+		{
+			name:     "whole setup locked",
+			config:   &CreateAuthTokenConfig{LiveConnectConstraints: constraints},
+			wantBody: map[string]any{"bidiGenerateContentSetup": wantSetup},
+		},
+		{
+			name:   "set fields locked",
+			config: &CreateAuthTokenConfig{LiveConnectConstraints: constraints, LockAdditionalFields: []string{}},
+			wantBody: map[string]any{
+				"bidiGenerateContentSetup": wantSetup,
+				"fieldMask":                "generationConfig.temperature,model,systemInstruction.parts,systemInstruction.role",
+			},
+		},
+		{
+			name:   "additional fields locked",
+			config: &CreateAuthTokenConfig{LiveConnectConstraints: constraints, LockAdditionalFields: []string{"topK", "tools"}},
+			wantBody: map[string]any{
+				"bidiGenerateContentSetup": wantSetup,
+				"fieldMask":                "generationConfig.temperature,model,systemInstruction.parts,systemInstruction.role,generationConfig.topK,tools",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []batchesRequest
+			client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"name": "auth_tokens/abc"}`))
+			})
+			token, err := client.AuthTokens.Create(ctx, tt.config)
+			if err != nil {
+				t.Fatalf("Create() failed: %v", err)
+			}
+			if diff := cmp.Diff(&AuthToken{Name: "auth_tokens/abc"}, token); diff != "" {
+				t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+			}
+			want := []batchesRequest{{Method: http.MethodPost, Path: "/v1beta/auth_tokens", Body: tt.wantBody}}
+			if diff := cmp.Diff(want, requests); diff != "" {
+				t.Errorf("requests mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("vertex", func(t *testing.T) {
+		var requests []batchesRequest
+		client := newTestBatches(t, BackendGeminiAPI, &requests, func(w http.ResponseWriter, r *http.Request) {})
+		client.AuthTokens.apiClient.clientConfig.Backend = BackendVertexAI
+		if _, err := client.AuthTokens.Create(ctx, nil); err == nil {
+			t.Errorf("Create() succeeded, want error")
+		}
+		if len(requests) > 0 {
+			t.Errorf("Create() sent %d requests, want none", len(requests))
+		}
+	})
+}
+
+func TestLiveConnectAuthToken(t *testing.T) {
+	paths := make(chan string, 1)
+	client := newTestLiveClient(t, func(r *http.Request, conn *websocket.Conn) {
+		paths <- r.URL.Path + "?" + r.URL.RawQuery
+		readLiveMessages(conn)
+	})
+	client.Live.apiClient.clientConfig.APIKey = "auth_tokens/abc"
+	session, err := client.Live.Connect(context.Background(), "gemini-2.0-flash-live-001", nil)
+	if err != nil {
+		t.Fatalf("Connect() failed: %v", err)
+	}
+	defer session.Close()
+	want := "/ws/google.ai.generativelanguage.v1beta.GenerativeService.BidiGenerateContentConstrained?access_token=auth_tokens%2Fabc"
+	if got := <-paths; got != want {
+		t.Errorf("Connect() requested %q, want %q", got, want)
+	}
+}
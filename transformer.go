@@ -17,7 +17,9 @@ package genai
 import (
 	"fmt"
 	"log"
+	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -58,7 +60,10 @@ func tModel(ac *apiClient, origin any) (string, error) {
 			return "", fmt.Errorf("tModel: model is empty")
 		}
 		if ac.clientConfig.Backend == BackendVertexAI {
-			if strings.HasPrefix(model, "projects/") || strings.HasPrefix(model, "models/") || strings.HasPrefix(model, "publishers/") {
+			if strings.HasPrefix(model, "tunedModels/") {
+				return "", fmt.Errorf("tModel: %s is a tuned model of the Gemini API, use the endpoint of the tuned model on Vertex AI", model)
+			}
+			if strings.HasPrefix(model, "projects/") || strings.HasPrefix(model, "models/") || strings.HasPrefix(model, "publishers/") || strings.HasPrefix(model, "endpoints/") {
 				return model, nil
 			} else if strings.Contains(model, "/") {
 				parts := strings.SplitN(model, "/", 2)
@@ -67,6 +72,9 @@ func tModel(ac *apiClient, origin any) (string, error) {
 				return fmt.Sprintf("publishers/google/models/%s", model), nil
 			}
 		} else {
+			if strings.HasPrefix(model, "projects/") || strings.HasPrefix(model, "endpoints/") {
+				return "", fmt.Errorf("tModel: %s is a Vertex AI resource. You can choose to use Vertex AI by setting ClientConfig.Backend to BackendVertexAI", model)
+			}
 			if strings.HasPrefix(model, "models/") || strings.HasPrefix(model, "tunedModels/") {
 				return model, nil
 			} else {
@@ -89,6 +97,8 @@ func tModelFullName(ac *apiClient, origin any) (string, error) {
 			return fmt.Sprintf("projects/%s/locations/%s/%s", ac.clientConfig.Project, ac.clientConfig.Location, name), nil
 		} else if strings.HasPrefix(name, "models/") && ac.clientConfig.Backend == BackendVertexAI {
 			return fmt.Sprintf("projects/%s/locations/%s/publishers/google/%s", ac.clientConfig.Project, ac.clientConfig.Location, name), nil
+		} else if strings.HasPrefix(name, "endpoints/") && ac.clientConfig.Backend == BackendVertexAI {
+			return fmt.Sprintf("projects/%s/locations/%s/%s", ac.clientConfig.Project, ac.clientConfig.Location, name), nil
 		} else {
 			return name, nil
 		}
@@ -273,3 +283,199 @@ func tAudioBlob(ac *apiClient, blob any) (any, error) {
 		return nil, fmt.Errorf("tAudioBlob: blob is not a map")
 	}
 }
+
+func tTuningJobName(ac *apiClient, name any) (string, error) {
+	if ac.clientConfig.Backend == BackendVertexAI {
+		return tResourceName(ac, name.(string), "tuningJobs", 2), nil
+	}
+	return tResourceName(ac, name.(string), "tunedModels", 2), nil
+}
+
+// tTuningJobStatus maps the state of a tuned model of the Gemini API to the state
+// of a tuning job.
+func tTuningJobStatus(_ *apiClient, status any) (any, error) {
+	switch status {
+	case "STATE_UNSPECIFIED":
+		return JobStateUnspecified, nil
+	case "CREATING":
+		return JobStateRunning, nil
+	case "ACTIVE":
+		return JobStateSucceeded, nil
+	case "FAILED":
+		return JobStateFailed, nil
+	default:
+		return status, nil
+	}
+}
+
+// tTuningText concatenates the text parts of a content or of a list of contents, as
+// the Gemini API takes the input and the output of tuning examples as text.
+func tTuningText(_ *apiClient, contents any) (any, error) {
+	var list []any
+	switch c := contents.(type) {
+	case []any:
+		list = c
+	case map[string]any:
+		list = []any{c}
+	default:
+		return nil, fmt.Errorf("unsupported tuning example content type: %T", contents)
+	}
+	var sb strings.Builder
+	for _, content := range list {
+		parts, _ := getValueByPath(content.(map[string]any), []string{"parts"}).([]any)
+		for _, part := range parts {
+			if text, ok := getValueByPath(part.(map[string]any), []string{"text"}).(string); ok {
+				sb.WriteString(text)
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// tTuningMethodSpec moves the dataset URIs and hyperparameters of a Vertex AI
+// tuning job request to the tuning spec of the tuning method.
+func tTuningMethodSpec(_ *apiClient, request map[string]any, method any) (map[string]any, error) {
+	switch method {
+	case string(TuningMethodSupervisedFineTuning):
+		if getValueByPath(request, []string{"supervisedTuningSpec", "hyperParameters", "beta"}) != nil {
+			return nil, fmt.Errorf("beta parameter is only supported with the %s method", TuningMethodPreferenceTuning)
+		}
+	case string(TuningMethodPreferenceTuning):
+		if spec, ok := request["supervisedTuningSpec"]; ok {
+			request["preferenceOptimizationSpec"] = spec
+			delete(request, "supervisedTuningSpec")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported tuning method: %v", method)
+	}
+	return request, nil
+}
+
+func tBatchJobName(ac *apiClient, name any) (string, error) {
+	if ac.clientConfig.Backend == BackendVertexAI {
+		return tResourceName(ac, name.(string), "batchPredictionJobs", 2), nil
+	}
+	return tResourceName(ac, name.(string), "batches", 2), nil
+}
+
+// tJobState maps the state of a batch job of the Gemini API to the state of a job.
+func tJobState(_ *apiClient, state any) (any, error) {
+	switch state {
+	case "BATCH_STATE_UNSPECIFIED":
+		return JobStateUnspecified, nil
+	case "BATCH_STATE_PENDING":
+		return JobStatePending, nil
+	case "BATCH_STATE_RUNNING":
+		return JobStateRunning, nil
+	case "BATCH_STATE_SUCCEEDED":
+		return JobStateSucceeded, nil
+	case "BATCH_STATE_FAILED":
+		return JobStateFailed, nil
+	case "BATCH_STATE_CANCELLED":
+		return JobStateCancelled, nil
+	case "BATCH_STATE_EXPIRED":
+		return JobStateExpired, nil
+	default:
+		return state, nil
+	}
+}
+
+// tBatchJobSource checks that a single source of a batch job is set, and infers the
+// format of Cloud Storage and BigQuery sources if unset.
+func tBatchJobSource(_ *apiClient, src any) (any, error) {
+	s, ok := src.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("tBatchJobSource: unsupported source type: %T", src)
+	}
+	sources := 0
+	for _, key := range []string{"gcsUri", "bigqueryUri", "fileName", "inlinedRequests"} {
+		if getValueByPath(s, []string{key}) != nil {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, fmt.Errorf("tBatchJobSource: exactly one of GCSURI, BigqueryURI, FileName and InlinedRequests must be set")
+	}
+	if getValueByPath(s, []string{"format"}) == nil {
+		if getValueByPath(s, []string{"gcsUri"}) != nil {
+			s["format"] = "jsonl"
+		} else if getValueByPath(s, []string{"bigqueryUri"}) != nil {
+			s["format"] = "bigquery"
+		}
+	}
+	return s, nil
+}
+
+// tBatchJobDestination checks that a single destination of a batch job is set, and
+// infers its format from the URI if unset.
+func tBatchJobDestination(_ *apiClient, dest any) (any, error) {
+	d, ok := dest.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("tBatchJobDestination: unsupported destination type: %T", dest)
+	}
+	gcsURI := getValueByPath(d, []string{"gcsUri"})
+	bigqueryURI := getValueByPath(d, []string{"bigqueryUri"})
+	if (gcsURI == nil) == (bigqueryURI == nil) {
+		return nil, fmt.Errorf("tBatchJobDestination: exactly one of GCSURI and BigqueryURI must be set")
+	}
+	if getValueByPath(d, []string{"format"}) == nil {
+		if gcsURI != nil {
+			d["format"] = "jsonl"
+		} else {
+			d["format"] = "bigquery"
+		}
+	}
+	return d, nil
+}
+
+// tAuthTokenSetup adapts the Live API setup of a create auth token request body
+// to the AuthToken service, and sets the field mask locking the fields of the
+// setup as configured by config.LockAdditionalFields.
+func tAuthTokenSetup(body map[string]any, config *CreateAuthTokenConfig) map[string]any {
+	delete(body, "fieldMask")
+	bidiSetup, _ := body["bidiGenerateContentSetup"].(map[string]any)
+	setup, _ := bidiSetup["setup"].(map[string]any)
+	if len(setup) == 0 {
+		delete(body, "bidiGenerateContentSetup")
+		return body
+	}
+	// The AuthToken service expects the setup itself, not a setup message.
+	body["bidiGenerateContentSetup"] = setup
+	if config.LockAdditionalFields == nil {
+		// The whole setup is locked.
+		return body
+	}
+
+	var fields []string
+	for k, v := range setup {
+		if m, ok := v.(map[string]any); ok && len(m) > 0 {
+			for kk := range m {
+				fields = append(fields, k+"."+kk)
+			}
+		} else {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	generationConfigFields := jsonFieldNames(reflect.TypeOf(GenerationConfig{}))
+	for _, field := range config.LockAdditionalFields {
+		if generationConfigFields[field] {
+			field = "generationConfig." + field
+		}
+		fields = append(fields, field)
+	}
+	body["fieldMask"] = strings.Join(fields, ",")
+	return body
+}
+
+// jsonFieldNames returns the JSON names of the fields of a struct type.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
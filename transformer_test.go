@@ -64,6 +64,26 @@ func TestModelTransformer(t *testing.T) {
 			want:         "projects/test-project/locations/test-location/publishers/google/models/gemini-2.0-flash",
 			wantFullName: "projects/test-project/locations/test-location/publishers/google/models/gemini-2.0-flash",
 		},
+		{
+			name:         "VertexAI_Model_Endpoint",
+			backend:      BackendVertexAI,
+			input:        "endpoints/123",
+			want:         "endpoints/123",
+			wantFullName: "projects/test-project/locations/test-location/endpoints/123",
+		},
+		{
+			name:         "VertexAI_Model_Endpoint_Project_Prefix",
+			backend:      BackendVertexAI,
+			input:        "projects/test-project/locations/europe-west4/endpoints/123",
+			want:         "projects/test-project/locations/europe-west4/endpoints/123",
+			wantFullName: "projects/test-project/locations/europe-west4/endpoints/123",
+		},
+		{
+			name:    "VertexAI_Model_GeminiAPI_TunedModel",
+			backend: BackendVertexAI,
+			input:   "tunedModels/your-tuned-model",
+			wantErr: true,
+		},
 
 		{
 			name:         "GoogleAI_Model_Short",
@@ -86,6 +106,12 @@ func TestModelTransformer(t *testing.T) {
 			want:         "tunedModels/your-tuned-model",
 			wantFullName: "tunedModels/your-tuned-model",
 		},
+		{
+			name:    "GoogleAI_Model_VertexAI_Endpoint",
+			backend: BackendGeminiAPI,
+			input:   "projects/test-project/locations/test-location/endpoints/123",
+			wantErr: true,
+		},
 		{
 			name:    "Empty_Model",
 			backend: BackendVertexAI,
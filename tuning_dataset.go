@@ -0,0 +1,428 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// charsPerToken is the approximate number of characters of a token of text.
+	charsPerToken = 4
+	// tokensPerMediaPart is the approximate number of tokens of an image part.
+	tokensPerMediaPart = 258
+)
+
+// TuningExample is an example of a tuning dataset: the contents given to the model
+// and the content the tuned model should respond with.
+type TuningExample struct {
+	// Required. The conversation given to the model. The roles must alternate,
+	// starting and ending with [RoleUser]. An empty role defaults to RoleUser.
+	Input []*Content `json:"input,omitempty"`
+	// Required. The expected response of the model. An empty role defaults to
+	// [RoleModel].
+	Output *Content `json:"output,omitempty"`
+}
+
+// EstimateTokens returns a rough estimate of the number of tokens of the example,
+// counting a token per 4 characters of text and 258 tokens per media part. Use
+// [Models.CountTokens] for exact counts.
+func (e *TuningExample) EstimateTokens() int {
+	return estimateTokens(e.Input) + estimateTokens([]*Content{e.Output})
+}
+
+// estimateTokens returns a rough estimate of the number of tokens of the contents.
+func estimateTokens(contents []*Content) int {
+	tokens := 0
+	for _, content := range contents {
+		if content == nil {
+			continue
+		}
+		for _, part := range content.Parts {
+			switch {
+			case part == nil:
+			case part.Text != "":
+				tokens += (utf8.RuneCountInString(part.Text) + charsPerToken - 1) / charsPerToken
+			case part.InlineData != nil || part.FileData != nil:
+				tokens += tokensPerMediaPart
+			}
+		}
+	}
+	return tokens
+}
+
+// validate checks that the example can be used to tune a model on the backend.
+func (e *TuningExample) validate(backend Backend) error {
+	if err := validateTuningInput(backend, e.Input); err != nil {
+		return err
+	}
+	if e.Output == nil {
+		return fmt.Errorf("output is nil")
+	}
+	if err := validateTuningContent(backend, e.Output, RoleModel, RoleModel); err != nil {
+		return fmt.Errorf("output: %w", err)
+	}
+	return nil
+}
+
+// validateTuningInput checks that the roles of the input of an example alternate,
+// starting and ending with RoleUser.
+func validateTuningInput(backend Backend, input []*Content) error {
+	if len(input) == 0 {
+		return fmt.Errorf("input is empty")
+	}
+	if backend != BackendVertexAI && len(input) > 1 {
+		return fmt.Errorf("input has %d contents, but the Gemini API only supports single-turn examples", len(input))
+	}
+	for i, content := range input {
+		want := RoleUser
+		if i%2 == 1 {
+			want = RoleModel
+		}
+		if err := validateTuningContent(backend, content, RoleUser, want); err != nil {
+			return fmt.Errorf("input %d: %w", i, err)
+		}
+	}
+	if len(input)%2 == 0 {
+		return fmt.Errorf("input ends with a %s content, want a %s content", RoleModel, RoleUser)
+	}
+	return nil
+}
+
+// PreferenceTuningExample is an example of a preference tuning dataset: the
+// contents given to the model, and a preferred and a dispreferred response. See
+// [TuningMethodPreferenceTuning].
+type PreferenceTuningExample struct {
+	// Required. The conversation given to the model. The roles must alternate,
+	// starting and ending with [RoleUser]. An empty role defaults to RoleUser.
+	Input []*Content `json:"input,omitempty"`
+	// Required. The response the tuned model should prefer. An empty role defaults
+	// to [RoleModel].
+	Chosen *Content `json:"chosen,omitempty"`
+	// Required. The response the tuned model should avoid. An empty role defaults to
+	// [RoleModel].
+	Rejected *Content `json:"rejected,omitempty"`
+}
+
+// EstimateTokens returns a rough estimate of the number of tokens of the example,
+// see [TuningExample.EstimateTokens].
+func (e *PreferenceTuningExample) EstimateTokens() int {
+	return estimateTokens(e.Input) + estimateTokens([]*Content{e.Chosen, e.Rejected})
+}
+
+// validate checks that the example can be used for preference tuning, which only
+// Vertex AI supports.
+func (e *PreferenceTuningExample) validate() error {
+	if err := validateTuningInput(BackendVertexAI, e.Input); err != nil {
+		return err
+	}
+	if e.Chosen == nil {
+		return fmt.Errorf("chosen response is nil")
+	}
+	if err := validateTuningContent(BackendVertexAI, e.Chosen, RoleModel, RoleModel); err != nil {
+		return fmt.Errorf("chosen response: %w", err)
+	}
+	if e.Rejected == nil {
+		return fmt.Errorf("rejected response is nil")
+	}
+	if err := validateTuningContent(BackendVertexAI, e.Rejected, RoleModel, RoleModel); err != nil {
+		return fmt.Errorf("rejected response: %w", err)
+	}
+	return nil
+}
+
+func validateTuningContent(backend Backend, content *Content, defaultRole, wantRole string) error {
+	if content == nil {
+		return fmt.Errorf("content is nil")
+	}
+	role := content.Role
+	if role == "" {
+		role = defaultRole
+	}
+	if role != wantRole {
+		return fmt.Errorf("role is %q, want %q", role, wantRole)
+	}
+	if len(content.Parts) == 0 {
+		return fmt.Errorf("content has no parts")
+	}
+	for i, part := range content.Parts {
+		switch {
+		case part == nil:
+			return fmt.Errorf("part %d is nil", i)
+		case part.Text != "":
+		case backend != BackendVertexAI:
+			return fmt.Errorf("part %d is not text, but the Gemini API only supports text examples", i)
+		case part.FileData != nil:
+			if !strings.HasPrefix(part.FileData.FileURI, "gs://") {
+				return fmt.Errorf("part %d references %q, but tuning datasets only support gs:// URIs", i, part.FileData.FileURI)
+			}
+		case part.InlineData != nil:
+			return fmt.Errorf("part %d has inline data, which tuning datasets do not support, upload it to Cloud Storage", i)
+		default:
+			return fmt.Errorf("part %d has no text or file data", i)
+		}
+	}
+	return nil
+}
+
+// vertexDatasetPattern matches the resource names of Vertex AI datasets.
+var vertexDatasetPattern = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/datasets/[^/]+$`)
+
+// validateTuningSource checks that exactly one of the sources of a dataset is set
+// and well-formed.
+func validateTuningSource(gcsURI, vertexDatasetResource string, hasExamples bool) error {
+	sources := 0
+	for _, set := range []bool{gcsURI != "", vertexDatasetResource != "", hasExamples} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("exactly one of the GCS URI, the Vertex AI dataset resource and the examples must be set, got %d", sources)
+	}
+	if gcsURI != "" {
+		if _, _, err := parseGCSURI(gcsURI); err != nil {
+			return err
+		}
+	}
+	if vertexDatasetResource != "" && !vertexDatasetPattern.MatchString(vertexDatasetResource) {
+		return fmt.Errorf("%q is not a Vertex AI dataset, want projects/{project}/locations/{location}/datasets/{dataset}", vertexDatasetResource)
+	}
+	return nil
+}
+
+// validate checks that the dataset can be used to tune a model on the backend.
+func (d *TuningDataset) validate(backend Backend) error {
+	if d == nil {
+		return fmt.Errorf("training dataset is nil")
+	}
+	if err := validateTuningSource(d.GCSURI, d.VertexDatasetResource, len(d.Examples) > 0); err != nil {
+		return fmt.Errorf("training dataset: %w", err)
+	}
+	if backend == BackendVertexAI && len(d.Examples) > 0 {
+		return fmt.Errorf("training dataset: Vertex AI does not support inline examples, upload them with Files.UploadTuningDataset and set TuningDataset.GCSURI")
+	}
+	if backend != BackendVertexAI && len(d.Examples) == 0 {
+		return fmt.Errorf("training dataset: the Gemini API only supports inline examples")
+	}
+	for i, e := range d.Examples {
+		if e == nil {
+			return fmt.Errorf("training dataset: example %d is nil", i)
+		}
+		if err := e.validate(backend); err != nil {
+			return fmt.Errorf("training dataset: example %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// validate checks that the validation dataset has a single well-formed source.
+func (d *TuningValidationDataset) validate() error {
+	if err := validateTuningSource(d.GCSURI, d.VertexDatasetResource, false); err != nil {
+		return fmt.Errorf("validation dataset: %w", err)
+	}
+	return nil
+}
+
+// TuningDatasetBuilder collects validated tuning examples and writes them in the
+// JSONL format of the tuning API of a backend:
+//
+//	b := genai.NewTuningDatasetBuilder(genai.BackendVertexAI)
+//	err := b.Add(&genai.TuningExample{
+//		Input:  []*genai.Content{genai.NewContentFromText("Why is the sky blue?", genai.RoleUser)},
+//		Output: genai.NewContentFromText("Because of Rayleigh scattering.", genai.RoleModel),
+//	})
+//	fileData, err := client.Files.UploadTuningDataset(ctx, b, "train.jsonl")
+//	job, err := client.Tunings.Tune(ctx, "gemini-2.0-flash-001", &genai.TuningDataset{GCSURI: fileData.FileURI}, nil)
+//
+// A builder holds either supervised examples, added with Add, or preference
+// examples, added with AddPreference.
+type TuningDatasetBuilder struct {
+	backend            Backend
+	examples           []*TuningExample
+	preferenceExamples []*PreferenceTuningExample
+}
+
+// NewTuningDatasetBuilder returns an empty builder of a dataset used to tune models
+// on the given backend.
+func NewTuningDatasetBuilder(backend Backend) *TuningDatasetBuilder {
+	return &TuningDatasetBuilder{backend: backend}
+}
+
+// Add validates the examples and adds them to the dataset. If an example is
+// invalid, an error naming it is returned and none of the examples are added.
+func (b *TuningDatasetBuilder) Add(examples ...*TuningExample) error {
+	if len(b.preferenceExamples) > 0 {
+		return fmt.Errorf("dataset holds preference examples, supervised examples cannot be added")
+	}
+	for i, e := range examples {
+		if e == nil {
+			return fmt.Errorf("example %d is nil", len(b.examples)+i)
+		}
+		if err := e.validate(b.backend); err != nil {
+			return fmt.Errorf("example %d: %w", len(b.examples)+i, err)
+		}
+	}
+	b.examples = append(b.examples, examples...)
+	return nil
+}
+
+// AddPreference validates the preference examples and adds them to the dataset,
+// like Add. Preference tuning is only supported on BackendVertexAI.
+func (b *TuningDatasetBuilder) AddPreference(examples ...*PreferenceTuningExample) error {
+	if b.backend != BackendVertexAI {
+		return fmt.Errorf("preference tuning is only supported in Vertex AI")
+	}
+	if len(b.examples) > 0 {
+		return fmt.Errorf("dataset holds supervised examples, preference examples cannot be added")
+	}
+	for i, e := range examples {
+		if e == nil {
+			return fmt.Errorf("example %d is nil", len(b.preferenceExamples)+i)
+		}
+		if err := e.validate(); err != nil {
+			return fmt.Errorf("example %d: %w", len(b.preferenceExamples)+i, err)
+		}
+	}
+	b.preferenceExamples = append(b.preferenceExamples, examples...)
+	return nil
+}
+
+// Dataset returns a dataset holding the examples inline, as expected by
+// [Tunings.Tune] on BackendGeminiAPI. On BackendVertexAI, upload the examples
+// with [Files.UploadTuningDataset] instead.
+func (b *TuningDatasetBuilder) Dataset() *TuningDataset {
+	return &TuningDataset{Examples: b.examples}
+}
+
+// Examples returns the examples of the dataset.
+func (b *TuningDatasetBuilder) Examples() []*TuningExample {
+	return b.examples
+}
+
+// PreferenceExamples returns the preference examples of the dataset.
+func (b *TuningDatasetBuilder) PreferenceExamples() []*PreferenceTuningExample {
+	return b.preferenceExamples
+}
+
+// Len returns the number of examples of the dataset.
+func (b *TuningDatasetBuilder) Len() int {
+	return len(b.examples) + len(b.preferenceExamples)
+}
+
+// EstimateTokens returns a rough estimate of the number of tokens of the dataset,
+// see [TuningExample.EstimateTokens].
+func (b *TuningDatasetBuilder) EstimateTokens() int {
+	tokens := 0
+	for _, e := range b.examples {
+		tokens += e.EstimateTokens()
+	}
+	for _, e := range b.preferenceExamples {
+		tokens += e.EstimateTokens()
+	}
+	return tokens
+}
+
+// WriteJSONL writes the dataset to w, an example per line. On BackendVertexAI,
+// every line holds the contents of the conversation, ending with the output:
+//
+//	{"contents":[{"role":"user","parts":[{"text":"..."}]},{"role":"model","parts":[{"text":"..."}]}]}
+//
+// On BackendGeminiAPI, every line holds the text of the input and of the output:
+//
+//	{"textInput":"...","output":"..."}
+//
+// Preference examples are written with the chosen response scored 1 and the
+// rejected response scored 0:
+//
+//	{"contents":[...],"completions":[{"score":1,"completion":{...}},{"score":0,"completion":{...}}]}
+func (b *TuningDatasetBuilder) WriteJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for i, e := range b.preferenceExamples {
+		line := map[string]any{
+			"contents": tuningInputContents(e.Input),
+			"completions": []map[string]any{
+				{"score": 1, "completion": &Content{Parts: e.Chosen.Parts, Role: RoleModel}},
+				{"score": 0, "completion": &Content{Parts: e.Rejected.Parts, Role: RoleModel}},
+			},
+		}
+		if err := enc.Encode(line); err != nil {
+			return fmt.Errorf("example %d: %w", i, err)
+		}
+	}
+	for i, e := range b.examples {
+		var line any
+		if b.backend == BackendVertexAI {
+			contents := append(tuningInputContents(e.Input), &Content{Parts: e.Output.Parts, Role: RoleModel})
+			line = map[string]any{"contents": contents}
+		} else {
+			line = map[string]any{"textInput": contentText(e.Input[0]), "output": contentText(e.Output)}
+		}
+		if err := enc.Encode(line); err != nil {
+			return fmt.Errorf("example %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// tuningInputContents returns the input of an example with explicit alternating
+// roles.
+func tuningInputContents(input []*Content) []*Content {
+	contents := make([]*Content, 0, len(input)+1)
+	for i, content := range input {
+		role := RoleUser
+		if i%2 == 1 {
+			role = RoleModel
+		}
+		contents = append(contents, &Content{Parts: content.Parts, Role: role})
+	}
+	return contents
+}
+
+// contentText concatenates the text parts of a content.
+func contentText(content *Content) string {
+	var sb strings.Builder
+	for _, part := range content.Parts {
+		sb.WriteString(part.Text)
+	}
+	return sb.String()
+}
+
+// UploadTuningDataset writes the dataset in the JSONL format and uploads it to the
+// Cloud Storage staging location of the client, see [ClientConfig.GCSStagingURI].
+// The object name defaults to the SHA-256 hash of the JSONL followed by ".jsonl".
+func (m Files) UploadTuningDataset(ctx context.Context, dataset *TuningDatasetBuilder, objectName string) (*FileData, error) {
+	if dataset == nil || dataset.Len() == 0 {
+		return nil, fmt.Errorf("tuning dataset is empty")
+	}
+	var buf bytes.Buffer
+	if err := dataset.WriteJSONL(&buf); err != nil {
+		return nil, err
+	}
+	if objectName == "" {
+		sum := sha256.Sum256(buf.Bytes())
+		objectName = hex.EncodeToString(sum[:]) + ".jsonl"
+	}
+	return m.uploadToGCS(ctx, &buf, int64(buf.Len()), objectName, "application/jsonl")
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTuningDatasetBuilderAdd(t *testing.T) {
+	text := func(s, role string) *Content { return NewContentFromText(s, Role(role)) }
+	image := &Content{Parts: []*Part{NewPartFromURI("gs://bucket/cat.png", "image/png"), NewPartFromText("What is it?")}}
+	for _, tt := range []struct {
+		name    string
+		backend Backend
+		example *TuningExample
+		wantErr string
+	}{
+		{name: "SingleTurn", backend: BackendGeminiAPI, example: &TuningExample{Input: []*Content{{Parts: []*Part{{Text: "1"}}}}, Output: &Content{Parts: []*Part{{Text: "2"}}}}},
+		{name: "MultiTurn", backend: BackendVertexAI, example: &TuningExample{Input: []*Content{text("a", RoleUser), text("b", RoleModel), text("c", RoleUser)}, Output: text("d", RoleModel)}},
+		{name: "GCSFile", backend: BackendVertexAI, example: &TuningExample{Input: []*Content{image}, Output: text("A cat.", RoleModel)}},
+		{name: "MultiTurnGeminiAPI", backend: BackendGeminiAPI, example: &TuningExample{Input: []*Content{text("a", RoleUser), text("b", RoleModel), text("c", RoleUser)}, Output: text("d", RoleModel)}, wantErr: "single-turn"},
+		{name: "FileGeminiAPI", backend: BackendGeminiAPI, example: &TuningExample{Input: []*Content{image}, Output: text("A cat.", RoleModel)}, wantErr: "text examples"},
+		{name: "InlineData", backend: BackendVertexAI, example: &TuningExample{Input: []*Content{{Parts: []*Part{NewPartFromBytes(pngHeader, "image/png")}}}, Output: text("A cat.", RoleModel)}, wantErr: "inline data"},
+		{name: "HTTPFile", backend: BackendVertexAI, example: &TuningExample{Input: []*Content{{Parts: []*Part{NewPartFromURI("https://example.com/cat.png", "image/png")}}}, Output: text("A cat.", RoleModel)}, wantErr: "gs:// URIs"},
+		{name: "EndsWithModel", backend: BackendVertexAI, example: &TuningExample{Input: []*Content{text("a", RoleUser), text("b", RoleModel)}, Output: text("d", RoleModel)}, wantErr: "ends with a model content"},
+		{name: "WrongRole", backend: BackendVertexAI, example: &TuningExample{Input: []*Content{text("a", RoleModel)}, Output: text("d", RoleModel)}, wantErr: `input 0: role is "model"`},
+		{name: "NoOutput", backend: BackendVertexAI, example: &TuningExample{Input: []*Content{text("a", RoleUser)}}, wantErr: "output is nil"},
+		{name: "EmptyOutput", backend: BackendVertexAI, example: &TuningExample{Input: []*Content{text("a", RoleUser)}, Output: &Content{}}, wantErr: "output: content has no parts"},
+		{name: "NoInput", backend: BackendVertexAI, example: &TuningExample{Output: text("d", RoleModel)}, wantErr: "input is empty"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewTuningDatasetBuilder(tt.backend)
+			err := b.Add(tt.example)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Add() failed: %v", err)
+				}
+				if len(b.Examples()) != 1 {
+					t.Errorf("Add() added %d examples, want 1", len(b.Examples()))
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Add() error = %v, want an error containing %q", err, tt.wantErr)
+			}
+			if len(b.Examples()) != 0 {
+				t.Errorf("Add() added an invalid example")
+			}
+		})
+	}
+}
+
+func TestTuningDatasetBuilderWriteJSONL(t *testing.T) {
+	examples := []*TuningExample{
+		{Input: []*Content{NewContentFromText("Hi", RoleUser)}, Output: NewContentFromText("Hello!", RoleModel)},
+		{Input: []*Content{{Parts: []*Part{{Text: "2+"}, {Text: "2?"}}}}, Output: &Content{Parts: []*Part{{Text: "4"}}}},
+	}
+
+	gemini := NewTuningDatasetBuilder(BackendGeminiAPI)
+	if err := gemini.Add(examples...); err != nil {
+		t.Fatal(err)
+	}
+	var sb strings.Builder
+	if err := gemini.WriteJSONL(&sb); err != nil {
+		t.Fatalf("WriteJSONL() failed: %v", err)
+	}
+	want := `{"output":"Hello!","textInput":"Hi"}
+{"output":"4","textInput":"2+2?"}
+`
+	if diff := cmp.Diff(want, sb.String()); diff != "" {
+		t.Errorf("WriteJSONL() mismatch (-want +got):\n%s", diff)
+	}
+
+	vertex := NewTuningDatasetBuilder(BackendVertexAI)
+	if err := vertex.Add(examples...); err != nil {
+		t.Fatal(err)
+	}
+	sb.Reset()
+	if err := vertex.WriteJSONL(&sb); err != nil {
+		t.Fatalf("WriteJSONL() failed: %v", err)
+	}
+	want = `{"contents":[{"parts":[{"text":"Hi"}],"role":"user"},{"parts":[{"text":"Hello!"}],"role":"model"}]}
+{"contents":[{"parts":[{"text":"2+"},{"text":"2?"}],"role":"user"},{"parts":[{"text":"4"}],"role":"model"}]}
+`
+	if diff := cmp.Diff(want, sb.String()); diff != "" {
+		t.Errorf("WriteJSONL() mismatch (-want +got):\n%s", diff)
+	}
+
+	// "Hi" and "Hello!" count 1 and 2 tokens, "2+", "2?" and "4" a token each.
+	if got, want := vertex.EstimateTokens(), 6; got != want {
+		t.Errorf("EstimateTokens() = %d, want %d", got, want)
+	}
+}
+
+func TestFilesUploadTuningDataset(t *testing.T) {
+	ctx := context.Background()
+	var gotName, gotContentType, gotData string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/upload/storage/v1/b/staging/o" {
+			http.NotFound(w, r)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		gotName, gotContentType, gotData = r.URL.Query().Get("name"), r.Header.Get("Content-Type"), string(data)
+		json.NewEncoder(w).Encode(map[string]string{"bucket": "staging", "name": gotName})
+	}))
+	defer ts.Close()
+	defer func(u string) { gcsBaseURL = u }(gcsBaseURL)
+	gcsBaseURL = ts.URL + "/"
+
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:       BackendGeminiAPI,
+		APIKey:        "test-api-key",
+		HTTPClient:    ts.Client(),
+		GCSStagingURI: "gs://staging/tuning",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := NewTuningDatasetBuilder(BackendVertexAI)
+	if _, err := client.Files.UploadTuningDataset(ctx, b, ""); err == nil {
+		t.Errorf("UploadTuningDataset() of an empty dataset succeeded, want error")
+	}
+	if err := b.Add(&TuningExample{Input: []*Content{NewContentFromText("Hi", RoleUser)}, Output: NewContentFromText("Hello!", RoleModel)}); err != nil {
+		t.Fatal(err)
+	}
+	fileData, err := client.Files.UploadTuningDataset(ctx, b, "train.jsonl")
+	if err != nil {
+		t.Fatalf("UploadTuningDataset() failed: %v", err)
+	}
+	if diff := cmp.Diff(&FileData{FileURI: "gs://staging/tuning/train.jsonl", MIMEType: "application/jsonl"}, fileData); diff != "" {
+		t.Errorf("UploadTuningDataset() mismatch (-want +got):\n%s", diff)
+	}
+	var sb strings.Builder
+	b.WriteJSONL(&sb)
+	if gotName != "tuning/train.jsonl" || gotContentType != "application/jsonl" || gotData != sb.String() {
+		t.Errorf("uploaded object %q (%s) = %q, want tuning/train.jsonl (application/jsonl) = %q", gotName, gotContentType, gotData, sb.String())
+	}
+}
+
+func TestTuningDatasetBuilderAddPreference(t *testing.T) {
+	example := &PreferenceTuningExample{
+		Input:    []*Content{NewContentFromText("Write a haiku", RoleUser)},
+		Chosen:   NewContentFromText("An old silent pond", RoleModel),
+		Rejected: NewContentFromText("No.", RoleModel),
+	}
+
+	b := NewTuningDatasetBuilder(BackendVertexAI)
+	if err := b.AddPreference(example); err != nil {
+		t.Fatalf("AddPreference() failed: %v", err)
+	}
+	if err := b.Add(&TuningExample{Input: example.Input, Output: example.Chosen}); err == nil {
+		t.Errorf("Add() to a preference dataset succeeded, want error")
+	}
+	if err := b.AddPreference(&PreferenceTuningExample{Input: example.Input, Chosen: example.Chosen}); err == nil || !strings.Contains(err.Error(), "example 1: rejected response is nil") {
+		t.Errorf("AddPreference() error = %v, want a missing rejected response error", err)
+	}
+	if b.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", b.Len())
+	}
+	// The input counts 4 tokens, the chosen response 5 and the rejected one 1.
+	if got, want := b.EstimateTokens(), 10; got != want {
+		t.Errorf("EstimateTokens() = %d, want %d", got, want)
+	}
+
+	var sb strings.Builder
+	if err := b.WriteJSONL(&sb); err != nil {
+		t.Fatalf("WriteJSONL() failed: %v", err)
+	}
+	want := `{"completions":[{"completion":{"parts":[{"text":"An old silent pond"}],"role":"model"},"score":1},{"completion":{"parts":[{"text":"No."}],"role":"model"},"score":0}],"contents":[{"parts":[{"text":"Write a haiku"}],"role":"user"}]}
+`
+	if diff := cmp.Diff(want, sb.String()); diff != "" {
+		t.Errorf("WriteJSONL() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := NewTuningDatasetBuilder(BackendGeminiAPI).AddPreference(example); err == nil {
+		t.Errorf("AddPreference() on BackendGeminiAPI succeeded, want error")
+	}
+}
@@ -0,0 +1,873 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+)
+
+func getTuningJobParametersToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		fromName, err = tTuningJobName(ac, fromName)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"_url", "name"}, fromName)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func listTuningJobsConfigToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromPageSize := getValueByPath(fromObject, []string{"pageSize"})
+	if fromPageSize != nil {
+		setValueByPath(parentObject, []string{"_query", "pageSize"}, fromPageSize)
+	}
+
+	fromPageToken := getValueByPath(fromObject, []string{"pageToken"})
+	if fromPageToken != nil {
+		setValueByPath(parentObject, []string{"_query", "pageToken"}, fromPageToken)
+	}
+
+	fromFilter := getValueByPath(fromObject, []string{"filter"})
+	if fromFilter != nil {
+		setValueByPath(parentObject, []string{"_query", "filter"}, fromFilter)
+	}
+
+	return toObject, nil
+}
+
+func listTuningJobsParametersToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		fromConfig, err = listTuningJobsConfigToMldev(ac, fromConfig.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func tuningExampleToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromTextInput := getValueByPath(fromObject, []string{"input"})
+	if fromTextInput != nil {
+		fromTextInput, err = tTuningText(ac, fromTextInput)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"textInput"}, fromTextInput)
+	}
+
+	fromOutput := getValueByPath(fromObject, []string{"output"})
+	if fromOutput != nil {
+		fromOutput, err = tTuningText(ac, fromOutput)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"output"}, fromOutput)
+	}
+
+	return toObject, nil
+}
+
+func tuningDatasetToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+	if getValueByPath(fromObject, []string{"gcsUri"}) != nil {
+		return nil, fmt.Errorf("gcsUri parameter is not supported in Gemini API")
+	}
+
+	if getValueByPath(fromObject, []string{"vertexDatasetResource"}) != nil {
+		return nil, fmt.Errorf("vertexDatasetResource parameter is not supported in Gemini API")
+	}
+
+	fromExamples := getValueByPath(fromObject, []string{"examples"})
+	if fromExamples != nil {
+		fromExamples, err = applyConverterToSlice(ac, fromExamples.([]any), tuningExampleToMldev)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"examples", "examples"}, fromExamples)
+	}
+
+	return toObject, nil
+}
+
+func createTuningJobConfigToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+	if method := getValueByPath(fromObject, []string{"method"}); method != nil && method != string(TuningMethodSupervisedFineTuning) {
+		return nil, fmt.Errorf("method %v is not supported in Gemini API", method)
+	}
+	if getValueByPath(fromObject, []string{"validationDataset"}) != nil {
+		return nil, fmt.Errorf("validationDataset parameter is not supported in Gemini API")
+	}
+
+	fromTunedModelDisplayName := getValueByPath(fromObject, []string{"tunedModelDisplayName"})
+	if fromTunedModelDisplayName != nil {
+		setValueByPath(parentObject, []string{"displayName"}, fromTunedModelDisplayName)
+	}
+
+	fromDescription := getValueByPath(fromObject, []string{"description"})
+	if fromDescription != nil {
+		setValueByPath(parentObject, []string{"description"}, fromDescription)
+	}
+
+	fromEpochCount := getValueByPath(fromObject, []string{"epochCount"})
+	if fromEpochCount != nil {
+		setValueByPath(parentObject, []string{"tuningTask", "hyperparameters", "epochCount"}, fromEpochCount)
+	}
+
+	fromLearningRateMultiplier := getValueByPath(fromObject, []string{"learningRateMultiplier"})
+	if fromLearningRateMultiplier != nil {
+		setValueByPath(parentObject, []string{"tuningTask", "hyperparameters", "learningRateMultiplier"}, fromLearningRateMultiplier)
+	}
+
+	if getValueByPath(fromObject, []string{"adapterSize"}) != nil {
+		return nil, fmt.Errorf("adapterSize parameter is not supported in Gemini API")
+	}
+
+	if getValueByPath(fromObject, []string{"exportLastCheckpointOnly"}) != nil {
+		return nil, fmt.Errorf("exportLastCheckpointOnly parameter is not supported in Gemini API")
+	}
+
+	fromBatchSize := getValueByPath(fromObject, []string{"batchSize"})
+	if fromBatchSize != nil {
+		setValueByPath(parentObject, []string{"tuningTask", "hyperparameters", "batchSize"}, fromBatchSize)
+	}
+
+	fromLearningRate := getValueByPath(fromObject, []string{"learningRate"})
+	if fromLearningRate != nil {
+		setValueByPath(parentObject, []string{"tuningTask", "hyperparameters", "learningRate"}, fromLearningRate)
+	}
+
+	if getValueByPath(fromObject, []string{"labels"}) != nil {
+		return nil, fmt.Errorf("labels parameter is not supported in Gemini API")
+	}
+
+	if getValueByPath(fromObject, []string{"beta"}) != nil {
+		return nil, fmt.Errorf("beta parameter is not supported in Gemini API")
+	}
+
+	return toObject, nil
+}
+
+func createTuningJobParametersToMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromBaseModel := getValueByPath(fromObject, []string{"baseModel"})
+	if fromBaseModel != nil {
+		setValueByPath(toObject, []string{"baseModel"}, fromBaseModel)
+	}
+
+	fromTrainingDataset := getValueByPath(fromObject, []string{"trainingDataset"})
+	if fromTrainingDataset != nil {
+		fromTrainingDataset, err = tuningDatasetToMldev(ac, fromTrainingDataset.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"tuningTask", "trainingData"}, fromTrainingDataset)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		fromConfig, err = createTuningJobConfigToMldev(ac, fromConfig.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func getTuningJobParametersToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		fromName, err = tTuningJobName(ac, fromName)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"_url", "name"}, fromName)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func listTuningJobsConfigToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromPageSize := getValueByPath(fromObject, []string{"pageSize"})
+	if fromPageSize != nil {
+		setValueByPath(parentObject, []string{"_query", "pageSize"}, fromPageSize)
+	}
+
+	fromPageToken := getValueByPath(fromObject, []string{"pageToken"})
+	if fromPageToken != nil {
+		setValueByPath(parentObject, []string{"_query", "pageToken"}, fromPageToken)
+	}
+
+	fromFilter := getValueByPath(fromObject, []string{"filter"})
+	if fromFilter != nil {
+		setValueByPath(parentObject, []string{"_query", "filter"}, fromFilter)
+	}
+
+	return toObject, nil
+}
+
+func listTuningJobsParametersToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		fromConfig, err = listTuningJobsConfigToVertex(ac, fromConfig.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func cancelTuningJobParametersToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		fromName, err = tTuningJobName(ac, fromName)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"_url", "name"}, fromName)
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	return toObject, nil
+}
+
+func tuningDatasetToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromGCSURI := getValueByPath(fromObject, []string{"gcsUri"})
+	if fromGCSURI != nil {
+		setValueByPath(parentObject, []string{"supervisedTuningSpec", "trainingDatasetUri"}, fromGCSURI)
+	}
+
+	fromVertexDatasetResource := getValueByPath(fromObject, []string{"vertexDatasetResource"})
+	if fromVertexDatasetResource != nil {
+		setValueByPath(parentObject, []string{"supervisedTuningSpec", "trainingDatasetUri"}, fromVertexDatasetResource)
+	}
+
+	if getValueByPath(fromObject, []string{"examples"}) != nil {
+		return nil, fmt.Errorf("examples parameter is not supported in Vertex AI")
+	}
+
+	return toObject, nil
+}
+
+func tuningValidationDatasetToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromGCSURI := getValueByPath(fromObject, []string{"gcsUri"})
+	if fromGCSURI != nil {
+		setValueByPath(toObject, []string{"validationDatasetUri"}, fromGCSURI)
+	}
+
+	fromVertexDatasetResource := getValueByPath(fromObject, []string{"vertexDatasetResource"})
+	if fromVertexDatasetResource != nil {
+		setValueByPath(toObject, []string{"validationDatasetUri"}, fromVertexDatasetResource)
+	}
+
+	return toObject, nil
+}
+
+func createTuningJobConfigToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromValidationDataset := getValueByPath(fromObject, []string{"validationDataset"})
+	if fromValidationDataset != nil {
+		fromValidationDataset, err = tuningValidationDatasetToVertex(ac, fromValidationDataset.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(parentObject, []string{"supervisedTuningSpec", "validationDatasetUri"}, getValueByPath(fromValidationDataset.(map[string]any), []string{"validationDatasetUri"}))
+	}
+
+	fromTunedModelDisplayName := getValueByPath(fromObject, []string{"tunedModelDisplayName"})
+	if fromTunedModelDisplayName != nil {
+		setValueByPath(parentObject, []string{"tunedModelDisplayName"}, fromTunedModelDisplayName)
+	}
+
+	fromDescription := getValueByPath(fromObject, []string{"description"})
+	if fromDescription != nil {
+		setValueByPath(parentObject, []string{"description"}, fromDescription)
+	}
+
+	fromEpochCount := getValueByPath(fromObject, []string{"epochCount"})
+	if fromEpochCount != nil {
+		setValueByPath(parentObject, []string{"supervisedTuningSpec", "hyperParameters", "epochCount"}, fromEpochCount)
+	}
+
+	fromLearningRateMultiplier := getValueByPath(fromObject, []string{"learningRateMultiplier"})
+	if fromLearningRateMultiplier != nil {
+		setValueByPath(parentObject, []string{"supervisedTuningSpec", "hyperParameters", "learningRateMultiplier"}, fromLearningRateMultiplier)
+	}
+
+	fromAdapterSize := getValueByPath(fromObject, []string{"adapterSize"})
+	if fromAdapterSize != nil {
+		setValueByPath(parentObject, []string{"supervisedTuningSpec", "hyperParameters", "adapterSize"}, fromAdapterSize)
+	}
+
+	fromExportLastCheckpointOnly := getValueByPath(fromObject, []string{"exportLastCheckpointOnly"})
+	if fromExportLastCheckpointOnly != nil {
+		setValueByPath(parentObject, []string{"supervisedTuningSpec", "exportLastCheckpointOnly"}, fromExportLastCheckpointOnly)
+	}
+
+	if getValueByPath(fromObject, []string{"batchSize"}) != nil {
+		return nil, fmt.Errorf("batchSize parameter is not supported in Vertex AI")
+	}
+
+	if getValueByPath(fromObject, []string{"learningRate"}) != nil {
+		return nil, fmt.Errorf("learningRate parameter is not supported in Vertex AI")
+	}
+
+	fromLabels := getValueByPath(fromObject, []string{"labels"})
+	if fromLabels != nil {
+		setValueByPath(parentObject, []string{"labels"}, fromLabels)
+	}
+
+	fromBeta := getValueByPath(fromObject, []string{"beta"})
+	if fromBeta != nil {
+		setValueByPath(parentObject, []string{"supervisedTuningSpec", "hyperParameters", "beta"}, fromBeta)
+	}
+
+	return toObject, nil
+}
+
+func createTuningJobParametersToVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromBaseModel := getValueByPath(fromObject, []string{"baseModel"})
+	if fromBaseModel != nil {
+		setValueByPath(toObject, []string{"baseModel"}, fromBaseModel)
+	}
+
+	fromTrainingDataset := getValueByPath(fromObject, []string{"trainingDataset"})
+	if fromTrainingDataset != nil {
+		_, err = tuningDatasetToVertex(ac, fromTrainingDataset.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fromConfig := getValueByPath(fromObject, []string{"config"})
+	if fromConfig != nil {
+		fromConfig, err = createTuningJobConfigToVertex(ac, fromConfig.(map[string]any), toObject)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"config"}, fromConfig)
+	}
+
+	fromMethod := getValueByPath(fromObject, []string{"config", "method"})
+	if fromMethod == nil {
+		fromMethod = string(TuningMethodSupervisedFineTuning)
+	}
+	toObject, err = tTuningMethodSpec(ac, toObject, fromMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return toObject, nil
+}
+
+func tuningJobFromMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"name"})
+	if fromName != nil {
+		setValueByPath(toObject, []string{"name"}, fromName)
+		setValueByPath(toObject, []string{"tunedModel", "model"}, fromName)
+		setValueByPath(toObject, []string{"tunedModel", "endpoint"}, fromName)
+	}
+
+	fromState := getValueByPath(fromObject, []string{"state"})
+	if fromState != nil {
+		fromState, err = tTuningJobStatus(ac, fromState)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"state"}, fromState)
+	}
+
+	fromCreateTime := getValueByPath(fromObject, []string{"createTime"})
+	if fromCreateTime != nil {
+		setValueByPath(toObject, []string{"createTime"}, fromCreateTime)
+	}
+
+	fromStartTime := getValueByPath(fromObject, []string{"tuningTask", "startTime"})
+	if fromStartTime != nil {
+		setValueByPath(toObject, []string{"startTime"}, fromStartTime)
+	}
+
+	fromEndTime := getValueByPath(fromObject, []string{"tuningTask", "completeTime"})
+	if fromEndTime != nil {
+		setValueByPath(toObject, []string{"endTime"}, fromEndTime)
+	}
+
+	fromUpdateTime := getValueByPath(fromObject, []string{"updateTime"})
+	if fromUpdateTime != nil {
+		setValueByPath(toObject, []string{"updateTime"}, fromUpdateTime)
+	}
+
+	fromDescription := getValueByPath(fromObject, []string{"description"})
+	if fromDescription != nil {
+		setValueByPath(toObject, []string{"description"}, fromDescription)
+	}
+
+	fromBaseModel := getValueByPath(fromObject, []string{"baseModel"})
+	if fromBaseModel != nil {
+		setValueByPath(toObject, []string{"baseModel"}, fromBaseModel)
+	}
+
+	fromTunedModelDisplayName := getValueByPath(fromObject, []string{"displayName"})
+	if fromTunedModelDisplayName != nil {
+		setValueByPath(toObject, []string{"tunedModelDisplayName"}, fromTunedModelDisplayName)
+	}
+
+	return toObject, nil
+}
+
+func listTuningJobsResponseFromMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromNextPageToken := getValueByPath(fromObject, []string{"nextPageToken"})
+	if fromNextPageToken != nil {
+		setValueByPath(toObject, []string{"nextPageToken"}, fromNextPageToken)
+	}
+
+	fromTuningJobs := getValueByPath(fromObject, []string{"tunedModels"})
+	if fromTuningJobs != nil {
+		fromTuningJobs, err = applyConverterToSlice(ac, fromTuningJobs.([]any), tuningJobFromMldev)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"tuningJobs"}, fromTuningJobs)
+	}
+
+	return toObject, nil
+}
+
+func tuningOperationFromMldev(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromName := getValueByPath(fromObject, []string{"metadata", "tunedModel"})
+	if fromName != nil {
+		setValueByPath(toObject, []string{"name"}, fromName)
+		setValueByPath(toObject, []string{"tunedModel", "model"}, fromName)
+		setValueByPath(toObject, []string{"tunedModel", "endpoint"}, fromName)
+	}
+
+	return toObject, nil
+}
+
+func tuningJobFromVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	for _, field := range []string{
+		"name", "state", "createTime", "startTime", "endTime", "updateTime", "error", "description",
+		"baseModel", "tunedModel", "tunedModelDisplayName", "experiment", "labels", "pipelineJob",
+		"supervisedTuningSpec", "preferenceOptimizationSpec",
+	} {
+		fromField := getValueByPath(fromObject, []string{field})
+		if fromField != nil {
+			setValueByPath(toObject, []string{field}, fromField)
+		}
+	}
+
+	return toObject, nil
+}
+
+func listTuningJobsResponseFromVertex(ac *apiClient, fromObject map[string]any, parentObject map[string]any) (toObject map[string]any, err error) {
+	toObject = make(map[string]any)
+
+	fromNextPageToken := getValueByPath(fromObject, []string{"nextPageToken"})
+	if fromNextPageToken != nil {
+		setValueByPath(toObject, []string{"nextPageToken"}, fromNextPageToken)
+	}
+
+	fromTuningJobs := getValueByPath(fromObject, []string{"tuningJobs"})
+	if fromTuningJobs != nil {
+		fromTuningJobs, err = applyConverterToSlice(ac, fromTuningJobs.([]any), tuningJobFromVertex)
+		if err != nil {
+			return nil, err
+		}
+
+		setValueByPath(toObject, []string{"tuningJobs"}, fromTuningJobs)
+	}
+
+	return toObject, nil
+}
+
+// Tunings provides methods for managing the tuning jobs of models.
+// You don't need to initiate this struct. Create a client instance via NewClient, and
+// then access Tunings through client.Tunings field.
+type Tunings struct {
+	apiClient *apiClient
+}
+
+// Get retrieves a tuning job.
+func (m Tunings) Get(ctx context.Context, name string, config *GetTuningJobConfig) (*TuningJob, error) {
+	parameterMap := make(map[string]any)
+
+	kwargs := map[string]any{"name": name, "config": config}
+	deepMarshal(kwargs, &parameterMap)
+
+	var httpOptions *HTTPOptions
+	if config == nil {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, nil)
+	} else {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, config.HTTPOptions)
+		config.HTTPOptions = nil
+	}
+	var response = new(TuningJob)
+	var responseMap map[string]any
+	var fromConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	var toConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		toConverter = getTuningJobParametersToVertex
+		fromConverter = tuningJobFromVertex
+	} else {
+		toConverter = getTuningJobParametersToMldev
+		fromConverter = tuningJobFromMldev
+	}
+
+	body, err := toConverter(m.apiClient, parameterMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	var path string
+	var urlParams map[string]any
+	if _, ok := body["_url"]; ok {
+		urlParams = body["_url"].(map[string]any)
+		delete(body, "_url")
+	}
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		path, err = formatMap("{name}", urlParams)
+	} else {
+		path, err = formatMap("{name}", urlParams)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid url params: %#v.\n%w", urlParams, err)
+	}
+	if _, ok := body["_query"]; ok {
+		query, err := createURLQuery(body["_query"].(map[string]any))
+		if err != nil {
+			return nil, err
+		}
+		path += "?" + query
+		delete(body, "_query")
+	}
+
+	if _, ok := body["config"]; ok {
+		delete(body, "config")
+	}
+	responseMap, err = sendRequest(ctx, m.apiClient, path, http.MethodGet, body, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	responseMap, err = fromConverter(m.apiClient, responseMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = mapToStruct(responseMap, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (m Tunings) list(ctx context.Context, config *ListTuningJobsConfig) (*ListTuningJobsResponse, error) {
+	parameterMap := make(map[string]any)
+
+	kwargs := map[string]any{"config": config}
+	deepMarshal(kwargs, &parameterMap)
+
+	var httpOptions *HTTPOptions
+	if config == nil {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, nil)
+	} else {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, config.HTTPOptions)
+		config.HTTPOptions = nil
+	}
+	var response = new(ListTuningJobsResponse)
+	var responseMap map[string]any
+	var fromConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	var toConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		toConverter = listTuningJobsParametersToVertex
+		fromConverter = listTuningJobsResponseFromVertex
+	} else {
+		toConverter = listTuningJobsParametersToMldev
+		fromConverter = listTuningJobsResponseFromMldev
+	}
+
+	body, err := toConverter(m.apiClient, parameterMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	var path string
+	var urlParams map[string]any
+	if _, ok := body["_url"]; ok {
+		urlParams = body["_url"].(map[string]any)
+		delete(body, "_url")
+	}
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		path, err = formatMap("tuningJobs", urlParams)
+	} else {
+		path, err = formatMap("tunedModels", urlParams)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid url params: %#v.\n%w", urlParams, err)
+	}
+	if _, ok := body["_query"]; ok {
+		query, err := createURLQuery(body["_query"].(map[string]any))
+		if err != nil {
+			return nil, err
+		}
+		path += "?" + query
+		delete(body, "_query")
+	}
+
+	if _, ok := body["config"]; ok {
+		delete(body, "config")
+	}
+	responseMap, err = sendRequest(ctx, m.apiClient, path, http.MethodGet, body, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	responseMap, err = fromConverter(m.apiClient, responseMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = mapToStruct(responseMap, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (m Tunings) tune(ctx context.Context, baseModel string, trainingDataset *TuningDataset, config *CreateTuningJobConfig) (*TuningJob, error) {
+	parameterMap := make(map[string]any)
+
+	kwargs := map[string]any{"baseModel": baseModel, "trainingDataset": trainingDataset, "config": config}
+	deepMarshal(kwargs, &parameterMap)
+
+	var httpOptions *HTTPOptions
+	if config == nil {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, nil)
+	} else {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, config.HTTPOptions)
+		config.HTTPOptions = nil
+	}
+	var response = new(TuningJob)
+	var responseMap map[string]any
+	var fromConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	var toConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		toConverter = createTuningJobParametersToVertex
+		fromConverter = tuningJobFromVertex
+	} else {
+		toConverter = createTuningJobParametersToMldev
+		fromConverter = tuningOperationFromMldev
+	}
+
+	body, err := toConverter(m.apiClient, parameterMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	var path string
+	var urlParams map[string]any
+	if _, ok := body["_url"]; ok {
+		urlParams = body["_url"].(map[string]any)
+		delete(body, "_url")
+	}
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		path, err = formatMap("tuningJobs", urlParams)
+	} else {
+		path, err = formatMap("tunedModels", urlParams)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid url params: %#v.\n%w", urlParams, err)
+	}
+	if _, ok := body["_query"]; ok {
+		query, err := createURLQuery(body["_query"].(map[string]any))
+		if err != nil {
+			return nil, err
+		}
+		path += "?" + query
+		delete(body, "_query")
+	}
+
+	if _, ok := body["config"]; ok {
+		delete(body, "config")
+	}
+	responseMap, err = sendRequest(ctx, m.apiClient, path, http.MethodPost, body, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	responseMap, err = fromConverter(m.apiClient, responseMap, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = mapToStruct(responseMap, response)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Cancel cancels a tuning job. The cancellation is asynchronous: the job goes to
+// the JOB_STATE_CANCELLING state, then to the JOB_STATE_CANCELLED state unless it
+// completed in the meantime.
+func (m Tunings) Cancel(ctx context.Context, name string, config *CancelTuningJobConfig) error {
+	parameterMap := make(map[string]any)
+
+	kwargs := map[string]any{"name": name, "config": config}
+	deepMarshal(kwargs, &parameterMap)
+
+	var httpOptions *HTTPOptions
+	if config == nil {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, nil)
+	} else {
+		httpOptions = mergeHTTPOptions(m.apiClient.clientConfig, config.HTTPOptions)
+		config.HTTPOptions = nil
+	}
+	var toConverter func(*apiClient, map[string]any, map[string]any) (map[string]any, error)
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		toConverter = cancelTuningJobParametersToVertex
+	} else {
+
+		return fmt.Errorf("method Cancel is only supported in the Vertex AI client. You can choose to use Vertex AI by setting ClientConfig.Backend to BackendVertexAI.")
+
+	}
+
+	body, err := toConverter(m.apiClient, parameterMap, nil)
+	if err != nil {
+		return err
+	}
+	var path string
+	var urlParams map[string]any
+	if _, ok := body["_url"]; ok {
+		urlParams = body["_url"].(map[string]any)
+		delete(body, "_url")
+	}
+	path, err = formatMap("{name}:cancel", urlParams)
+	if err != nil {
+		return fmt.Errorf("invalid url params: %#v.\n%w", urlParams, err)
+	}
+
+	if _, ok := body["config"]; ok {
+		delete(body, "config")
+	}
+	_, err = sendRequest(ctx, m.apiClient, path, http.MethodPost, body, httpOptions)
+	return err
+}
+
+// List retrieves a paginated list of tuning jobs.
+func (m Tunings) List(ctx context.Context, config *ListTuningJobsConfig) (Page[TuningJob], error) {
+	listFunc := func(ctx context.Context, config map[string]any) ([]*TuningJob, string, error) {
+		var c ListTuningJobsConfig
+		if err := mapToStruct(config, &c); err != nil {
+			return nil, "", err
+		}
+		resp, err := m.list(ctx, &c)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.TuningJobs, resp.NextPageToken, nil
+	}
+	c := make(map[string]any)
+	deepMarshal(config, &c)
+	return newPage(ctx, "tuningJobs", c, listFunc)
+}
+
+// All retrieves all tuning jobs.
+//
+// This method handles pagination internally, making multiple API calls as needed
+// to fetch all entries. It returns an iterator that yields each tuning job one by
+// one. You do not need to manage pagination tokens or make multiple calls to
+// retrieve all data.
+func (m Tunings) All(ctx context.Context) iter.Seq2[*TuningJob, error] {
+	listFunc := func(ctx context.Context, config map[string]any) ([]*TuningJob, string, error) {
+		var c ListTuningJobsConfig
+		if err := mapToStruct(config, &c); err != nil {
+			return nil, "", err
+		}
+		resp, err := m.list(ctx, &c)
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.TuningJobs, resp.NextPageToken, nil
+	}
+	p, err := newPage(ctx, "tuningJobs", map[string]any{}, listFunc)
+	if err != nil {
+		return yieldErrorAndEndIterator[TuningJob](err)
+	}
+	return p.All(ctx)
+}
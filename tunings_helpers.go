@@ -0,0 +1,163 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+)
+
+// Tune creates a supervised fine-tuning job of the base model and returns it
+// without waiting for its completion, see [Tunings.Wait].
+//
+// On BackendVertexAI, the training dataset and the optional validation dataset of
+// the config are read from a JSONL file in Cloud Storage or from a Vertex AI
+// dataset. On BackendGeminiAPI, the training dataset holds the examples inline, see
+// [TuningDatasetBuilder.Dataset], and validation datasets are not supported.
+//
+// Set [CreateTuningJobConfig.Method] to [TuningMethodPreferenceTuning] to tune the
+// model on a dataset of preference examples instead, see
+// [TuningDatasetBuilder.AddPreference]. Preference tuning is only supported on
+// BackendVertexAI.
+//
+//	job, err := client.Tunings.Tune(ctx, "gemini-2.0-flash-001",
+//		&genai.TuningDataset{GCSURI: "gs://my-bucket/train.jsonl"},
+//		&genai.CreateTuningJobConfig{
+//			ValidationDataset: &genai.TuningValidationDataset{GCSURI: "gs://my-bucket/validation.jsonl"},
+//			EpochCount:        genai.Ptr[int32](3),
+//		})
+func (m Tunings) Tune(ctx context.Context, baseModel string, trainingDataset *TuningDataset, config *CreateTuningJobConfig) (*TuningJob, error) {
+	backend := m.apiClient.clientConfig.Backend
+	if err := trainingDataset.validate(backend); err != nil {
+		return nil, err
+	}
+	if config != nil && config.ValidationDataset != nil {
+		if backend != BackendVertexAI {
+			return nil, fmt.Errorf("validation datasets are only supported in the Vertex AI client. You can choose to use Vertex AI by setting ClientConfig.Backend to BackendVertexAI.")
+		}
+		if err := config.ValidationDataset.validate(); err != nil {
+			return nil, err
+		}
+	}
+	job, err := m.tune(ctx, baseModel, trainingDataset, config)
+	if err != nil {
+		return nil, err
+	}
+	if job.State == "" {
+		// The Gemini API returns the operation creating the tuned model, which is yet
+		// to start.
+		job.State = JobStatePending
+	}
+	return job, nil
+}
+
+// TunedModelName returns the name to pass as the model of [Models.GenerateContent]
+// and the other methods of [Models] to use the tuned model: the endpoint of the
+// model on Vertex AI, or the tuned model on the Gemini API. It returns "" until the
+// job succeeds.
+//
+//	job, err := client.Tunings.Wait(ctx, job.Name, nil)
+//	resp, err := client.Models.GenerateContent(ctx, job.TunedModelName(), genai.Text("Hello"), nil)
+func (j *TuningJob) TunedModelName() string {
+	if j.TunedModel == nil {
+		return ""
+	}
+	if j.TunedModel.Endpoint != "" {
+		return j.TunedModel.Endpoint
+	}
+	return j.TunedModel.Model
+}
+
+// Checkpoint returns the intermediate checkpoint of the tuned model with the given
+// ID, or nil if the tuning job has no such checkpoint. The endpoint of the
+// checkpoint can be passed as the model of [Models.GenerateContent], e.g. to
+// evaluate the checkpoint before selecting it with [Tunings.SelectCheckpoint].
+func (j *TuningJob) Checkpoint(id string) *TunedModelCheckpoint {
+	if j.TunedModel == nil {
+		return nil
+	}
+	for _, c := range j.TunedModel.Checkpoints {
+		if c.CheckpointID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// SelectCheckpoint makes an intermediate checkpoint of the model tuned by a job the
+// default checkpoint of the model, used when the model is deployed or exported,
+// and returns the updated model. Checkpoints are only supported on Vertex AI, for
+// jobs that did not set [CreateTuningJobConfig.ExportLastCheckpointOnly].
+func (m Tunings) SelectCheckpoint(ctx context.Context, job *TuningJob, checkpointID string) (*Model, error) {
+	if m.apiClient.clientConfig.Backend != BackendVertexAI {
+		return nil, fmt.Errorf("method SelectCheckpoint is only supported in the Vertex AI client. You can choose to use Vertex AI by setting ClientConfig.Backend to BackendVertexAI.")
+	}
+	if job.TunedModel == nil || job.TunedModel.Model == "" {
+		return nil, fmt.Errorf("tuning job %s has no tuned model", job.Name)
+	}
+	if job.Checkpoint(checkpointID) == nil {
+		return nil, fmt.Errorf("tuning job %s has no checkpoint %q", job.Name, checkpointID)
+	}
+	return Models{apiClient: m.apiClient}.Update(ctx, job.TunedModel.Model, &UpdateModelConfig{DefaultCheckpointID: checkpointID})
+}
+
+// HasEnded reports whether the tuning job reached a terminal state: succeeded,
+// failed, cancelled or expired.
+func (j *TuningJob) HasEnded() bool {
+	switch j.State {
+	case JobStateSucceeded, JobStateFailed, JobStateCancelled, JobStateExpired, JobStatePartiallySucceeded:
+		return true
+	}
+	return false
+}
+
+// TuningJobError is returned by [Tunings.Wait] when a tuning job ends without
+// succeeding.
+type TuningJobError struct {
+	// The ended tuning job.
+	Job *TuningJob
+}
+
+// Error returns a string representation of the TuningJobError.
+func (e TuningJobError) Error() string {
+	if e.Job.Error != nil && e.Job.Error.Message != "" {
+		return fmt.Sprintf("tuning job %s ended in state %s: %s", e.Job.Name, e.Job.State, e.Job.Error.Message)
+	}
+	return fmt.Sprintf("tuning job %s ended in state %s", e.Job.Name, e.Job.State)
+}
+
+// Wait polls a tuning job until it reaches a terminal state and returns the ended
+// job. A [TuningJobError] is returned along with the job if it failed, was
+// cancelled or expired. The polls back off and the wait is bounded as for
+// [Operations.Wait]. [WaitOperationConfig.Progress] is called with the name of
+// the job and its state under "state".
+func (m Tunings) Wait(ctx context.Context, name string, config *WaitOperationConfig) (*TuningJob, error) {
+	job, err := m.Get(ctx, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	state := func(job *TuningJob) operationState {
+		s := operationState{name: job.Name, done: job.HasEnded(), metadata: map[string]any{"state": string(job.State)}}
+		if s.done && job.State != JobStateSucceeded && job.State != JobStatePartiallySucceeded {
+			s.err = TuningJobError{Job: job}
+		}
+		return s
+	}
+	if config != nil && config.Progress != nil {
+		config.Progress(job.Name, state(job).metadata)
+	}
+	return waitOperation(ctx, job, config, state,
+		func(ctx context.Context, job *TuningJob) (*TuningJob, error) { return m.Get(ctx, name, nil) })
+}
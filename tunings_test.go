@@ -0,0 +1,466 @@
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTuningsGet(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	t.Run("GeminiAPI", func(t *testing.T) {
+		var gotPath string
+		client := newTestClient(t, BackendGeminiAPI, func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			json.NewEncoder(w).Encode(map[string]any{
+				"name":        "tunedModels/abc",
+				"baseModel":   "models/gemini-1.5-flash-001-tuning",
+				"displayName": "my model",
+				"state":       "ACTIVE",
+				"tuningTask":  map[string]any{"startTime": start, "completeTime": end},
+			})
+		})
+		job, err := client.Tunings.Get(ctx, "abc", nil)
+		if err != nil {
+			t.Fatalf("Get() failed: %v", err)
+		}
+		if gotPath != "/v1beta/tunedModels/abc" {
+			t.Errorf("Get() path = %q, want %q", gotPath, "/v1beta/tunedModels/abc")
+		}
+		want := &TuningJob{
+			Name:                  "tunedModels/abc",
+			State:                 JobStateSucceeded,
+			StartTime:             start,
+			EndTime:               end,
+			BaseModel:             "models/gemini-1.5-flash-001-tuning",
+			TunedModel:            &TunedModel{Model: "tunedModels/abc", Endpoint: "tunedModels/abc"},
+			TunedModelDisplayName: "my model",
+		}
+		if diff := cmp.Diff(want, job); diff != "" {
+			t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("VertexAI", func(t *testing.T) {
+		var gotPath string
+		client := newTestClient(t, BackendVertexAI, func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			json.NewEncoder(w).Encode(map[string]any{
+				"name":       "projects/project/locations/us-central1/tuningJobs/123",
+				"state":      "JOB_STATE_FAILED",
+				"error":      map[string]any{"code": 3, "message": "invalid dataset"},
+				"tunedModel": map[string]any{"model": "projects/project/locations/us-central1/models/m"},
+			})
+		})
+		job, err := client.Tunings.Get(ctx, "123", nil)
+		if err != nil {
+			t.Fatalf("Get() failed: %v", err)
+		}
+		if want := "/v1beta1/projects/project/locations/us-central1/tuningJobs/123"; gotPath != want {
+			t.Errorf("Get() path = %q, want %q", gotPath, want)
+		}
+		want := &TuningJob{
+			Name:       "projects/project/locations/us-central1/tuningJobs/123",
+			State:      JobStateFailed,
+			Error:      &JobError{Code: 3, Message: "invalid dataset"},
+			TunedModel: &TunedModel{Model: "projects/project/locations/us-central1/models/m"},
+		}
+		if diff := cmp.Diff(want, job); diff != "" {
+			t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestTuningsAll(t *testing.T) {
+	ctx := context.Background()
+	var gotQueries []string
+	client := newTestClient(t, BackendVertexAI, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1beta1/projects/project/locations/us-central1/tuningJobs" {
+			t.Errorf("All() path = %q", r.URL.Path)
+		}
+		gotQueries = append(gotQueries, r.URL.RawQuery)
+		if r.URL.Query().Get("pageToken") == "" {
+			json.NewEncoder(w).Encode(map[string]any{
+				"tuningJobs":    []map[string]any{{"name": "tuningJobs/1"}},
+				"nextPageToken": "next",
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"tuningJobs": []map[string]any{{"name": "tuningJobs/2"}}})
+	})
+	var names []string
+	for job, err := range client.Tunings.All(ctx) {
+		if err != nil {
+			t.Fatalf("All() failed: %v", err)
+		}
+		names = append(names, job.Name)
+	}
+	if diff := cmp.Diff([]string{"tuningJobs/1", "tuningJobs/2"}, names); diff != "" {
+		t.Errorf("All() mismatch (-want +got):\n%s", diff)
+	}
+	if len(gotQueries) != 2 {
+		t.Fatalf("All() sent %d requests, want 2", len(gotQueries))
+	}
+}
+
+func TestTuningsCancel(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("VertexAI", func(t *testing.T) {
+		var gotMethod, gotPath string
+		client := newTestClient(t, BackendVertexAI, func(w http.ResponseWriter, r *http.Request) {
+			gotMethod, gotPath = r.Method, r.URL.Path
+			w.Write([]byte(`{}`))
+		})
+		if err := client.Tunings.Cancel(ctx, "projects/project/locations/us-central1/tuningJobs/123", nil); err != nil {
+			t.Fatalf("Cancel() failed: %v", err)
+		}
+		if want := "/v1beta1/projects/project/locations/us-central1/tuningJobs/123:cancel"; gotMethod != http.MethodPost || gotPath != want {
+			t.Errorf("Cancel() sent %s %s, want POST %s", gotMethod, gotPath, want)
+		}
+	})
+
+	t.Run("GeminiAPI", func(t *testing.T) {
+		client := newTestClient(t, BackendGeminiAPI, func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("Cancel() sent an unexpected request: %s %s", r.Method, r.URL.Path)
+		})
+		if err := client.Tunings.Cancel(ctx, "tunedModels/abc", nil); err == nil {
+			t.Error("Cancel() succeeded, want an error")
+		}
+	})
+}
+
+func TestTuningsWait(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name    string
+		states  []string
+		wantErr bool
+	}{
+		{name: "Succeeded", states: []string{"JOB_STATE_PENDING", "JOB_STATE_RUNNING", "JOB_STATE_SUCCEEDED"}},
+		{name: "Cancelled", states: []string{"JOB_STATE_RUNNING", "JOB_STATE_CANCELLING", "JOB_STATE_CANCELLED"}, wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			client := newTestClient(t, BackendVertexAI, func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(map[string]any{"name": "tuningJobs/123", "state": tt.states[polls]})
+				polls++
+			})
+			var progress []string
+			job, err := client.Tunings.Wait(ctx, "123", &WaitOperationConfig{
+				PollInterval: time.Millisecond,
+				Progress: func(name string, metadata map[string]any) {
+					progress = append(progress, metadata["state"].(string))
+				},
+			})
+			if diff := cmp.Diff(tt.states, progress); diff != "" {
+				t.Errorf("Wait() progress mismatch (-want +got):\n%s", diff)
+			}
+			if job == nil || string(job.State) != tt.states[len(tt.states)-1] {
+				t.Errorf("Wait() = %+v, want a job in state %s", job, tt.states[len(tt.states)-1])
+			}
+			var jobErr TuningJobError
+			if gotErr := errors.As(err, &jobErr); gotErr != tt.wantErr {
+				t.Errorf("Wait() error = %v, want a TuningJobError: %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("ContextDone", func(t *testing.T) {
+		client := newTestClient(t, BackendVertexAI, func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]any{"name": "tuningJobs/123", "state": "JOB_STATE_RUNNING"})
+		})
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		if _, err := client.Tunings.Wait(ctx, "123", &WaitOperationConfig{PollInterval: time.Millisecond}); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
+
+func TestTuningsTune(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("VertexAI", func(t *testing.T) {
+		var gotPath string
+		var gotBody map[string]any
+		client := newTestClient(t, BackendVertexAI, func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			json.NewDecoder(r.Body).Decode(&gotBody)
+			json.NewEncoder(w).Encode(map[string]any{"name": "projects/project/locations/us-central1/tuningJobs/123", "state": "JOB_STATE_PENDING"})
+		})
+		job, err := client.Tunings.Tune(ctx, "gemini-2.0-flash-001", &TuningDataset{GCSURI: "gs://bucket/train.jsonl"}, &CreateTuningJobConfig{
+			ValidationDataset:     &TuningValidationDataset{VertexDatasetResource: "projects/p/locations/us-central1/datasets/456"},
+			TunedModelDisplayName: "my model",
+			EpochCount:            Ptr[int32](3),
+			AdapterSize:           AdapterSizeFour,
+			Labels:                map[string]string{"team": "a"},
+		})
+		if err != nil {
+			t.Fatalf("Tune() failed: %v", err)
+		}
+		if want := "/v1beta1/projects/project/locations/us-central1/tuningJobs"; gotPath != want {
+			t.Errorf("Tune() path = %q, want %q", gotPath, want)
+		}
+		wantBody := map[string]any{
+			"baseModel": "gemini-2.0-flash-001",
+			"supervisedTuningSpec": map[string]any{
+				"trainingDatasetUri":   "gs://bucket/train.jsonl",
+				"validationDatasetUri": "projects/p/locations/us-central1/datasets/456",
+				"hyperParameters":      map[string]any{"epochCount": float64(3), "adapterSize": "ADAPTER_SIZE_FOUR"},
+			},
+			"tunedModelDisplayName": "my model",
+			"labels":                map[string]any{"team": "a"},
+		}
+		if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+			t.Errorf("Tune() body mismatch (-want +got):\n%s", diff)
+		}
+		if job.Name != "projects/project/locations/us-central1/tuningJobs/123" || job.State != JobStatePending {
+			t.Errorf("Tune() = %+v, want the created job", job)
+		}
+	})
+
+	t.Run("GeminiAPI", func(t *testing.T) {
+		var gotPath string
+		var gotBody map[string]any
+		client := newTestClient(t, BackendGeminiAPI, func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			json.NewDecoder(r.Body).Decode(&gotBody)
+			json.NewEncoder(w).Encode(map[string]any{
+				"name":     "tunedModels/abc/operations/xyz",
+				"metadata": map[string]any{"tunedModel": "tunedModels/abc"},
+			})
+		})
+		b := NewTuningDatasetBuilder(BackendGeminiAPI)
+		if err := b.Add(&TuningExample{Input: []*Content{NewContentFromText("1", RoleUser)}, Output: NewContentFromText("2", RoleModel)}); err != nil {
+			t.Fatal(err)
+		}
+		job, err := client.Tunings.Tune(ctx, "models/gemini-1.5-flash-001-tuning", b.Dataset(), &CreateTuningJobConfig{
+			TunedModelDisplayName: "my model",
+			BatchSize:             Ptr[int32](4),
+		})
+		if err != nil {
+			t.Fatalf("Tune() failed: %v", err)
+		}
+		if gotPath != "/v1beta/tunedModels" {
+			t.Errorf("Tune() path = %q, want %q", gotPath, "/v1beta/tunedModels")
+		}
+		wantBody := map[string]any{
+			"baseModel":   "models/gemini-1.5-flash-001-tuning",
+			"displayName": "my model",
+			"tuningTask": map[string]any{
+				"trainingData": map[string]any{
+					"examples": map[string]any{"examples": []any{map[string]any{"textInput": "1", "output": "2"}}},
+				},
+				"hyperparameters": map[string]any{"batchSize": float64(4)},
+			},
+		}
+		if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+			t.Errorf("Tune() body mismatch (-want +got):\n%s", diff)
+		}
+		want := &TuningJob{
+			Name:       "tunedModels/abc",
+			State:      JobStatePending,
+			TunedModel: &TunedModel{Model: "tunedModels/abc", Endpoint: "tunedModels/abc"},
+		}
+		if diff := cmp.Diff(want, job); diff != "" {
+			t.Errorf("Tune() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	example := &TuningExample{Input: []*Content{NewContentFromText("1", RoleUser)}, Output: NewContentFromText("2", RoleModel)}
+	for _, tt := range []struct {
+		name    string
+		backend Backend
+		dataset *TuningDataset
+		config  *CreateTuningJobConfig
+		wantErr string
+	}{
+		{name: "NoSource", backend: BackendVertexAI, dataset: &TuningDataset{}, wantErr: "exactly one"},
+		{name: "TwoSources", backend: BackendVertexAI, dataset: &TuningDataset{GCSURI: "gs://b/t.jsonl", VertexDatasetResource: "projects/p/locations/l/datasets/1"}, wantErr: "exactly one"},
+		{name: "NotGCS", backend: BackendVertexAI, dataset: &TuningDataset{GCSURI: "/tmp/t.jsonl"}, wantErr: "not a gs:// URI"},
+		{name: "NotDataset", backend: BackendVertexAI, dataset: &TuningDataset{VertexDatasetResource: "datasets/1"}, wantErr: "not a Vertex AI dataset"},
+		{name: "ExamplesVertexAI", backend: BackendVertexAI, dataset: &TuningDataset{Examples: []*TuningExample{example}}, wantErr: "UploadTuningDataset"},
+		{name: "GCSGeminiAPI", backend: BackendGeminiAPI, dataset: &TuningDataset{GCSURI: "gs://b/t.jsonl"}, wantErr: "only supports inline examples"},
+		{name: "InvalidExample", backend: BackendGeminiAPI, dataset: &TuningDataset{Examples: []*TuningExample{{Output: example.Output}}}, wantErr: "example 0: input is empty"},
+		{name: "ValidationGeminiAPI", backend: BackendGeminiAPI, dataset: &TuningDataset{Examples: []*TuningExample{example}}, config: &CreateTuningJobConfig{ValidationDataset: &TuningValidationDataset{GCSURI: "gs://b/v.jsonl"}}, wantErr: "only supported in the Vertex AI client"},
+		{name: "InvalidValidation", backend: BackendVertexAI, dataset: &TuningDataset{GCSURI: "gs://b/t.jsonl"}, config: &CreateTuningJobConfig{ValidationDataset: &TuningValidationDataset{}}, wantErr: "validation dataset: exactly one"},
+		{name: "BatchSizeVertexAI", backend: BackendVertexAI, dataset: &TuningDataset{GCSURI: "gs://b/t.jsonl"}, config: &CreateTuningJobConfig{BatchSize: Ptr[int32](4)}, wantErr: "batchSize parameter is not supported in Vertex AI"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, tt.backend, func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("Tune() sent an unexpected request: %s %s", r.Method, r.URL.Path)
+			})
+			_, err := client.Tunings.Tune(ctx, "gemini-2.0-flash-001", tt.dataset, tt.config)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Tune() error = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTuningsTunePreference(t *testing.T) {
+	ctx := context.Background()
+	var gotBody map[string]any
+	client := newTestClient(t, BackendVertexAI, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		json.NewEncoder(w).Encode(map[string]any{
+			"name":  "projects/project/locations/us-central1/tuningJobs/123",
+			"state": "JOB_STATE_PENDING",
+			"preferenceOptimizationSpec": map[string]any{
+				"trainingDatasetUri": "gs://bucket/preferences.jsonl",
+				"hyperParameters":    map[string]any{"epochCount": "2", "beta": 0.5},
+			},
+		})
+	})
+	job, err := client.Tunings.Tune(ctx, "gemini-2.5-flash", &TuningDataset{GCSURI: "gs://bucket/preferences.jsonl"}, &CreateTuningJobConfig{
+		Method:     TuningMethodPreferenceTuning,
+		EpochCount: Ptr[int32](2),
+		Beta:       Ptr[float32](0.5),
+	})
+	if err != nil {
+		t.Fatalf("Tune() failed: %v", err)
+	}
+	wantBody := map[string]any{
+		"baseModel": "gemini-2.5-flash",
+		"preferenceOptimizationSpec": map[string]any{
+			"trainingDatasetUri": "gs://bucket/preferences.jsonl",
+			"hyperParameters":    map[string]any{"epochCount": float64(2), "beta": 0.5},
+		},
+	}
+	if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+		t.Errorf("Tune() body mismatch (-want +got):\n%s", diff)
+	}
+	wantSpec := &PreferenceOptimizationSpec{
+		TrainingDatasetURI: "gs://bucket/preferences.jsonl",
+		HyperParameters:    &PreferenceOptimizationHyperParameters{EpochCount: 2, Beta: 0.5},
+	}
+	if diff := cmp.Diff(wantSpec, job.PreferenceOptimizationSpec); diff != "" {
+		t.Errorf("Tune() spec mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := client.Tunings.Tune(ctx, "gemini-2.5-flash", &TuningDataset{GCSURI: "gs://bucket/train.jsonl"}, &CreateTuningJobConfig{Beta: Ptr[float32](0.5)}); err == nil {
+		t.Errorf("Tune() with beta and supervised fine-tuning succeeded, want error")
+	}
+	gemini := newTestClient(t, BackendGeminiAPI, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Tune() sent an unexpected request: %s %s", r.Method, r.URL.Path)
+	})
+	dataset := &TuningDataset{Examples: []*TuningExample{{Input: []*Content{NewContentFromText("1", RoleUser)}, Output: NewContentFromText("2", RoleModel)}}}
+	if _, err := gemini.Tunings.Tune(ctx, "models/m", dataset, &CreateTuningJobConfig{Method: TuningMethodPreferenceTuning}); err == nil {
+		t.Errorf("Tune() with preference tuning on BackendGeminiAPI succeeded, want error")
+	}
+}
+
+func TestGenerateContentTunedModel(t *testing.T) {
+	ctx := context.Background()
+	for _, tt := range []struct {
+		backend  Backend
+		job      *TuningJob
+		wantPath string
+	}{
+		{
+			backend:  BackendGeminiAPI,
+			job:      &TuningJob{TunedModel: &TunedModel{Model: "tunedModels/abc", Endpoint: "tunedModels/abc"}},
+			wantPath: "/v1beta/tunedModels/abc:generateContent",
+		},
+		{
+			backend:  BackendVertexAI,
+			job:      &TuningJob{TunedModel: &TunedModel{Model: "projects/project/locations/us-central1/models/1@1", Endpoint: "projects/project/locations/us-central1/endpoints/123"}},
+			wantPath: "/v1beta1/projects/project/locations/us-central1/endpoints/123:generateContent",
+		},
+		{
+			backend:  BackendVertexAI,
+			job:      &TuningJob{TunedModel: &TunedModel{Endpoint: "endpoints/123"}},
+			wantPath: "/v1beta1/projects/project/locations/us-central1/endpoints/123:generateContent",
+		},
+	} {
+		t.Run(tt.backend.String(), func(t *testing.T) {
+			var gotPath string
+			client := newTestClient(t, tt.backend, func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				w.Write([]byte(finalTextResponseJSON))
+			})
+			if _, err := client.Models.GenerateContent(ctx, tt.job.TunedModelName(), Text("Hello"), nil); err != nil {
+				t.Fatalf("GenerateContent() failed: %v", err)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("GenerateContent() path = %q, want %q", gotPath, tt.wantPath)
+			}
+		})
+	}
+
+	if name := (&TuningJob{}).TunedModelName(); name != "" {
+		t.Errorf("TunedModelName() of a running job = %q, want empty", name)
+	}
+}
+
+func TestTuningsCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	var gotMethod, gotPath string
+	var gotBody map[string]any
+	client := newTestClient(t, BackendVertexAI, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		if r.Method == http.MethodGet {
+			json.NewEncoder(w).Encode(map[string]any{
+				"name":  "projects/project/locations/us-central1/tuningJobs/123",
+				"state": "JOB_STATE_SUCCEEDED",
+				"tunedModel": map[string]any{
+					"model":    "projects/project/locations/us-central1/models/456@1",
+					"endpoint": "projects/project/locations/us-central1/endpoints/789",
+					"checkpoints": []map[string]any{
+						{"checkpointId": "1", "epoch": "1", "step": "10", "endpoint": "projects/project/locations/us-central1/endpoints/c1"},
+						{"checkpointId": "2", "epoch": "2", "step": "20", "endpoint": "projects/project/locations/us-central1/endpoints/c2"},
+					},
+				},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"name": "projects/project/locations/us-central1/models/456@1", "defaultCheckpointId": "1"})
+	})
+
+	job, err := client.Tunings.Get(ctx, "123", nil)
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	want := &TunedModelCheckpoint{CheckpointID: "1", Epoch: 1, Step: 10, Endpoint: "projects/project/locations/us-central1/endpoints/c1"}
+	if diff := cmp.Diff(want, job.Checkpoint("1")); diff != "" {
+		t.Errorf("Checkpoint() mismatch (-want +got):\n%s", diff)
+	}
+	if c := job.Checkpoint("3"); c != nil {
+		t.Errorf("Checkpoint(%q) = %+v, want nil", "3", c)
+	}
+
+	model, err := client.Tunings.SelectCheckpoint(ctx, job, "1")
+	if err != nil {
+		t.Fatalf("SelectCheckpoint() failed: %v", err)
+	}
+	if want := "/v1beta1/projects/project/locations/us-central1/models/456@1"; gotMethod != http.MethodPatch || gotPath != want {
+		t.Errorf("SelectCheckpoint() sent %s %s, want PATCH %s", gotMethod, gotPath, want)
+	}
+	if diff := cmp.Diff(map[string]any{"defaultCheckpointId": "1"}, gotBody); diff != "" {
+		t.Errorf("SelectCheckpoint() body mismatch (-want +got):\n%s", diff)
+	}
+	if model.DefaultCheckpointID != "1" {
+		t.Errorf("SelectCheckpoint() = %+v, want the model with default checkpoint 1", model)
+	}
+	if _, err := client.Tunings.SelectCheckpoint(ctx, job, "3"); err == nil {
+		t.Errorf("SelectCheckpoint() of an unknown checkpoint succeeded, want error")
+	}
+
+	if _, err := client.Tunings.Tune(ctx, "gemini-2.0-flash-001", &TuningDataset{GCSURI: "gs://b/t.jsonl"}, &CreateTuningJobConfig{ExportLastCheckpointOnly: Ptr(true)}); err != nil {
+		t.Fatalf("Tune() failed: %v", err)
+	}
+	if got := getValueByPath(gotBody, []string{"supervisedTuningSpec", "exportLastCheckpointOnly"}); got != true {
+		t.Errorf("Tune() exportLastCheckpointOnly = %v, want true", got)
+	}
+}
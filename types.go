@@ -286,6 +286,21 @@ const (
 	FeatureSelectionPreferencePrioritizeCost    FeatureSelectionPreference = "PRIORITIZE_COST"
 )
 
+// How Vertex AI requests consume Provisioned Throughput. See
+// https://cloud.google.com/vertex-ai/generative-ai/docs/use-provisioned-throughput.
+type ProvisionedThroughputMode string
+
+const (
+	// Use Provisioned Throughput if available, and fall back to on-demand (shared)
+	// capacity once the provisioned capacity is exhausted. This is the default.
+	ProvisionedThroughputModeUnspecified ProvisionedThroughputMode = ""
+	// Only use Provisioned Throughput. Requests exceeding the provisioned capacity
+	// fail with a 429 error instead of falling back to on-demand capacity.
+	ProvisionedThroughputModeDedicated ProvisionedThroughputMode = "dedicated"
+	// Only use on-demand capacity, bypassing Provisioned Throughput.
+	ProvisionedThroughputModeShared ProvisionedThroughputMode = "shared"
+)
+
 // Defines the function behavior. Defaults to `BLOCKING`.
 type Behavior string
 
@@ -521,6 +536,93 @@ const (
 	FunctionResponseSchedulingInterrupt FunctionResponseScheduling = "INTERRUPT"
 )
 
+// The state of a job.
+type JobState string
+
+const (
+	// The job state is unspecified.
+	JobStateUnspecified JobState = "JOB_STATE_UNSPECIFIED"
+	// The job has been just created or resumed and processing has not yet begun.
+	JobStateQueued JobState = "JOB_STATE_QUEUED"
+	// The service is preparing to run the job.
+	JobStatePending JobState = "JOB_STATE_PENDING"
+	// The job is in progress.
+	JobStateRunning JobState = "JOB_STATE_RUNNING"
+	// The job completed successfully.
+	JobStateSucceeded JobState = "JOB_STATE_SUCCEEDED"
+	// The job failed.
+	JobStateFailed JobState = "JOB_STATE_FAILED"
+	// The job is being cancelled. From this state the job may only go to either
+	// `JOB_STATE_SUCCEEDED`, `JOB_STATE_FAILED` or `JOB_STATE_CANCELLED`.
+	JobStateCancelling JobState = "JOB_STATE_CANCELLING"
+	// The job has been cancelled.
+	JobStateCancelled JobState = "JOB_STATE_CANCELLED"
+	// The job has been stopped, and can be resumed.
+	JobStatePaused JobState = "JOB_STATE_PAUSED"
+	// The job has expired.
+	JobStateExpired JobState = "JOB_STATE_EXPIRED"
+	// The job is being updated. Only jobs in the `JOB_STATE_RUNNING` state can be
+	// updated. After updating, the job goes back to the `JOB_STATE_RUNNING` state.
+	JobStateUpdating JobState = "JOB_STATE_UPDATING"
+	// The job is partially succeeded, some results may be missing due to errors.
+	JobStatePartiallySucceeded JobState = "JOB_STATE_PARTIALLY_SUCCEEDED"
+)
+
+// Optional. Adapter size for tuning.
+type AdapterSize string
+
+const (
+	// Adapter size is unspecified.
+	AdapterSizeUnspecified AdapterSize = "ADAPTER_SIZE_UNSPECIFIED"
+	// Adapter size 1.
+	AdapterSizeOne AdapterSize = "ADAPTER_SIZE_ONE"
+	// Adapter size 2.
+	AdapterSizeTwo AdapterSize = "ADAPTER_SIZE_TWO"
+	// Adapter size 4.
+	AdapterSizeFour AdapterSize = "ADAPTER_SIZE_FOUR"
+	// Adapter size 8.
+	AdapterSizeEight AdapterSize = "ADAPTER_SIZE_EIGHT"
+	// Adapter size 16.
+	AdapterSizeSixteen AdapterSize = "ADAPTER_SIZE_SIXTEEN"
+	// Adapter size 32.
+	AdapterSizeThirtyTwo AdapterSize = "ADAPTER_SIZE_THIRTY_TWO"
+)
+
+// The method of a tuning job.
+type TuningMethod string
+
+const (
+	// Supervised fine-tuning, on examples of the expected responses.
+	TuningMethodSupervisedFineTuning TuningMethod = "SUPERVISED_FINE_TUNING"
+	// Preference optimization tuning, on pairs of chosen and rejected responses.
+	// This method is not supported in Gemini API.
+	TuningMethodPreferenceTuning TuningMethod = "PREFERENCE_TUNING"
+)
+
+// The tasks for which an embedding is computed, to optimize its quality for the
+// task, see [EmbedContentConfig.TaskType].
+const (
+	// Unset value, which will default to one of the other values.
+	TaskTypeUnspecified = "TASK_TYPE_UNSPECIFIED"
+	// The text is a query in a search or retrieval setting.
+	TaskTypeRetrievalQuery = "RETRIEVAL_QUERY"
+	// The text is a document from the corpus being searched. See
+	// [EmbedContentConfig.Title].
+	TaskTypeRetrievalDocument = "RETRIEVAL_DOCUMENT"
+	// The text is compared with other texts for semantic similarity.
+	TaskTypeSemanticSimilarity = "SEMANTIC_SIMILARITY"
+	// The text is classified.
+	TaskTypeClassification = "CLASSIFICATION"
+	// The text is clustered with other texts.
+	TaskTypeClustering = "CLUSTERING"
+	// The text is a question to be answered by a document.
+	TaskTypeQuestionAnswering = "QUESTION_ANSWERING"
+	// The text is a claim to be verified by a document.
+	TaskTypeFactVerification = "FACT_VERIFICATION"
+	// The text is a query for the retrieval of code.
+	TaskTypeCodeRetrievalQuery = "CODE_RETRIEVAL_QUERY"
+)
+
 // Describes how the video in the Part should be used by the model.
 type VideoMetadata struct {
 	// Optional. The frame rate of the video sent to the model. If not specified, the
@@ -575,11 +677,12 @@ func (c *VideoMetadata) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(c),
 	}
 
+	// Keep the fractional seconds so that short segments are not rounded away.
 	if c.StartOffset != 0 {
-		aux.StartOffset = fmt.Sprintf("%.0fs", c.StartOffset.Seconds())
+		aux.StartOffset = strconv.FormatFloat(c.StartOffset.Seconds(), 'f', -1, 64) + "s"
 	}
 	if c.EndOffset != 0 {
-		aux.EndOffset = fmt.Sprintf("%.0fs", c.EndOffset.Seconds())
+		aux.EndOffset = strconv.FormatFloat(c.EndOffset.Seconds(), 'f', -1, 64) + "s"
 		if aux.StartOffset == "" {
 			aux.StartOffset = "0s"
 		}
@@ -698,8 +801,13 @@ type Part struct {
 	Text string `json:"text,omitempty"`
 }
 
-// NewPartFromURI builds a Part from a given file URI and mime type.
+// NewPartFromURI builds a Part from a given file URI and mime type. If the mime
+// type is empty, it is detected from the extension of the URI, see
+// [DetectMIMEType].
 func NewPartFromURI(fileURI, mimeType string) *Part {
+	if mimeType == "" {
+		mimeType = mimeTypeFromName(fileURI)
+	}
 	return &Part{
 		FileData: &FileData{
 			FileURI:  fileURI,
@@ -725,8 +833,17 @@ func NewPartFromText(text string) *Part {
 	}
 }
 
-// NewPartFromBytes builds a Part from a given byte array and mime type.
+// NewPartFromBytes builds a Part from a given byte array and mime type. If the
+// mime type is empty, it is detected from the content, see [DetectMIMEType].
+//
+// Unlike [NewPartFromPath] and [NewPartFromReader], it does not check the size of
+// the data, which is already in memory and cannot return an error: the limit
+// applies to the whole request, where larger data can be uploaded with an
+// [InlineDataOffload] policy. Without one, a request exceeding the limit fails.
 func NewPartFromBytes(data []byte, mimeType string) *Part {
+	if mimeType == "" {
+		mimeType = mimeTypeFromContent(data)
+	}
 	return &Part{
 		InlineData: &Blob{
 			Data:     data,
@@ -897,6 +1014,9 @@ type HTTPOptions struct {
 	APIVersion string `json:"apiVersion,omitempty"`
 	// Optional. Additional HTTP headers to be sent with the request.
 	Headers http.Header `json:"headers,omitempty"`
+	// Optional. How Vertex AI requests consume Provisioned Throughput. Ignored for
+	// the Gemini API. Use BaseURL to target a dedicated endpoint.
+	ProvisionedThroughput ProvisionedThroughputMode `json:"provisionedThroughput,omitempty"`
 }
 
 // Schema is used to define the format of input/output data.
@@ -910,6 +1030,9 @@ type Schema struct {
 	AnyOf []*Schema `json:"anyOf,omitempty"`
 	// Optional. Default value of the data.
 	Default any `json:"default,omitempty"`
+	// Optional. A map of definitions for use by `ref`. Only allowed at the root of the
+	// schema. This field is not supported in Gemini API.
+	Defs map[string]*Schema `json:"defs,omitempty"`
 	// Optional. The description of the data.
 	Description string `json:"description,omitempty"`
 	// Optional. Possible values of the element of primitive type with enum format. Examples:
@@ -949,6 +1072,10 @@ type Schema struct {
 	// Optional. The order of the properties. Not a standard field in open API spec. Only
 	// used to support the order of the properties.
 	PropertyOrdering []string `json:"propertyOrdering,omitempty"`
+	// Optional. Allows indirect references between schema nodes. The value should be
+	// a valid reference to a child of the root `defs`, e.g. "#/defs/Node". This field
+	// is not supported in Gemini API.
+	Ref string `json:"ref,omitempty"`
 	// Optional. Required properties of Type.OBJECT.
 	Required []string `json:"required,omitempty"`
 	// Optional. The title of the Schema.
@@ -1531,6 +1658,11 @@ type GenerateContentConfig struct {
 	// If set, a compatible response_mime_type must also be set.
 	// Compatible mimetypes: `application/json`: Schema for JSON response.
 	ResponseSchema *Schema `json:"responseSchema,omitempty"`
+	// Optional. Output schema of the generated response, as a JSON Schema document.
+	// It is an alternative to ResponseSchema that accepts a JSON Schema verbatim,
+	// without translating it into the [Schema] type. If set, ResponseSchema must be
+	// omitted and ResponseMIMEType must be `application/json`.
+	ResponseJSONSchema json.RawMessage `json:"responseJsonSchema,omitempty"`
 	// Optional. Configuration for model router requests.
 	RoutingConfig *GenerationConfigRoutingConfig `json:"routingConfig,omitempty"`
 	// Optional. Configuration for model selection.
@@ -1825,6 +1957,10 @@ type Candidate struct {
 	// Output only. List of ratings for the safety of a response candidate. There is at
 	// most one rating per category.
 	SafetyRatings []*SafetyRating `json:"safetyRatings,omitempty"`
+	// Output only. Attribution information for the sources which contributed to a
+	// grounded answer, see [Models.GenerateAnswer].
+	// This field is only available in the Gemini API.
+	GroundingAttributions []*GroundingAttribution `json:"groundingAttributions,omitempty"`
 }
 
 // Content filter results for a prompt sent in the request.
@@ -1889,6 +2025,9 @@ type GenerateContentResponse struct {
 	PromptFeedback *GenerateContentResponsePromptFeedback `json:"promptFeedback,omitempty"`
 	// Usage metadata about the response(s).
 	UsageMetadata *GenerateContentResponseUsageMetadata `json:"usageMetadata,omitempty"`
+	// The history of contents exchanged with the model during automatic function
+	// calling. It is only set by [Models.GenerateContentWithTools].
+	AutomaticFunctionCallingHistory []*Content `json:"automaticFunctionCallingHistory,omitempty"`
 }
 
 // Text concatenates all the text parts in the GenerateContentResponse.
@@ -2024,10 +2163,12 @@ func (c *GenerateContentResponse) MarshalJSON() ([]byte, error) {
 type EmbedContentConfig struct {
 	// Optional. Used to override HTTP request options.
 	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
-	// Type of task for which the embedding will be used.
+	// Type of task for which the embedding will be used, e.g. TaskTypeRetrievalQuery
+	// for queries and TaskTypeRetrievalDocument for the documents they are compared
+	// with.
 	TaskType string `json:"taskType,omitempty"`
 	// Title for the text. Only applicable when TaskType is
-	// `RETRIEVAL_DOCUMENT`.
+	// TaskTypeRetrievalDocument.
 	Title string `json:"title,omitempty"`
 	// Reduced dimension for the output embedding. If set,
 	// excessive values in the output embedding are truncated from the end.
@@ -3018,6 +3159,536 @@ type ListCachedContentsResponse struct {
 	CachedContents []*CachedContent `json:"cachedContents,omitempty"`
 }
 
+// Optional parameters for tunings.get method.
+type GetTuningJobConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+}
+
+// TunedModel is the model produced by a tuning job.
+type TunedModel struct {
+	// Output only. The resource name of the tuned model. Format:
+	// `projects/{project}/locations/{location}/models/{model}` on Vertex AI, or
+	// `tunedModels/{model}` on the Gemini API.
+	Model string `json:"model,omitempty"`
+	// Output only. The resource name of the endpoint serving the tuned model. Format:
+	// `projects/{project}/locations/{location}/endpoints/{endpoint}` on Vertex AI, or
+	// `tunedModels/{model}` on the Gemini API.
+	Endpoint string `json:"endpoint,omitempty"`
+	// Output only. The intermediate checkpoints of the tuned model, if the tuning job
+	// exported them. Only populated on Vertex AI.
+	Checkpoints []*TunedModelCheckpoint `json:"checkpoints,omitempty"`
+}
+
+// TunedModelCheckpoint is an intermediate checkpoint of a tuned model.
+type TunedModelCheckpoint struct {
+	// The ID of the checkpoint.
+	CheckpointID string `json:"checkpointId,omitempty"`
+	// The epoch of the checkpoint.
+	Epoch int64 `json:"epoch,omitempty"`
+	// The step of the checkpoint.
+	Step int64 `json:"step,omitempty"`
+	// The resource name of the endpoint serving the checkpoint. Format:
+	// `projects/{project}/locations/{location}/endpoints/{endpoint}`.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+func (c *TunedModelCheckpoint) UnmarshalJSON(data []byte) error {
+	type Alias TunedModelCheckpoint
+	aux := &struct {
+		Epoch string `json:"epoch,omitempty"`
+		Step  string `json:"step,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(c),
+	}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if aux.Epoch != "" {
+		epoch, err := strconv.ParseInt(aux.Epoch, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing Epoch: %w", err)
+		}
+		c.Epoch = epoch
+	}
+
+	if aux.Step != "" {
+		step, err := strconv.ParseInt(aux.Step, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing Step: %w", err)
+		}
+		c.Step = step
+	}
+
+	return nil
+}
+
+func (c *TunedModelCheckpoint) MarshalJSON() ([]byte, error) {
+	type Alias TunedModelCheckpoint
+	aux := struct {
+		Epoch string `json:"epoch,omitempty"`
+		Step  string `json:"step,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(c),
+	}
+
+	aux.Epoch = strconv.FormatInt(c.Epoch, 10)
+	aux.Step = strconv.FormatInt(c.Step, 10)
+	return json.Marshal(aux)
+}
+
+// The error of a job.
+type JobError struct {
+	// Optional. The status code.
+	Code int32 `json:"code,omitempty"`
+	// Optional. A developer-facing error message, which should be in English.
+	Message string `json:"message,omitempty"`
+	// Optional. A list of messages that carry the error details.
+	Details []string `json:"details,omitempty"`
+}
+
+// A tuning job.
+type TuningJob struct {
+	// Output only. Identifier. Resource name of a TuningJob. Format:
+	// `projects/{project}/locations/{location}/tuningJobs/{tuning_job}` on Vertex AI,
+	// or `tunedModels/{model}` on the Gemini API.
+	Name string `json:"name,omitempty"`
+	// Output only. The detailed state of the job.
+	State JobState `json:"state,omitempty"`
+	// Output only. Time when the TuningJob was created.
+	CreateTime time.Time `json:"createTime,omitempty"`
+	// Output only. Time when the TuningJob for the first time entered the
+	// `JOB_STATE_RUNNING` state.
+	StartTime time.Time `json:"startTime,omitempty"`
+	// Output only. Time when the TuningJob entered any of the following states:
+	// `JOB_STATE_SUCCEEDED`, `JOB_STATE_FAILED`, `JOB_STATE_CANCELLED`,
+	// `JOB_STATE_EXPIRED`.
+	EndTime time.Time `json:"endTime,omitempty"`
+	// Output only. Time when the TuningJob was most recently updated.
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+	// Output only. Only populated when job's state is `JOB_STATE_FAILED` or
+	// `JOB_STATE_CANCELLED`.
+	Error *JobError `json:"error,omitempty"`
+	// Optional. The description of the TuningJob.
+	Description string `json:"description,omitempty"`
+	// The base model that is being tuned, e.g. "gemini-2.0-flash-001".
+	BaseModel string `json:"baseModel,omitempty"`
+	// Output only. The tuned model resources associated with this TuningJob.
+	TunedModel *TunedModel `json:"tunedModel,omitempty"`
+	// Optional. The display name of the TunedModel. The name can be up to 128
+	// characters long and can consist of any UTF-8 characters.
+	TunedModelDisplayName string `json:"tunedModelDisplayName,omitempty"`
+	// Output only. The Experiment associated with this TuningJob.
+	Experiment string `json:"experiment,omitempty"`
+	// Optional. The labels with user-defined metadata to organize TuningJob and
+	// generated resources such as Model and Endpoint.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Output only. The resource name of the PipelineJob associated with the
+	// TuningJob. Format:
+	// `projects/{project}/locations/{location}/pipelineJobs/{pipeline_job}`.
+	PipelineJob string `json:"pipelineJob,omitempty"`
+	// Output only. The tuning spec of supervised fine-tuning jobs.
+	SupervisedTuningSpec *SupervisedTuningSpec `json:"supervisedTuningSpec,omitempty"`
+	// Output only. The tuning spec of preference optimization tuning jobs.
+	PreferenceOptimizationSpec *PreferenceOptimizationSpec `json:"preferenceOptimizationSpec,omitempty"`
+}
+
+func (c *TuningJob) MarshalJSON() ([]byte, error) {
+	type Alias TuningJob
+	aux := &struct {
+		CreateTime *time.Time `json:"createTime,omitempty"`
+		StartTime  *time.Time `json:"startTime,omitempty"`
+		EndTime    *time.Time `json:"endTime,omitempty"`
+		UpdateTime *time.Time `json:"updateTime,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(c),
+	}
+
+	if !c.CreateTime.IsZero() {
+		aux.CreateTime = &c.CreateTime
+	}
+	if !c.StartTime.IsZero() {
+		aux.StartTime = &c.StartTime
+	}
+	if !c.EndTime.IsZero() {
+		aux.EndTime = &c.EndTime
+	}
+	if !c.UpdateTime.IsZero() {
+		aux.UpdateTime = &c.UpdateTime
+	}
+
+	return json.Marshal(aux)
+}
+
+// Hyperparameters for supervised fine-tuning.
+type SupervisedHyperParameters struct {
+	// Optional. Number of complete passes the model makes over the entire training
+	// dataset during training.
+	EpochCount int64 `json:"epochCount,omitempty,string"`
+	// Optional. Multiplier for adjusting the default learning rate.
+	LearningRateMultiplier float64 `json:"learningRateMultiplier,omitempty"`
+	// Optional. Adapter size for tuning.
+	AdapterSize AdapterSize `json:"adapterSize,omitempty"`
+}
+
+// Tuning spec for supervised fine-tuning.
+type SupervisedTuningSpec struct {
+	// Required. Cloud Storage URI or Vertex AI dataset resource of the training
+	// dataset.
+	TrainingDatasetURI string `json:"trainingDatasetUri,omitempty"`
+	// Optional. Cloud Storage URI or Vertex AI dataset resource of the validation
+	// dataset.
+	ValidationDatasetURI string `json:"validationDatasetUri,omitempty"`
+	// Optional. Hyperparameters for SFT.
+	HyperParameters *SupervisedHyperParameters `json:"hyperParameters,omitempty"`
+	// Optional. If true, only the last checkpoint of the tuning job is exported.
+	// Otherwise, intermediate checkpoints are exported too.
+	ExportLastCheckpointOnly bool `json:"exportLastCheckpointOnly,omitempty"`
+}
+
+// Hyperparameters for preference optimization tuning.
+type PreferenceOptimizationHyperParameters struct {
+	// Optional. Number of complete passes the model makes over the entire training
+	// dataset during training.
+	EpochCount int64 `json:"epochCount,omitempty,string"`
+	// Optional. Multiplier for adjusting the default learning rate.
+	LearningRateMultiplier float64 `json:"learningRateMultiplier,omitempty"`
+	// Optional. Adapter size for tuning.
+	AdapterSize AdapterSize `json:"adapterSize,omitempty"`
+	// Optional. Weight for the KL divergence regularization.
+	Beta float64 `json:"beta,omitempty"`
+}
+
+// Tuning spec for preference optimization tuning.
+type PreferenceOptimizationSpec struct {
+	// Required. Cloud Storage URI or Vertex AI dataset resource of the training
+	// dataset.
+	TrainingDatasetURI string `json:"trainingDatasetUri,omitempty"`
+	// Optional. Cloud Storage URI or Vertex AI dataset resource of the validation
+	// dataset.
+	ValidationDatasetURI string `json:"validationDatasetUri,omitempty"`
+	// Optional. Hyperparameters for preference optimization tuning.
+	HyperParameters *PreferenceOptimizationHyperParameters `json:"hyperParameters,omitempty"`
+	// Optional. If true, only the last checkpoint of the tuning job is exported.
+	// Otherwise, intermediate checkpoints are exported too.
+	ExportLastCheckpointOnly bool `json:"exportLastCheckpointOnly,omitempty"`
+}
+
+// Configuration for the list tuning jobs method.
+type ListTuningJobsConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. PageSize specifies the maximum number of tuning jobs to return per
+	// API call. If zero, the server will use a default value.
+	PageSize int32 `json:"pageSize,omitempty"`
+	// Optional. PageToken represents a token used for pagination in API responses. It's
+	// an opaque string that should be passed to subsequent requests to retrieve the next
+	// page of results. An empty PageToken typically indicates that there are no further
+	// pages available.
+	PageToken string `json:"pageToken,omitempty"`
+	// Optional. The standard list filter.
+	Filter string `json:"filter,omitempty"`
+}
+
+// Response for the list tuning jobs method.
+type ListTuningJobsResponse struct {
+	// A token to retrieve the next page of results. Pass to ListTuningJobsConfig.PageToken
+	// to obtain that page.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+	// List of TuningJobs in the requested page.
+	TuningJobs []*TuningJob `json:"tuningJobs,omitempty"`
+}
+
+// Optional parameters for tunings.cancel method.
+type CancelTuningJobConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+}
+
+// Supervised fine-tuning training dataset. Exactly one of GCSURI,
+// VertexDatasetResource and Examples must be set.
+type TuningDataset struct {
+	// Optional. Cloud Storage URI of the JSONL file of the dataset, e.g. uploaded
+	// with [Files.UploadTuningDataset]. This field is not supported in Gemini API.
+	GCSURI string `json:"gcsUri,omitempty"`
+	// Optional. The resource name of the Vertex AI Multimodal Dataset used as the
+	// dataset. Format: `projects/{project}/locations/{location}/datasets/{dataset}`.
+	// This field is not supported in Gemini API.
+	VertexDatasetResource string `json:"vertexDatasetResource,omitempty"`
+	// Optional. Inline examples, see [TuningDatasetBuilder]. This field is not
+	// supported in Vertex AI.
+	Examples []*TuningExample `json:"examples,omitempty"`
+}
+
+// Validation dataset of a tuning job, evaluated at the end of every epoch. Exactly
+// one of GCSURI and VertexDatasetResource must be set.
+type TuningValidationDataset struct {
+	// Optional. Cloud Storage URI of the JSONL file of the validation dataset.
+	GCSURI string `json:"gcsUri,omitempty"`
+	// Optional. The resource name of the Vertex AI Multimodal Dataset used as the
+	// validation dataset. Format:
+	// `projects/{project}/locations/{location}/datasets/{dataset}`.
+	VertexDatasetResource string `json:"vertexDatasetResource,omitempty"`
+}
+
+// Supervised fine-tuning job creation request - optional fields.
+type CreateTuningJobConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. The tuning method. Defaults to
+	// [TuningMethodSupervisedFineTuning].
+	Method TuningMethod `json:"method,omitempty"`
+	// Optional. Validation dataset for tuning. This field is not supported in
+	// Gemini API.
+	ValidationDataset *TuningValidationDataset `json:"validationDataset,omitempty"`
+	// Optional. The display name of the tuned model. The name can be up to 128
+	// characters long and can consist of any UTF-8 characters.
+	TunedModelDisplayName string `json:"tunedModelDisplayName,omitempty"`
+	// Optional. The description of the TuningJob.
+	Description string `json:"description,omitempty"`
+	// Optional. Number of complete passes the model makes over the entire training
+	// dataset during training.
+	EpochCount *int32 `json:"epochCount,omitempty"`
+	// Optional. Multiplier for adjusting the default learning rate.
+	LearningRateMultiplier *float32 `json:"learningRateMultiplier,omitempty"`
+	// Optional. Adapter size for tuning. This field is not supported in Gemini API.
+	AdapterSize AdapterSize `json:"adapterSize,omitempty"`
+	// Optional. If true, only the last checkpoint of the tuned model is exported.
+	// Otherwise, the intermediate checkpoints are exported too, see
+	// [TunedModel.Checkpoints]. This field is not supported in Gemini API.
+	ExportLastCheckpointOnly *bool `json:"exportLastCheckpointOnly,omitempty"`
+	// Optional. The batch size hyperparameter for tuning. This field is not
+	// supported in Vertex AI.
+	BatchSize *int32 `json:"batchSize,omitempty"`
+	// Optional. The learning rate hyperparameter for tuning. This field is not
+	// supported in Vertex AI.
+	LearningRate *float32 `json:"learningRate,omitempty"`
+	// Optional. The labels with user-defined metadata to organize the TuningJob.
+	// This field is not supported in Gemini API.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Optional. Weight for the KL divergence regularization of preference tuning.
+	// Only used with [TuningMethodPreferenceTuning]. This field is not supported in
+	// Gemini API.
+	Beta *float32 `json:"beta,omitempty"`
+}
+
+// Config for the source of a batch job. Exactly one of GCSURI, BigqueryURI,
+// FileName and InlinedRequests must be set.
+type BatchJobSource struct {
+	// Optional. Storage format of the input files. Must be one of:
+	// 'jsonl', 'bigquery'. Inferred from the URIs if empty.
+	Format string `json:"format,omitempty"`
+	// Optional. The Google Cloud Storage URIs to input files.
+	GCSURI []string `json:"gcsUri,omitempty"`
+	// Optional. The BigQuery URI to input table, e.g. "bq://project.dataset.table".
+	// This field is not supported in Gemini API.
+	BigqueryURI string `json:"bigqueryUri,omitempty"`
+	// Optional. The Gemini Developer API's file resource name of the input data,
+	// e.g. "files/12345", see [Batches.UploadRequests]. This field is not supported
+	// in Vertex AI.
+	FileName string `json:"fileName,omitempty"`
+	// Optional. The Gemini Developer API's inlined input data to run batch job. This
+	// field is not supported in Vertex AI.
+	InlinedRequests []*InlinedRequest `json:"inlinedRequests,omitempty"`
+}
+
+// Config for inlined request.
+type InlinedRequest struct {
+	// Optional. ID of the model to use. Defaults to the model of the batch job.
+	Model string `json:"model,omitempty"`
+	// Content of the request.
+	Contents []*Content `json:"contents,omitempty"`
+	// Optional. The metadata to be associated with the request, e.g. {"key":
+	// "request-1"} to match the request with its response.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Optional. Configuration that contains optional model parameters.
+	Config *GenerateContentConfig `json:"config,omitempty"`
+}
+
+// Config for the response of an inlined request.
+type InlinedResponse struct {
+	// The response to the request.
+	Response *GenerateContentResponse `json:"response,omitempty"`
+	// The error encountered while processing the request.
+	Error *JobError `json:"error,omitempty"`
+	// The metadata of the request.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Config for the destination of a batch job. Exactly one of GCSURI and BigqueryURI
+// must be set.
+type BatchJobDestination struct {
+	// Optional. Storage format of the output files. Must be one of:
+	// 'jsonl', 'bigquery'. Inferred from the URI if empty.
+	Format string `json:"format,omitempty"`
+	// Optional. The Google Cloud Storage URI prefix of the output files.
+	GCSURI string `json:"gcsUri,omitempty"`
+	// Optional. The BigQuery URI to the output table, e.g.
+	// "bq://project.dataset.table".
+	BigqueryURI string `json:"bigqueryUri,omitempty"`
+	// Output only. The Gemini Developer API's file resource name of the output
+	// data, e.g. "files/12345". Set for batch jobs whose source is a file.
+	FileName string `json:"fileName,omitempty"`
+	// Output only. The responses to the requests of the batch job. Set for Gemini
+	// Developer API batch jobs whose source is inlined requests.
+	InlinedResponses []*InlinedResponse `json:"inlinedResponses,omitempty"`
+}
+
+// Config for optional parameters of the create batch job method.
+type CreateBatchJobConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. The user-defined name of this BatchJob.
+	DisplayName string `json:"displayName,omitempty"`
+	// Optional. GCS or BigQuery URI prefix for the output predictions. Example:
+	// "gs://path/to/output/data" or "bq://projectId.bqDatasetId". If not specified,
+	// it is derived from the source, see [Batches.Create]. This field is not
+	// supported in Gemini API.
+	Dest *BatchJobDestination `json:"dest,omitempty"`
+}
+
+// Config for a batch job.
+type BatchJob struct {
+	// Output only. Resource name of the Job.
+	Name string `json:"name,omitempty"`
+	// Output only. The user-defined name of this Job.
+	DisplayName string `json:"displayName,omitempty"`
+	// Output only. Job state.
+	State JobState `json:"state,omitempty"`
+	// Output only. Only populated when the job's state is JOB_STATE_FAILED or
+	// JOB_STATE_CANCELLED.
+	Error *JobError `json:"error,omitempty"`
+	// Output only. Time when the Job was created.
+	CreateTime time.Time `json:"createTime,omitempty"`
+	// Output only. Time when the Job for the first time entered the
+	// `JOB_STATE_RUNNING` state.
+	StartTime time.Time `json:"startTime,omitempty"`
+	// Output only. Time when the Job entered any of the following states:
+	// `JOB_STATE_SUCCEEDED`, `JOB_STATE_FAILED`, `JOB_STATE_CANCELLED`.
+	EndTime time.Time `json:"endTime,omitempty"`
+	// Output only. Time when the Job was most recently updated.
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+	// The name of the model that produces the predictions via the BatchJob.
+	Model string `json:"model,omitempty"`
+	// Configuration for the input data.
+	Src *BatchJobSource `json:"src,omitempty"`
+	// Configuration for the output data.
+	Dest *BatchJobDestination `json:"dest,omitempty"`
+	// Output only. Statistics on the requests of the batch job.
+	Stats *BatchJobStats `json:"stats,omitempty"`
+	// Output only. Information on the output of the batch job. Only populated in
+	// Vertex AI.
+	OutputInfo *BatchJobOutputInfo `json:"outputInfo,omitempty"`
+}
+
+// Information on the output of a Vertex AI batch job.
+type BatchJobOutputInfo struct {
+	// Output only. The Cloud Storage directory into which the responses are written.
+	GCSOutputDirectory string `json:"gcsOutputDirectory,omitempty"`
+	// Output only. The BigQuery dataset into which the responses are written, e.g.
+	// "bq://project.dataset".
+	BigqueryOutputDataset string `json:"bigqueryOutputDataset,omitempty"`
+	// Output only. The ID of the table of the BigQuery dataset into which the
+	// responses are written.
+	BigqueryOutputTable string `json:"bigqueryOutputTable,omitempty"`
+}
+
+// Statistics on the requests of a batch job.
+type BatchJobStats struct {
+	// Output only. The number of requests of the batch job. Only populated in Gemini
+	// API.
+	RequestCount int64 `json:"requestCount,omitempty,string"`
+	// Output only. The number of requests that succeeded.
+	SucceededRequestCount int64 `json:"succeededRequestCount,omitempty,string"`
+	// Output only. The number of requests that failed.
+	FailedRequestCount int64 `json:"failedRequestCount,omitempty,string"`
+	// Output only. The number of requests that are still being processed.
+	PendingRequestCount int64 `json:"pendingRequestCount,omitempty,string"`
+}
+
+func (c *BatchJob) MarshalJSON() ([]byte, error) {
+	type Alias BatchJob
+	aux := &struct {
+		CreateTime *time.Time `json:"createTime,omitempty"`
+		StartTime  *time.Time `json:"startTime,omitempty"`
+		EndTime    *time.Time `json:"endTime,omitempty"`
+		UpdateTime *time.Time `json:"updateTime,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(c),
+	}
+
+	if !c.CreateTime.IsZero() {
+		aux.CreateTime = &c.CreateTime
+	}
+	if !c.StartTime.IsZero() {
+		aux.StartTime = &c.StartTime
+	}
+	if !c.EndTime.IsZero() {
+		aux.EndTime = &c.EndTime
+	}
+	if !c.UpdateTime.IsZero() {
+		aux.UpdateTime = &c.UpdateTime
+	}
+
+	return json.Marshal(aux)
+}
+
+// Optional parameters for the get batch job method.
+type GetBatchJobConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+}
+
+// Optional parameters for the cancel batch job method.
+type CancelBatchJobConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+}
+
+// Config for optional parameters of the list batch jobs method.
+type ListBatchJobsConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. PageSize specifies the maximum number of batch jobs to return per
+	// page.
+	PageSize int32 `json:"pageSize,omitempty"`
+	// Optional. PageToken represents a token used for pagination in API responses.
+	PageToken string `json:"pageToken,omitempty"`
+	// Optional. The standard list filter.
+	Filter string `json:"filter,omitempty"`
+}
+
+// Config for batches.list return value.
+type ListBatchJobsResponse struct {
+	// A token to retrieve the next page of results. Pass to
+	// ListBatchJobsConfig.PageToken to obtain that page.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+	// List of BatchJobs in the requested page.
+	BatchJobs []*BatchJob `json:"batchJobs,omitempty"`
+}
+
+// Optional parameters for the delete batch job method.
+type DeleteBatchJobConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+}
+
+// The return value of delete operation.
+type DeleteResourceJob struct {
+	// The name of the long-running deletion operation.
+	Name string `json:"name,omitempty"`
+	// Whether the deletion completed.
+	Done bool `json:"done,omitempty"`
+	// The error of the deletion, if it failed.
+	Error *JobError `json:"error,omitempty"`
+}
+
 // Used to override the default configuration.
 type ListFilesConfig struct {
 	// Optional. Used to override HTTP request options.
@@ -3093,6 +3764,7 @@ type File struct {
 //   - NewDownloadURIFromFile
 //   - NewDownloadURIFromVideo
 //   - NewDownloadURIFromGeneratedVideo
+//   - NewDownloadURIFromName
 //   - ...
 type DownloadURI interface {
 	uri() string
@@ -3114,6 +3786,22 @@ func NewDownloadURIFromGeneratedVideo(v *GeneratedVideo) DownloadURI {
 	return v
 }
 
+// NewDownloadURIFromName creates a DownloadURI from the name of a file, e.g.
+// "files/abc", or from its download URI.
+func NewDownloadURIFromName(name string) DownloadURI {
+	return fileNameDownloadURI(name)
+}
+
+type fileNameDownloadURI string
+
+func (n fileNameDownloadURI) uri() string {
+	return string(n)
+}
+
+func (n fileNameDownloadURI) setVideoBytes(b []byte) bool {
+	return false
+}
+
 func (f *File) uri() string {
 	return f.DownloadURI
 }
@@ -3212,11 +3900,45 @@ type DeleteFileConfig struct {
 type DeleteFileResponse struct {
 }
 
+// A long-running operation, e.g. of the creation of a tuning job or of a batch
+// job.
+type Operation struct {
+	// The server-assigned name, which is only unique within the same service that originally
+	// returns it. If you use the default HTTP mapping, the `name` should be a resource
+	// name ending with `operations/{unique_id}`.
+	Name string `json:"name,omitempty"`
+	// Optional. Service-specific metadata associated with the operation. It typically contains
+	// progress information and common metadata such as create time. Some services might
+	// not provide such metadata. Any method that returns a long-running operation should
+	// document the metadata type, if any.
+	Metadata map[string]any `json:"metadata,omitempty"`
+	// If the value is `false`, it means the operation is still in progress. If `true`,
+	// the operation is completed, and either `error` or `response` is available.
+	Done bool `json:"done,omitempty"`
+	// Optional. The error result of the operation in case of failure or cancellation.
+	Error map[string]any `json:"error,omitempty"`
+	// Optional. The normal response of the operation in case of success. Its type
+	// depends on the method which started the operation.
+	Response map[string]any `json:"response,omitempty"`
+}
+
 type GetOperationConfig struct {
 	// Optional. Used to override HTTP request options.
 	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
 }
 
+// Optional parameters for the cancel operation method.
+type CancelOperationConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+}
+
+// Optional parameters for the delete operation method.
+type DeleteOperationConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+}
+
 type FetchPredictOperationConfig struct {
 	// Optional. Used to override HTTP request options.
 	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
@@ -3304,6 +4026,25 @@ type UploadFileConfig struct {
 	MIMEType string `json:"mimeType,omitempty"`
 	// Optional. Optional display name of the file.
 	DisplayName string `json:"displayName,omitempty"`
+	// Optional. Called after every chunk of the file is uploaded, e.g. to show the
+	// progress of a large video upload.
+	Progress func(UploadProgress) `json:"-"`
+	// Optional. Verifies that the SHA-256 hash of the uploaded file computed by the
+	// server matches the hash of the uploaded content. On a mismatch, the upload
+	// returns the file along with a [ChecksumMismatchError].
+	VerifyChecksum bool `json:"-"`
+}
+
+// UploadProgress reports the progress of a file upload.
+type UploadProgress struct {
+	// The number of bytes uploaded so far.
+	BytesSent int64
+	// The size of the file in bytes, or 0 if unknown.
+	TotalBytes int64
+	// The index of the chunk just uploaded, starting at 0.
+	Chunk int
+	// The size of the chunk just uploaded in bytes.
+	ChunkBytes int
 }
 
 // Used to override the default configuration.
@@ -3579,6 +4320,10 @@ func (c *LiveServerGoAway) UnmarshalJSON(data []byte) error {
 		Alias: (*Alias)(c),
 	}
 
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
 	if aux.TimeLeft != "" {
 		d, err := time.ParseDuration(aux.TimeLeft)
 		if err != nil {
@@ -4021,12 +4766,18 @@ type LiveConnectConfig struct {
 	// Optional. Configures the realtime input behavior in BidiGenerateContent.
 	RealtimeInputConfig *RealtimeInputConfig `json:"realtimeInputConfig,omitempty"`
 	// Optional. Configures context window compression mechanism.
-	// If included, server will compress context window to fit into given length.
+	// If included, server will compress context window to fit into given length,
+	// so that long sessions are not terminated when the context window is full.
+	// The sliding window is used if no mechanism is set, see
+	// [NewSlidingWindowCompression].
 	ContextWindowCompression *ContextWindowCompressionConfig `json:"contextWindowCompression,omitempty"`
 	// Optional. Configures the proactivity of the model. This allows the model to respond
 	// proactively to
 	// the input and to ignore irrelevant input.
 	Proactivity *ProactivityConfig `json:"proactivity,omitempty"`
+	// Optional. Configures the automatic reconnection of the session when the
+	// server terminates its connection. It is not sent to the server.
+	Reconnect *LiveReconnectConfig `json:"-"`
 }
 
 // Parameters for sending client content to the live API.
@@ -4095,3 +4846,592 @@ type CreateAuthTokenConfig struct {
 	// Optional. Additional fields to lock in the effective LiveConnectParameters.
 	LockAdditionalFields []string `json:"lockAdditionalFields,omitempty"`
 }
+
+func (c *CreateAuthTokenConfig) MarshalJSON() ([]byte, error) {
+	type Alias CreateAuthTokenConfig
+	aux := &struct {
+		ExpireTime           *time.Time `json:"expireTime,omitempty"`
+		NewSessionExpireTime *time.Time `json:"newSessionExpireTime,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(c),
+	}
+
+	if !c.ExpireTime.IsZero() {
+		aux.ExpireTime = &c.ExpireTime
+	}
+	if !c.NewSessionExpireTime.IsZero() {
+		aux.NewSessionExpireTime = &c.NewSessionExpireTime
+	}
+
+	return json.Marshal(aux)
+}
+
+// A short-lived token, to connect to the Live API from the clients of an
+// application, e.g. browsers or mobile apps, in place of an API key.
+type AuthToken struct {
+	// The name of the token, to be used as the API key of the client connecting
+	// to the Live API, e.g. "auth_tokens/...".
+	Name string `json:"name,omitempty"`
+}
+
+// The state of a Chunk.
+type ChunkState string
+
+const (
+	// The default value. This value is used if the state is omitted.
+	ChunkStateUnspecified ChunkState = "STATE_UNSPECIFIED"
+	// The chunk is being processed (embedding and vector storage).
+	ChunkStatePendingProcessing ChunkState = "STATE_PENDING_PROCESSING"
+	// The chunk is processed and available for querying.
+	ChunkStateActive ChunkState = "STATE_ACTIVE"
+	// The chunk failed processing.
+	ChunkStateFailed ChunkState = "STATE_FAILED"
+)
+
+// The operator of a metadata filter Condition.
+type ConditionOperator string
+
+const (
+	// The default value. This value is unused.
+	ConditionOperatorUnspecified ConditionOperator = "OPERATOR_UNSPECIFIED"
+	// Supported by numeric values.
+	ConditionOperatorLess ConditionOperator = "LESS"
+	// Supported by numeric values.
+	ConditionOperatorLessEqual ConditionOperator = "LESS_EQUAL"
+	// Supported by numeric and string values.
+	ConditionOperatorEqual ConditionOperator = "EQUAL"
+	// Supported by numeric values.
+	ConditionOperatorGreaterEqual ConditionOperator = "GREATER_EQUAL"
+	// Supported by numeric values.
+	ConditionOperatorGreater ConditionOperator = "GREATER"
+	// Supported by numeric and string values.
+	ConditionOperatorNotEqual ConditionOperator = "NOT_EQUAL"
+	// Supported by string values, when the metadata has a string list value.
+	ConditionOperatorIncludes ConditionOperator = "INCLUDES"
+	// Supported by string values, when the metadata has a string list value.
+	ConditionOperatorExcludes ConditionOperator = "EXCLUDES"
+)
+
+// A collection of Documents, for semantic retrieval.
+type Corpus struct {
+	// Immutable. Identifier. The name of the corpus, e.g. "corpora/my-corpus-123".
+	Name string `json:"name,omitempty"`
+	// Optional. The human-readable display name of the corpus.
+	DisplayName string `json:"displayName,omitempty"`
+	// Output only. The timestamp of when the corpus was created.
+	CreateTime time.Time `json:"createTime,omitempty"`
+	// Output only. The timestamp of when the corpus was last updated.
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+}
+
+func (c *Corpus) MarshalJSON() ([]byte, error) {
+	type Alias Corpus
+	aux := &struct {
+		CreateTime *time.Time `json:"createTime,omitempty"`
+		UpdateTime *time.Time `json:"updateTime,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(c),
+	}
+
+	if !c.CreateTime.IsZero() {
+		aux.CreateTime = &c.CreateTime
+	}
+	if !c.UpdateTime.IsZero() {
+		aux.UpdateTime = &c.UpdateTime
+	}
+
+	return json.Marshal(aux)
+}
+
+// A list of string values of a CustomMetadata.
+type StringList struct {
+	// The string values of the metadata.
+	Values []string `json:"values,omitempty"`
+}
+
+// User provided metadata of a Document or Chunk, stored as a key-value pair. Only one
+// of the values may be set.
+type CustomMetadata struct {
+	// Required. The key of the metadata.
+	Key string `json:"key,omitempty"`
+	// The string value of the metadata.
+	StringValue string `json:"stringValue,omitempty"`
+	// The string list value of the metadata.
+	StringListValue *StringList `json:"stringListValue,omitempty"`
+	// The numeric value of the metadata.
+	NumericValue *float32 `json:"numericValue,omitempty"`
+}
+
+// A collection of Chunks in a Corpus.
+type Document struct {
+	// Immutable. Identifier. The name of the document, e.g.
+	// "corpora/my-corpus-123/documents/the-doc-abc".
+	Name string `json:"name,omitempty"`
+	// Optional. The human-readable display name of the document.
+	DisplayName string `json:"displayName,omitempty"`
+	// Optional. User provided custom metadata of the document, which can be used
+	// to filter the queries. A document can have up to 20 metadata.
+	CustomMetadata []*CustomMetadata `json:"customMetadata,omitempty"`
+	// Output only. The timestamp of when the document was created.
+	CreateTime time.Time `json:"createTime,omitempty"`
+	// Output only. The timestamp of when the document was last updated.
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+}
+
+func (c *Document) MarshalJSON() ([]byte, error) {
+	type Alias Document
+	aux := &struct {
+		CreateTime *time.Time `json:"createTime,omitempty"`
+		UpdateTime *time.Time `json:"updateTime,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(c),
+	}
+
+	if !c.CreateTime.IsZero() {
+		aux.CreateTime = &c.CreateTime
+	}
+	if !c.UpdateTime.IsZero() {
+		aux.UpdateTime = &c.UpdateTime
+	}
+
+	return json.Marshal(aux)
+}
+
+// The content of a Chunk.
+type ChunkData struct {
+	// The content of the chunk as a string. The maximum number of tokens per chunk
+	// is 2043.
+	StringValue string `json:"stringValue,omitempty"`
+}
+
+// A subpart of a Document, the unit of semantic retrieval.
+type Chunk struct {
+	// Immutable. Identifier. The name of the chunk, e.g.
+	// "corpora/my-corpus-123/documents/the-doc-abc/chunks/some-chunk".
+	Name string `json:"name,omitempty"`
+	// Required. The content of the chunk.
+	Data *ChunkData `json:"data,omitempty"`
+	// Optional. User provided custom metadata of the chunk, which can be used to
+	// filter the queries. A chunk can have up to 20 metadata.
+	CustomMetadata []*CustomMetadata `json:"customMetadata,omitempty"`
+	// Output only. The timestamp of when the chunk was created.
+	CreateTime time.Time `json:"createTime,omitempty"`
+	// Output only. The timestamp of when the chunk was last updated.
+	UpdateTime time.Time `json:"updateTime,omitempty"`
+	// Output only. The processing state of the chunk.
+	State ChunkState `json:"state,omitempty"`
+}
+
+func (c *Chunk) MarshalJSON() ([]byte, error) {
+	type Alias Chunk
+	aux := &struct {
+		CreateTime *time.Time `json:"createTime,omitempty"`
+		UpdateTime *time.Time `json:"updateTime,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(c),
+	}
+
+	if !c.CreateTime.IsZero() {
+		aux.CreateTime = &c.CreateTime
+	}
+	if !c.UpdateTime.IsZero() {
+		aux.UpdateTime = &c.UpdateTime
+	}
+
+	return json.Marshal(aux)
+}
+
+// A condition on the value of a metadata of a MetadataFilter.
+type Condition struct {
+	// Required. The operator applied to the value and the metadata.
+	Operation ConditionOperator `json:"operation,omitempty"`
+	// The string value to compare the metadata with.
+	StringValue string `json:"stringValue,omitempty"`
+	// The numeric value to compare the metadata with.
+	NumericValue *float32 `json:"numericValue,omitempty"`
+}
+
+// A filter on the CustomMetadata of the Documents and Chunks of a query. The
+// conditions of a filter are joined by OR, and the filters by AND.
+type MetadataFilter struct {
+	// Required. The key of the metadata to filter on.
+	Key string `json:"key,omitempty"`
+	// Required. The conditions on the value of the metadata, joined by OR.
+	Conditions []*Condition `json:"conditions,omitempty"`
+}
+
+// Optional parameters for the create corpus method.
+type CreateCorpusConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. The human-readable display name of the corpus, up to 512 characters.
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// Optional parameters for the get corpus method.
+type GetCorpusConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+}
+
+// Optional parameters for the list corpora method.
+type ListCorporaConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. The maximum number of corpora to return per page, at most 20. If
+	// zero, the server will use a default value.
+	PageSize int32 `json:"pageSize,omitempty"`
+	// Optional. A token received from a previous list call, to retrieve the next page.
+	PageToken string `json:"pageToken,omitempty"`
+}
+
+// Response for the list corpora method.
+type ListCorporaResponse struct {
+	// A token to retrieve the next page of results.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+	// The list of corpora.
+	Corpora []*Corpus `json:"corpora,omitempty"`
+}
+
+// Optional parameters for the update corpus method. Only the set fields are updated.
+type UpdateCorpusConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. The new display name of the corpus.
+	DisplayName string `json:"displayName,omitempty"`
+}
+
+// Optional parameters for the delete corpus method.
+type DeleteCorpusConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. If true, the documents and chunks of the corpus are also deleted.
+	// Otherwise, the deletion fails if the corpus has documents.
+	Force bool `json:"force,omitempty"`
+}
+
+// Optional parameters for the query corpus method.
+type QueryCorpusConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. Filters on the metadata of the documents and chunks, joined by AND.
+	MetadataFilters []*MetadataFilter `json:"metadataFilters,omitempty"`
+	// Optional. The maximum number of chunks to return, at most 100. If zero, the
+	// server will use a default value of 10.
+	ResultsCount int32 `json:"resultsCount,omitempty"`
+}
+
+// A chunk relevant to a query.
+type RelevantChunk struct {
+	// The relevance of the chunk to the query.
+	ChunkRelevanceScore float32 `json:"chunkRelevanceScore,omitempty"`
+	// The chunk associated with the query.
+	Chunk *Chunk `json:"chunk,omitempty"`
+}
+
+// Response for the query corpus method.
+type QueryCorpusResponse struct {
+	// The relevant chunks, from the most relevant.
+	RelevantChunks []*RelevantChunk `json:"relevantChunks,omitempty"`
+}
+
+// Optional parameters for the create document method.
+type CreateDocumentConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. The human-readable display name of the document, up to 512 characters.
+	DisplayName string `json:"displayName,omitempty"`
+	// Optional. User provided custom metadata of the document.
+	CustomMetadata []*CustomMetadata `json:"customMetadata,omitempty"`
+}
+
+// Optional parameters for the get document method.
+type GetDocumentConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+}
+
+// Optional parameters for the list documents method.
+type ListDocumentsConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. The maximum number of documents to return per page, at most 20. If
+	// zero, the server will use a default value.
+	PageSize int32 `json:"pageSize,omitempty"`
+	// Optional. A token received from a previous list call, to retrieve the next page.
+	PageToken string `json:"pageToken,omitempty"`
+}
+
+// Response for the list documents method.
+type ListDocumentsResponse struct {
+	// A token to retrieve the next page of results.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+	// The list of documents.
+	Documents []*Document `json:"documents,omitempty"`
+}
+
+// Optional parameters for the update document method. Only the set fields are
+// updated.
+type UpdateDocumentConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. The new display name of the document.
+	DisplayName string `json:"displayName,omitempty"`
+	// Optional. The new custom metadata of the document, replacing all the previous
+	// ones.
+	CustomMetadata []*CustomMetadata `json:"customMetadata,omitempty"`
+}
+
+// Optional parameters for the delete document method.
+type DeleteDocumentConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. If true, the chunks of the document are also deleted. Otherwise,
+	// the deletion fails if the document has chunks.
+	Force bool `json:"force,omitempty"`
+}
+
+// Optional parameters for the create chunk method.
+type CreateChunkConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. User provided custom metadata of the chunk.
+	CustomMetadata []*CustomMetadata `json:"customMetadata,omitempty"`
+}
+
+// Optional parameters for the get chunk method.
+type GetChunkConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+}
+
+// Optional parameters for the list chunks method.
+type ListChunksConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. The maximum number of chunks to return per page, at most 100. If
+	// zero, the server will use a default value.
+	PageSize int32 `json:"pageSize,omitempty"`
+	// Optional. A token received from a previous list call, to retrieve the next page.
+	PageToken string `json:"pageToken,omitempty"`
+}
+
+// Response for the list chunks method.
+type ListChunksResponse struct {
+	// A token to retrieve the next page of results.
+	NextPageToken string `json:"nextPageToken,omitempty"`
+	// The list of chunks.
+	Chunks []*Chunk `json:"chunks,omitempty"`
+}
+
+// Optional parameters for the update chunk method. Only the set fields are updated.
+type UpdateChunkConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. The new content of the chunk.
+	Data *ChunkData `json:"data,omitempty"`
+	// Optional. The new custom metadata of the chunk, replacing all the previous
+	// ones.
+	CustomMetadata []*CustomMetadata `json:"customMetadata,omitempty"`
+}
+
+// Optional parameters for the delete chunk method.
+type DeleteChunkConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+}
+
+// The style in which grounded answers are returned.
+type AnswerStyle string
+
+const (
+	// Unspecified answer style.
+	AnswerStyleUnspecified AnswerStyle = "ANSWER_STYLE_UNSPECIFIED"
+	// Succinct but abstract style.
+	AnswerStyleAbstractive AnswerStyle = "ABSTRACTIVE"
+	// Very brief and extractive style.
+	AnswerStyleExtractive AnswerStyle = "EXTRACTIVE"
+	// Verbose style including extra details. The response may be formatted as a
+	// sentence, paragraph, multiple paragraphs, or bullet points, etc.
+	AnswerStyleVerbose AnswerStyle = "VERBOSE"
+)
+
+// A passage included inline in a grounding configuration.
+type GroundingPassage struct {
+	// Identifier for the passage for attributing this passage in grounded answers.
+	ID string `json:"id,omitempty"`
+	// Content of the passage.
+	Content *Content `json:"content,omitempty"`
+}
+
+// Configuration for retrieving grounding content from a Corpus or Document created
+// with the semantic retrieval API, see [Corpora].
+type SemanticRetrieverConfig struct {
+	// Required. Name of the resource for retrieval, e.g. "corpora/123" or
+	// "corpora/123/documents/abc".
+	Source string `json:"source,omitempty"`
+	// Required. Query to use for matching the chunks of the resource by similarity.
+	Query *Content `json:"query,omitempty"`
+	// Optional. Filters on the metadata of the documents and chunks, joined by AND.
+	MetadataFilters []*MetadataFilter `json:"metadataFilters,omitempty"`
+	// Optional. Maximum number of relevant chunks to retrieve.
+	MaxChunksCount int32 `json:"maxChunksCount,omitempty"`
+	// Optional. Minimum relevance score for the retrieved relevant chunks.
+	MinimumRelevanceScore *float32 `json:"minimumRelevanceScore,omitempty"`
+}
+
+// Optional parameters for the generate answer method. Exactly one of InlinePassages
+// and SemanticRetriever must be set.
+type GenerateAnswerConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. Style in which the answer is returned.
+	AnswerStyle AnswerStyle `json:"answerStyle,omitempty"`
+	// Optional. Passages provided inline with the request, to ground the answer on.
+	InlinePassages []*GroundingPassage `json:"inlinePassages,omitempty"`
+	// Optional. Content retrieved from resources created via the semantic retrieval
+	// API, to ground the answer on.
+	SemanticRetriever *SemanticRetrieverConfig `json:"semanticRetriever,omitempty"`
+	// Optional. Safety settings for blocking unsafe content.
+	SafetySettings []*SafetySetting `json:"safetySettings,omitempty"`
+	// Optional. Controls the randomness of the output, between 0 and 1. A value
+	// close to 0 is usually recommended for grounded answers.
+	Temperature *float32 `json:"temperature,omitempty"`
+}
+
+// Identifier for a part within a GroundingPassage.
+type GroundingPassageID struct {
+	// Output only. ID of the passage matching the ID of GroundingPassage.
+	PassageID string `json:"passageId,omitempty"`
+	// Output only. Index of the part within the content of the passage.
+	PartIndex int32 `json:"partIndex,omitempty"`
+}
+
+// Identifier for a Chunk retrieved via the SemanticRetrieverConfig.
+type SemanticRetrieverChunk struct {
+	// Output only. Name of the source matching the source of the
+	// SemanticRetrieverConfig, e.g. "corpora/123".
+	Source string `json:"source,omitempty"`
+	// Output only. Name of the chunk containing the attributed text, e.g.
+	// "corpora/123/documents/abc/chunks/xyz".
+	Chunk string `json:"chunk,omitempty"`
+}
+
+// Identifier for the source contributing to an attribution. Only one of the fields
+// is set.
+type AttributionSourceID struct {
+	// Identifier for an inline passage.
+	GroundingPassage *GroundingPassageID `json:"groundingPassage,omitempty"`
+	// Identifier for a chunk fetched via the semantic retriever.
+	SemanticRetrieverChunk *SemanticRetrieverChunk `json:"semanticRetrieverChunk,omitempty"`
+}
+
+// Attribution for a source that contributed to an answer.
+type GroundingAttribution struct {
+	// Output only. Identifier for the source contributing to this attribution.
+	SourceID *AttributionSourceID `json:"sourceId,omitempty"`
+	// Grounding source content that makes up this attribution.
+	Content *Content `json:"content,omitempty"`
+}
+
+// Feedback related to the input data used to answer the question, as opposed to the
+// model-generated response to the question.
+type GenerateAnswerInputFeedback struct {
+	// Optional. If set, the input was blocked and no candidates are returned.
+	BlockReason BlockedReason `json:"blockReason,omitempty"`
+	// Ratings for safety of the input. There is at most one rating per category.
+	SafetyRatings []*SafetyRating `json:"safetyRatings,omitempty"`
+}
+
+// Response from the model for a grounded answer.
+type GenerateAnswerResponse struct {
+	// The answer from the model, with its GroundingAttributions.
+	Answer *Candidate `json:"answer,omitempty"`
+	// Output only. The model's estimate of the probability that its answer is correct
+	// and grounded in the input passages. A low value indicates that the answer
+	// might not be grounded in the sources; it's recommended to check it, e.g. to
+	// answer "I don't know" below a threshold.
+	AnswerableProbability *float32 `json:"answerableProbability,omitempty"`
+	// Output only. Feedback related to the input data used to answer the question.
+	InputFeedback *GenerateAnswerInputFeedback `json:"inputFeedback,omitempty"`
+}
+
+// A record to rank with Models.Rerank, e.g. a document retrieved for a query.
+type RankingRecord struct {
+	// Optional. The unique ID of the record, to identify it in the response.
+	ID string `json:"id,omitempty"`
+	// Optional. The title of the record. Either the title or the content must be set.
+	Title string `json:"title,omitempty"`
+	// Optional. The content of the record. Either the title or the content must be
+	// set.
+	Content string `json:"content,omitempty"`
+	// Output only. The relevance of the record to the query, between 0 and 1.
+	Score float32 `json:"score,omitempty"`
+}
+
+// Optional parameters for the rerank method.
+type RerankConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. The number of records to return. If zero, all the records are
+	// returned.
+	TopN int32 `json:"topN,omitempty"`
+	// Optional. If true, only the IDs and scores of the records are returned.
+	IgnoreRecordDetailsInResponse bool `json:"ignoreRecordDetailsInResponse,omitempty"`
+	// Optional. The name of the ranking config of the project. Defaults to
+	// "default_ranking_config".
+	RankingConfig string `json:"rankingConfig,omitempty"`
+}
+
+// Response for the rerank method.
+type RerankResponse struct {
+	// The records, sorted by descending score.
+	Records []*RankingRecord `json:"records,omitempty"`
+}
+
+// Optional parameters for the RawPredict and StreamRawPredict methods.
+type RawPredictConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+}
+
+// Response of the RawPredict method, or event of the StreamRawPredict method.
+type RawPredictResponse struct {
+	// The type of a streamed event, e.g. "content_block_delta" for Anthropic models.
+	// Empty for a unary response and for events without type.
+	Event string `json:"event,omitempty"`
+	// The content type of a unary response.
+	ContentType string `json:"contentType,omitempty"`
+	// The body of a unary response, or the data of a streamed event.
+	Body []byte `json:"body,omitempty"`
+	// Optional. Used to retain the HTTP headers of a unary response.
+	HTTPHeaders http.Header `json:"httpHeaders,omitempty"`
+}
+
+// Optional parameters for the Predict method.
+type PredictConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions `json:"httpOptions,omitempty"`
+	// Optional. The parameters of the prediction, whose schema is defined by the
+	// model deployed to the endpoint.
+	Parameters any `json:"parameters,omitempty"`
+}
+
+// Response of the Predict method.
+type PredictResponse struct {
+	// The predictions, one per instance, whose schema is defined by the model
+	// deployed to the endpoint.
+	Predictions []json.RawMessage `json:"predictions,omitempty"`
+	// The ID of the deployed model which served the prediction.
+	DeployedModelID string `json:"deployedModelId,omitempty"`
+	// The resource name of the model which served the prediction.
+	Model string `json:"model,omitempty"`
+	// The display name of the model which served the prediction.
+	ModelDisplayName string `json:"modelDisplayName,omitempty"`
+	// The version ID of the model which served the prediction.
+	ModelVersionID string `json:"modelVersionId,omitempty"`
+}
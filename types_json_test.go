@@ -255,6 +255,17 @@ func TestUnmarshalJSON(t *testing.T) {
 			wantErr: false,
 			target:  "VideoMetadata",
 		},
+		{
+			name:    "VideoMetadata with fractional offsets",
+			jsonStr: `{"startOffset": "1.500s", "endOffset": "90.25s", "fps": 0.5}`,
+			want: &VideoMetadata{
+				FPS:         Ptr(0.5),
+				StartOffset: 1500 * time.Millisecond,
+				EndOffset:   90*time.Second + 250*time.Millisecond,
+			},
+			wantErr: false,
+			target:  "VideoMetadata",
+		},
 		{
 			name:    "VideoMetadata invalid start offset",
 			jsonStr: `{"startOffset": "abc"}`,
@@ -517,6 +528,17 @@ func TestMarshalJSON(t *testing.T) {
 			wantErr: false,
 			target:  "VideoMetadata",
 		},
+		{
+			name: "VideoMetadata with fractional offsets and fps",
+			input: &VideoMetadata{
+				FPS:         Ptr(0.5),
+				StartOffset: 1500 * time.Millisecond,
+				EndOffset:   90*time.Second + 250*time.Millisecond,
+			},
+			want:    `{"endOffset":"90.25s","startOffset":"1.5s","fps":0.5}`,
+			wantErr: false,
+			target:  "VideoMetadata",
+		},
 		// File tests
 		{
 			name:    "File empty",
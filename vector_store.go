@@ -0,0 +1,185 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// maxEmbedBatchSize is the maximum number of texts embedded in one request by
+// [Models.EmbedAndUpsert].
+const maxEmbedBatchSize = 100
+
+// VectorRecord is a text stored with its embedding in a [VectorStore].
+type VectorRecord struct {
+	// The unique ID of the record.
+	ID string
+	// The embedded text, e.g. a chunk of a document.
+	Text string
+	// Optional. Application-defined metadata, e.g. the URI of the document.
+	Metadata map[string]string
+	// The embedding of the text.
+	Values []float32
+}
+
+// VectorMatch is a record returned by [VectorStore.Query].
+type VectorMatch struct {
+	// The matching record.
+	Record *VectorRecord
+	// The cosine similarity of the record with the query.
+	Score float64
+}
+
+// VectorStore stores embeddings for their retrieval by similarity, e.g. in a
+// retrieval-augmented generation pipeline. See [Models.EmbedAndUpsert] and
+// [Models.EmbedAndQuery] to embed texts with [Models.EmbedContent] and store or
+// query them.
+//
+// Implementations must be safe for concurrent use.
+type VectorStore interface {
+	// Upsert adds the records, replacing the records of the same IDs.
+	Upsert(ctx context.Context, records ...*VectorRecord) error
+	// Query returns the k records most similar to the embedding by cosine
+	// similarity, from the most similar.
+	Query(ctx context.Context, values []float32, k int) ([]*VectorMatch, error)
+	// Delete removes the records of the IDs. Unknown IDs are ignored.
+	Delete(ctx context.Context, ids ...string) error
+}
+
+// EmbedAndUpsert embeds the texts of the records without values with the model and
+// upserts all the records into the store. The task type of the config defaults to
+// [TaskTypeRetrievalDocument].
+func (m Models) EmbedAndUpsert(ctx context.Context, model string, store VectorStore, records []*VectorRecord, config *EmbedContentConfig) error {
+	var pending []*VectorRecord
+	for i, r := range records {
+		if r == nil {
+			return fmt.Errorf("EmbedAndUpsert: record %d is nil", i)
+		}
+		if len(r.Values) == 0 {
+			pending = append(pending, r)
+		}
+	}
+	for batch := range slices.Chunk(pending, maxEmbedBatchSize) {
+		contents := make([]*Content, len(batch))
+		for i, r := range batch {
+			contents[i] = NewContentFromText(r.Text, RoleUser)
+		}
+		embeddings, err := m.embedTexts(ctx, model, contents, config, TaskTypeRetrievalDocument)
+		if err != nil {
+			return err
+		}
+		for i, r := range batch {
+			r.Values = embeddings[i].Values
+		}
+	}
+	return store.Upsert(ctx, records...)
+}
+
+// EmbedAndQuery embeds the query with the model and returns the k records of the
+// store most similar to it. The task type of the config defaults to
+// [TaskTypeRetrievalQuery].
+func (m Models) EmbedAndQuery(ctx context.Context, model string, store VectorStore, query string, k int, config *EmbedContentConfig) ([]*VectorMatch, error) {
+	embeddings, err := m.embedTexts(ctx, model, Text(query), config, TaskTypeRetrievalQuery)
+	if err != nil {
+		return nil, err
+	}
+	return store.Query(ctx, embeddings[0].Values, k)
+}
+
+// embedTexts embeds the contents with the task type of the config, or taskType
+// by default, and checks that every content is embedded.
+func (m Models) embedTexts(ctx context.Context, model string, contents []*Content, config *EmbedContentConfig, taskType string) ([]*ContentEmbedding, error) {
+	c := EmbedContentConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.TaskType == "" {
+		c.TaskType = taskType
+	}
+	response, err := m.EmbedContent(ctx, model, contents, &c)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Embeddings) != len(contents) {
+		return nil, fmt.Errorf("received %d embeddings for %d texts", len(response.Embeddings), len(contents))
+	}
+	return response.Embeddings, nil
+}
+
+// InMemoryVectorStore is a [VectorStore] keeping the records in memory and
+// comparing the query with every record. It is useful for tests and prototypes
+// of up to a few thousand records.
+type InMemoryVectorStore struct {
+	mu      sync.Mutex
+	records map[string]*VectorRecord
+}
+
+// NewInMemoryVectorStore returns an empty [InMemoryVectorStore].
+func NewInMemoryVectorStore() *InMemoryVectorStore {
+	return &InMemoryVectorStore{records: make(map[string]*VectorRecord)}
+}
+
+// Upsert adds the records, replacing the records of the same IDs.
+func (s *InMemoryVectorStore) Upsert(ctx context.Context, records ...*VectorRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range records {
+		if r.ID == "" {
+			return fmt.Errorf("record ID is empty")
+		}
+		s.records[r.ID] = r
+	}
+	return nil
+}
+
+// Query returns the k records most similar to the embedding, in the order of
+// their IDs if equally similar.
+func (s *InMemoryVectorStore) Query(ctx context.Context, values []float32, k int) ([]*VectorMatch, error) {
+	s.mu.Lock()
+	records := make([]*VectorRecord, 0, len(s.records))
+	for _, r := range s.records {
+		records = append(records, r)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+	candidates := make([][]float32, len(records))
+	for i, r := range records {
+		candidates[i] = r.Values
+	}
+	nearest, err := NearestEmbeddings(values, candidates, k)
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]*VectorMatch, len(nearest))
+	for i, n := range nearest {
+		matches[i] = &VectorMatch{Record: records[n.Index], Score: n.Score}
+	}
+	return matches, nil
+}
+
+// Delete removes the records of the IDs.
+func (s *InMemoryVectorStore) Delete(ctx context.Context, ids ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		delete(s.records, id)
+	}
+	return nil
+}
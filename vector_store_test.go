@@ -0,0 +1,102 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInMemoryVectorStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryVectorStore()
+	records := []*VectorRecord{
+		{ID: "a", Text: "cats", Values: []float32{1, 0}},
+		{ID: "b", Text: "dogs", Values: []float32{0, 1}},
+		{ID: "c", Text: "pets", Values: []float32{1, 1}},
+	}
+	if err := store.Upsert(ctx, records...); err != nil {
+		t.Fatalf("Upsert() failed: %v", err)
+	}
+	if err := store.Upsert(ctx, &VectorRecord{ID: "b", Text: "kittens", Values: []float32{1, 0.1}}); err != nil {
+		t.Fatalf("Upsert() failed: %v", err)
+	}
+	if err := store.Delete(ctx, "c", "unknown"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	matches, err := store.Query(ctx, []float32{1, 0}, 5)
+	if err != nil {
+		t.Fatalf("Query() failed: %v", err)
+	}
+	var got []string
+	for _, m := range matches {
+		got = append(got, m.Record.Text)
+	}
+	if diff := cmp.Diff([]string{"cats", "kittens"}, got); diff != "" {
+		t.Errorf("Query() mismatch (-want +got):\n%s", diff)
+	}
+	if matches[0].Score != 1 {
+		t.Errorf("Query() score = %v, want 1", matches[0].Score)
+	}
+	if err := store.Upsert(ctx, &VectorRecord{Text: "no ID"}); err == nil {
+		t.Errorf("Upsert() succeeded without ID, want error")
+	}
+}
+
+func TestModelsEmbedAndQuery(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	models := newTestClient(t, BackendGeminiAPI, replyInOrder(t, []string{
+		`{"embeddings": [{"values": [1, 0]}, {"values": [0, 1]}]}`,
+		`{"embeddings": [{"values": [0.1, 1]}]}`,
+	}, &requests)).Models
+	store := NewInMemoryVectorStore()
+	records := []*VectorRecord{
+		{ID: "1", Text: "The cat sleeps."},
+		{ID: "2", Text: "The dog barks."},
+		{ID: "3", Text: "Precomputed.", Values: []float32{-1, 0}},
+	}
+	if err := models.EmbedAndUpsert(ctx, "text-embedding-004", store, records, nil); err != nil {
+		t.Fatalf("EmbedAndUpsert() failed: %v", err)
+	}
+	matches, err := models.EmbedAndQuery(ctx, "text-embedding-004", store, "Which animal barks?", 1, nil)
+	if err != nil {
+		t.Fatalf("EmbedAndQuery() failed: %v", err)
+	}
+	if len(matches) != 1 || matches[0].Record.ID != "2" {
+		t.Errorf("EmbedAndQuery() = %+v, want record 2", matches)
+	}
+
+	var taskTypes []any
+	for _, r := range requests {
+		for _, req := range r["requests"].([]any) {
+			taskTypes = append(taskTypes, req.(map[string]any)["taskType"])
+		}
+	}
+	if diff := cmp.Diff([]any{"RETRIEVAL_DOCUMENT", "RETRIEVAL_DOCUMENT", "RETRIEVAL_QUERY"}, taskTypes); diff != "" {
+		t.Errorf("task types mismatch (-want +got):\n%s", diff)
+	}
+
+	err = models.EmbedAndUpsert(ctx, "text-embedding-004", store, []*VectorRecord{{ID: "4", Text: "Birds fly."}, nil}, nil)
+	if err == nil || !strings.Contains(err.Error(), "record 1") {
+		t.Errorf("EmbedAndUpsert() with a nil record = %v, want an error naming record 1", err)
+	}
+	if len(requests) != 2 {
+		t.Errorf("EmbedAndUpsert() with a nil record sent %d requests, want none", len(requests)-2)
+	}
+}
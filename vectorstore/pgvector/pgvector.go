@@ -0,0 +1,189 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pgvector implements the [genai.VectorStore] interface with a
+// PostgreSQL table and the pgvector extension, see
+// https://github.com/pgvector/pgvector. It works with any database/sql driver
+// for PostgreSQL, e.g. github.com/jackc/pgx/v5/stdlib:
+//
+//	db, err := sql.Open("pgx", os.Getenv("DATABASE_URL"))
+//	if err != nil {
+//		return err
+//	}
+//	store, err := pgvector.New(db, "documents")
+//	if err != nil {
+//		return err
+//	}
+//	if err := store.CreateTable(ctx, 768); err != nil {
+//		return err
+//	}
+//	err = client.Models.EmbedAndUpsert(ctx, "text-embedding-004", store, records, nil)
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Store is a [genai.VectorStore] backed by a PostgreSQL table with the pgvector
+// extension. The table has the columns id (text primary key), text (text),
+// metadata (jsonb) and embedding (vector).
+type Store struct {
+	db    *sql.DB
+	table string
+}
+
+// New returns a [Store] storing the records in table, which may be qualified by
+// a schema.
+func New(db *sql.DB, table string) (*Store, error) {
+	if !identifierRegexp.MatchString(table) {
+		return nil, fmt.Errorf("pgvector.New: invalid table name %q", table)
+	}
+	return &Store{db: db, table: table}, nil
+}
+
+// CreateTable creates the pgvector extension and the table of the store if they
+// don't exist, for embeddings of the given dimensions.
+func (s *Store) CreateTable(ctx context.Context, dimensions int) error {
+	if _, err := s.db.ExecContext(ctx, "CREATE EXTENSION IF NOT EXISTS vector"); err != nil {
+		return fmt.Errorf("pgvector: error creating extension: %w", err)
+	}
+	query := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (id text PRIMARY KEY, text text NOT NULL, metadata jsonb, embedding vector(%d) NOT NULL)", s.table, dimensions)
+	if _, err := s.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("pgvector: error creating table: %w", err)
+	}
+	return nil
+}
+
+// Upsert adds the records in a transaction, replacing the records of the same IDs.
+func (s *Store) Upsert(ctx context.Context, records ...*genai.VectorRecord) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	query := fmt.Sprintf("INSERT INTO %s (id, text, metadata, embedding) VALUES ($1, $2, $3::jsonb, $4::vector) ON CONFLICT (id) DO UPDATE SET text = EXCLUDED.text, metadata = EXCLUDED.metadata, embedding = EXCLUDED.embedding", s.table)
+	for _, r := range records {
+		if r.ID == "" {
+			return fmt.Errorf("record ID is empty")
+		}
+		metadata, err := json.Marshal(r.Metadata)
+		if err != nil {
+			return fmt.Errorf("pgvector: error encoding metadata of record %q: %w", r.ID, err)
+		}
+		if _, err := tx.ExecContext(ctx, query, r.ID, r.Text, string(metadata), formatVector(r.Values)); err != nil {
+			return fmt.Errorf("pgvector: error upserting record %q: %w", r.ID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Query returns the k records most similar to the embedding, using the cosine
+// distance operator of pgvector.
+func (s *Store) Query(ctx context.Context, values []float32, k int) ([]*genai.VectorMatch, error) {
+	if k <= 0 {
+		return nil, nil
+	}
+	query := fmt.Sprintf("SELECT id, text, metadata, embedding::text, 1 - (embedding <=> $1::vector) FROM %s ORDER BY embedding <=> $1::vector LIMIT $2", s.table)
+	rows, err := s.db.QueryContext(ctx, query, formatVector(values), k)
+	if err != nil {
+		return nil, fmt.Errorf("pgvector: error querying records: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []*genai.VectorMatch
+	for rows.Next() {
+		r := &genai.VectorRecord{}
+		var metadata sql.NullString
+		var embedding string
+		var score float64
+		if err := rows.Scan(&r.ID, &r.Text, &metadata, &embedding, &score); err != nil {
+			return nil, fmt.Errorf("pgvector: error reading record: %w", err)
+		}
+		if metadata.Valid {
+			if err := json.Unmarshal([]byte(metadata.String), &r.Metadata); err != nil {
+				return nil, fmt.Errorf("pgvector: error decoding metadata of record %q: %w", r.ID, err)
+			}
+		}
+		if r.Values, err = parseVector(embedding); err != nil {
+			return nil, fmt.Errorf("pgvector: error decoding embedding of record %q: %w", r.ID, err)
+		}
+		matches = append(matches, &genai.VectorMatch{Record: r, Score: score})
+	}
+	return matches, rows.Err()
+}
+
+// Delete removes the records of the IDs.
+func (s *Store) Delete(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+		args[i] = id
+	}
+	query := fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", s.table, strings.Join(placeholders, ", "))
+	if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("pgvector: error deleting records: %w", err)
+	}
+	return nil
+}
+
+// formatVector returns the text representation of an embedding in pgvector,
+// e.g. "[1,2.5,3]".
+func formatVector(values []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, v := range values {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// parseVector parses the text representation of an embedding in pgvector.
+func parseVector(s string) ([]float32, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("invalid vector %q", s)
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	if s == "" {
+		return nil, nil
+	}
+	fields := strings.Split(s, ",")
+	values := make([]float32, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(strings.TrimSpace(f), 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vector %q: %w", s, err)
+		}
+		values[i] = float32(v)
+	}
+	return values, nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/genai"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	d := &fakeSQLDriver{rows: [][]driver.Value{
+		{"a", "cats", `{"uri":"gs://bucket/cats.txt"}`, "[1,0.5]", 0.9},
+		{"b", "dogs", nil, "[0, 1]", 0.1},
+	}}
+	db := sql.OpenDB(d)
+	defer db.Close()
+
+	if _, err := New(db, "docs; DROP TABLE users"); err == nil {
+		t.Errorf("New() succeeded for an invalid table name, want error")
+	}
+	store, err := New(db, "public.docs")
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if err := store.CreateTable(ctx, 2); err != nil {
+		t.Fatalf("CreateTable() failed: %v", err)
+	}
+	if err := store.Upsert(ctx, &genai.VectorRecord{ID: "a", Text: "cats", Metadata: map[string]string{"uri": "gs://bucket/cats.txt"}, Values: []float32{1, 0.5}}); err != nil {
+		t.Fatalf("Upsert() failed: %v", err)
+	}
+	matches, err := store.Query(ctx, []float32{1, 0}, 2)
+	if err != nil {
+		t.Fatalf("Query() failed: %v", err)
+	}
+	if err := store.Delete(ctx, "a", "b"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+
+	wantMatches := []*genai.VectorMatch{
+		{Record: &genai.VectorRecord{ID: "a", Text: "cats", Metadata: map[string]string{"uri": "gs://bucket/cats.txt"}, Values: []float32{1, 0.5}}, Score: 0.9},
+		{Record: &genai.VectorRecord{ID: "b", Text: "dogs", Values: []float32{0, 1}}, Score: 0.1},
+	}
+	if diff := cmp.Diff(wantMatches, matches); diff != "" {
+		t.Errorf("Query() mismatch (-want +got):\n%s", diff)
+	}
+	wantStatements := []fakeSQLStatement{
+		{"CREATE EXTENSION IF NOT EXISTS vector", nil},
+		{"CREATE TABLE IF NOT EXISTS public.docs (id text PRIMARY KEY, text text NOT NULL, metadata jsonb, embedding vector(2) NOT NULL)", nil},
+		{"INSERT INTO public.docs (id, text, metadata, embedding) VALUES ($1, $2, $3::jsonb, $4::vector) ON CONFLICT (id) DO UPDATE SET text = EXCLUDED.text, metadata = EXCLUDED.metadata, embedding = EXCLUDED.embedding", []driver.Value{"a", "cats", `{"uri":"gs://bucket/cats.txt"}`, "[1,0.5]"}},
+		{"SELECT id, text, metadata, embedding::text, 1 - (embedding <=> $1::vector) FROM public.docs ORDER BY embedding <=> $1::vector LIMIT $2", []driver.Value{"[1,0]", int64(2)}},
+		{"DELETE FROM public.docs WHERE id IN ($1, $2)", []driver.Value{"a", "b"}},
+	}
+	if diff := cmp.Diff(wantStatements, d.statements, cmp.AllowUnexported(fakeSQLStatement{}), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("statements mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestVectorFormat(t *testing.T) {
+	values := []float32{1, -0.25, 3e-8, math.MaxFloat32}
+	got, err := parseVector(formatVector(values))
+	if err != nil {
+		t.Fatalf("parseVector() failed: %v", err)
+	}
+	if diff := cmp.Diff(values, got, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := parseVector("1,2"); err == nil {
+		t.Errorf("parseVector() succeeded without brackets, want error")
+	}
+}
+
+// fakeSQLStatement is a statement executed through fakeSQLDriver.
+type fakeSQLStatement struct {
+	query string
+	args  []driver.Value
+}
+
+// fakeSQLDriver is a database/sql driver and connector recording the statements,
+// and returning rows to every query.
+type fakeSQLDriver struct {
+	mu         sync.Mutex
+	statements []fakeSQLStatement
+	rows       [][]driver.Value
+}
+
+func (d *fakeSQLDriver) Open(name string) (driver.Conn, error) { return &fakeSQLConn{d: d}, nil }
+func (d *fakeSQLDriver) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeSQLConn{d: d}, nil
+}
+func (d *fakeSQLDriver) Driver() driver.Driver { return d }
+
+type fakeSQLConn struct{ d *fakeSQLDriver }
+
+func (c *fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeSQLStmt{d: c.d, query: query}, nil
+}
+func (c *fakeSQLConn) Close() error              { return nil }
+func (c *fakeSQLConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *fakeSQLConn) Commit() error             { return nil }
+func (c *fakeSQLConn) Rollback() error           { return nil }
+
+type fakeSQLStmt struct {
+	d     *fakeSQLDriver
+	query string
+}
+
+func (s *fakeSQLStmt) Close() error  { return nil }
+func (s *fakeSQLStmt) NumInput() int { return -1 }
+
+func (s *fakeSQLStmt) record(args []driver.Value) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.statements = append(s.d.statements, fakeSQLStatement{s.query, args})
+}
+
+func (s *fakeSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.record(args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.record(args)
+	return &fakeSQLRows{rows: s.d.rows}, nil
+}
+
+type fakeSQLRows struct{ rows [][]driver.Value }
+
+func (r *fakeSQLRows) Columns() []string {
+	return []string{"id", "text", "metadata", "embedding", "score"}
+}
+func (r *fakeSQLRows) Close() error { return nil }
+
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// youTubeVideoIDPattern matches the 11 characters long identifiers of YouTube videos.
+var youTubeVideoIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// NewPartFromYouTubeURL builds a Part referencing a public YouTube video, so that
+// the model can answer questions about it. The URL can be any of the usual forms
+// of video links, e.g. "https://www.youtube.com/watch?v=ID", "https://youtu.be/ID"
+// or "https://www.youtube.com/shorts/ID". It is normalized to the
+// "https://www.youtube.com/watch?v=ID" form expected by the API.
+//
+// Use [Part.VideoMetadata] to only ask about a segment of the video.
+func NewPartFromYouTubeURL(videoURL string) (*Part, error) {
+	id, err := youTubeVideoID(videoURL)
+	if err != nil {
+		return nil, err
+	}
+	return NewPartFromURI("https://www.youtube.com/watch?v="+id, "video/mp4"), nil
+}
+
+// youTubeVideoID extracts the identifier of the video from a YouTube URL.
+func youTubeVideoID(videoURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(videoURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid YouTube URL %q: %w", videoURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid YouTube URL %q: scheme must be http or https", videoURL)
+	}
+
+	var id string
+	switch strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.") {
+	case "youtu.be":
+		id = strings.Trim(u.Path, "/")
+	case "youtube.com", "m.youtube.com", "music.youtube.com":
+		path := strings.Trim(u.Path, "/")
+		if path == "watch" {
+			id = u.Query().Get("v")
+		} else if prefix, rest, ok := strings.Cut(path, "/"); ok && (prefix == "shorts" || prefix == "embed" || prefix == "live" || prefix == "v") {
+			id = rest
+		}
+	default:
+		return "", fmt.Errorf("invalid YouTube URL %q: unsupported host %q", videoURL, u.Host)
+	}
+	if !youTubeVideoIDPattern.MatchString(id) {
+		return "", fmt.Errorf("invalid YouTube URL %q: missing or malformed video ID", videoURL)
+	}
+	return id, nil
+}
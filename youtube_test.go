@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewPartFromYouTubeURL(t *testing.T) {
+	want := &Part{FileData: &FileData{FileURI: "https://www.youtube.com/watch?v=9hE5-98ZeCg", MIMEType: "video/mp4"}}
+	valid := []string{
+		"https://www.youtube.com/watch?v=9hE5-98ZeCg",
+		"https://youtube.com/watch?v=9hE5-98ZeCg&t=42s",
+		"http://m.youtube.com/watch?feature=share&v=9hE5-98ZeCg",
+		"https://youtu.be/9hE5-98ZeCg?si=abc",
+		"https://www.youtube.com/shorts/9hE5-98ZeCg",
+		"https://www.youtube.com/embed/9hE5-98ZeCg",
+		" https://www.youtube.com/live/9hE5-98ZeCg ",
+	}
+	for _, u := range valid {
+		t.Run(u, func(t *testing.T) {
+			got, err := NewPartFromYouTubeURL(u)
+			if err != nil {
+				t.Fatalf("NewPartFromYouTubeURL() failed: %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("NewPartFromYouTubeURL() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	invalid := []string{
+		"",
+		"9hE5-98ZeCg",
+		"ftp://www.youtube.com/watch?v=9hE5-98ZeCg",
+		"https://vimeo.com/watch?v=9hE5-98ZeCg",
+		"https://www.youtube.com/watch",
+		"https://www.youtube.com/watch?v=short",
+		"https://www.youtube.com/channel/UC1234567890",
+		"https://youtu.be/",
+	}
+	for _, u := range invalid {
+		t.Run("Invalid "+u, func(t *testing.T) {
+			if _, err := NewPartFromYouTubeURL(u); err == nil {
+				t.Errorf("NewPartFromYouTubeURL(%q) succeeded, want error", u)
+			}
+		})
+	}
+}